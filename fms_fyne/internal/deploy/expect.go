@@ -0,0 +1,39 @@
+package deploy
+
+import (
+	"fmt"
+	"strings"
+
+	"fms/internal/model"
+	"fms/internal/parser"
+)
+
+// 템플릿의 기대 결과(# expect:)가 실패하여 배포를 막을 때 반환되는 에러입니다.
+type ExpectationError struct {
+	TemplateVersion string                     `json:"templateVersion"` // 검사한 템플릿 버전
+	Failures        []parser.ExpectationResult `json:"failures"`        // 실패한 기대 결과 (줄 순서)
+}
+
+func (e *ExpectationError) Error() string {
+	lines := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		lines[i] = failure.String()
+	}
+	return fmt.Sprintf("템플릿 %s의 기대 결과 %d개가 실패하여 배포하지 않습니다:\n%s",
+		e.TemplateVersion, len(e.Failures), strings.Join(lines, "\n"))
+}
+
+// 배포 전에 템플릿의 기대 결과를 템플릿 자신의 규칙으로 평가합니다.
+// 하나라도 실패하면 실패 목록을 담은 *ExpectationError를 반환합니다.
+// 기대 결과가 없는 템플릿은 항상 통과합니다.
+// 템플릿 변수를 참조하는 템플릿은 장비마다 규칙이 다르므로 CheckVariables에서 장비별로 평가합니다.
+func CheckExpectations(template *model.Template) error {
+	if model.HasTemplateVariables(template.Contents) {
+		return nil
+	}
+	failures := parser.FailedExpectations(template.Contents)
+	if len(failures) == 0 {
+		return nil
+	}
+	return &ExpectationError{TemplateVersion: template.Version, Failures: failures}
+}
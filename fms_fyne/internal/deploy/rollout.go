@@ -0,0 +1,187 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"fms/internal/model"
+)
+
+// 단계별 배포 진행 단계 상수
+const (
+	RolloutPhaseDeploy      = "deploy" // 웨이브 배포 중
+	RolloutPhasePause       = "pause"  // 상태 확인 전 대기 중
+	RolloutPhaseHealthCheck = "health" // 배포 후 상태 확인 중
+)
+
+// 단계별 배포 진행 상황을 나타냅니다.
+type RolloutProgress struct {
+	Wave       int    // 현재 웨이브 (1부터)
+	TotalWaves int    // 전체 웨이브 수
+	Phase      string // 진행 단계 (deploy/pause/health)
+	Done       int    // 배포가 끝난 장비 수 (전체 기준)
+	Total      int    // 전체 장비 수
+	DeviceName string // 마지막으로 배포가 끝난 장비
+}
+
+// 웨이브 하나의 배포 결과를 나타냅니다.
+type WaveResult struct {
+	Wave           int      // 웨이브 번호 (1 = 카나리)
+	Devices        []string // 웨이브에 포함된 장비
+	Failed         int      // 배포 또는 상태 확인에 실패한 장비 수
+	FailurePercent float64  // 실패율 (%)
+}
+
+// 단계별 배포 전체 결과를 나타냅니다.
+type RolloutResult struct {
+	RolloutID  string          // 단계별 배포 ID
+	Waves      []WaveResult    // 실행된 웨이브 결과
+	Halted     bool            // 실패율 초과로 중단되었는지 여부
+	HaltReason string          // 중단 사유
+	Results    []*DeployResult // 장비별 배포 결과 (입력 순서)
+}
+
+// 여러 장비에 템플릿을 웨이브 단위로 나누어 배포합니다.
+// 첫 웨이브(카나리) 배포 후 설정된 시간만큼 대기하고 상태를 다시 확인하며,
+// 웨이브의 실패율이 허용치를 넘으면 남은 장비는 배포하지 않고 중단 상태로 기록합니다.
+// 템플릿 변수가 하나라도 정의되지 않은 장비가 있으면 어떤 장비에도 배포하지 않고 에러를 반환합니다.
+func (d *Deployer) DeployRollout(ctx context.Context, firewalls []*model.Firewall, template *model.Template, opts *model.RolloutOptions, progressCb func(RolloutProgress)) (*RolloutResult, error) {
+	if opts == nil {
+		opts = model.DefaultRolloutOptions()
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if err := CheckVariables(firewalls, template, d.currentVariables()); err != nil {
+		return nil, err
+	}
+
+	total := len(firewalls)
+	rollout := &RolloutResult{
+		RolloutID: time.Now().Format("20060102-150405"),
+		Results:   make([]*DeployResult, 0, total),
+	}
+
+	sizes := opts.WaveSizes(total)
+	report := func(wave int, phase string, done int, deviceName string) {
+		if progressCb != nil {
+			progressCb(RolloutProgress{
+				Wave:       wave,
+				TotalWaves: len(sizes),
+				Phase:      phase,
+				Done:       done,
+				Total:      total,
+				DeviceName: deviceName,
+			})
+		}
+	}
+
+	start := 0
+	for i, size := range sizes {
+		wave := i + 1
+		waveFirewalls := firewalls[start : start+size]
+		offset := start
+		start += size
+
+		// 웨이브 배포
+		report(wave, RolloutPhaseDeploy, offset, "")
+		results := d.deployToMultiple(ctx, waveFirewalls, template, false, func(done, _ int, deviceName string) {
+			report(wave, RolloutPhaseDeploy, offset+done, deviceName)
+		})
+		for _, result := range results {
+			result.History.RolloutID = rollout.RolloutID
+			result.History.Wave = wave
+		}
+		rollout.Results = append(rollout.Results, results...)
+
+		if ctx.Err() != nil {
+			rollout.Waves = append(rollout.Waves, summarizeWave(wave, results))
+			break
+		}
+
+		// 다음 웨이브가 있으면 대기 후 상태 확인
+		if wave < len(sizes) && opts.PauseSeconds > 0 {
+			report(wave, RolloutPhasePause, start, "")
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Duration(opts.PauseSeconds) * time.Second):
+			}
+		}
+		if ctx.Err() == nil {
+			// 상태 확인 실패 장비는 마지막 정상 배포 내용으로 롤백
+			report(wave, RolloutPhaseHealthCheck, start, "")
+			d.rollbackUnhealthy(ctx, results)
+		}
+
+		waveResult := summarizeWave(wave, results)
+		rollout.Waves = append(rollout.Waves, waveResult)
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		// 실패율 초과 시 중단
+		if wave < len(sizes) && waveResult.FailurePercent > opts.MaxFailurePercent {
+			rollout.Halted = true
+			rollout.HaltReason = fmt.Sprintf("웨이브 %d 실패율 %.0f%% (%d/%d)가 허용치 %.0f%%를 초과하여 중단",
+				wave, waveResult.FailurePercent, waveResult.Failed, len(results), opts.MaxFailurePercent)
+			break
+		}
+	}
+
+	// 배포하지 않은 나머지 장비 기록 (배포할 예정이던 리비전과 포함 템플릿도 함께 기록)
+	for i, fw := range firewalls[len(rollout.Results):] {
+		result := newDeployResult(fw, template)
+		result.History.RolloutID = rollout.RolloutID
+		result.History.Wave = waveOf(sizes, len(rollout.Results)+i)
+		if rollout.Halted {
+			markHalted(result, rollout.HaltReason)
+		} else {
+			markCancelled(result, ctx.Err())
+		}
+		rollout.Results = append(rollout.Results, result)
+	}
+
+	return rollout, nil
+}
+
+// 배포 결과를 롤아웃 중단 상태로 기록합니다.
+// 장비의 배포 상태와 버전은 변경하지 않습니다.
+func markHalted(result *DeployResult, reason string) {
+	result.Success = false
+	result.ErrorMsg = reason
+	result.History.Status = model.DeployStatusHalted
+	result.History.Results = append(result.History.Results, model.RuleResult{
+		Rule:   "-",
+		Text:   "-",
+		Status: model.RuleStatusHalted,
+		Reason: reason,
+	})
+}
+
+// 웨이브 결과를 집계합니다.
+func summarizeWave(wave int, results []*DeployResult) WaveResult {
+	waveResult := WaveResult{Wave: wave}
+	for _, result := range results {
+		waveResult.Devices = append(waveResult.Devices, result.Firewall.DeviceName)
+		if !result.Success {
+			waveResult.Failed++
+		}
+	}
+	if len(results) > 0 {
+		waveResult.FailurePercent = float64(waveResult.Failed) * 100 / float64(len(results))
+	}
+	return waveResult
+}
+
+// 장비 순번이 속한 웨이브 번호를 반환합니다.
+func waveOf(sizes []int, index int) int {
+	for i, size := range sizes {
+		if index < size {
+			return i + 1
+		}
+		index -= size
+	}
+	return len(sizes)
+}
@@ -3,7 +3,9 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -14,41 +16,116 @@ import (
 )
 
 // HTTP 에러를 분석하여 사용자 친화적인 메시지를 반환합니다.
+// 연결 실패로 분류할 수 없는 에러는 에러 메시지를 그대로 반환합니다.
 func AnalyzeConnectionError(err error) string {
 	if err == nil {
 		return ""
 	}
 
-	errStr := err.Error()
+	var tlsErr *TLSError
+	var statusErr *StatusError
+	switch {
+	case errors.As(err, &tlsErr):
+		return tlsErr.Reason
+	case errors.As(err, &statusErr):
+		if statusErr.Category() == model.ErrorCategoryAuth {
+			return "인증 실패"
+		}
+		if statusErr.Body == "" {
+			return fmt.Sprintf("HTTP %d 오류", statusErr.StatusCode)
+		}
+		return fmt.Sprintf("HTTP %d 오류: %s", statusErr.StatusCode, statusErr.Body)
+	}
 
-	// 연결 거부 체크 (서버가 명시적으로 거부)
-	if strings.Contains(errStr, "connection refused") {
+	switch CategoryOf(err) {
+	case model.ErrorCategoryTimeout:
+		return "응답 없음 (시간 초과)"
+	case model.ErrorCategoryRefused:
 		return "연결 거부"
+	case model.ErrorCategoryDNS:
+		return "호스트 이름 확인 실패"
+	case model.ErrorCategoryAuth:
+		return "인증 실패"
+	case model.ErrorCategoryProtocol:
+		return "응답 형식 오류"
+	case model.ErrorCategoryMissing:
+		return "응답에 장비 결과 없음"
+	case model.ErrorCategoryNetwork:
+		return "응답 없음"
 	}
-
-	// 그 외 모든 경우 (타임아웃, 네트워크 문제, DNS 실패 등)
-	return "응답 없음"
+	return err.Error()
 }
 
 // Client는 HTTP 클라이언트를 나타냅니다.
 type Client struct {
-	httpClient *http.Client
-	config     *model.Config
+	httpClient   *http.Client // 장비 직접 연결용
+	agentClient  *http.Client // 에이전트 서버 연결용
+	deviceTLSErr error        // 장비 TLS 설정 로드 실패 시 에러
+	agentTLSErr  error        // 에이전트 TLS 설정 로드 실패 시 에러
+	config       *model.Config
 }
 
 // 새로운 HTTP 클라이언트를 생성합니다.
+// TLS 설정을 불러올 수 없으면 해당 연결의 모든 요청이 TLS 설정 오류를 반환합니다.
 func NewClient(config *model.Config) *Client {
 	timeout := time.Duration(config.GetTimeoutSeconds()) * time.Second
-	return &Client{
-		httpClient: &http.Client{
-			Timeout: timeout,
-		},
-		config: config,
+	c := &Client{config: config}
+	c.httpClient, c.deviceTLSErr = newHTTPClient(timeout, config.DeviceTLS)
+	c.agentClient, c.agentTLSErr = newHTTPClient(timeout, config.AgentTLS)
+	return c
+}
+
+// 인증 정보를 추가하여 요청을 보냅니다. agent가 true이면 에이전트 서버용 TLS 설정을 사용합니다.
+// 전송 실패는 원인별 에러 타입으로 반환합니다.
+func (c *Client) do(req *http.Request, body []byte, auth *model.AuthConfig, agent bool) (*http.Response, error) {
+	if err := applyAuth(req, body, auth); err != nil {
+		return nil, err
+	}
+
+	client, tlsErr := c.httpClient, c.deviceTLSErr
+	if agent {
+		client, tlsErr = c.agentClient, c.agentTLSErr
+	}
+	if tlsErr != nil {
+		return nil, ClassifyError(tlsErr)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+	return resp, nil
+}
+
+// 응답 본문을 읽습니다. 상태 코드가 200이 아니면 StatusError를 반환합니다.
+func readResponse(resp *http.Response, target string) ([]byte, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &ProtocolError{Err: fmt.Errorf("응답 읽기 실패: %v", err)}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{Target: target, StatusCode: resp.StatusCode, Body: excerptBody(body)}
+	}
+	return body, nil
+}
+
+// JSON 본문으로 POST 요청을 보냅니다.
+func (c *Client) postJSON(ctx context.Context, url string, body []byte, auth *model.AuthConfig, agent bool) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
 	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.do(req, body, auth, agent)
+}
+
+// 장비 직접 연결 URL을 생성합니다.
+func (c *Client) deviceURL(deviceIP, path string) string {
+	return fmt.Sprintf("%s://%s%s", c.config.GetDeviceScheme(), model.DeviceURLHost(deviceIP), path)
 }
 
 // Agent 서버를 통해 장비 상태를 확인합니다.
-func (c *Client) CheckHealthViaAgent(ipAddrs []string) (map[string]bool, error) {
+func (c *Client) CheckHealthViaAgent(ctx context.Context, ipAddrs []string) (map[string]bool, error) {
 	url := fmt.Sprintf("%s/agent/req-respCheck", strings.TrimSuffix(c.config.AgentServerURL, "/"))
 
 	// 요청 데이터 생성
@@ -61,51 +138,74 @@ func (c *Client) CheckHealthViaAgent(ipAddrs []string) (map[string]bool, error)
 	}
 
 	// POST 요청
-	resp, err := c.httpClient.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	resp, err := c.postJSON(ctx, url, jsonData, &c.config.Auth, true)
 	if err != nil {
-		return nil, fmt.Errorf("Agent 서버 연결 실패: %v", err)
+		return nil, fmt.Errorf("Agent 서버 연결 실패: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Agent 서버 응답 오류: %d", resp.StatusCode)
-	}
-
-	// 응답 파싱
-	body, err := io.ReadAll(resp.Body)
+	body, err := readResponse(resp, "Agent 서버")
 	if err != nil {
-		return nil, fmt.Errorf("응답 읽기 실패: %v", err)
+		return nil, err
 	}
 
+	// 응답 파싱
 	var result map[string]bool
 	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("응답 파싱 실패: %v", err)
+		return nil, &ProtocolError{Err: fmt.Errorf("응답 파싱 실패: %v", err)}
 	}
 
 	return result, nil
 }
 
 // 직접 연결로 장비 상태를 확인합니다.
-func (c *Client) CheckHealthDirect(deviceIP string) (bool, error) {
-	url := fmt.Sprintf("http://%s/respCheck", deviceIP)
+func (c *Client) CheckHealthDirect(ctx context.Context, fw *model.Firewall) (bool, error) {
+	url := c.deviceURL(fw.DeviceName, "/respCheck")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, fmt.Errorf("요청 생성 실패: %v", err)
+	}
 
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.do(req, nil, c.config.AuthFor(fw), false)
 	if err != nil {
-		return false, fmt.Errorf("장비 연결 실패: %v", err)
+		return false, fmt.Errorf("장비 연결 실패: %w", err)
 	}
 	defer resp.Body.Close()
 
+	// 인증 거부는 장비 정지와 구분하여 에러로 반환
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		_, err := readResponse(resp, "장비")
+		return false, err
+	}
+
 	return resp.StatusCode == http.StatusOK, nil
 }
 
 // Agent 서버를 통해 템플릿을 배포합니다.
-func (c *Client) DeployViaAgent(deviceIP string, template string) (*model.DeployResult, error) {
+func (c *Client) DeployViaAgent(ctx context.Context, deviceIP string, template string) (*model.DeployResult, error) {
+	results, err := c.DeployBatchViaAgent(ctx, []string{deviceIP}, template)
+	if err != nil {
+		return nil, err
+	}
+
+	// 해당 장비의 결과 찾기
+	if result, ok := results[deviceIP]; ok {
+		return result, nil
+	}
+
+	return nil, &MissingDeviceError{DeviceIP: deviceIP}
+}
+
+// Agent 서버를 통해 여러 장비에 한번에 템플릿을 배포합니다.
+// 결과는 장비 IP별로 반환되며, 응답에 없는 장비는 결과 맵에 포함되지 않습니다.
+func (c *Client) DeployBatchViaAgent(ctx context.Context, deviceIPs []string, template string) (map[string]*model.DeployResult, error) {
 	url := fmt.Sprintf("%s/agent/req-deploy", strings.TrimSuffix(c.config.AgentServerURL, "/"))
 
 	// 요청 데이터 생성 (index.html과 동일한 형식)
 	reqData := map[string]interface{}{
 		"template": template,
-		"ipAddrs":  []string{deviceIP},
+		"ipAddrs":  deviceIPs,
 	}
 	jsonData, err := json.Marshal(reqData)
 	if err != nil {
@@ -113,19 +213,15 @@ func (c *Client) DeployViaAgent(deviceIP string, template string) (*model.Deploy
 	}
 
 	// POST 요청
-	resp, err := c.httpClient.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	resp, err := c.postJSON(ctx, url, jsonData, &c.config.Auth, true)
 	if err != nil {
-		return nil, fmt.Errorf("Agent 서버 연결 실패: %v", err)
+		return nil, fmt.Errorf("Agent 서버 연결 실패: %w", err)
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := readResponse(resp, "Agent 서버")
 	if err != nil {
-		return nil, fmt.Errorf("응답 읽기 실패: %v", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Agent 서버 응답 오류: %d", resp.StatusCode)
+		return nil, err
 	}
 
 	// 응답 파싱
@@ -133,22 +229,21 @@ func (c *Client) DeployViaAgent(deviceIP string, template string) (*model.Deploy
 		Data []model.DeployResult `json:"data"`
 	}
 	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("응답 파싱 실패: %v", err)
+		return nil, &ProtocolError{Err: fmt.Errorf("응답 파싱 실패: %v", err)}
 	}
 
-	// 해당 장비의 결과 찾기
-	for _, result := range response.Data {
-		if result.IP == deviceIP {
-			return &result, nil
-		}
+	results := make(map[string]*model.DeployResult, len(response.Data))
+	for i := range response.Data {
+		results[response.Data[i].IP] = &response.Data[i]
 	}
 
-	return nil, fmt.Errorf("장비 %s의 배포 결과를 찾을 수 없습니다", deviceIP)
+	return results, nil
 }
 
 // 직접 연결로 템플릿을 배포합니다.
-func (c *Client) DeployDirect(deviceIP string, template string) (*model.DeployResult, error) {
-	url := fmt.Sprintf("http://%s/agent/req-deploy", deviceIP)
+func (c *Client) DeployDirect(ctx context.Context, fw *model.Firewall, template string) (*model.DeployResult, error) {
+	deviceIP := fw.DeviceName
+	url := c.deviceURL(deviceIP, "/agent/req-deploy")
 
 	// 요청 데이터 생성 (템플릿을 변환 없이 그대로 전송)
 	reqData := map[string]interface{}{
@@ -161,19 +256,15 @@ func (c *Client) DeployDirect(deviceIP string, template string) (*model.DeployRe
 	}
 
 	// POST 요청
-	resp, err := c.httpClient.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	resp, err := c.postJSON(ctx, url, jsonData, c.config.AuthFor(fw), false)
 	if err != nil {
-		return nil, fmt.Errorf("장비 연결 실패: %v", err)
+		return nil, fmt.Errorf("장비 연결 실패: %w", err)
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := readResponse(resp, "장비")
 	if err != nil {
-		return nil, fmt.Errorf("응답 읽기 실패: %v", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("장비 응답 오류: %d", resp.StatusCode)
+		return nil, err
 	}
 
 	// 응답 파싱
@@ -181,7 +272,7 @@ func (c *Client) DeployDirect(deviceIP string, template string) (*model.DeployRe
 		Data []model.DeployResult `json:"data"`
 	}
 	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("응답 파싱 실패: %v", err)
+		return nil, &ProtocolError{Err: fmt.Errorf("응답 파싱 실패: %v", err)}
 	}
 
 	// 해당 장비의 결과 찾기
@@ -196,22 +287,22 @@ func (c *Client) DeployDirect(deviceIP string, template string) (*model.DeployRe
 		return &response.Data[0], nil
 	}
 
-	return nil, fmt.Errorf("장비 %s의 배포 결과를 찾을 수 없습니다", deviceIP)
+	return nil, &MissingDeviceError{DeviceIP: deviceIP}
 }
 
 // 장비 상태를 확인합니다. (설정에 따라 Agent 또는 Direct)
-func (c *Client) CheckHealth(fw *model.Firewall) (string, error) {
+func (c *Client) CheckHealth(ctx context.Context, fw *model.Firewall) (string, error) {
 	var isRunning bool
 	var err error
 
 	if c.config.IsAgentMode() {
-		result, err := c.CheckHealthViaAgent([]string{fw.DeviceName})
+		result, err := c.CheckHealthViaAgent(ctx, []string{fw.DeviceName})
 		if err != nil {
 			return model.ServerStatusStop, err
 		}
 		isRunning = result[fw.DeviceName]
 	} else {
-		isRunning, err = c.CheckHealthDirect(fw.DeviceName)
+		isRunning, err = c.CheckHealthDirect(ctx, fw)
 		if err != nil {
 			return model.ServerStatusStop, err
 		}
@@ -224,9 +315,15 @@ func (c *Client) CheckHealth(fw *model.Firewall) (string, error) {
 }
 
 // 템플릿을 배포합니다. (설정에 따라 Agent 또는 Direct)
-func (c *Client) DeployTemplate(fw *model.Firewall, template string) (*model.DeployResult, error) {
+func (c *Client) DeployTemplate(ctx context.Context, fw *model.Firewall, template string) (*model.DeployResult, error) {
 	if c.config.IsAgentMode() {
-		return c.DeployViaAgent(fw.DeviceName, template)
+		return c.DeployViaAgent(ctx, fw.DeviceName, template)
 	}
-	return c.DeployDirect(fw.DeviceName, template)
+	return c.DeployDirect(ctx, fw, template)
+}
+
+// 장비에 적용된 규칙을 조회합니다.
+// Agent 서버와 장비의 HTTP API에는 규칙 조회 엔드포인트가 없으므로 항상 ErrFetchRulesUnsupported를 반환합니다.
+func (c *Client) FetchRules(ctx context.Context, fw *model.Firewall) (string, error) {
+	return "", ErrFetchRulesUnsupported
 }
@@ -0,0 +1,61 @@
+package model
+
+import "fmt"
+
+// 인증 방식 상수
+const (
+	AuthTypeNone   = "none"   // 인증 없음
+	AuthTypeBearer = "bearer" // Authorization: Bearer <토큰>
+	AuthTypeBasic  = "basic"  // Authorization: Basic <사용자명:비밀번호>
+	AuthTypeHMAC   = "hmac"   // 요청 본문과 시각에 대한 HMAC-SHA256 서명
+)
+
+// 에이전트 서버 또는 장비 요청의 인증 설정을 나타냅니다.
+// 비밀값(Secret)은 JSON으로 직렬화되지 않으며 저장소가 별도 파일에 보관합니다.
+type AuthConfig struct {
+	Type     string `json:"type"`               // 인증 방식 (none/bearer/basic/hmac)
+	Username string `json:"username,omitempty"` // basic 사용자명 또는 HMAC 키 ID
+	Secret   string `json:"-"`                  // 토큰, 비밀번호 또는 HMAC 키
+}
+
+// 인증을 사용하는지 확인합니다.
+func (a *AuthConfig) IsEnabled() bool {
+	return a != nil && a.Type != "" && a.Type != AuthTypeNone
+}
+
+// 인증 설정이 유효한지 검사합니다.
+func (a *AuthConfig) Validate() error {
+	switch a.Type {
+	case "", AuthTypeNone:
+		return nil
+	case AuthTypeBearer:
+		if a.Secret == "" {
+			return fmt.Errorf("Bearer 토큰을 입력해주세요")
+		}
+	case AuthTypeBasic:
+		if a.Username == "" || a.Secret == "" {
+			return fmt.Errorf("Basic 인증 사용자명과 비밀번호를 입력해주세요")
+		}
+	case AuthTypeHMAC:
+		if a.Secret == "" {
+			return fmt.Errorf("HMAC 서명 키를 입력해주세요")
+		}
+	default:
+		return fmt.Errorf("지원하지 않는 인증 방식입니다: %s", a.Type)
+	}
+	return nil
+}
+
+// 인증 설정의 복사본을 반환합니다.
+func (a *AuthConfig) Clone() *AuthConfig {
+	if a == nil {
+		return nil
+	}
+	clone := *a
+	return &clone
+}
+
+// 인증 방식 옵션 목록을 반환합니다.
+func GetAuthTypeOptions() []string {
+	return []string{AuthTypeNone, AuthTypeBearer, AuthTypeBasic, AuthTypeHMAC}
+}
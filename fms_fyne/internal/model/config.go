@@ -5,24 +5,80 @@ package model
 const (
 	ConnectionModeAgent  = "agent"  // 에이전트 서버를 통한 연결
 	ConnectionModeDirect = "direct" // 직접 연결
+	ConnectionModeSSH    = "ssh"    // 장비 SSH 접속
+)
+
+// 장비 직접 연결 스킴 상수
+const (
+	SchemeHTTP  = "http"
+	SchemeHTTPS = "https"
+)
+
+// 기본 SSH 포트
+const DefaultSSHPort = 22
+
+// SSH 모드 규칙 전달 형식 상수
+const (
+	RuleFormatAgent   = "agent"   // 장비 셸에서 agent 명령 실행
+	RuleFormatSmartfw = "smartfw" // /proc/smartfw에 요청 라인 기록
 )
 
 // 기본 타임아웃 (초)
 const DefaultTimeoutSeconds = 5
 
+// 기본 동시 배포 수
+const DefaultMaxConcurrentDeploys = 5
+
+// 기본 Agent 일괄 배포 크기 (요청 1회당 장비 수)
+const DefaultAgentBatchSize = 20
+
 // 애플리케이션 설정을 나타냅니다.
 type Config struct {
-	ConnectionMode string `json:"connectionMode"` // 연결 모드: "agent" 또는 "direct"
-	AgentServerURL string `json:"agentServerURL"` // 에이전트 서버 URL (예: http://172.24.10.6:8080)
-	TimeoutSeconds int    `json:"timeoutSeconds"` // HTTP 타임아웃 (초)
+	ConnectionMode       string     `json:"connectionMode"`       // 연결 모드: "agent" 또는 "direct"
+	AgentServerURL       string     `json:"agentServerURL"`       // 에이전트 서버 URL (예: http://172.24.10.6:8080)
+	TimeoutSeconds       int        `json:"timeoutSeconds"`       // HTTP 타임아웃 (초)
+	MaxConcurrentDeploys int        `json:"maxConcurrentDeploys"` // 동시에 배포할 최대 장비 수
+	DisableAutoRollback  bool       `json:"disableAutoRollback"`  // 배포 실패 시 자동 롤백 비활성화
+	AgentBatchSize       int        `json:"agentBatchSize"`       // Agent 모드에서 요청 1회에 배포할 장비 수
+	DeviceScheme         string     `json:"deviceScheme"`         // 장비 직접 연결 스킴: "http" 또는 "https"
+	AgentTLS             TLSConfig  `json:"agentTLS"`             // 에이전트 서버 TLS 설정 (https URL 사용 시)
+	DeviceTLS            TLSConfig  `json:"deviceTLS"`            // 장비 직접 연결 TLS 설정 (https 스킴 사용 시)
+	Auth                 AuthConfig `json:"auth"`                 // 요청 인증 설정 (에이전트 서버 및 장비 공통)
+	SSH                  SSHConfig  `json:"ssh"`                  // 장비 SSH 접속 설정 (ssh 모드 사용 시)
+}
+
+// SSH 접속 설정을 나타냅니다.
+type SSHConfig struct {
+	Port           int      `json:"port"`           // SSH 포트 (미설정 시 22, 장비 주소에 포트가 있으면 무시)
+	Username       string   `json:"username"`       // 로그인 사용자
+	KeyFile        string   `json:"keyFile"`        // 개인키 경로 (PEM/OpenSSH, 암호 없는 키)
+	KnownHostsFile string   `json:"knownHostsFile"` // 호스트 키 검증용 known_hosts 경로 (미설정 시 ~/.ssh/known_hosts)
+	HostKeySHA256  []string `json:"hostKeySha256"`  // 허용할 호스트 키 지문 (SHA256:..., 설정 시 known_hosts 대신 사용)
+	RuleFormat     string   `json:"ruleFormat"`     // 규칙 전달 형식: "agent"(기본) 또는 "smartfw"
+}
+
+// TLS 연결 설정을 나타냅니다.
+type TLSConfig struct {
+	CAFile       string   `json:"caFile"`       // 서버 인증서 검증용 CA 번들 경로 (PEM, 미설정 시 시스템 CA)
+	CertFile     string   `json:"certFile"`     // 클라이언트 인증서 경로 (PEM, mTLS)
+	KeyFile      string   `json:"keyFile"`      // 클라이언트 개인키 경로 (PEM, mTLS)
+	ServerName   string   `json:"serverName"`   // 인증서 검증 시 사용할 서버 이름 (IP로 접속하는 경우 등)
+	PinnedSHA256 []string `json:"pinnedSha256"` // 허용할 서버 공개키(SPKI) SHA-256 해시 (base64)
+}
+
+// 사용자 지정 TLS 설정이 있는지 확인합니다.
+func (t *TLSConfig) IsEmpty() bool {
+	return t.CAFile == "" && t.CertFile == "" && t.KeyFile == "" && t.ServerName == "" && len(t.PinnedSHA256) == 0
 }
 
 // 기본 설정을 반환합니다.
 func DefaultConfig() *Config {
 	return &Config{
-		ConnectionMode: ConnectionModeDirect,
-		AgentServerURL: "http://172.24.10.6:8080",
-		TimeoutSeconds: DefaultTimeoutSeconds,
+		ConnectionMode:       ConnectionModeDirect,
+		AgentServerURL:       "http://172.24.10.6:8080",
+		TimeoutSeconds:       DefaultTimeoutSeconds,
+		MaxConcurrentDeploys: DefaultMaxConcurrentDeploys,
+		AgentBatchSize:       DefaultAgentBatchSize,
 	}
 }
 
@@ -37,6 +93,63 @@ func (c *Config) GetTimeoutSeconds() int {
 	return c.TimeoutSeconds
 }
 
+// 동시 배포 수를 반환합니다 (미설정 시 기본값, 최대 50)
+func (c *Config) GetMaxConcurrentDeploys() int {
+	if c.MaxConcurrentDeploys <= 0 {
+		return DefaultMaxConcurrentDeploys
+	}
+	if c.MaxConcurrentDeploys > 50 {
+		return 50
+	}
+	return c.MaxConcurrentDeploys
+}
+
+// Agent 일괄 배포 크기를 반환합니다 (미설정 시 기본값, 최대 500)
+func (c *Config) GetAgentBatchSize() int {
+	if c.AgentBatchSize <= 0 {
+		return DefaultAgentBatchSize
+	}
+	if c.AgentBatchSize > 500 {
+		return 500
+	}
+	return c.AgentBatchSize
+}
+
+// 장비 직접 연결 스킴을 반환합니다 (미설정 시 http)
+func (c *Config) GetDeviceScheme() string {
+	if c.DeviceScheme == SchemeHTTPS {
+		return SchemeHTTPS
+	}
+	return SchemeHTTP
+}
+
+// SSH 포트를 반환합니다 (미설정 시 22)
+func (c *Config) GetSSHPort() int {
+	if c.SSH.Port <= 0 || c.SSH.Port > 65535 {
+		return DefaultSSHPort
+	}
+	return c.SSH.Port
+}
+
+// SSH 모드에서 규칙을 smartfw 형식으로 전달하는지 확인합니다.
+func (c *Config) IsSmartfwRuleFormat() bool {
+	return c.SSH.RuleFormat == RuleFormatSmartfw
+}
+
+// 장비 요청에 사용할 인증 설정을 반환합니다.
+// 장비별 인증 설정이 있으면 전역 설정 대신 사용합니다.
+func (c *Config) AuthFor(fw *Firewall) *AuthConfig {
+	if fw != nil && fw.Auth != nil {
+		return fw.Auth
+	}
+	return &c.Auth
+}
+
+// 배포 실패 시 이전 버전으로 자동 롤백하는지 확인합니다.
+func (c *Config) IsAutoRollbackEnabled() bool {
+	return !c.DisableAutoRollback
+}
+
 // 연결 모드가 에이전트 모드인지 확인합니다.
 func (c *Config) IsAgentMode() bool {
 	return c.ConnectionMode == ConnectionModeAgent
@@ -46,3 +159,8 @@ func (c *Config) IsAgentMode() bool {
 func (c *Config) IsDirectMode() bool {
 	return c.ConnectionMode == ConnectionModeDirect
 }
+
+// 연결 모드가 SSH 모드인지 확인합니다.
+func (c *Config) IsSSHMode() bool {
+	return c.ConnectionMode == ConnectionModeSSH
+}
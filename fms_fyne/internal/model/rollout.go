@@ -0,0 +1,55 @@
+package model
+
+import "fmt"
+
+// 단계별(카나리) 배포 설정을 나타냅니다.
+type RolloutOptions struct {
+	CanarySize        int     `json:"canarySize"`        // 첫 웨이브(카나리) 장비 수
+	WaveSize          int     `json:"waveSize"`          // 이후 웨이브당 장비 수
+	PauseSeconds      int     `json:"pauseSeconds"`      // 웨이브 배포 후 상태 확인까지 대기 시간 (초)
+	MaxFailurePercent float64 `json:"maxFailurePercent"` // 허용 실패율 (%), 초과 시 롤아웃 중단
+}
+
+// 기본 단계별 배포 설정을 반환합니다.
+func DefaultRolloutOptions() *RolloutOptions {
+	return &RolloutOptions{
+		CanarySize:        1,
+		WaveSize:          10,
+		PauseSeconds:      30,
+		MaxFailurePercent: 0,
+	}
+}
+
+// 단계별 배포 설정이 유효한지 검사합니다.
+func (o *RolloutOptions) Validate() error {
+	if o.CanarySize < 1 {
+		return fmt.Errorf("카나리 장비 수는 1 이상이어야 합니다")
+	}
+	if o.WaveSize < 1 {
+		return fmt.Errorf("웨이브 크기는 1 이상이어야 합니다")
+	}
+	if o.PauseSeconds < 0 || o.PauseSeconds > 3600 {
+		return fmt.Errorf("대기 시간은 0~3600초 사이여야 합니다")
+	}
+	if o.MaxFailurePercent < 0 || o.MaxFailurePercent > 100 {
+		return fmt.Errorf("허용 실패율은 0~100 사이여야 합니다")
+	}
+	return nil
+}
+
+// 장비 수를 웨이브별 장비 수 목록으로 분할합니다.
+// 첫 웨이브는 카나리 크기, 이후 웨이브는 웨이브 크기를 따릅니다.
+func (o *RolloutOptions) WaveSizes(total int) []int {
+	var sizes []int
+	remaining := total
+	size := o.CanarySize
+	for remaining > 0 {
+		if size > remaining {
+			size = remaining
+		}
+		sizes = append(sizes, size)
+		remaining -= size
+		size = o.WaveSize
+	}
+	return sizes
+}
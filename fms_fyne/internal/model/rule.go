@@ -2,6 +2,8 @@ package model
 
 import (
 	"fmt"
+	"net"
+	"regexp"
 	"strconv"
 	"strings"
 )
@@ -36,12 +38,20 @@ const (
 	ActionREJECT Action = 2
 )
 
+// Family 주소 체계 타입
+type Family int
+
+const (
+	FamilyIPv4 Family = 0
+	FamilyIPv6 Family = 1
+)
+
 // ProtocolOptions 프로토콜별 세부 옵션
 type ProtocolOptions struct {
 	// TCP 옵션
 	TCPFlags string // 예: "syn/syn", "syn,ack/syn"
 
-	// ICMP 옵션
+	// ICMP 옵션 (IPv6 규칙은 ICMPv6 type/code)
 	ICMPType string // 예: "echo-request", "8"
 	ICMPCode string // 예: "0", "3" (선택)
 }
@@ -77,10 +87,17 @@ type FirewallRule struct {
 	Options  *ProtocolOptions // 프로토콜 옵션
 	Action   Action
 	DPort    string // Destination 포트
+	SPort    string // Source 포트
 	SIP      string // Source IP (콤마리스트 지원)
 	DIP      string // Destination IP (콤마리스트 지원)
 	Black    bool   // 블랙리스트 규칙 여부
 	White    bool   // 화이트리스트 규칙 여부
+
+	InInterface  string // 입력 인터페이스 (INPUT, FORWARD)
+	OutInterface string // 출력 인터페이스 (OUTPUT, FORWARD)
+	State        string // 연결 상태 (예: "ESTABLISHED,RELATED")
+
+	Family Family // 주소 체계 (기본값 IPv4)
 }
 
 // NewFirewallRule 기본값으로 새 규칙 생성
@@ -92,6 +109,16 @@ func NewFirewallRule() *FirewallRule {
 	}
 }
 
+// Clone 규칙 복사본 반환 (프로토콜 옵션 포함)
+func (r *FirewallRule) Clone() *FirewallRule {
+	clone := *r
+	if r.Options != nil {
+		options := *r.Options
+		clone.Options = &options
+	}
+	return &clone
+}
+
 // ChainToString Chain을 문자열로 변환
 func ChainToString(c Chain) string {
 	switch c {
@@ -110,24 +137,30 @@ func ChainToString(c Chain) string {
 	}
 }
 
-// StringToChain 문자열을 Chain으로 변환
-func StringToChain(s string) Chain {
+// ParseChain 문자열을 Chain으로 변환 (알 수 없는 값은 에러)
+func ParseChain(s string) (Chain, error) {
 	switch strings.ToUpper(s) {
 	case "INPUT":
-		return ChainINPUT
+		return ChainINPUT, nil
 	case "OUTPUT":
-		return ChainOUTPUT
+		return ChainOUTPUT, nil
 	case "FORWARD":
-		return ChainFORWARD
+		return ChainFORWARD, nil
 	case "PREROUTING":
-		return ChainPREROUTING
+		return ChainPREROUTING, nil
 	case "POSTROUTING":
-		return ChainPOSTROUTING
+		return ChainPOSTROUTING, nil
 	default:
-		return ChainINPUT
+		return ChainINPUT, fmt.Errorf("알 수 없는 체인: %s", s)
 	}
 }
 
+// StringToChain 문자열을 Chain으로 변환 (알 수 없는 값은 INPUT)
+func StringToChain(s string) Chain {
+	v, _ := ParseChain(s)
+	return v
+}
+
 // ProtocolToString Protocol을 문자열로 변환
 func ProtocolToString(p Protocol) string {
 	switch p {
@@ -144,20 +177,71 @@ func ProtocolToString(p Protocol) string {
 	}
 }
 
-// StringToProtocol 문자열을 Protocol로 변환
-func StringToProtocol(s string) Protocol {
+// ParseProtocol 문자열을 Protocol로 변환 (알 수 없는 값은 에러)
+func ParseProtocol(s string) (Protocol, error) {
 	switch strings.ToLower(s) {
 	case "tcp":
-		return ProtocolTCP
+		return ProtocolTCP, nil
 	case "udp":
-		return ProtocolUDP
+		return ProtocolUDP, nil
 	case "icmp":
-		return ProtocolICMP
+		return ProtocolICMP, nil
 	case "any":
-		return ProtocolANY
+		return ProtocolANY, nil
 	default:
-		return ProtocolTCP
+		return ProtocolTCP, fmt.Errorf("알 수 없는 프로토콜: %s", s)
+	}
+}
+
+// StringToProtocol 문자열을 Protocol로 변환 (알 수 없는 값은 TCP)
+func StringToProtocol(s string) Protocol {
+	v, _ := ParseProtocol(s)
+	return v
+}
+
+// FamilyToString Family를 문자열로 변환
+func FamilyToString(f Family) string {
+	if f == FamilyIPv6 {
+		return "ipv6"
+	}
+	return "ipv4"
+}
+
+// ParseFamily 문자열을 Family로 변환 (알 수 없는 값은 에러)
+func ParseFamily(s string) (Family, error) {
+	switch strings.ToLower(s) {
+	case "ipv4", "ip", "inet", "4":
+		return FamilyIPv4, nil
+	case "ipv6", "ip6", "inet6", "6":
+		return FamilyIPv6, nil
+	default:
+		return FamilyIPv4, fmt.Errorf("알 수 없는 주소 체계: %s", s)
+	}
+}
+
+// AddressFamily IP 주소 또는 CIDR의 주소 체계 반환 (IP가 아니면 false)
+func AddressFamily(s string) (Family, bool) {
+	ip, _, _ := strings.Cut(strings.TrimSpace(s), "/")
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return FamilyIPv4, false
+	}
+	if addr.To4() != nil {
+		return FamilyIPv4, true
 	}
+	return FamilyIPv6, true
+}
+
+// InferFamily 주소 목록(쉼표 구분)에 IPv6 주소가 있으면 IPv6, 없으면 IPv4 반환
+func InferFamily(lists ...string) Family {
+	for _, list := range lists {
+		for _, item := range strings.Split(list, ",") {
+			if family, ok := AddressFamily(item); ok && family == FamilyIPv6 {
+				return FamilyIPv6
+			}
+		}
+	}
+	return FamilyIPv4
 }
 
 // ActionToString Action을 문자열로 변환
@@ -174,20 +258,26 @@ func ActionToString(a Action) string {
 	}
 }
 
-// StringToAction 문자열을 Action으로 변환
-func StringToAction(s string) Action {
+// ParseAction 문자열을 Action으로 변환 (알 수 없는 값은 에러)
+func ParseAction(s string) (Action, error) {
 	switch strings.ToUpper(s) {
 	case "DROP":
-		return ActionDROP
+		return ActionDROP, nil
 	case "ACCEPT":
-		return ActionACCEPT
+		return ActionACCEPT, nil
 	case "REJECT":
-		return ActionREJECT
+		return ActionREJECT, nil
 	default:
-		return ActionDROP
+		return ActionDROP, fmt.Errorf("알 수 없는 동작: %s", s)
 	}
 }
 
+// StringToAction 문자열을 Action으로 변환 (알 수 없는 값은 DROP)
+func StringToAction(s string) Action {
+	v, _ := ParseAction(s)
+	return v
+}
+
 // GetChainOptions UI Select용 Chain 옵션 목록
 func GetChainOptions() []string {
 	return []string{"INPUT", "OUTPUT", "FORWARD"}
@@ -199,6 +289,52 @@ func GetProtocolOptions() []string {
 	return []string{"tcp", "udp", "icmp", "any"}
 }
 
+// ParseConnState 연결 상태 목록을 검사하여 대문자로 정규화 (예: "established,related" → "ESTABLISHED,RELATED")
+func ParseConnState(s string) (string, error) {
+	var states []string
+	for _, state := range strings.Split(s, ",") {
+		state = strings.ToUpper(strings.TrimSpace(state))
+		if !isValidConnState(state) {
+			return s, fmt.Errorf("알 수 없는 연결 상태: %s", state)
+		}
+		states = append(states, state)
+	}
+	return strings.Join(states, ","), nil
+}
+
+func isValidConnState(state string) bool {
+	for _, s := range GetConnStateOptions() {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// GetConnStateOptions 연결 상태 옵션 목록 (체크박스용)
+func GetConnStateOptions() []string {
+	return []string{"NEW", "ESTABLISHED", "RELATED", "INVALID"}
+}
+
+// 인터페이스 이름 최대 길이 (커널 IFNAMSIZ 16에서 끝의 NUL 제외, iptables와 동일)
+const maxInterfaceNameLength = 15
+
+// 인터페이스 이름 허용 문자 (끝의 +는 iptables 와일드카드, 예: ppp+)
+var interfaceNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]*\+?$`)
+
+// ValidateInterfaceName 인터페이스 이름 검사 (최대 15자, 영문자/숫자/./_/-, 끝에만 + 허용)
+func ValidateInterfaceName(name string) error {
+	if name == "" || len(name) > maxInterfaceNameLength || !interfaceNamePattern.MatchString(name) {
+		return fmt.Errorf("잘못된 인터페이스 이름: %q (최대 %d자, 영문자/숫자/./_/- 사용, 끝의 +는 와일드카드)", name, maxInterfaceNameLength)
+	}
+	return nil
+}
+
+// GetFamilyOptions UI Select용 주소 체계 옵션 목록
+func GetFamilyOptions() []string {
+	return []string{"ipv4", "ipv6"}
+}
+
 // GetActionOptions UI Select용 Action 옵션 목록
 func GetActionOptions() []string {
 	return []string{"DROP", "ACCEPT"}
@@ -445,3 +581,160 @@ func ICMPCodeNumberToName(num int) string {
 	}
 	return strconv.Itoa(num)
 }
+
+// GetICMPv6TypeOptions ICMPv6 type 옵션 목록 (UI Select용, IPv6 규칙)
+func GetICMPv6TypeOptions() []string {
+	return []string{
+		"None",
+		"destination-unreachable",
+		"packet-too-big",
+		"time-exceeded",
+		"parameter-problem",
+		"echo-request",
+		"echo-reply",
+		"router-solicitation",
+		"router-advertisement",
+		"neighbour-solicitation",
+		"neighbour-advertisement",
+		"redirect",
+	}
+}
+
+// icmpv6TypeMap ICMPv6 타입 이름 → 숫자 매핑 (ip6tables 이름, neighbor 표기 포함)
+var icmpv6TypeMap = map[string]int{
+	"destination-unreachable": 1,
+	"packet-too-big":          2,
+	"time-exceeded":           3,
+	"parameter-problem":       4,
+	"echo-request":            128,
+	"echo-reply":              129,
+	"router-solicitation":     133,
+	"router-advertisement":    134,
+	"neighbour-solicitation":  135,
+	"neighbor-solicitation":   135,
+	"neighbour-advertisement": 136,
+	"neighbor-advertisement":  136,
+	"redirect":                137,
+}
+
+// icmpv6TypeReverseMap ICMPv6 타입 숫자 → 이름 매핑
+var icmpv6TypeReverseMap = map[int]string{
+	1:   "destination-unreachable",
+	2:   "packet-too-big",
+	3:   "time-exceeded",
+	4:   "parameter-problem",
+	128: "echo-request",
+	129: "echo-reply",
+	133: "router-solicitation",
+	134: "router-advertisement",
+	135: "neighbour-solicitation",
+	136: "neighbour-advertisement",
+	137: "redirect",
+}
+
+// ICMPv6TypeNameToNumber ICMPv6 type 이름을 숫자로 변환
+func ICMPv6TypeNameToNumber(name string) (int, error) {
+	if num, ok := icmpv6TypeMap[name]; ok {
+		return num, nil
+	}
+	if num, err := strconv.Atoi(name); err == nil {
+		return num, nil
+	}
+	return 0, fmt.Errorf("알 수 없는 ICMPv6 타입: %s", name)
+}
+
+// ICMPv6TypeNumberToName ICMPv6 type 숫자를 이름으로 변환
+func ICMPv6TypeNumberToName(num int) string {
+	if name, ok := icmpv6TypeReverseMap[num]; ok {
+		return name
+	}
+	return strconv.Itoa(num)
+}
+
+// GetICMPv6CodeOptions ICMPv6 code 옵션 목록 (Type 1: destination-unreachable 전용)
+func GetICMPv6CodeOptions() []string {
+	return []string{
+		"None",
+		"no-route (0)",
+		"communication-prohibited (1)",
+		"beyond-scope (2)",
+		"address-unreachable (3)",
+		"port-unreachable (4)",
+		"failed-policy (5)",
+		"reject-route (6)",
+		"Custom...",
+	}
+}
+
+// icmpv6CodeMap ICMPv6 Code 이름 → 숫자 매핑 (Type 1: destination-unreachable)
+var icmpv6CodeMap = map[string]int{
+	"no-route":                 0,
+	"communication-prohibited": 1,
+	"beyond-scope":             2,
+	"address-unreachable":      3,
+	"port-unreachable":         4,
+	"failed-policy":            5,
+	"reject-route":             6,
+}
+
+// icmpv6CodeReverseMap ICMPv6 Code 숫자 → 이름 매핑
+var icmpv6CodeReverseMap = map[int]string{
+	0: "no-route",
+	1: "communication-prohibited",
+	2: "beyond-scope",
+	3: "address-unreachable",
+	4: "port-unreachable",
+	5: "failed-policy",
+	6: "reject-route",
+}
+
+// ICMPv6CodeNameToNumber ICMPv6 code 이름을 숫자로 변환
+func ICMPv6CodeNameToNumber(name string) (int, error) {
+	if num, ok := icmpv6CodeMap[name]; ok {
+		return num, nil
+	}
+	if num, err := strconv.Atoi(name); err == nil {
+		return num, nil
+	}
+	return 0, fmt.Errorf("알 수 없는 ICMPv6 코드: %s", name)
+}
+
+// ICMPv6CodeNumberToName ICMPv6 code 숫자를 이름으로 변환
+func ICMPv6CodeNumberToName(num int) string {
+	if name, ok := icmpv6CodeReverseMap[num]; ok {
+		return name
+	}
+	return strconv.Itoa(num)
+}
+
+// ICMPTypeNumber 주소 체계에 맞는 ICMP type 숫자 반환 (IPv6는 ICMPv6 type)
+func ICMPTypeNumber(family Family, name string) (int, error) {
+	if family == FamilyIPv6 {
+		return ICMPv6TypeNameToNumber(name)
+	}
+	return ICMPTypeNameToNumber(name)
+}
+
+// ICMPCodeNumber 주소 체계에 맞는 ICMP code 숫자 반환 (IPv6는 ICMPv6 code)
+func ICMPCodeNumber(family Family, name string) (int, error) {
+	if family == FamilyIPv6 {
+		return ICMPv6CodeNameToNumber(name)
+	}
+	return ICMPCodeNameToNumber(name)
+}
+
+// ICMPTypeName 주소 체계에 맞는 ICMP type 이름 반환
+func ICMPTypeName(family Family, num int) string {
+	if family == FamilyIPv6 {
+		return ICMPv6TypeNumberToName(num)
+	}
+	return ICMPTypeNumberToName(num)
+}
+
+// ICMPCodeName 주소 체계에 맞는 ICMP code 이름 반환
+func ICMPCodeName(family Family, num int) string {
+	if family == FamilyIPv6 {
+		return ICMPv6CodeNumberToName(num)
+	}
+	return ICMPCodeNumberToName(num)
+}
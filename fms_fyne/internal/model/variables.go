@@ -0,0 +1,169 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// 템플릿 변수 참조 형식 (예: --sip=${MGMT_NET})
+const (
+	VariablePrefix = "${"
+	VariableSuffix = "}"
+)
+
+// 변수 이름 형식 (영문자 또는 _로 시작, 영문자/숫자/_)
+var variableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// 템플릿 안의 변수 참조 (이름 형식과 관계없이 ${ 와 } 사이)
+var variableRefPattern = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// 장비 그룹입니다. 그룹에 속한 장비는 그룹의 변수 값을 기본값으로 사용합니다.
+type DeviceGroup struct {
+	Name        string            `json:"name"`                  // 그룹 이름 (Primary Key)
+	Variables   map[string]string `json:"variables,omitempty"`   // 템플릿 변수 값
+	Description string            `json:"description,omitempty"` // 설명 (선택)
+}
+
+// 새로운 장비 그룹을 생성합니다.
+func NewDeviceGroup(name string) *DeviceGroup {
+	return &DeviceGroup{
+		Name:      name,
+		Variables: make(map[string]string),
+	}
+}
+
+// 장비 그룹의 복사본을 반환합니다.
+func (g *DeviceGroup) Clone() *DeviceGroup {
+	clone := *g
+	clone.Variables = cloneVariables(g.Variables)
+	return &clone
+}
+
+// 장비 그룹이 유효한지 검사합니다.
+func (g *DeviceGroup) Validate() error {
+	if strings.TrimSpace(g.Name) == "" {
+		return fmt.Errorf("장비 그룹 이름이 비어 있습니다")
+	}
+	if err := ValidateVariables(g.Variables); err != nil {
+		return fmt.Errorf("장비 그룹 %s: %v", g.Name, err)
+	}
+	return nil
+}
+
+// 변수 이름 형식을 검사합니다.
+func ValidateVariableName(name string) error {
+	if !variableNamePattern.MatchString(name) {
+		return fmt.Errorf("잘못된 변수 이름: %q (영문자 또는 _로 시작, 영문자/숫자/_ 사용)", name)
+	}
+	return nil
+}
+
+// 변수 이름과 값을 검사합니다.
+// 값은 규칙 한 줄의 옵션 값으로 들어가므로 비어 있거나 공백을 포함할 수 없습니다.
+func ValidateVariables(variables map[string]string) error {
+	for _, name := range sortedVariableNames(variables) {
+		if err := ValidateVariableName(name); err != nil {
+			return err
+		}
+		value := variables[name]
+		if value == "" {
+			return fmt.Errorf("변수 %s의 값이 비어 있습니다", name)
+		}
+		if strings.ContainsFunc(value, unicode.IsSpace) {
+			return fmt.Errorf("변수 %s의 값에 공백을 사용할 수 없습니다: %q", name, value)
+		}
+		if strings.Contains(value, VariablePrefix) {
+			return fmt.Errorf("변수 %s의 값에 다른 변수를 사용할 수 없습니다: %q", name, value)
+		}
+	}
+	return nil
+}
+
+// 템플릿 내용에 변수 참조가 있는지 확인합니다. (주석 포함)
+func HasTemplateVariables(contents string) bool {
+	return strings.Contains(contents, VariablePrefix)
+}
+
+// 템플릿 내용에서 참조하는 변수 이름을 처음 나온 순서대로 중복 없이 반환합니다.
+func TemplateVariables(contents string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, match := range variableRefPattern.FindAllStringSubmatch(contents, -1) {
+		if name := match[1]; !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// 변수 참조를 값으로 바꾼 템플릿 내용을 반환합니다.
+// 정의되지 않은 변수가 있으면 모든 누락 변수를 에러로 반환합니다.
+func RenderVariables(contents string, variables map[string]string) (string, error) {
+	if missing := MissingVariables(contents, variables); len(missing) > 0 {
+		return "", fmt.Errorf("정의되지 않은 변수: %s", formatVariableRefs(missing))
+	}
+	return variableRefPattern.ReplaceAllStringFunc(contents, func(ref string) string {
+		return variables[strings.TrimSuffix(strings.TrimPrefix(ref, VariablePrefix), VariableSuffix)]
+	}), nil
+}
+
+// 템플릿이 참조하지만 값이 없는 변수 이름을 반환합니다.
+func MissingVariables(contents string, variables map[string]string) []string {
+	var missing []string
+	for _, name := range TemplateVariables(contents) {
+		if _, ok := variables[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// 장비 그룹의 변수 위에 장비의 변수를 덮어쓴 최종 변수 값을 반환합니다.
+// group이 nil이면 장비의 변수만 사용합니다.
+func MergeVariables(group *DeviceGroup, fw *Firewall) map[string]string {
+	merged := make(map[string]string)
+	if group != nil {
+		for name, value := range group.Variables {
+			merged[name] = value
+		}
+	}
+	for name, value := range fw.Variables {
+		merged[name] = value
+	}
+	return merged
+}
+
+// 변수 이름 목록을 ${이름} 형식으로 결합합니다.
+func formatVariableRefs(names []string) string {
+	refs := make([]string, len(names))
+	for i, name := range names {
+		refs[i] = VariablePrefix + name + VariableSuffix
+	}
+	return strings.Join(refs, ", ")
+}
+
+// 변수 이름을 정렬하여 반환합니다.
+func sortedVariableNames(variables map[string]string) []string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// 변수 맵의 복사본을 반환합니다. (nil은 nil)
+func cloneVariables(variables map[string]string) map[string]string {
+	if variables == nil {
+		return nil
+	}
+	clone := make(map[string]string, len(variables))
+	for name, value := range variables {
+		clone[name] = value
+	}
+	return clone
+}
@@ -0,0 +1,28 @@
+package parser
+
+import (
+	"strings"
+
+	"fms/internal/model"
+)
+
+// AnalyzeText 템플릿 텍스트의 필터 규칙을 분석하여 가려진/불필요한/충돌하는 규칙을 반환
+// NAT 규칙과 파싱할 수 없는 라인은 분석에서 제외하며, 결과의 라인 번호는 텍스트 기준입니다.
+func AnalyzeText(text string) []model.RuleFinding {
+	var rules []*model.FirewallRule
+	var lines []int
+
+	for i, line := range strings.Split(text, "\n") {
+		if IsNATLine(line) {
+			continue
+		}
+		rule, err := ParseLine(line)
+		if err != nil || rule == nil {
+			continue
+		}
+		rules = append(rules, rule)
+		lines = append(lines, i+1)
+	}
+
+	return model.AnalyzeRules(rules, lines)
+}
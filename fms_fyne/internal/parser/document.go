@@ -0,0 +1,288 @@
+package parser
+
+import (
+	"strings"
+
+	"fms/internal/model"
+)
+
+// LineKind 템플릿 문서 라인 종류
+type LineKind int
+
+const (
+	LineBlank   LineKind = iota // 빈 줄
+	LineComment                 // 규칙에 붙지 않은 주석 (섹션 주석 등)
+	LineRule                    // 필터 규칙
+	LineNATRule                 // NAT 규칙
+	LineOther                   // 파싱할 수 없는 줄 (원문 유지)
+)
+
+// DocumentLine 템플릿 문서의 한 줄
+// 규칙 라인은 빈 줄 없이 바로 위에 붙어 있는 주석을 함께 보관하며,
+// 규칙이 삭제되면 붙어 있는 주석도 함께 삭제됩니다.
+type DocumentLine struct {
+	Kind     LineKind
+	Text     string              // 원문 (변경되지 않은 규칙은 원문 그대로 출력)
+	Comments []string            // 규칙에 붙어 있는 주석 원문
+	Rule     *model.FirewallRule // LineRule인 경우
+	NATRule  *model.NATRule      // LineNATRule인 경우
+}
+
+// key 규칙 비교용 정규화 텍스트
+func (l *DocumentLine) key() string {
+	switch l.Kind {
+	case LineRule:
+		return RuleToLine(l.Rule)
+	case LineNATRule:
+		return NATRuleToLine(l.NATRule)
+	default:
+		return l.Text
+	}
+}
+
+// Document 라인 순서, 주석, 빈 줄을 보존하는 템플릿 문서
+// 텍스트 → 빌더 → 텍스트 변환 시 변경되지 않은 라인은 원문 그대로 유지됩니다.
+type Document struct {
+	Lines []*DocumentLine
+}
+
+// ParseDocument 템플릿 텍스트를 문서로 파싱 (관대한 모드)
+func ParseDocument(text string) *Document {
+	doc := &Document{}
+	if text == "" {
+		return doc
+	}
+
+	var comments []string // 다음 규칙에 붙일 주석
+	flushComments := func() {
+		for _, c := range comments {
+			doc.Lines = append(doc.Lines, &DocumentLine{Kind: LineComment, Text: c})
+		}
+		comments = nil
+	}
+
+	for _, raw := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(raw)
+
+		if strings.HasPrefix(trimmed, "#") {
+			comments = append(comments, raw)
+			continue
+		}
+
+		line := parseDocumentLine(raw, trimmed)
+		if line.Kind == LineRule || line.Kind == LineNATRule {
+			line.Comments = comments
+			comments = nil
+		} else {
+			flushComments()
+		}
+		doc.Lines = append(doc.Lines, line)
+	}
+	flushComments()
+
+	return doc
+}
+
+// parseDocumentLine 주석이 아닌 한 줄을 문서 라인으로 변환
+func parseDocumentLine(raw, trimmed string) *DocumentLine {
+	line := &DocumentLine{Kind: LineOther, Text: raw}
+
+	switch {
+	case trimmed == "":
+		line.Kind = LineBlank
+	case IsNATLine(trimmed):
+		if rule, err := ParseNATLine(trimmed); err == nil && rule != nil {
+			line.Kind = LineNATRule
+			line.NATRule = rule
+		}
+	default:
+		if rule, err := ParseLine(trimmed); err == nil && rule != nil {
+			line.Kind = LineRule
+			line.Rule = rule
+		}
+	}
+
+	return line
+}
+
+// Rules 필터 규칙 목록 반환 (문서 순서, 복사본)
+func (d *Document) Rules() []*model.FirewallRule {
+	rules := []*model.FirewallRule{}
+	for _, line := range d.Lines {
+		if line.Kind == LineRule {
+			rules = append(rules, line.Rule.Clone())
+		}
+	}
+	return rules
+}
+
+// NATRules NAT 규칙 목록 반환 (문서 순서, 복사본)
+func (d *Document) NATRules() []*model.NATRule {
+	rules := []*model.NATRule{}
+	for _, line := range d.Lines {
+		if line.Kind == LineNATRule {
+			rules = append(rules, line.NATRule.Clone())
+		}
+	}
+	return rules
+}
+
+// SetRules 빌더에서 편집한 필터 규칙 목록을 문서에 반영
+func (d *Document) SetRules(rules []*model.FirewallRule) {
+	lines := make([]*DocumentLine, len(rules))
+	for i, rule := range rules {
+		lines[i] = &DocumentLine{Kind: LineRule, Text: RuleToLine(rule), Rule: rule.Clone()}
+	}
+	d.apply(LineRule, lines)
+}
+
+// SetNATRules 빌더에서 편집한 NAT 규칙 목록을 문서에 반영
+func (d *Document) SetNATRules(rules []*model.NATRule) {
+	lines := make([]*DocumentLine, len(rules))
+	for i, rule := range rules {
+		lines[i] = &DocumentLine{Kind: LineNATRule, Text: NATRuleToLine(rule), NATRule: rule.Clone()}
+	}
+	d.apply(LineNATRule, lines)
+}
+
+// String 문서를 텍스트로 변환
+func (d *Document) String() string {
+	var lines []string
+	for _, line := range d.Lines {
+		lines = append(lines, line.Comments...)
+		lines = append(lines, line.Text)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// apply 같은 종류의 규칙 라인을 새 목록으로 교체
+// 기존 규칙과 새 규칙을 내용 기준으로 정렬(LCS)하여
+// - 일치하는 규칙은 원문과 주석을 그대로 유지하고
+// - 일치 구간 사이에서 삭제/추가가 겹치면 제자리에서 수정하며 (주석 유지)
+// - 남는 기존 규칙은 주석과 함께 삭제하고
+// - 남는 새 규칙은 앞 규칙 바로 뒤에 추가합니다.
+func (d *Document) apply(kind LineKind, newLines []*DocumentLine) {
+	var oldLines []*DocumentLine
+	for _, line := range d.Lines {
+		if line.Kind == kind {
+			oldLines = append(oldLines, line)
+		}
+	}
+
+	// 새 목록 순서대로 최종 라인 결정 (기존 라인이면 유지/수정, 아니면 추가)
+	final := make([]*DocumentLine, len(newLines))
+	retained := make(map[*DocumentLine]bool)
+	prevOld, prevNew := -1, -1
+	for _, m := range append(matchLines(oldLines, newLines), [2]int{len(oldLines), len(newLines)}) {
+		// 일치 구간 사이: 앞에서부터 짝지어 제자리 수정
+		oi, ni := prevOld+1, prevNew+1
+		for ; oi < m[0] && ni < m[1]; oi, ni = oi+1, ni+1 {
+			old := oldLines[oi]
+			old.Text, old.Rule, old.NATRule = newLines[ni].Text, newLines[ni].Rule, newLines[ni].NATRule
+			final[ni] = old
+			retained[old] = true
+		}
+		for ; ni < m[1]; ni++ {
+			final[ni] = newLines[ni]
+		}
+		if m[0] < len(oldLines) {
+			final[m[1]] = oldLines[m[0]]
+			retained[oldLines[m[0]]] = true
+		}
+		prevOld, prevNew = m[0], m[1]
+	}
+
+	// 기존 라인 위치에 맞춰 재구성
+	var result []*DocumentLine
+	next := 0 // 아직 배치하지 않은 final 인덱스
+	for _, line := range d.Lines {
+		if line.Kind != kind {
+			result = append(result, line)
+			continue
+		}
+		if !retained[line] {
+			continue
+		}
+		// 유지되는 라인 앞의 추가 규칙 (목록 맨 앞에 추가된 경우)
+		for next < len(final) && final[next] != line {
+			result = append(result, final[next])
+			next++
+		}
+		result = append(result, line)
+		next++
+		// 유지되는 라인 뒤에 이어서 추가된 규칙
+		for next < len(final) && !retained[final[next]] {
+			result = append(result, final[next])
+			next++
+		}
+	}
+
+	// 유지되는 라인이 없으면 기본 위치에 추가
+	if next < len(final) {
+		at := insertPosition(result, kind)
+		tail := append([]*DocumentLine{}, result[at:]...)
+		result = append(append(result[:at], final[next:]...), tail...)
+	}
+
+	d.Lines = result
+}
+
+// insertPosition 같은 종류의 규칙이 없을 때 새 규칙을 추가할 위치
+// 필터 규칙은 첫 NAT 규칙 앞에, 그 외에는 마지막 내용 라인 뒤에 추가합니다.
+func insertPosition(lines []*DocumentLine, kind LineKind) int {
+	if kind == LineRule {
+		for i, line := range lines {
+			if line.Kind == LineNATRule {
+				return i
+			}
+		}
+	}
+	for i := len(lines) - 1; i >= 0; i-- {
+		if lines[i].Kind != LineBlank {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// matchLines 두 라인 목록의 최장 공통 부분 수열(LCS)을 [기존 인덱스, 새 인덱스] 쌍으로 반환
+func matchLines(oldLines, newLines []*DocumentLine) [][2]int {
+	oldKeys := make([]string, len(oldLines))
+	for i, line := range oldLines {
+		oldKeys[i] = line.key()
+	}
+	newKeys := make([]string, len(newLines))
+	for i, line := range newLines {
+		newKeys[i] = line.key()
+	}
+
+	// lcs[i][j]: oldKeys[i:]와 newKeys[j:]의 LCS 길이
+	lcs := make([][]int, len(oldKeys)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newKeys)+1)
+	}
+	for i := len(oldKeys) - 1; i >= 0; i-- {
+		for j := len(newKeys) - 1; j >= 0; j-- {
+			if oldKeys[i] == newKeys[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var matches [][2]int
+	for i, j := 0, 0; i < len(oldKeys) && j < len(newKeys); {
+		switch {
+		case oldKeys[i] == newKeys[j]:
+			matches = append(matches, [2]int{i, j})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return matches
+}
@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"fms/internal/model"
+)
+
+// Mode 파싱 모드
+type Mode int
+
+const (
+	// ModeLenient 알 수 없는 옵션은 무시하고 잘못된 값은 기본값으로 변환 (기존 데이터 가져오기용)
+	ModeLenient Mode = iota
+	// ModeStrict 템플릿 검증기(model.ValidateLine)의 오류를 파싱 오류로 처리 (템플릿 저장용)
+	// 알 수 없는 옵션, 중복 옵션, 잘못된 값, 불필요한 토큰 모두 저장 시 검증과 같은 규칙으로 판단
+	ModeStrict
+)
+
+// checkLine 템플릿 검증기(model.ValidateLine)로 라인을 검사하여 첫 오류를 반환
+// 엄격 모드의 유효성은 이 검사만으로 판단 (파서와 검증기가 같은 문법을 사용)
+func checkLine(line string) error {
+	return firstError(model.ValidateLine(line))
+}
+
+// checkProtocol 템플릿 검증기의 -p 문법(model.ValidateProtocol)으로 프로토콜 문자열을 검사하여 첫 오류를 반환
+func checkProtocol(s string, family model.Family) error {
+	return firstError(model.ValidateProtocol(s, family))
+}
+
+// firstError 진단 목록의 첫 오류를 에러로 변환 (경고는 무시)
+func firstError(diagnostics []model.Diagnostic) error {
+	for _, d := range diagnostics {
+		if d.IsError() {
+			return errors.New(d.Message)
+		}
+	}
+	return nil
+}
+
+// CheckText 템플릿 텍스트 전체를 모드에 맞게 파싱하여 라인별 오류 목록을 반환
+// -t=nat 라인은 NAT 규칙으로, 나머지는 일반 규칙으로 파싱
+func CheckText(text string, mode Mode) []error {
+	var errors []error
+
+	for i, line := range strings.Split(text, "\n") {
+		var err error
+		if IsNATLine(line) {
+			_, err = ParseNATLineWithMode(line, mode)
+		} else {
+			_, err = ParseLineWithMode(line, mode)
+		}
+		if err != nil {
+			errors = append(errors, fmt.Errorf("라인 %d: %w", i+1, err))
+		}
+	}
+
+	return errors
+}
@@ -7,9 +7,22 @@ import (
 	"fms/internal/model"
 )
 
-// ParseNATLine NAT 규칙 라인을 파싱하여 NATRule로 변환
+// ParseNATLine NAT 규칙 라인을 파싱하여 NATRule로 변환 (관대한 모드)
 // agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=6080 --to-dest=192.168.30.180:8080
+// IPv6 변환 대상은 대괄호로 감쌉니다: --to-dest=[2001:db8::10]:8080
 func ParseNATLine(line string) (*model.NATRule, error) {
+	return ParseNATLineWithMode(line, ModeLenient)
+}
+
+// ParseNATLineWithMode NAT 규칙 라인을 모드에 맞게 파싱하여 NATRule로 변환
+// 엄격 모드에서는 템플릿 검증기(model.ValidateLine)의 오류를 파싱 오류로 반환
+func ParseNATLineWithMode(line string, mode Mode) (*model.NATRule, error) {
+	if mode == ModeStrict {
+		if err := checkLine(line); err != nil {
+			return nil, err
+		}
+	}
+
 	line = strings.TrimSpace(line)
 
 	// 빈 줄 처리
@@ -23,50 +36,62 @@ func ParseNATLine(line string) (*model.NATRule, error) {
 	}
 
 	// agent 형식이 아니면 오류
-	if !strings.HasPrefix(line, "agent ") {
+	tokens := model.TokenizeLine(line)
+	if tokens[0].Text != "agent" || len(tokens) == 1 {
 		return nil, fmt.Errorf("알 수 없는 형식: %s", line)
 	}
 
 	// -t=nat 확인
-	if !strings.Contains(line, "-t=nat") {
+	if !model.IsNATOptions(tokens[1:]) {
 		return nil, fmt.Errorf("NAT 규칙이 아닙니다: %s", line)
 	}
 
+	// 값 검사는 엄격 모드에서 검증기가 하므로 여기서는 변환만 함
+	// (변환할 수 없는 값은 기본값, 알 수 없는 옵션은 무시, 중복 옵션은 마지막 값)
 	rule := model.NewNATRule()
-	parts := strings.Fields(line)
-
-	for _, part := range parts {
-		switch {
-		case strings.HasPrefix(part, "--nat-type="):
-			rule.NATType = model.StringToNATType(part[11:])
-		case strings.HasPrefix(part, "-p="):
-			rule.Protocol = model.StringToProtocol(part[3:])
-		case strings.HasPrefix(part, "--match-port="):
-			rule.MatchPort = part[13:]
-		case strings.HasPrefix(part, "--match-ip="):
-			rule.MatchIP = part[11:]
-		case strings.HasPrefix(part, "-s="):
-			rule.MatchIP = part[3:]
-		case strings.HasPrefix(part, "--to-dest="):
-			// 192.168.30.180:8080 형식 파싱
-			dest := part[10:]
-			if idx := strings.LastIndex(dest, ":"); idx != -1 {
-				rule.TranslateIP = dest[:idx]
-				rule.TranslatePort = dest[idx+1:]
-			} else {
-				rule.TranslateIP = dest
+	familySet := false
+
+loop:
+	for _, tok := range tokens[1:] {
+		name, value := tok.Option()
+
+		switch name {
+		case "--nat-type":
+			rule.NATType, _ = model.ParseNATType(value)
+		case "-p":
+			rule.Protocol, _ = model.ParseProtocol(value)
+		case "--family":
+			if family, err := model.ParseFamily(value); err == nil {
+				rule.Family = family
+				familySet = true
 			}
-		case strings.HasPrefix(part, "--to-source="):
-			rule.TranslateIP = part[12:]
-		case strings.HasPrefix(part, "-i="):
-			rule.InInterface = part[3:]
-		case strings.HasPrefix(part, "-o="):
-			rule.OutInterface = part[3:]
-		case strings.HasPrefix(part, "--desc="):
-			rule.Description = part[7:]
+		case "--match-port":
+			rule.MatchPort = value
+		case "--match-ip", "-s":
+			// --match-ip와 -s는 같은 필드의 별칭
+			rule.MatchIP = value
+		case "--to-dest":
+			// 192.168.30.180:8080, [2001:db8::10]:8080 형식 파싱
+			rule.TranslateIP, rule.TranslatePort = model.SplitDestination(value)
+		case "--to-source":
+			rule.TranslateIP = value
+		case "-i":
+			rule.InInterface = value
+		case "-o":
+			rule.OutInterface = value
+		case "--desc":
+			// 설명은 공백을 포함할 수 있으므로 --desc= 이후 줄 끝까지를 설명으로 사용
+			rest := strings.TrimPrefix(string([]rune(line)[tok.Column-1:]), name)
+			rule.Description = strings.TrimSpace(strings.TrimPrefix(rest, "="))
+			break loop
 		}
 	}
 
+	// --family를 생략하면 주소로 주소 체계를 정함
+	if !familySet {
+		rule.Family = model.InferFamily(rule.MatchIP, rule.TranslateIP)
+	}
+
 	return rule, nil
 }
 
@@ -82,6 +107,10 @@ func NATRuleToLine(rule *model.NATRule) string {
 	parts = append(parts, "-t=nat")
 	parts = append(parts, fmt.Sprintf("--nat-type=%s", strings.ToLower(model.NATTypeToString(rule.NATType))))
 	parts = append(parts, fmt.Sprintf("-p=%s", model.ProtocolToString(rule.Protocol)))
+	// 주소로 알 수 없는 IPv6 규칙만 주소 체계 출력 (예: IPv6 MASQUERADE)
+	if rule.Family == model.FamilyIPv6 && model.InferFamily(rule.MatchIP, rule.TranslateIP) != model.FamilyIPv6 {
+		parts = append(parts, fmt.Sprintf("--family=%s", model.FamilyToString(rule.Family)))
+	}
 
 	switch rule.NATType {
 	case model.NATTypeDNAT:
@@ -91,13 +120,9 @@ func NATRuleToLine(rule *model.NATRule) string {
 		if rule.MatchIP != "" && rule.MatchIP != "ANY" {
 			parts = append(parts, fmt.Sprintf("-s=%s", rule.MatchIP))
 		}
-		// --to-dest=IP:PORT
+		// --to-dest=IP:PORT (IPv6는 [IP]:PORT)
 		if rule.TranslateIP != "" {
-			dest := rule.TranslateIP
-			if rule.TranslatePort != "" {
-				dest += ":" + rule.TranslatePort
-			}
-			parts = append(parts, fmt.Sprintf("--to-dest=%s", dest))
+			parts = append(parts, fmt.Sprintf("--to-dest=%s", model.JoinDestination(rule.TranslateIP, rule.TranslatePort)))
 		}
 
 	case model.NATTypeSNAT:
@@ -200,8 +225,13 @@ func NATRuleToSmartfw(rule *model.NATRule, id string) string {
 	return ""
 }
 
-// ParseTextToNATRules 전체 텍스트에서 NAT 규칙 추출
+// ParseTextToNATRules 전체 텍스트에서 NAT 규칙 추출 (관대한 모드)
 func ParseTextToNATRules(text string) ([]*model.NATRule, []string, []error) {
+	return ParseTextToNATRulesWithMode(text, ModeLenient)
+}
+
+// ParseTextToNATRulesWithMode 전체 텍스트에서 모드에 맞게 NAT 규칙 추출
+func ParseTextToNATRulesWithMode(text string, mode Mode) ([]*model.NATRule, []string, []error) {
 	var rules []*model.NATRule
 	var comments []string
 	var errors []error
@@ -221,12 +251,12 @@ func ParseTextToNATRules(text string) ([]*model.NATRule, []string, []error) {
 			continue
 		}
 
-		// NAT 규칙만 파싱 (-t=nat 포함된 라인)
-		if !strings.Contains(line, "-t=nat") {
+		// NAT 규칙만 파싱 (-t=nat 옵션이 있는 라인)
+		if !model.IsNATLine(line) {
 			continue
 		}
 
-		rule, err := ParseNATLine(line)
+		rule, err := ParseNATLineWithMode(line, mode)
 		if err != nil {
 			errors = append(errors, fmt.Errorf("라인 %d: %w", i+1, err))
 			continue
@@ -257,7 +287,7 @@ func NATRulesToText(rules []*model.NATRule, comments []string) string {
 	return strings.Join(lines, "\n")
 }
 
-// IsNATLine 라인이 NAT 규칙인지 확인
+// IsNATLine 라인이 NAT 규칙인지 확인 (템플릿 검증기와 같은 기준)
 func IsNATLine(line string) bool {
-	return strings.Contains(line, "-t=nat")
+	return model.IsNATLine(line)
 }
@@ -0,0 +1,274 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"fms/internal/model"
+)
+
+// 객체를 참조할 수 있는 옵션 (주소 객체: --sip, --dip / 서비스 객체: --dport, --sport)
+var objectRefOptions = map[string]model.ObjectKind{
+	"--sip":   model.ObjectKindAddress,
+	"--dip":   model.ObjectKindAddress,
+	"--dport": model.ObjectKindService,
+	"--sport": model.ObjectKindService,
+}
+
+// ObjectReferences 템플릿 텍스트에서 참조하는 객체 이름을 처음 나온 순서대로 반환
+// 주석과 NAT 규칙은 제외합니다.
+func ObjectReferences(text string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(text, "\n") {
+		if IsNATLine(line) {
+			continue
+		}
+		for _, field := range ruleFields(line) {
+			name, value, _ := strings.Cut(field, "=")
+			if _, ok := objectRefOptions[name]; !ok {
+				continue
+			}
+			for _, item := range strings.Split(value, ",") {
+				if ref, ok := model.ObjectRefName(item); ok && !seen[ref] {
+					seen[ref] = true
+					names = append(names, ref)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// ExpandObjects 템플릿의 객체 참조(@이름)를 객체 멤버로 펼친 텍스트를 반환
+// 주소 객체의 범위는 CIDR 목록으로 바뀌며, 주소 체계(IPv4/IPv6)가 섞인 주소 객체나
+// -p=any 규칙에서 여러 프로토콜을 가진 서비스 객체를 참조하면 규칙을 주소 체계/프로토콜별로 나누어 여러 줄로 펼칩니다.
+// 참조가 없는 라인, 주석, NAT 규칙은 그대로 유지합니다.
+func ExpandObjects(text string, objects []*model.NamedObject) (string, error) {
+	byName := make(map[string]*model.NamedObject, len(objects))
+	for _, obj := range objects {
+		byName[obj.Name] = obj
+	}
+
+	var out []string
+	var errs []string
+	for i, line := range strings.Split(text, "\n") {
+		expanded, err := expandLine(line, byName)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("라인 %d: %v", i+1, err))
+			continue
+		}
+		out = append(out, expanded...)
+	}
+	if len(errs) > 0 {
+		return "", fmt.Errorf("객체 참조를 펼칠 수 없습니다:\n%s", strings.Join(errs, "\n"))
+	}
+	return strings.Join(out, "\n"), nil
+}
+
+// ruleFields 규칙 라인의 옵션 필드를 반환 (주석이나 agent 명령이 아니면 nil)
+func ruleFields(line string) []string {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || fields[0] != "agent" {
+		return nil
+	}
+	return fields[1:]
+}
+
+// expandLine 규칙 한 줄의 객체 참조를 펼침
+func expandLine(line string, objects map[string]*model.NamedObject) ([]string, error) {
+	fields := ruleFields(line)
+	if fields == nil || IsNATLine(line) || !hasObjectRef(fields) {
+		return []string{line}, nil
+	}
+
+	protocolIndex, protocol := -1, "tcp" // -p 생략 시 기본값
+	family := ""
+	for i, field := range fields {
+		name, value, _ := strings.Cut(field, "=")
+		switch name {
+		case "-p":
+			protocolIndex = i
+			base, _, _ := strings.Cut(value, "?")
+			protocol = strings.ToLower(base)
+		case "--family":
+			f, err := model.ParseFamily(value)
+			if err != nil {
+				return nil, err
+			}
+			family = model.FamilyToString(f)
+		}
+	}
+
+	// 옵션별 펼친 값 (주소는 주소 체계별, 포트는 프로토콜별)
+	addresses := make(map[int]map[string][]string)
+	ports := make(map[int]map[string][]string)
+	var families, protocols []string
+
+	for i, field := range fields {
+		name, value, _ := strings.Cut(field, "=")
+		kind, ok := objectRefOptions[name]
+		if !ok || value == "" {
+			continue
+		}
+		// 주소는 참조가 없는 옵션도 주소 체계별로 나눔 (--sip=@객체 --dip=IP 조합)
+		if kind == model.ObjectKindService && !strings.Contains(value, model.ObjectRefPrefix) {
+			continue
+		}
+		var err error
+		if kind == model.ObjectKindAddress {
+			addresses[i], err = expandAddresses(value, objects)
+			families = appendKeys(families, addresses[i], "ipv4", "ipv6")
+		} else {
+			ports[i], err = expandPorts(value, protocol, objects)
+			protocols = appendKeys(protocols, ports[i], "tcp", "udp")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+	}
+
+	if family != "" {
+		families = []string{family}
+	}
+	if len(families) == 0 {
+		families = []string{""}
+	}
+	if protocol != "any" || len(protocols) == 0 {
+		protocols = []string{protocol}
+	}
+
+	var lines []string
+	for _, f := range families {
+	combination:
+		for _, p := range protocols {
+			expanded := append([]string(nil), fields...)
+			for i, values := range addresses {
+				if f != "" && len(values[f]) == 0 {
+					continue combination
+				}
+				expanded[i] = replaceValue(expanded[i], values[f])
+			}
+			for i, values := range ports {
+				if len(values[p]) == 0 {
+					continue combination
+				}
+				expanded[i] = replaceValue(expanded[i], values[p])
+			}
+			if protocol == "any" && p != "any" && protocolIndex != -1 {
+				expanded[protocolIndex] = "-p=" + p
+			}
+			lines = append(lines, "agent "+strings.Join(expanded, " "))
+		}
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("규칙의 주소 체계/프로토콜에 맞는 객체 멤버가 없습니다")
+	}
+	return lines, nil
+}
+
+// hasObjectRef 객체를 참조하는 옵션이 있는지 확인
+func hasObjectRef(fields []string) bool {
+	for _, field := range fields {
+		name, value, _ := strings.Cut(field, "=")
+		if _, ok := objectRefOptions[name]; ok && strings.Contains(value, model.ObjectRefPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupObject 참조 이름으로 객체를 찾고 종류를 확인
+func lookupObject(ref string, kind model.ObjectKind, objects map[string]*model.NamedObject) (*model.NamedObject, error) {
+	obj, ok := objects[ref]
+	if !ok {
+		return nil, fmt.Errorf("객체를 찾을 수 없습니다: %s%s", model.ObjectRefPrefix, ref)
+	}
+	if obj.Kind != kind {
+		return nil, fmt.Errorf("%s는 %s 객체가 아닙니다", obj.Ref(), kind)
+	}
+	return obj, nil
+}
+
+// expandAddresses 주소 목록의 객체 참조를 펼쳐 주소 체계별 주소 목록으로 반환 ("ipv4", "ipv6", ""는 전체)
+func expandAddresses(value string, objects map[string]*model.NamedObject) (map[string][]string, error) {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		ref, ok := model.ObjectRefName(item)
+		if !ok {
+			items = append(items, item)
+			continue
+		}
+		obj, err := lookupObject(ref, model.ObjectKindAddress, objects)
+		if err != nil {
+			return nil, err
+		}
+		addrs, err := obj.Addresses()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, addrs...)
+	}
+
+	result := map[string][]string{"": items}
+	for _, item := range items {
+		f, _ := model.AddressFamily(item)
+		key := model.FamilyToString(f)
+		result[key] = append(result[key], item)
+	}
+	return result, nil
+}
+
+// expandPorts 포트 목록의 객체 참조를 펼쳐 프로토콜별 포트 목록으로 반환
+// 직접 입력한 포트는 모든 프로토콜에 포함됩니다.
+func expandPorts(value, protocol string, objects map[string]*model.NamedObject) (map[string][]string, error) {
+	if protocol != "tcp" && protocol != "udp" && protocol != "any" {
+		return nil, fmt.Errorf("%s 규칙에는 서비스 객체를 사용할 수 없습니다", protocol)
+	}
+
+	result := make(map[string][]string)
+	for _, item := range strings.Split(value, ",") {
+		ref, ok := model.ObjectRefName(item)
+		if !ok {
+			for _, p := range []string{"tcp", "udp", "any"} {
+				result[p] = append(result[p], item)
+			}
+			continue
+		}
+		obj, err := lookupObject(ref, model.ObjectKindService, objects)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range obj.Protocols() {
+			key := model.ProtocolToString(p)
+			result[key] = append(result[key], obj.Ports(p)...)
+		}
+		if protocol != "any" && len(obj.Ports(model.StringToProtocol(protocol))) == 0 {
+			return nil, fmt.Errorf("%s에 %s 포트가 없습니다", obj.Ref(), protocol)
+		}
+	}
+	return result, nil
+}
+
+// appendKeys 값이 있는 키를 순서대로 중복 없이 추가
+func appendKeys(keys []string, values map[string][]string, order ...string) []string {
+	for _, key := range order {
+		if len(values[key]) == 0 {
+			continue
+		}
+		found := false
+		for _, k := range keys {
+			found = found || k == key
+		}
+		if !found {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// replaceValue "옵션=값" 필드의 값을 쉼표로 결합한 목록으로 교체
+func replaceValue(field string, values []string) string {
+	name, _, _ := strings.Cut(field, "=")
+	return name + "=" + strings.Join(values, ",")
+}
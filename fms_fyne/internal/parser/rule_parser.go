@@ -10,6 +10,7 @@ import (
 // ParseProtocolWithOptions 프로토콜 문자열을 파싱
 // 입력: "tcp?flags=syn/syn" 또는 "tcp"
 // 출력: Protocol, *ProtocolOptions, error
+// 값은 변환만 하고 알 수 없는 옵션은 무시 (유효성은 model.ValidateProtocol로 검사)
 func ParseProtocolWithOptions(s string) (model.Protocol, *model.ProtocolOptions, error) {
 	// "?" 기준으로 분리
 	parts := strings.SplitN(s, "?", 2)
@@ -105,9 +106,25 @@ func FormatOptionsOnly(opts *model.ProtocolOptions) string {
 	return strings.Join(params, "&")
 }
 
-// ParseLine 단일 라인을 파싱하여 FirewallRule로 변환
+// ParseLine 단일 라인을 파싱하여 FirewallRule로 변환 (관대한 모드)
 // 빈 줄이나 주석은 nil을 반환
 func ParseLine(line string) (*model.FirewallRule, error) {
+	return ParseLineWithMode(line, ModeLenient)
+}
+
+// ParseLineWithMode 단일 라인을 모드에 맞게 파싱하여 FirewallRule로 변환
+// 빈 줄이나 주석은 nil을 반환
+// 엄격 모드에서는 템플릿 검증기(model.ValidateLine)의 오류를 파싱 오류로 반환
+func ParseLineWithMode(line string, mode Mode) (*model.FirewallRule, error) {
+	if mode == ModeStrict {
+		if err := checkLine(line); err != nil {
+			return nil, err
+		}
+		if model.IsNATLine(line) {
+			return nil, fmt.Errorf("NAT 규칙입니다: %s", strings.TrimSpace(line))
+		}
+	}
+
 	line = strings.TrimSpace(line)
 
 	// 빈 줄 처리
@@ -121,37 +138,58 @@ func ParseLine(line string) (*model.FirewallRule, error) {
 	}
 
 	// agent 형식이 아니면 오류
-	if !strings.HasPrefix(line, "agent ") {
+	tokens := model.TokenizeLine(line)
+	if tokens[0].Text != "agent" || len(tokens) == 1 {
 		return nil, fmt.Errorf("알 수 없는 형식: %s", line)
 	}
 
+	// 값 검사는 엄격 모드에서 검증기가 하므로 여기서는 변환만 함
+	// (변환할 수 없는 값은 기본값, 알 수 없는 옵션은 무시, 중복 옵션은 마지막 값)
 	rule := model.NewFirewallRule()
-	parts := strings.Fields(line)
+	familySet := false
+
+	for _, tok := range tokens[1:] {
+		name, value := tok.Option()
 
-	for _, part := range parts {
 		switch {
-		case strings.HasPrefix(part, "-c="):
-			rule.Chain = model.StringToChain(part[3:])
-		case strings.HasPrefix(part, "-p="):
+		case name == "-c":
+			rule.Chain, _ = model.ParseChain(value)
+		case name == "-p":
 			// 프로토콜 옵션 파싱 (쿼리 스트링 형식 지원)
-			proto, opts, _ := ParseProtocolWithOptions(part[3:])
-			rule.Protocol = proto
-			rule.Options = opts
-		case strings.HasPrefix(part, "-a="):
-			rule.Action = model.StringToAction(part[3:])
-		case strings.HasPrefix(part, "--dport="):
-			rule.DPort = part[8:]
-		case strings.HasPrefix(part, "--sip="):
-			rule.SIP = part[6:]
-		case strings.HasPrefix(part, "--dip="):
-			rule.DIP = part[6:]
-		case part == "--black":
+			rule.Protocol, rule.Options, _ = ParseProtocolWithOptions(value)
+		case name == "-a":
+			rule.Action, _ = model.ParseAction(value)
+		case name == "--family":
+			if family, err := model.ParseFamily(value); err == nil {
+				rule.Family = family
+				familySet = true
+			}
+		case name == "--dport":
+			rule.DPort = value
+		case name == "--sport":
+			rule.SPort = value
+		case name == "--sip":
+			rule.SIP = value
+		case name == "--dip":
+			rule.DIP = value
+		case name == "-i":
+			rule.InInterface = value
+		case name == "-o":
+			rule.OutInterface = value
+		case name == "--state":
+			rule.State, _ = model.ParseConnState(value)
+		case tok.Text == "--black":
 			rule.Black = true
-		case part == "--white":
+		case tok.Text == "--white":
 			rule.White = true
 		}
 	}
 
+	// --family를 생략하면 주소로 주소 체계를 정함
+	if !familySet {
+		rule.Family = model.InferFamily(rule.SIP, rule.DIP)
+	}
+
 	return rule, nil
 }
 
@@ -168,17 +206,33 @@ func RuleToLine(rule *model.FirewallRule) string {
 	// 프로토콜 옵션 포함하여 포맷
 	parts = append(parts, fmt.Sprintf("-p=%s", FormatProtocolWithOptions(rule.Protocol, rule.Options)))
 	parts = append(parts, fmt.Sprintf("-a=%s", model.ActionToString(rule.Action)))
+	// 주소로 알 수 없는 IPv6 규칙만 주소 체계 출력 (예: 주소 없는 IPv6 규칙)
+	if rule.Family == model.FamilyIPv6 && model.InferFamily(rule.SIP, rule.DIP) != model.FamilyIPv6 {
+		parts = append(parts, fmt.Sprintf("--family=%s", model.FamilyToString(rule.Family)))
+	}
 
 	// 선택 필드 (값이 있을 때만 출력)
 	if rule.DPort != "" {
 		parts = append(parts, fmt.Sprintf("--dport=%s", rule.DPort))
 	}
+	if rule.SPort != "" {
+		parts = append(parts, fmt.Sprintf("--sport=%s", rule.SPort))
+	}
 	if rule.SIP != "" {
 		parts = append(parts, fmt.Sprintf("--sip=%s", rule.SIP))
 	}
 	if rule.DIP != "" {
 		parts = append(parts, fmt.Sprintf("--dip=%s", rule.DIP))
 	}
+	if rule.InInterface != "" {
+		parts = append(parts, fmt.Sprintf("-i=%s", rule.InInterface))
+	}
+	if rule.OutInterface != "" {
+		parts = append(parts, fmt.Sprintf("-o=%s", rule.OutInterface))
+	}
+	if rule.State != "" {
+		parts = append(parts, fmt.Sprintf("--state=%s", rule.State))
+	}
 
 	// 플래그 (true일 때만 출력)
 	if rule.Black {
@@ -191,9 +245,15 @@ func RuleToLine(rule *model.FirewallRule) string {
 	return strings.Join(parts, " ")
 }
 
-// ParseTextToRules 전체 텍스트를 파싱하여 규칙 목록으로 변환
+// ParseTextToRules 전체 텍스트를 파싱하여 규칙 목록으로 변환 (관대한 모드)
 // 주석과 빈 줄은 별도로 보존
 func ParseTextToRules(text string) ([]*model.FirewallRule, []string, []error) {
+	return ParseTextToRulesWithMode(text, ModeLenient)
+}
+
+// ParseTextToRulesWithMode 전체 텍스트를 모드에 맞게 파싱하여 규칙 목록으로 변환
+// 주석과 빈 줄은 별도로 보존
+func ParseTextToRulesWithMode(text string, mode Mode) ([]*model.FirewallRule, []string, []error) {
 	var rules []*model.FirewallRule
 	var comments []string // 주석 라인 보존
 	var errors []error
@@ -214,11 +274,11 @@ func ParseTextToRules(text string) ([]*model.FirewallRule, []string, []error) {
 		}
 
 		// NAT 규칙은 건너뛰기 (ParseTextToNATRules에서 처리)
-		if strings.Contains(line, "-t=nat") {
+		if model.IsNATLine(line) {
 			continue
 		}
 
-		rule, err := ParseLine(line)
+		rule, err := ParseLineWithMode(line, mode)
 		if err != nil {
 			errors = append(errors, fmt.Errorf("라인 %d: %w", i+1, err))
 			continue
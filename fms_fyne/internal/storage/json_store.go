@@ -17,8 +17,14 @@ type JSONStore struct {
 
 	// 캐시된 데이터
 	templates map[string]*model.Template
+	revisions map[string][]*model.TemplateRevision // 버전별 템플릿 리비전 (리비전 번호순)
 	firewalls map[int]*model.Firewall
 	history   map[int]*model.DeployHistory
+	objects   map[string]*model.NamedObject
+	groups    map[string]*model.DeviceGroup
+
+	// 인증 비밀값 (secrets.json)
+	secrets secrets
 
 	// Auto increment 카운터
 	nextFirewallID int
@@ -38,8 +44,11 @@ func NewJSONStore(configDir string) (*JSONStore, error) {
 	store := &JSONStore{
 		configDir: configDir,
 		templates: make(map[string]*model.Template),
+		revisions: make(map[string][]*model.TemplateRevision),
 		firewalls: make(map[int]*model.Firewall),
 		history:   make(map[int]*model.DeployHistory),
+		objects:   make(map[string]*model.NamedObject),
+		groups:    make(map[string]*model.DeviceGroup),
 	}
 
 	// 설정 디렉토리 생성
@@ -57,16 +66,28 @@ func NewJSONStore(configDir string) (*JSONStore, error) {
 
 // 모든 데이터 파일을 로드합니다.
 func (s *JSONStore) loadAll() error {
+	if err := s.loadSecrets(); err != nil {
+		return err
+	}
 	if err := s.loadTemplates(); err != nil {
 		return err
 	}
+	if err := s.loadRevisions(); err != nil {
+		return err
+	}
 	if err := s.loadFirewalls(); err != nil {
 		return err
 	}
 	if err := s.loadHistory(); err != nil {
 		return err
 	}
-	return nil
+	if err := s.loadObjects(); err != nil {
+		return err
+	}
+	if err := s.loadGroups(); err != nil {
+		return err
+	}
+	return s.migrateRevisions()
 }
 
 // 템플릿 데이터를 로드합니다.
@@ -111,6 +132,7 @@ func (s *JSONStore) loadFirewalls() error {
 
 	maxID := 0
 	for _, f := range firewalls {
+		s.applyFirewallSecret(f)
 		s.firewalls[f.Index] = f
 		if f.Index > maxID {
 			maxID = f.Index
@@ -177,7 +199,13 @@ func (s *JSONStore) saveFirewalls() error {
 	}
 
 	path := filepath.Join(s.configDir, firewallsFile)
-	return os.WriteFile(path, data, 0644)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+
+	// 장비별 인증 비밀값은 별도 파일에 저장
+	s.syncFirewallSecrets()
+	return s.saveSecrets()
 }
 
 // 배포 이력 데이터를 저장합니다.
@@ -222,16 +250,13 @@ func (s *JSONStore) GetTemplate(version string) (*model.Template, error) {
 	return t.Clone(), nil
 }
 
-// 템플릿을 저장합니다.
+// 템플릿을 저장합니다. 내용이 바뀌었으면 작성자와 메모 없이 새 리비전을 추가합니다.
 func (s *JSONStore) SaveTemplate(template *model.Template) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	s.templates[template.Version] = template.Clone()
-	return s.saveTemplates()
+	_, err := s.CommitTemplate(template, "", "")
+	return err
 }
 
-// 템플릿을 삭제합니다.
+// 템플릿을 삭제합니다. 배포 이력이 참조할 수 있도록 리비전은 남겨 둡니다.
 func (s *JSONStore) DeleteTemplate(version string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -244,12 +269,16 @@ func (s *JSONStore) DeleteTemplate(version string) error {
 	return s.saveTemplates()
 }
 
-// 모든 템플릿을 삭제합니다.
+// 모든 템플릿과 리비전을 삭제합니다.
 func (s *JSONStore) ClearTemplates() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	s.templates = make(map[string]*model.Template)
+	s.revisions = make(map[string][]*model.TemplateRevision)
+	if err := s.saveRevisions(); err != nil {
+		return err
+	}
 	return s.saveTemplates()
 }
 
@@ -422,21 +451,42 @@ func (s *JSONStore) ExportAll() (*ExportData, error) {
 		return nil, err
 	}
 
+	objects, err := s.GetAllObjects()
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := s.GetAllGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	revisions := s.allRevisions()
+	s.mu.RUnlock()
+
 	return &ExportData{
 		Templates: templates,
+		Revisions: revisions,
 		Firewalls: firewalls,
 		History:   history,
+		Objects:   objects,
+		Groups:    groups,
 	}, nil
 }
 
 // 데이터를 가져옵니다.
+// 리비전은 아직 리비전이 없는 버전에만 가져오며, 가져온 템플릿 내용이 최신 리비전과 다르면 새 리비전을 추가합니다.
 func (s *JSONStore) ImportAll(data *ExportData) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// 템플릿 가져오기
+	// 템플릿 리비전과 템플릿 가져오기
+	s.importRevisions(data.Revisions)
 	for _, t := range data.Templates {
-		s.templates[t.Version] = t.Clone()
+		template := t.Clone()
+		s.commitRevision(template, "", importedRevisionNote)
+		s.templates[t.Version] = template
 	}
 
 	// 장비 가져오기
@@ -468,16 +518,35 @@ func (s *JSONStore) ImportAll(data *ExportData) error {
 		}
 	}
 
+	// 객체 가져오기
+	for _, o := range data.Objects {
+		s.objects[o.Name] = o.Clone()
+	}
+
+	// 장비 그룹 가져오기
+	for _, g := range data.Groups {
+		s.groups[g.Name] = g.Clone()
+	}
+
 	// 모든 데이터 저장
 	if err := s.saveTemplates(); err != nil {
 		return err
 	}
+	if err := s.saveRevisions(); err != nil {
+		return err
+	}
 	if err := s.saveFirewalls(); err != nil {
 		return err
 	}
 	if err := s.saveHistory(); err != nil {
 		return err
 	}
+	if err := s.saveObjects(); err != nil {
+		return err
+	}
+	if err := s.saveGroups(); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -499,7 +568,9 @@ func (s *JSONStore) GetConfig() (*model.Config, error) {
 	data, err := os.ReadFile(path)
 	if os.IsNotExist(err) {
 		// 설정 파일이 없으면 기본값 반환
-		return model.DefaultConfig(), nil
+		config := model.DefaultConfig()
+		config.Auth.Secret = s.secrets.Config
+		return config, nil
 	}
 	if err != nil {
 		return nil, err
@@ -509,6 +580,7 @@ func (s *JSONStore) GetConfig() (*model.Config, error) {
 	if err := json.Unmarshal(data, &config); err != nil {
 		return nil, err
 	}
+	config.Auth.Secret = s.secrets.Config
 
 	return &config, nil
 }
@@ -524,5 +596,11 @@ func (s *JSONStore) SaveConfig(config *model.Config) error {
 	}
 
 	path := filepath.Join(s.configDir, configFile)
-	return os.WriteFile(path, data, 0644)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+
+	// 인증 비밀값은 config.json이 아닌 별도 파일에 저장
+	s.secrets.Config = config.Auth.Secret
+	return s.saveSecrets()
 }
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"fms/internal/model"
+)
+
+// 주소/서비스 객체 파일명
+const objectsFile = "objects.json"
+
+// 객체 데이터를 로드합니다.
+func (s *JSONStore) loadObjects() error {
+	path := filepath.Join(s.configDir, objectsFile)
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var objects []*model.NamedObject
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return err
+	}
+
+	for _, o := range objects {
+		s.objects[o.Name] = o
+	}
+	return nil
+}
+
+// 객체 데이터를 이름순으로 저장합니다.
+func (s *JSONStore) saveObjects() error {
+	data, err := json.MarshalIndent(s.sortedObjects(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.configDir, objectsFile)
+	return os.WriteFile(path, data, 0644)
+}
+
+// 객체 복사본을 이름순으로 반환합니다.
+func (s *JSONStore) sortedObjects() []*model.NamedObject {
+	objects := make([]*model.NamedObject, 0, len(s.objects))
+	for _, o := range s.objects {
+		objects = append(objects, o.Clone())
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Name < objects[j].Name
+	})
+	return objects
+}
+
+// ===== Object 메서드 =====
+
+// 모든 객체를 이름순으로 반환합니다.
+func (s *JSONStore) GetAllObjects() ([]*model.NamedObject, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sortedObjects(), nil
+}
+
+// 이름으로 객체를 반환합니다.
+func (s *JSONStore) GetObject(name string) (*model.NamedObject, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	o, ok := s.objects[name]
+	if !ok {
+		return nil, fmt.Errorf("객체를 찾을 수 없습니다: %s", name)
+	}
+	return o.Clone(), nil
+}
+
+// 객체를 저장합니다. (같은 이름의 객체는 덮어씀)
+func (s *JSONStore) SaveObject(object *model.NamedObject) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.objects[object.Name] = object.Clone()
+	return s.saveObjects()
+}
+
+// 객체를 삭제합니다.
+func (s *JSONStore) DeleteObject(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.objects[name]; !ok {
+		return fmt.Errorf("객체를 찾을 수 없습니다: %s", name)
+	}
+
+	delete(s.objects, name)
+	return s.saveObjects()
+}
+
+// 모든 객체를 삭제합니다.
+func (s *JSONStore) ClearObjects() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.objects = make(map[string]*model.NamedObject)
+	return s.saveObjects()
+}
@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"fms/internal/model"
+)
+
+// 템플릿 리비전 파일명
+const revisionsFile = "template_revisions.json"
+
+// 리비전 없이 저장되어 있던 템플릿에 만드는 첫 리비전의 메모
+const legacyRevisionNote = "기존 템플릿"
+
+// 가져온 템플릿에 만드는 리비전의 메모
+const importedRevisionNote = "가져온 템플릿"
+
+// 템플릿 리비전 데이터를 로드합니다.
+func (s *JSONStore) loadRevisions() error {
+	path := filepath.Join(s.configDir, revisionsFile)
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var revisions []*model.TemplateRevision
+	if err := json.Unmarshal(data, &revisions); err != nil {
+		return err
+	}
+
+	for _, r := range revisions {
+		s.revisions[r.Version] = append(s.revisions[r.Version], r)
+	}
+	for _, list := range s.revisions {
+		sortRevisions(list)
+	}
+	return nil
+}
+
+// 템플릿 리비전 데이터를 버전, 리비전 번호순으로 저장합니다.
+func (s *JSONStore) saveRevisions() error {
+	data, err := json.MarshalIndent(s.allRevisions(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.configDir, revisionsFile)
+	return os.WriteFile(path, data, 0644)
+}
+
+// 모든 리비전 복사본을 버전, 리비전 번호순으로 반환합니다.
+func (s *JSONStore) allRevisions() []*model.TemplateRevision {
+	versions := make([]string, 0, len(s.revisions))
+	for version := range s.revisions {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	revisions := []*model.TemplateRevision{}
+	for _, version := range versions {
+		for _, r := range s.revisions[version] {
+			revisions = append(revisions, r.Clone())
+		}
+	}
+	return revisions
+}
+
+// 가져온 리비전을 아직 리비전이 없는 버전에만 추가합니다.
+// 이미 리비전이 있는 버전은 번호와 부모 리비전이 섞이지 않도록 가져오지 않습니다.
+func (s *JSONStore) importRevisions(revisions []*model.TemplateRevision) {
+	imported := make(map[string][]*model.TemplateRevision)
+	for _, r := range revisions {
+		if len(s.revisions[r.Version]) == 0 {
+			imported[r.Version] = append(imported[r.Version], r.Clone())
+		}
+	}
+	for version, list := range imported {
+		sortRevisions(list)
+		s.revisions[version] = list
+	}
+}
+
+// 현재 내용의 리비전이 없는 템플릿(리비전 도입 전 데이터)에 리비전을 만듭니다.
+// 리비전을 만든 경우에만 파일에 저장합니다.
+func (s *JSONStore) migrateRevisions() error {
+	migrated := false
+	for _, t := range s.templates {
+		if s.headRevision(t.Version) == nil || t.Revision != model.TemplateHash(t.Contents) {
+			s.commitRevision(t, "", legacyRevisionNote)
+			migrated = true
+		}
+	}
+	if !migrated {
+		return nil
+	}
+	if err := s.saveTemplates(); err != nil {
+		return err
+	}
+	return s.saveRevisions()
+}
+
+// 버전의 최신 리비전을 반환합니다. 리비전이 없으면 nil을 반환합니다.
+func (s *JSONStore) headRevision(version string) *model.TemplateRevision {
+	list := s.revisions[version]
+	if len(list) == 0 {
+		return nil
+	}
+	return list[len(list)-1]
+}
+
+// 템플릿 내용이 최신 리비전과 다르면 새 리비전을 추가하고
+// 템플릿의 Revision을 최신 리비전 해시로 설정합니다. 최신 리비전을 반환합니다.
+// 내용이 같으면 리비전을 추가하지 않으므로 작성자와 메모는 무시됩니다.
+func (s *JSONStore) commitRevision(template *model.Template, author, note string) *model.TemplateRevision {
+	head := s.headRevision(template.Version)
+	if head == nil || head.Hash != model.TemplateHash(template.Contents) {
+		head = model.NewTemplateRevision(template, head, author, note)
+		s.revisions[template.Version] = append(s.revisions[template.Version], head)
+	}
+	template.Revision = head.Hash
+	return head
+}
+
+// ===== Template Revision 메서드 =====
+
+// 템플릿을 저장하고 내용이 바뀌었으면 작성자와 변경 메모를 담은 새 리비전을 추가합니다.
+// 템플릿의 최신 리비전을 반환합니다.
+func (s *JSONStore) CommitTemplate(template *model.Template, author, note string) (*model.TemplateRevision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := template.Clone()
+	revision := s.commitRevision(stored, author, note)
+	s.templates[stored.Version] = stored
+	if err := s.saveRevisions(); err != nil {
+		return nil, err
+	}
+	if err := s.saveTemplates(); err != nil {
+		return nil, err
+	}
+	return revision.Clone(), nil
+}
+
+// 버전의 리비전 목록을 최신순으로 반환합니다.
+// 템플릿을 삭제해도 리비전은 남아 있으므로 삭제한 버전의 리비전도 조회할 수 있습니다.
+func (s *JSONStore) GetTemplateRevisions(version string) ([]*model.TemplateRevision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := s.revisions[version]
+	revisions := make([]*model.TemplateRevision, len(list))
+	for i, r := range list {
+		revisions[len(list)-1-i] = r.Clone()
+	}
+	return revisions, nil
+}
+
+// 버전의 리비전을 해시(또는 해시 앞부분)로 찾아 반환합니다.
+// 같은 내용으로 되돌린 경우처럼 해시가 같은 리비전이 여럿이면 최신 리비전을 반환합니다.
+func (s *JSONStore) GetTemplateRevision(version, hash string) (*model.TemplateRevision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := s.revisions[version]
+	if hash != "" {
+		for i := len(list) - 1; i >= 0; i-- {
+			if strings.HasPrefix(list[i].Hash, hash) {
+				return list[i].Clone(), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("템플릿 %s의 리비전을 찾을 수 없습니다: %s", version, model.ShortHash(hash))
+}
+
+// 리비전 목록을 리비전 번호순으로 정렬합니다.
+func sortRevisions(revisions []*model.TemplateRevision) {
+	sort.SliceStable(revisions, func(i, j int) bool {
+		return revisions[i].Number < revisions[j].Number
+	})
+}
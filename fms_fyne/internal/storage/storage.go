@@ -14,6 +14,11 @@ type Storage interface {
 	DeleteTemplate(version string) error
 	ClearTemplates() error
 
+	// TemplateRevision 관련 메서드
+	CommitTemplate(template *model.Template, author, note string) (*model.TemplateRevision, error)
+	GetTemplateRevisions(version string) ([]*model.TemplateRevision, error)
+	GetTemplateRevision(version, hash string) (*model.TemplateRevision, error)
+
 	// Firewall 관련 메서드
 	GetAllFirewalls() ([]*model.Firewall, error)
 	GetFirewall(index int) (*model.Firewall, error)
@@ -28,6 +33,20 @@ type Storage interface {
 	DeleteHistory(id int) error
 	ClearHistory() error
 
+	// NamedObject 관련 메서드
+	GetAllObjects() ([]*model.NamedObject, error)
+	GetObject(name string) (*model.NamedObject, error)
+	SaveObject(object *model.NamedObject) error
+	DeleteObject(name string) error
+	ClearObjects() error
+
+	// DeviceGroup 관련 메서드
+	GetAllGroups() ([]*model.DeviceGroup, error)
+	GetGroup(name string) (*model.DeviceGroup, error)
+	SaveGroup(group *model.DeviceGroup) error
+	DeleteGroup(name string) error
+	ClearGroups() error
+
 	// 전체 데이터 Export/Import
 	ExportAll() (*ExportData, error)
 	ImportAll(data *ExportData) error
@@ -35,7 +54,10 @@ type Storage interface {
 
 // Export/Import용 데이터 구조체입니다.
 type ExportData struct {
-	Templates []*model.Template      `json:"templates"`
-	Firewalls []*model.Firewall      `json:"firewalls"`
-	History   []*model.DeployHistory `json:"history"`
+	Templates []*model.Template         `json:"templates"`
+	Revisions []*model.TemplateRevision `json:"revisions,omitempty"`
+	Firewalls []*model.Firewall         `json:"firewalls"`
+	History   []*model.DeployHistory    `json:"history"`
+	Objects   []*model.NamedObject      `json:"objects,omitempty"`
+	Groups    []*model.DeviceGroup      `json:"groups,omitempty"`
 }
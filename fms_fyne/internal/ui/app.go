@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"fms/internal/model"
 	"fms/internal/storage"
@@ -28,6 +29,8 @@ type MainUI struct {
 	templateTab *TemplateTab
 	deviceTab   *DeviceTab
 	historyTab  *HistoryTab
+	objectTab   *ObjectTab
+	groupTab    *GroupTab
 }
 
 // 새로운 메인 UI 인스턴스를 생성합니다.
@@ -41,6 +44,8 @@ func NewMainUI(window fyne.Window, store *storage.JSONStore) *MainUI {
 	ui.templateTab = NewTemplateTab(window, store)
 	ui.deviceTab = NewDeviceTab(window, store, ui.templateTab)
 	ui.historyTab = NewHistoryTab(window, store)
+	ui.objectTab = NewObjectTab(window, store)
+	ui.groupTab = NewGroupTab(window, store)
 
 	// 탭 간 참조 설정
 	ui.deviceTab.SetHistoryTab(ui.historyTab)
@@ -51,13 +56,18 @@ func NewMainUI(window fyne.Window, store *storage.JSONStore) *MainUI {
 		container.NewTabItemWithIcon("템플릿 관리", theme.DocumentIcon(), ui.templateTab.Content()),
 		container.NewTabItemWithIcon("장비 관리", theme.ComputerIcon(), ui.deviceTab.Content()),
 		container.NewTabItemWithIcon("배포 이력", theme.HistoryIcon(), ui.historyTab.Content()),
+		container.NewTabItemWithIcon("객체 관리", theme.ListIcon(), ui.objectTab.Content()),
+		container.NewTabItemWithIcon("장비 그룹", theme.FolderIcon(), ui.groupTab.Content()),
 	)
 	ui.tabs.SetTabLocation(container.TabLocationTop)
 
 	// 탭 변경 시 이벤트 처리
 	ui.tabs.OnSelected = func(tab *container.TabItem) {
-		if ui.tabs.SelectedIndex() == 1 { // 장비 관리 탭
+		switch ui.tabs.SelectedIndex() {
+		case 1: // 장비 관리 탭
 			ui.deviceTab.RefreshTemplates()
+		case 4: // 장비 그룹 탭 (장비 탭에서 바뀐 소속 장비 반영)
+			ui.groupTab.ClearSelection()
 		}
 	}
 
@@ -117,10 +127,13 @@ func (m *MainUI) showSettingsDialog() {
 		return
 	}
 
-	// 연결 모드 라디오 그룹 (Agent Server는 임시로 비활성화)
-	connectionMode := widget.NewRadioGroup([]string{"Agent Server (준비중)", "Direct"}, nil)
-	connectionMode.SetSelected("Direct")
-	connectionMode.Disable() // Agent 모드 임시 비활성화
+	// 연결 모드 라디오 그룹 (Agent Server는 임시로 선택 불가)
+	connectionMode := widget.NewRadioGroup([]string{"Agent Server (준비중)", "Direct", "SSH"}, nil)
+	if config.IsSSHMode() {
+		connectionMode.SetSelected("SSH")
+	} else {
+		connectionMode.SetSelected("Direct")
+	}
 
 	// Agent Server URL 입력 필드 (Agent 모드 비활성화로 인해 항상 비활성화)
 	agentURLEntry := widget.NewEntry()
@@ -133,17 +146,61 @@ func (m *MainUI) showSettingsDialog() {
 	timeoutEntry.SetText(strconv.Itoa(config.GetTimeoutSeconds()))
 	timeoutEntry.SetPlaceHolder("10")
 
+	// 동시 배포 수 입력 필드
+	concurrencyEntry := widget.NewEntry()
+	concurrencyEntry.SetText(strconv.Itoa(config.GetMaxConcurrentDeploys()))
+	concurrencyEntry.SetPlaceHolder(strconv.Itoa(model.DefaultMaxConcurrentDeploys))
+
+	// Agent 일괄 배포 수 입력 필드
+	batchSizeEntry := widget.NewEntry()
+	batchSizeEntry.SetText(strconv.Itoa(config.GetAgentBatchSize()))
+	batchSizeEntry.SetPlaceHolder(strconv.Itoa(model.DefaultAgentBatchSize))
+
+	// 자동 롤백 체크박스
+	autoRollbackCheck := widget.NewCheck("배포 실패 시 이전 버전으로 자동 롤백", nil)
+	autoRollbackCheck.SetChecked(config.IsAutoRollbackEnabled())
+
+	// 장비 직접 연결 스킴 선택
+	schemeSelect := widget.NewSelect([]string{model.SchemeHTTP, model.SchemeHTTPS}, nil)
+	schemeSelect.SetSelected(config.GetDeviceScheme())
+
+	// TLS 설정 (저장 전까지 복사본을 편집)
+	agentTLS := config.AgentTLS
+	deviceTLS := config.DeviceTLS
+	agentTLSButton := widget.NewButton("Agent TLS 설정", func() {
+		m.showTLSDialog("Agent Server TLS 설정", &agentTLS)
+	})
+	deviceTLSButton := widget.NewButton("장비 TLS 설정", func() {
+		m.showTLSDialog("장비 직접 연결 TLS 설정", &deviceTLS)
+	})
+
+	// SSH 설정 (저장 전까지 복사본을 편집)
+	sshConfig := config.SSH
+	sshButton := widget.NewButton("SSH 설정", func() {
+		m.showSSHDialog(&sshConfig)
+	})
+
+	// 인증 설정 (에이전트 서버 및 장비 공통, 장비별 설정은 장비 탭에서 변경)
+	auth := newAuthForm(&config.Auth, false)
+
 	// 연결 모드에 따라 URL 입력 필드 활성화/비활성화
 	updateURLEntryState := func() {
 		if connectionMode.Selected == "Agent Server" {
 			agentURLEntry.Enable()
+			batchSizeEntry.Enable()
 		} else {
 			agentURLEntry.Disable()
+			batchSizeEntry.Disable()
 		}
 	}
 	updateURLEntryState()
 
 	connectionMode.OnChanged = func(selected string) {
+		// Agent 모드 임시 비활성화
+		if selected != "Direct" && selected != "SSH" {
+			connectionMode.SetSelected("Direct")
+			return
+		}
 		updateURLEntryState()
 	}
 
@@ -154,10 +211,19 @@ func (m *MainUI) showSettingsDialog() {
 	formItems := []*widget.FormItem{
 		widget.NewFormItem("Connection", connectionMode),
 		widget.NewFormItem("Agent Server URL", agentURLEntry),
+		widget.NewFormItem("장비 연결 스킴", schemeSelect),
+		widget.NewFormItem("TLS", container.NewHBox(agentTLSButton, deviceTLSButton)),
+		widget.NewFormItem("SSH", sshButton),
+	}
+	formItems = append(formItems, auth.formItems()...)
+	formItems = append(formItems,
 		widget.NewFormItem("Timeout (초)", timeoutEntry),
+		widget.NewFormItem("동시 배포 수", concurrencyEntry),
+		widget.NewFormItem("Agent 일괄 배포 수", batchSizeEntry),
+		widget.NewFormItem("자동 롤백", autoRollbackCheck),
 		widget.NewFormItem("", widget.NewLabel("")), // 빈 줄
 		widget.NewFormItem("설정 저장 경로", configPathLabel),
-	}
+	)
 
 	// 다이얼로그 표시
 	dialog.ShowForm("설정", "저장", "취소", formItems, func(ok bool) {
@@ -167,9 +233,12 @@ func (m *MainUI) showSettingsDialog() {
 
 		// 연결 모드 설정
 		var newConnectionMode string
-		if connectionMode.Selected == "Agent Server" {
+		switch connectionMode.Selected {
+		case "Agent Server":
 			newConnectionMode = model.ConnectionModeAgent
-		} else {
+		case "SSH":
+			newConnectionMode = model.ConnectionModeSSH
+		default:
 			newConnectionMode = model.ConnectionModeDirect
 		}
 
@@ -179,6 +248,12 @@ func (m *MainUI) showSettingsDialog() {
 			return
 		}
 
+		// SSH 설정 검증 (SSH 모드일 경우)
+		if newConnectionMode == model.ConnectionModeSSH && (sshConfig.Username == "" || sshConfig.KeyFile == "") {
+			dialog.ShowError(fmt.Errorf("SSH 사용자와 개인키 경로를 입력해주세요"), m.window)
+			return
+		}
+
 		// 타임아웃 값 파싱
 		timeoutSeconds, err := strconv.Atoi(timeoutEntry.Text)
 		if err != nil || timeoutSeconds < 5 || timeoutSeconds > 120 {
@@ -186,14 +261,42 @@ func (m *MainUI) showSettingsDialog() {
 			return
 		}
 
-		// 설정 저장
-		newConfig := &model.Config{
-			ConnectionMode: newConnectionMode,
-			AgentServerURL: agentURLEntry.Text,
-			TimeoutSeconds: timeoutSeconds,
+		// 동시 배포 수 파싱
+		maxConcurrentDeploys, err := strconv.Atoi(concurrencyEntry.Text)
+		if err != nil || maxConcurrentDeploys < 1 || maxConcurrentDeploys > 50 {
+			dialog.ShowError(fmt.Errorf("동시 배포 수는 1~50 사이의 숫자를 입력해주세요"), m.window)
+			return
+		}
+
+		// Agent 일괄 배포 수 파싱
+		agentBatchSize, err := strconv.Atoi(batchSizeEntry.Text)
+		if err != nil || agentBatchSize < 1 || agentBatchSize > 500 {
+			dialog.ShowError(fmt.Errorf("Agent 일괄 배포 수는 1~500 사이의 숫자를 입력해주세요"), m.window)
+			return
 		}
 
-		if err := m.store.SaveConfig(newConfig); err != nil {
+		// 인증 설정 검증
+		authConfig, err := auth.result()
+		if err != nil {
+			dialog.ShowError(err, m.window)
+			return
+		}
+
+		// 설정 저장 (폼에 없는 항목은 기존 값 유지)
+		newConfig := *config
+		newConfig.ConnectionMode = newConnectionMode
+		newConfig.AgentServerURL = agentURLEntry.Text
+		newConfig.TimeoutSeconds = timeoutSeconds
+		newConfig.MaxConcurrentDeploys = maxConcurrentDeploys
+		newConfig.DisableAutoRollback = !autoRollbackCheck.Checked
+		newConfig.AgentBatchSize = agentBatchSize
+		newConfig.DeviceScheme = schemeSelect.Selected
+		newConfig.AgentTLS = agentTLS
+		newConfig.DeviceTLS = deviceTLS
+		newConfig.Auth = *authConfig
+		newConfig.SSH = sshConfig
+
+		if err := m.store.SaveConfig(&newConfig); err != nil {
 			dialog.ShowError(err, m.window)
 			return
 		}
@@ -202,6 +305,142 @@ func (m *MainUI) showSettingsDialog() {
 	}, m.window)
 }
 
+// TLS 설정 다이얼로그를 표시합니다.
+// 확인 시 cfg에 입력한 값을 반영합니다. (설정 저장은 호출한 쪽에서 수행)
+func (m *MainUI) showTLSDialog(title string, cfg *model.TLSConfig) {
+	caEntry := widget.NewEntry()
+	caEntry.SetText(cfg.CAFile)
+	caEntry.SetPlaceHolder("미입력 시 시스템 CA 사용")
+
+	certEntry := widget.NewEntry()
+	certEntry.SetText(cfg.CertFile)
+	certEntry.SetPlaceHolder("mTLS 사용 시 입력")
+
+	keyEntry := widget.NewEntry()
+	keyEntry.SetText(cfg.KeyFile)
+	keyEntry.SetPlaceHolder("mTLS 사용 시 입력")
+
+	serverNameEntry := widget.NewEntry()
+	serverNameEntry.SetText(cfg.ServerName)
+	serverNameEntry.SetPlaceHolder("인증서의 호스트 이름")
+
+	pinEntry := widget.NewMultiLineEntry()
+	pinEntry.SetText(strings.Join(cfg.PinnedSHA256, "\n"))
+	pinEntry.SetPlaceHolder("sha256/base64 (한 줄에 하나)")
+	pinEntry.SetMinRowsVisible(3)
+
+	formItems := []*widget.FormItem{
+		widget.NewFormItem("CA 인증서 (PEM)", caEntry),
+		widget.NewFormItem("클라이언트 인증서", certEntry),
+		widget.NewFormItem("클라이언트 개인키", keyEntry),
+		widget.NewFormItem("서버 이름", serverNameEntry),
+		widget.NewFormItem("공개키 고정", pinEntry),
+	}
+
+	formDialog := dialog.NewForm(title, "확인", "취소", formItems, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		certFile := strings.TrimSpace(certEntry.Text)
+		keyFile := strings.TrimSpace(keyEntry.Text)
+		if (certFile == "") != (keyFile == "") {
+			dialog.ShowError(fmt.Errorf("클라이언트 인증서와 개인키를 모두 입력해주세요"), m.window)
+			return
+		}
+
+		var pins []string
+		for _, line := range strings.Split(pinEntry.Text, "\n") {
+			if pin := strings.TrimSpace(line); pin != "" {
+				pins = append(pins, pin)
+			}
+		}
+
+		*cfg = model.TLSConfig{
+			CAFile:       strings.TrimSpace(caEntry.Text),
+			CertFile:     certFile,
+			KeyFile:      keyFile,
+			ServerName:   strings.TrimSpace(serverNameEntry.Text),
+			PinnedSHA256: pins,
+		}
+	}, m.window)
+	formDialog.Resize(fyne.NewSize(500, 0))
+	formDialog.Show()
+}
+
+// SSH 설정 다이얼로그를 표시합니다.
+// 확인 시 cfg에 입력한 값을 반영합니다. (설정 저장은 호출한 쪽에서 수행)
+func (m *MainUI) showSSHDialog(cfg *model.SSHConfig) {
+	port := cfg.Port
+	if port <= 0 {
+		port = model.DefaultSSHPort
+	}
+	portEntry := widget.NewEntry()
+	portEntry.SetText(strconv.Itoa(port))
+
+	usernameEntry := widget.NewEntry()
+	usernameEntry.SetText(cfg.Username)
+
+	keyEntry := widget.NewEntry()
+	keyEntry.SetText(cfg.KeyFile)
+	keyEntry.SetPlaceHolder("암호 없는 개인키 (PEM/OpenSSH)")
+
+	knownHostsEntry := widget.NewEntry()
+	knownHostsEntry.SetText(cfg.KnownHostsFile)
+	knownHostsEntry.SetPlaceHolder("미입력 시 ~/.ssh/known_hosts")
+
+	fingerprintEntry := widget.NewMultiLineEntry()
+	fingerprintEntry.SetText(strings.Join(cfg.HostKeySHA256, "\n"))
+	fingerprintEntry.SetPlaceHolder("SHA256:... (한 줄에 하나, 입력 시 known_hosts 대신 사용)")
+	fingerprintEntry.SetMinRowsVisible(3)
+
+	ruleFormatSelect := widget.NewSelect([]string{model.RuleFormatAgent, model.RuleFormatSmartfw}, nil)
+	if cfg.RuleFormat == model.RuleFormatSmartfw {
+		ruleFormatSelect.SetSelected(model.RuleFormatSmartfw)
+	} else {
+		ruleFormatSelect.SetSelected(model.RuleFormatAgent)
+	}
+
+	formItems := []*widget.FormItem{
+		widget.NewFormItem("포트", portEntry),
+		widget.NewFormItem("사용자", usernameEntry),
+		widget.NewFormItem("개인키", keyEntry),
+		widget.NewFormItem("known_hosts", knownHostsEntry),
+		widget.NewFormItem("호스트 키 지문", fingerprintEntry),
+		widget.NewFormItem("규칙 형식", ruleFormatSelect),
+	}
+
+	formDialog := dialog.NewForm("SSH 설정", "확인", "취소", formItems, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		port, err := strconv.Atoi(strings.TrimSpace(portEntry.Text))
+		if err != nil || port < 1 || port > 65535 {
+			dialog.ShowError(fmt.Errorf("SSH 포트는 1~65535 사이의 숫자를 입력해주세요"), m.window)
+			return
+		}
+
+		var fingerprints []string
+		for _, line := range strings.Split(fingerprintEntry.Text, "\n") {
+			if fingerprint := strings.TrimSpace(line); fingerprint != "" {
+				fingerprints = append(fingerprints, fingerprint)
+			}
+		}
+
+		*cfg = model.SSHConfig{
+			Port:           port,
+			Username:       strings.TrimSpace(usernameEntry.Text),
+			KeyFile:        strings.TrimSpace(keyEntry.Text),
+			KnownHostsFile: strings.TrimSpace(knownHostsEntry.Text),
+			HostKeySHA256:  fingerprints,
+			RuleFormat:     ruleFormatSelect.Selected,
+		}
+	}, m.window)
+	formDialog.Resize(fyne.NewSize(500, 0))
+	formDialog.Show()
+}
+
 // 도움말 다이얼로그를 표시합니다.
 func (m *MainUI) showHelpDialog() {
 	component.ShowHelpPopup("도움말", component.AppHelpText, m.window.Canvas().Content())
@@ -213,7 +452,7 @@ func (m *MainUI) showImportDialog() {
 	tabIndex := m.tabs.SelectedIndex()
 
 	// 탭별 데이터 타입명
-	tabNames := []string{"템플릿", "장비", "배포 이력"}
+	tabNames := []string{"템플릿", "장비", "배포 이력", "객체", "장비 그룹"}
 	tabName := tabNames[tabIndex]
 
 	// 파일 선택 다이얼로그
@@ -337,6 +576,70 @@ func (m *MainUI) showImportDialog() {
 					}
 					dialog.ShowInformation("성공", fmt.Sprintf("%d개의 배포 이력이 가져오기 되었습니다.", validCount), m.window)
 					m.historyTab.RefreshHistory()
+				case 3: // 객체 관리 탭
+					var objects []*model.NamedObject
+					if err := json.Unmarshal(data, &objects); err != nil {
+						dialog.ShowError(fmt.Errorf("JSON 형태의 파일이 아닙니다: %v", err), m.window)
+						return
+					}
+
+					// 기존 데이터 모두 삭제
+					if err := m.store.ClearObjects(); err != nil {
+						dialog.ShowError(err, m.window)
+						return
+					}
+
+					// 객체 형식 검증: 이름, 종류, 멤버가 유효한지 확인
+					validCount := 0
+					for _, obj := range objects {
+						if obj.Validate() != nil {
+							continue // 유효하지 않은 객체는 건너뜀
+						}
+						if err := m.store.SaveObject(obj); err != nil {
+							dialog.ShowError(err, m.window)
+							return
+						}
+						validCount++
+					}
+					if validCount == 0 {
+						dialog.ShowError(fmt.Errorf("유효한 객체 데이터가 없습니다. 객체 형식의 JSON 파일을 선택해주세요."), m.window)
+						return
+					}
+					dialog.ShowInformation("성공", fmt.Sprintf("%d개의 객체가 가져오기 되었습니다.", validCount), m.window)
+					m.objectTab.ClearSelection()
+					m.objectTab.RefreshObjects()
+				case 4: // 장비 그룹 탭
+					var groups []*model.DeviceGroup
+					if err := json.Unmarshal(data, &groups); err != nil {
+						dialog.ShowError(fmt.Errorf("JSON 형태의 파일이 아닙니다: %v", err), m.window)
+						return
+					}
+
+					// 기존 데이터 모두 삭제
+					if err := m.store.ClearGroups(); err != nil {
+						dialog.ShowError(err, m.window)
+						return
+					}
+
+					// 장비 그룹 형식 검증: 이름과 변수가 유효한지 확인
+					validCount := 0
+					for _, group := range groups {
+						if group.Validate() != nil {
+							continue // 유효하지 않은 그룹은 건너뜀
+						}
+						if err := m.store.SaveGroup(group); err != nil {
+							dialog.ShowError(err, m.window)
+							return
+						}
+						validCount++
+					}
+					if validCount == 0 {
+						dialog.ShowError(fmt.Errorf("유효한 장비 그룹 데이터가 없습니다. 장비 그룹 형식의 JSON 파일을 선택해주세요."), m.window)
+						return
+					}
+					dialog.ShowInformation("성공", fmt.Sprintf("%d개의 장비 그룹이 가져오기 되었습니다.", validCount), m.window)
+					m.groupTab.ClearSelection()
+					m.groupTab.RefreshGroups()
 				}
 			}, m.window)
 	}, m.window)
@@ -397,6 +700,26 @@ func (m *MainUI) showExportDialog() {
 			dialog.ShowInformation("알림", "내보낼 배포 이력이 없습니다.", m.window)
 			return
 		}
+	case 3: // 객체 관리 탭
+		objects, err := m.store.GetAllObjects()
+		if err != nil {
+			dialog.ShowError(err, m.window)
+			return
+		}
+		if len(objects) == 0 {
+			dialog.ShowInformation("알림", "내보낼 객체가 없습니다.", m.window)
+			return
+		}
+	case 4: // 장비 그룹 탭
+		groups, err := m.store.GetAllGroups()
+		if err != nil {
+			dialog.ShowError(err, m.window)
+			return
+		}
+		if len(groups) == 0 {
+			dialog.ShowInformation("알림", "내보낼 장비 그룹이 없습니다.", m.window)
+			return
+		}
 	}
 
 	// 파일 저장 다이얼로그
@@ -436,6 +759,20 @@ func (m *MainUI) showExportDialog() {
 				return
 			}
 			data, jsonErr = json.MarshalIndent(histories, "", "  ")
+		case 3: // 객체 관리 탭
+			objects, err := m.store.GetAllObjects()
+			if err != nil {
+				dialog.ShowError(err, m.window)
+				return
+			}
+			data, jsonErr = json.MarshalIndent(objects, "", "  ")
+		case 4: // 장비 그룹 탭
+			groups, err := m.store.GetAllGroups()
+			if err != nil {
+				dialog.ShowError(err, m.window)
+				return
+			}
+			data, jsonErr = json.MarshalIndent(groups, "", "  ")
 		}
 
 		if jsonErr != nil {
@@ -459,6 +796,10 @@ func (m *MainUI) showExportDialog() {
 		saveDialog.SetFileName("firewallList.json")
 	case 2:
 		saveDialog.SetFileName("historyList.json")
+	case 3:
+		saveDialog.SetFileName("objectList.json")
+	case 4:
+		saveDialog.SetFileName("groupList.json")
 	}
 
 	// 실행 파일 위치의 config 폴더를 시작 경로로 설정, 없으면 실행 파일 디렉토리
@@ -482,7 +823,7 @@ func (m *MainUI) showExportDialog() {
 func (m *MainUI) showResetDialog() {
 	// 경고 다이얼로그 표시
 	dialog.ShowConfirm("⚠️ 경고",
-		"모든 데이터(템플릿, 장비, 배포이력)를 초기화하시겠습니까?",
+		"모든 데이터(템플릿, 장비, 배포이력, 객체, 장비 그룹)를 초기화하시겠습니까?",
 		func(ok bool) {
 			if !ok {
 				return
@@ -506,11 +847,27 @@ func (m *MainUI) showResetDialog() {
 				return
 			}
 
+			// 모든 객체 삭제
+			if err := m.store.ClearObjects(); err != nil {
+				dialog.ShowError(err, m.window)
+				return
+			}
+
+			// 모든 장비 그룹 삭제
+			if err := m.store.ClearGroups(); err != nil {
+				dialog.ShowError(err, m.window)
+				return
+			}
+
 			// UI 초기화 (서버 상태 체크 없이, 다이얼로그 없이)
 			m.templateTab.ClearSelection()
 			m.templateTab.RefreshTemplates()
 			m.deviceTab.ReloadDevices()
 			m.historyTab.ReloadHistory()
+			m.objectTab.ClearSelection()
+			m.objectTab.RefreshObjects()
+			m.groupTab.ClearSelection()
+			m.groupTab.RefreshGroups()
 
 			dialog.ShowInformation("완료", "모든 데이터가 초기화되었습니다.", m.window)
 		}, m.window)
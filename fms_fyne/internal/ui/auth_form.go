@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"strings"
+
+	"fms/internal/model"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+// 장비별 인증 설정에서 전역 설정을 사용하는 옵션
+const authInheritOption = "전역 설정 사용"
+
+// 인증 방식 표시 텍스트
+var authTypeLabels = map[string]string{
+	model.AuthTypeNone:   "인증 없음",
+	model.AuthTypeBearer: "Bearer 토큰",
+	model.AuthTypeBasic:  "Basic 인증",
+	model.AuthTypeHMAC:   "HMAC 서명",
+}
+
+// 인증 설정 입력 폼입니다.
+type authForm struct {
+	typeSelect    *widget.Select
+	usernameEntry *widget.Entry
+	secretEntry   *widget.Entry
+	inherit       bool
+}
+
+// 인증 설정 입력 폼을 생성합니다.
+// inherit가 true이면 "전역 설정 사용" 옵션을 추가하며, auth가 nil이면 해당 옵션이 선택됩니다.
+func newAuthForm(auth *model.AuthConfig, inherit bool) *authForm {
+	f := &authForm{inherit: inherit}
+
+	var options []string
+	if inherit {
+		options = append(options, authInheritOption)
+	}
+	for _, authType := range model.GetAuthTypeOptions() {
+		options = append(options, authTypeLabels[authType])
+	}
+
+	f.usernameEntry = widget.NewEntry()
+	f.usernameEntry.SetPlaceHolder("Basic 사용자명 / HMAC 키 ID")
+	f.secretEntry = widget.NewPasswordEntry()
+	f.secretEntry.SetPlaceHolder("토큰 / 비밀번호 / HMAC 키")
+
+	f.typeSelect = widget.NewSelect(options, func(string) {
+		f.updateState()
+	})
+
+	switch {
+	case auth == nil && inherit:
+		f.typeSelect.SetSelected(authInheritOption)
+	case auth == nil || !auth.IsEnabled():
+		f.typeSelect.SetSelected(authTypeLabels[model.AuthTypeNone])
+	default:
+		f.typeSelect.SetSelected(authTypeLabels[auth.Type])
+		f.usernameEntry.SetText(auth.Username)
+		f.secretEntry.SetText(auth.Secret)
+	}
+	f.updateState()
+	return f
+}
+
+// 선택된 인증 방식에 따라 입력 필드를 활성화/비활성화합니다.
+func (f *authForm) updateState() {
+	switch f.selectedType() {
+	case model.AuthTypeBearer:
+		f.usernameEntry.Disable()
+		f.secretEntry.Enable()
+	case model.AuthTypeBasic, model.AuthTypeHMAC:
+		f.usernameEntry.Enable()
+		f.secretEntry.Enable()
+	default:
+		f.usernameEntry.Disable()
+		f.secretEntry.Disable()
+	}
+}
+
+// 선택된 인증 방식을 반환합니다. (전역 설정 사용이면 빈 문자열)
+func (f *authForm) selectedType() string {
+	for authType, label := range authTypeLabels {
+		if label == f.typeSelect.Selected {
+			return authType
+		}
+	}
+	return ""
+}
+
+// 폼 항목을 반환합니다.
+func (f *authForm) formItems() []*widget.FormItem {
+	return []*widget.FormItem{
+		widget.NewFormItem("인증 방식", f.typeSelect),
+		widget.NewFormItem("인증 사용자/키 ID", f.usernameEntry),
+		widget.NewFormItem("인증 비밀값", f.secretEntry),
+	}
+}
+
+// 입력한 인증 설정을 검증하여 반환합니다.
+// 전역 설정 사용을 선택한 경우 nil을 반환합니다.
+func (f *authForm) result() (*model.AuthConfig, error) {
+	authType := f.selectedType()
+	if authType == "" && f.inherit {
+		return nil, nil
+	}
+	if authType == "" || authType == model.AuthTypeNone {
+		return &model.AuthConfig{Type: model.AuthTypeNone}, nil
+	}
+
+	auth := &model.AuthConfig{
+		Type:   authType,
+		Secret: f.secretEntry.Text,
+	}
+	if authType != model.AuthTypeBearer {
+		auth.Username = strings.TrimSpace(f.usernameEntry.Text)
+	}
+	if err := auth.Validate(); err != nil {
+		return nil, err
+	}
+	return auth, nil
+}
@@ -69,6 +69,10 @@ const AppHelpText = `FMS - Firewall Management System
 • Direct: 각 장비에 직접 HTTP 연결 (포트 80)
   - 상태확인: GET http://{장비IP}/respCheck
   - 배포: POST http://{장비IP}/deploy
+• SSH: 각 장비에 SSH로 접속하여 템플릿의 agent 명령을 실행 (공개키 인증)
+  - 상태확인: command -v agent
+  - 배포: 템플릿의 agent ... 줄을 순서대로 실행
+  - 규칙 조회: agent -m=list
 
 [규칙 포맷]
 req|INSERT|{ID}|{CHAIN}|{ACTION}|{PROTOCOL}|{SRC}|{DST}|{옵션들}
@@ -41,9 +41,14 @@ type RuleForm struct {
 	chainSel   *FixedWidthSelect
 	protoSel   *FixedWidthSelect
 	actionSel  *FixedWidthSelect
+	familySel  *FixedWidthSelect // 주소 체계 (ipv4/ipv6)
 	dportEntry *widget.Entry
+	sportEntry *widget.Entry
 	sipEntry   *widget.Entry
 	dipEntry   *widget.Entry
+	inIfEntry  *widget.Entry
+	outIfEntry *widget.Entry
+	stateGroup *widget.CheckGroup // 연결 상태 (--state)
 	addBtn     fyne.CanvasObject
 	content    *fyne.Container
 
@@ -59,6 +64,7 @@ type RuleForm struct {
 	icmpCodeSel    *widget.Select  // Code 드롭다운
 	icmpCodeEntry  *widget.Entry   // Code 커스텀 숫자용
 	icmpCodeRow    *fyne.Container // Code 행 (조건부 표시용)
+	icmpHeader     *widget.Label   // "ICMP Options" / "ICMPv6 Options"
 	icmpOptionsBox *fyne.Container
 
 	// 옵션 컨테이너
@@ -85,8 +91,10 @@ func (f *RuleForm) createUI() {
 	// 드롭다운 고정 너비
 	selectWidth := float32(100)
 
-	// Chain 선택
-	f.chainSel = NewFixedWidthSelect(model.GetChainOptions(), nil, selectWidth)
+	// Chain 선택 (체인에 따라 인터페이스 입력 활성화)
+	f.chainSel = NewFixedWidthSelect(model.GetChainOptions(), func(s string) {
+		f.onChainChanged(s)
+	}, selectWidth)
 
 	// Protocol 선택 (OnChanged 핸들러 추가)
 	f.protoSel = NewFixedWidthSelect(model.GetProtocolOptions(), func(s string) {
@@ -96,10 +104,19 @@ func (f *RuleForm) createUI() {
 	// Action 선택
 	f.actionSel = NewFixedWidthSelect(model.GetActionOptions(), nil, selectWidth)
 
+	// Family 선택 (주소 체계에 따라 ICMP type 목록 변경)
+	f.familySel = NewFixedWidthSelect(model.GetFamilyOptions(), func(s string) {
+		f.onFamilyChanged(s)
+	}, selectWidth)
+
 	// DPort 입력
 	f.dportEntry = widget.NewEntry()
 	f.dportEntry.SetPlaceHolder("포트")
 
+	// SPort 입력
+	f.sportEntry = widget.NewEntry()
+	f.sportEntry.SetPlaceHolder("출발지 포트")
+
 	// SIP 입력
 	f.sipEntry = widget.NewEntry()
 	f.sipEntry.SetPlaceHolder("Source IP")
@@ -108,6 +125,16 @@ func (f *RuleForm) createUI() {
 	f.dipEntry = widget.NewEntry()
 	f.dipEntry.SetPlaceHolder("Dest IP")
 
+	// 입력/출력 인터페이스
+	f.inIfEntry = widget.NewEntry()
+	f.inIfEntry.SetPlaceHolder("예: eth0")
+	f.outIfEntry = widget.NewEntry()
+	f.outIfEntry.SetPlaceHolder("예: eth1")
+
+	// 연결 상태
+	f.stateGroup = widget.NewCheckGroup(model.GetConnStateOptions(), nil)
+	f.stateGroup.Horizontal = true
+
 	// 추가 버튼 (진한 회색 배경)
 	f.addBtn = NewCustomButton("+ 추가", nil, nil, themes.Colors["darkgray"], func() {
 		f.submitRule()
@@ -136,18 +163,32 @@ func (f *RuleForm) createUI() {
 		container.NewGridWrap(fyne.NewSize(100, rowHeight), f.actionSel),
 		container.NewGridWrap(fyne.NewSize(labelWidth, rowHeight), widget.NewLabel("Port:")),
 		container.NewGridWrap(fyne.NewSize(140, rowHeight), f.dportEntry),
+		container.NewGridWrap(fyne.NewSize(labelWidth, rowHeight), widget.NewLabel("SPort:")),
+		container.NewGridWrap(fyne.NewSize(140, rowHeight), f.sportEntry),
 	)
 
-	// 두 번째 행: SIP, DIP
+	// 두 번째 행: SIP, DIP, Family
 	row2 := container.NewHBox(
 		container.NewGridWrap(fyne.NewSize(labelWidth, rowHeight), widget.NewLabel("SIP:")),
 		container.NewGridWrap(fyne.NewSize(230, rowHeight), f.sipEntry),
 		container.NewGridWrap(fyne.NewSize(labelWidth, rowHeight), widget.NewLabel("DIP:")),
 		container.NewGridWrap(fyne.NewSize(230, rowHeight), f.dipEntry),
+		container.NewGridWrap(fyne.NewSize(labelWidth, rowHeight), widget.NewLabel("Family:")),
+		container.NewGridWrap(fyne.NewSize(100, rowHeight), f.familySel),
+	)
+
+	// 세 번째 행: 입력/출력 인터페이스, 연결 상태
+	row3 := container.NewHBox(
+		container.NewGridWrap(fyne.NewSize(labelWidth, rowHeight), widget.NewLabel("InIF:")),
+		container.NewGridWrap(fyne.NewSize(100, rowHeight), f.inIfEntry),
+		container.NewGridWrap(fyne.NewSize(labelWidth, rowHeight), widget.NewLabel("OutIF:")),
+		container.NewGridWrap(fyne.NewSize(100, rowHeight), f.outIfEntry),
+		container.NewGridWrap(fyne.NewSize(labelWidth, rowHeight), widget.NewLabel("State:")),
+		f.stateGroup,
 	)
 
 	// 전체 폼 레이아웃 (Black/White 체크박스 제거됨 - BlackWhiteForm에서 별도 처리)
-	formContent := container.NewVBox(row1, row2, f.optionsContainer)
+	formContent := container.NewVBox(row1, row2, row3, f.optionsContainer)
 
 	// 헤더: "규칙 추가" 레이블 + 오른쪽에 추가 버튼
 	header := container.NewBorder(
@@ -291,8 +332,9 @@ func (f *RuleForm) createICMPOptionsUI() {
 	})
 
 	// 헤더: "ICMP Options" + 헬프 버튼
+	f.icmpHeader = widget.NewLabel("ICMP Options")
 	headerRow := container.NewHBox(
-		widget.NewLabel("ICMP Options"),
+		f.icmpHeader,
 		helpBtn,
 	)
 
@@ -308,6 +350,23 @@ func (f *RuleForm) showICMPHelp() {
 	ShowHelpPopup("ICMP Options 도움말", ICMPOptionsHelpText, f.content)
 }
 
+// onChainChanged 체인 변경 시 인터페이스 필드 활성/비활성화
+// INPUT은 출력 인터페이스, OUTPUT은 입력 인터페이스 조건을 사용할 수 없습니다.
+func (f *RuleForm) onChainChanged(chain string) {
+	setEntryEnabled(f.inIfEntry, chain != "OUTPUT")
+	setEntryEnabled(f.outIfEntry, chain != "INPUT")
+}
+
+// setEntryEnabled 입력 필드 활성화 상태 변경 (비활성화 시 값 초기화)
+func setEntryEnabled(entry *widget.Entry, enabled bool) {
+	if enabled {
+		entry.Enable()
+		return
+	}
+	entry.SetText("")
+	entry.Disable()
+}
+
 // onProtocolChanged 프로토콜 변경 시 옵션 UI 전환 및 포트 필드 활성/비활성화
 func (f *RuleForm) onProtocolChanged(proto string) {
 	f.optionsContainer.Objects = nil
@@ -318,12 +377,14 @@ func (f *RuleForm) onProtocolChanged(proto string) {
 		f.setTCPOptionsEnabled(true)
 		f.dportEntry.Enable()
 		f.dportEntry.SetPlaceHolder("포트")
+		f.sportEntry.Enable()
 	case "udp":
 		// UDP: TCP 옵션 박스 표시하되 비활성화
 		f.optionsContainer.Add(f.tcpOptionsBox)
 		f.setTCPOptionsEnabled(false)
 		f.dportEntry.Enable()
 		f.dportEntry.SetPlaceHolder("포트")
+		f.sportEntry.Enable()
 	case "icmp":
 		f.optionsContainer.Add(f.icmpOptionsBox)
 		f.setICMPOptionsEnabled(true)
@@ -331,17 +392,21 @@ func (f *RuleForm) onProtocolChanged(proto string) {
 		f.dportEntry.Disable()
 		f.dportEntry.SetText("")
 		f.dportEntry.SetPlaceHolder("N/A")
+		f.sportEntry.Disable()
+		f.sportEntry.SetText("")
 	case "any":
 		// ANY: TCP 옵션 박스 표시하되 비활성화
 		f.optionsContainer.Add(f.tcpOptionsBox)
 		f.setTCPOptionsEnabled(false)
 		f.dportEntry.Enable()
 		f.dportEntry.SetPlaceHolder("포트")
+		f.sportEntry.Enable()
 	default:
 		f.optionsContainer.Add(f.tcpOptionsBox)
 		f.setTCPOptionsEnabled(false)
 		f.dportEntry.Enable()
 		f.dportEntry.SetPlaceHolder("포트")
+		f.sportEntry.Enable()
 	}
 
 	f.optionsContainer.Refresh()
@@ -423,6 +488,19 @@ func (f *RuleForm) onTCPPresetChanged(presetName string) {
 	}
 }
 
+// onFamilyChanged 주소 체계 변경 시 ICMP type 목록 교체
+func (f *RuleForm) onFamilyChanged(family string) {
+	if family == "ipv6" {
+		f.icmpTypeSel.Options = model.GetICMPv6TypeOptions()
+		f.icmpHeader.SetText("ICMPv6 Options")
+	} else {
+		f.icmpTypeSel.Options = model.GetICMPTypeOptions()
+		f.icmpHeader.SetText("ICMP Options")
+	}
+	f.icmpTypeSel.SetSelected("None")
+	f.icmpTypeSel.Refresh()
+}
+
 // onICMPTypeChanged ICMP Type 변경 시 처리
 func (f *RuleForm) onICMPTypeChanged(typeName string) {
 	// Custom 옵션 제거됨 - Type Entry 항상 숨김
@@ -502,6 +580,19 @@ func (f *RuleForm) getICMPCode() string {
 	return ""
 }
 
+// getState 선택된 연결 상태를 옵션 순서대로 결합 (예: "ESTABLISHED,RELATED")
+func (f *RuleForm) getState() string {
+	var states []string
+	for _, state := range model.GetConnStateOptions() {
+		for _, selected := range f.stateGroup.Selected {
+			if selected == state {
+				states = append(states, state)
+			}
+		}
+	}
+	return strings.Join(states, ",")
+}
+
 // submitRule 규칙 생성 및 콜백 호출
 func (f *RuleForm) submitRule() {
 	rule := &model.FirewallRule{
@@ -511,8 +602,16 @@ func (f *RuleForm) submitRule() {
 		DPort:    f.dportEntry.Text,
 		SIP:      f.sipEntry.Text,
 		DIP:      f.dipEntry.Text,
+		SPort:    f.sportEntry.Text,
 		Black:    false, // 일반 규칙은 Black/White 아님
 		White:    false,
+
+		InInterface:  strings.TrimSpace(f.inIfEntry.Text),
+		OutInterface: strings.TrimSpace(f.outIfEntry.Text),
+		State:        f.getState(),
+	}
+	if f.familySel.Selected == "ipv6" {
+		rule.Family = model.FamilyIPv6
 	}
 
 	// 프로토콜 옵션 설정
@@ -547,9 +646,14 @@ func (f *RuleForm) Reset() {
 	f.chainSel.SetSelected("INPUT")
 	f.protoSel.SetSelected("tcp")
 	f.actionSel.SetSelected("DROP")
+	f.familySel.SetSelected("ipv4")
 	f.dportEntry.SetText("")
 	f.sipEntry.SetText("")
 	f.dipEntry.SetText("")
+	f.sportEntry.SetText("")
+	f.inIfEntry.SetText("")
+	f.outIfEntry.SetText("")
+	f.stateGroup.SetSelected(nil)
 
 	// TCP Flags 초기화
 	f.tcpFlagsPresetSel.SetSelected("None")
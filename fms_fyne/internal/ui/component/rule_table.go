@@ -1,6 +1,8 @@
 package component
 
 import (
+	"strings"
+
 	"fms/internal/model"
 	"fms/internal/parser"
 
@@ -19,11 +21,13 @@ const (
 	colOptions
 	colAction
 	colPort
+	colSPort
 	colSIP
 	colDIP
+	colMatch
 	colBlack
 	colWhite
-	colCount // 총 컬럼 수 = 10
+	colCount // 총 컬럼 수 = 12
 )
 
 // 고정 너비 컬럼 (픽셀)
@@ -36,18 +40,20 @@ const (
 
 // 가변 컬럼별 비율 (합계 = 1.0, 고정 컬럼 제외한 나머지에 적용)
 var columnRatios = []float32{
-	0.12, // Chain
-	0.10, // Proto
-	0.14, // 옵션
-	0.12, // Action
+	0.10, // Chain
+	0.08, // Proto
+	0.12, // 옵션
+	0.10, // Action
 	0.08, // Port
-	0.22, // SIP
-	0.22, // DIP
+	0.08, // SPort
+	0.17, // SIP
+	0.17, // DIP
+	0.10, // 인터페이스/연결 상태
 }
 
 // 헤더 텍스트
 var headerTexts = []string{
-	"", "Chain", "Proto", "Options", "Action", "Port", "SIP", "DIP", "Black", "White",
+	"", "Chain", "Proto", "Options", "Action", "Port", "SPort", "SIP", "DIP", "IF/State", "Black", "White",
 }
 
 // RuleTable widget.Table 기반 규칙 테이블
@@ -214,6 +220,17 @@ func (t *RuleTable) updateCell(id widget.TableCellID, obj fyne.CanvasObject) {
 		}
 		entry.Show()
 
+	case colSPort:
+		entry := stack.Objects[3].(*widget.Entry)
+		entry.SetText(rule.SPort)
+		entry.OnChanged = func(s string) {
+			if row < len(t.rules) {
+				t.rules[row].SPort = s
+				t.triggerChange()
+			}
+		}
+		entry.Show()
+
 	case colSIP:
 		entry := stack.Objects[3].(*widget.Entry)
 		entry.SetText(rule.SIP)
@@ -236,6 +253,12 @@ func (t *RuleTable) updateCell(id widget.TableCellID, obj fyne.CanvasObject) {
 		}
 		entry.Show()
 
+	case colMatch:
+		// 인터페이스/연결 상태는 표시만 (수정은 규칙 폼 또는 텍스트 편집기에서)
+		label := stack.Objects[4].(*widget.Label)
+		label.SetText(formatMatchOptions(rule))
+		label.Show()
+
 	case colBlack:
 		check := stack.Objects[5].(*widget.Check)
 		check.Checked = rule.Black
@@ -260,6 +283,28 @@ func (t *RuleTable) updateCell(id widget.TableCellID, obj fyne.CanvasObject) {
 	}
 }
 
+// formatMatchOptions 인터페이스/연결 상태 조건 표시 문자열 (예: "eth1→eth0 ESTABLISHED")
+func formatMatchOptions(rule *model.FirewallRule) string {
+	var parts []string
+	if rule.InInterface != "" || rule.OutInterface != "" {
+		in, out := rule.InInterface, rule.OutInterface
+		if in == "" {
+			in = "*"
+		}
+		if out == "" {
+			out = "*"
+		}
+		parts = append(parts, in+"→"+out)
+	}
+	if rule.State != "" {
+		parts = append(parts, rule.State)
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " ")
+}
+
 // updateColumnWidths 컬럼 너비 업데이트 (고정 + 비율 기반)
 func (t *RuleTable) updateColumnWidths(totalWidth float32) {
 	if totalWidth <= 0 {
@@ -275,7 +320,7 @@ func (t *RuleTable) updateColumnWidths(totalWidth float32) {
 	flexibleWidth := totalWidth - fixedWidthDelete - fixedWidthBlack - fixedWidthWhite - scrollbarWidth
 
 	// 가변 컬럼에 비율 적용
-	flexibleCols := []int{colChain, colProto, colOptions, colAction, colPort, colSIP, colDIP}
+	flexibleCols := []int{colChain, colProto, colOptions, colAction, colPort, colSPort, colSIP, colDIP, colMatch}
 	for i, col := range flexibleCols {
 		if i < len(columnRatios) {
 			t.table.SetColumnWidth(col, flexibleWidth*columnRatios[i])
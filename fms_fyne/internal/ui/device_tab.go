@@ -1,10 +1,11 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"image/color"
-	"net"
 	"sort"
+	"strconv"
 	"time"
 
 	"fms/internal/deploy"
@@ -127,6 +128,11 @@ func (d *DeviceTab) createDeployControlPanel() fyne.CanvasObject {
 		d.onDeploy()
 	})
 
+	// 단계별 배포 버튼 (카나리 배포 후 웨이브 단위로 확대)
+	stagedDeployBtn := component.NewCustomButton("단계별 배포", nil, nil, themes.Colors["darkgray"], func() {
+		d.onStagedDeploy()
+	})
+
 	// 상태확인 버튼 (아이콘 + 텍스트) - Disable/Enable 필요하므로 widget.Button 유지
 	refreshBtn := widget.NewButtonWithIcon("상태확인", theme.ViewRefreshIcon(), func() {
 		d.onRefreshAll()
@@ -134,7 +140,7 @@ func (d *DeviceTab) createDeployControlPanel() fyne.CanvasObject {
 	d.refreshBtn = refreshBtn
 
 	// 버튼 영역
-	buttonArea := container.NewHBox(deployBtn, refreshBtn)
+	buttonArea := container.NewHBox(deployBtn, stagedDeployBtn, refreshBtn)
 
 	return container.NewVBox(
 		container.NewBorder(nil, nil, templateSelector, buttonArea, nil),
@@ -348,6 +354,16 @@ func (d *DeviceTab) createDetailPanel() fyne.CanvasObject {
 		d.onApplyDetail()
 	})
 
+	// 장비별 인증 설정 버튼
+	authBtn := widget.NewButton("인증 설정", func() {
+		d.onDeviceAuth()
+	})
+
+	// 장비 그룹/템플릿 변수 설정 버튼
+	variablesBtn := widget.NewButton("그룹/변수", func() {
+		d.onDeviceVariables()
+	})
+
 	// IP 입력 필드와 에러 레이블을 VBox로 묶음
 	ipContainer := container.NewVBox(d.ipEntry, d.ipErrorLabel)
 
@@ -358,9 +374,9 @@ func (d *DeviceTab) createDetailPanel() fyne.CanvasObject {
 			widget.NewLabelWithStyle("장비 추가/수정", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 			widget.NewLabel("(IP 주소를 입력하거나 테이블에서 선택 후 수정)"),
 		),
-		container.NewGridWithColumns(4,
+		container.NewGridWithColumns(5,
 			widget.NewLabel("장비 IP:"), ipContainer,
-			widget.NewLabel(""), applyBtn,
+			authBtn, variablesBtn, applyBtn,
 		),
 	)
 
@@ -412,15 +428,15 @@ func (d *DeviceTab) onApplyDetail() {
 	d.ipErrorLabel.Hidden = true
 	d.ipErrorLabel.Text = ""
 
-	// IP 검증 (IP 또는 IP:PORT 형식 허용)
+	// IP 검증 (IPv4, IPv6, IP:PORT, [IPv6]:PORT 형식 허용)
 	if d.ipEntry.Text == "" {
 		d.ipErrorLabel.Text = "IP 주소를 입력해주세요"
 		d.ipErrorLabel.Hidden = false
 		d.ipErrorLabel.Refresh()
 		return
 	}
-	if !isValidIPOrHostPort(d.ipEntry.Text) {
-		d.ipErrorLabel.Text = "올바른 IP 주소 형식이 아닙니다 (예: 192.168.1.1 또는 192.168.1.1:8080)"
+	if err := model.ValidateDeviceAddress(d.ipEntry.Text); err != nil {
+		d.ipErrorLabel.Text = "올바른 IP 주소 형식이 아닙니다 (예: 192.168.1.1, 192.168.1.1:8080, 2001:db8::1, [2001:db8::1]:8080)"
 		d.ipErrorLabel.Hidden = false
 		d.ipErrorLabel.Refresh()
 		return
@@ -474,25 +490,292 @@ func (d *DeviceTab) onApplyDetail() {
 	d.selectedDeviceIndex = -1
 }
 
+// 선택된 장비의 인증 설정 다이얼로그를 표시합니다.
+func (d *DeviceTab) onDeviceAuth() {
+	if d.selectedDeviceIndex < 0 || d.selectedDeviceIndex >= len(d.firewalls) {
+		dialog.ShowInformation("알림", "테이블에서 장비를 선택해주세요.", d.window)
+		return
+	}
+	fw := d.firewalls[d.selectedDeviceIndex]
+
+	auth := newAuthForm(fw.Auth, true)
+	formDialog := dialog.NewForm(fmt.Sprintf("인증 설정 - %s", fw.DeviceName), "저장", "취소", auth.formItems(), func(ok bool) {
+		if !ok {
+			return
+		}
+
+		authConfig, err := auth.result()
+		if err != nil {
+			dialog.ShowError(err, d.window)
+			return
+		}
+
+		fw.Auth = authConfig
+		if err := d.store.SaveFirewall(fw); err != nil {
+			dialog.ShowError(err, d.window)
+		}
+	}, d.window)
+	formDialog.Resize(fyne.NewSize(450, 0))
+	formDialog.Show()
+}
+
+// 선택된 장비의 장비 그룹과 템플릿 변수 설정 다이얼로그를 표시합니다.
+// 장비의 변수는 같은 이름의 그룹 변수보다 우선합니다.
+func (d *DeviceTab) onDeviceVariables() {
+	if d.selectedDeviceIndex < 0 || d.selectedDeviceIndex >= len(d.firewalls) {
+		dialog.ShowInformation("알림", "테이블에서 장비를 선택해주세요.", d.window)
+		return
+	}
+	fw := d.firewalls[d.selectedDeviceIndex]
+
+	groups, err := d.store.GetAllGroups()
+	if err != nil {
+		dialog.ShowError(err, d.window)
+		return
+	}
+	groupOptions := []string{noGroupOption}
+	for _, group := range groups {
+		groupOptions = append(groupOptions, group.Name)
+	}
+	groupSelect := widget.NewSelect(groupOptions, nil)
+	groupSelect.SetSelected(noGroupOption)
+	if fw.Group != "" {
+		groupSelect.SetSelected(fw.Group)
+	}
+	variablesEntry := widget.NewMultiLineEntry()
+	variablesEntry.SetPlaceHolder(variablesPlaceHolder)
+	variablesEntry.SetMinRowsVisible(5)
+	variablesEntry.SetText(formatVariablesText(fw.Variables))
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("장비 그룹", groupSelect),
+		widget.NewFormItem("변수", variablesEntry),
+	}
+	formDialog := dialog.NewForm(fmt.Sprintf("그룹/변수 설정 - %s", fw.DeviceName), "저장", "취소", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		variables, err := parseVariablesText(variablesEntry.Text)
+		if err == nil {
+			err = model.ValidateVariables(variables)
+		}
+		if err != nil {
+			dialog.ShowError(err, d.window)
+			return
+		}
+
+		fw.Group = ""
+		if groupSelect.Selected != noGroupOption {
+			fw.Group = groupSelect.Selected
+		}
+		fw.Variables = variables
+		if len(variables) == 0 {
+			fw.Variables = nil
+		}
+		if err := d.store.SaveFirewall(fw); err != nil {
+			dialog.ShowError(err, d.window)
+			return
+		}
+		d.deviceTable.Refresh()
+	}, d.window)
+	formDialog.Resize(fyne.NewSize(450, 0))
+	formDialog.Show()
+}
+
 // 배포 시 호출됩니다.
 func (d *DeviceTab) onDeploy() {
+	template, checkedFirewalls, ok := d.deployTargets()
+	if !ok {
+		return
+	}
+	d.confirmPlan(template, checkedFirewalls, func() {
+		d.runDeploy(template, checkedFirewalls, nil)
+	})
+}
+
+// 단계별(카나리) 배포 시 호출됩니다.
+func (d *DeviceTab) onStagedDeploy() {
+	template, checkedFirewalls, ok := d.deployTargets()
+	if !ok {
+		return
+	}
+
+	defaults := model.DefaultRolloutOptions()
+	canaryEntry := widget.NewEntry()
+	canaryEntry.SetText(strconv.Itoa(defaults.CanarySize))
+	waveEntry := widget.NewEntry()
+	waveEntry.SetText(strconv.Itoa(defaults.WaveSize))
+	pauseEntry := widget.NewEntry()
+	pauseEntry.SetText(strconv.Itoa(defaults.PauseSeconds))
+	failureEntry := widget.NewEntry()
+	failureEntry.SetText(strconv.FormatFloat(defaults.MaxFailurePercent, 'f', -1, 64))
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("카나리 장비 수", canaryEntry),
+		widget.NewFormItem("웨이브 크기", waveEntry),
+		widget.NewFormItem("대기 시간(초)", pauseEntry),
+		widget.NewFormItem("허용 실패율(%)", failureEntry),
+	}
+
+	formDialog := dialog.NewForm("단계별 배포", "배포", "취소", items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		opts := &model.RolloutOptions{}
+		var err error
+		if opts.CanarySize, err = strconv.Atoi(canaryEntry.Text); err != nil {
+			dialog.ShowError(fmt.Errorf("카나리 장비 수는 숫자여야 합니다"), d.window)
+			return
+		}
+		if opts.WaveSize, err = strconv.Atoi(waveEntry.Text); err != nil {
+			dialog.ShowError(fmt.Errorf("웨이브 크기는 숫자여야 합니다"), d.window)
+			return
+		}
+		if opts.PauseSeconds, err = strconv.Atoi(pauseEntry.Text); err != nil {
+			dialog.ShowError(fmt.Errorf("대기 시간은 숫자여야 합니다"), d.window)
+			return
+		}
+		if opts.MaxFailurePercent, err = strconv.ParseFloat(failureEntry.Text, 64); err != nil {
+			dialog.ShowError(fmt.Errorf("허용 실패율은 숫자여야 합니다"), d.window)
+			return
+		}
+		if err := opts.Validate(); err != nil {
+			dialog.ShowError(err, d.window)
+			return
+		}
+
+		d.confirmPlan(template, checkedFirewalls, func() {
+			d.runDeploy(template, checkedFirewalls, opts)
+		})
+	}, d.window)
+	formDialog.Resize(fyne.NewSize(400, 300))
+	formDialog.Show()
+}
+
+// 배포할 템플릿을 조회하고 객체 참조(@이름)를 현재 객체 값으로 펼칩니다.
+func (d *DeviceTab) deployTemplate(version string) (*model.Template, error) {
+	return deploy.ExpandingLookup(d.store.GetTemplate, d.store.GetAllObjects)(version)
+}
+
+// 장비 그룹의 변수 위에 장비의 변수를 덮어쓴 템플릿 변수 값을 반환합니다.
+func (d *DeviceTab) deployVariables(fw *model.Firewall) (map[string]string, error) {
+	return deploy.GroupVariables(d.store.GetGroup)(fw)
+}
+
+// 배포 전 장비별 규칙 변경 내용을 미리보기로 보여주고, 확인 시 onConfirm을 호출합니다.
+// 변경되는 규칙이 없으면 강제 배포를 선택한 경우에만 배포합니다.
+func (d *DeviceTab) confirmPlan(template *model.Template, checkedFirewalls []*model.Firewall, onConfirm func()) {
+	plan, err := deploy.BuildPlan(checkedFirewalls, template, d.deployTemplate, d.deployVariables)
+	if err != nil {
+		dialog.ShowError(err, d.window)
+		return
+	}
+
+	content := container.NewVBox()
+	if plan.IsEmpty() {
+		content.Add(widget.NewLabel("변경되는 규칙이 없습니다. 그래도 배포하려면 강제 배포를 선택하세요."))
+	}
+
+	for _, device := range plan.Devices {
+		added, removed, moved := device.CountChanges()
+		header := widget.NewLabel(fmt.Sprintf("%s (%s → %s)  추가 %d / 삭제 %d / 순서 변경 %d",
+			device.DeviceName, device.CurrentVersion, device.TargetVersion, added, removed, moved))
+		header.TextStyle = fyne.TextStyle{Bold: true}
+		content.Add(header)
+
+		if device.Warning != "" {
+			content.Add(widget.NewLabel(device.Warning))
+		}
+
+		if !device.HasChanges() {
+			content.Add(widget.NewLabel("  변경 없음"))
+			continue
+		}
+		for _, change := range device.Changes {
+			line := widget.NewLabel(planChangeText(change))
+			line.TextStyle = fyne.TextStyle{Monospace: true}
+			content.Add(line)
+		}
+		content.Add(widget.NewSeparator())
+	}
+
+	confirmText := "배포"
+	if plan.IsEmpty() {
+		confirmText = "강제 배포"
+	}
+
+	planDialog := dialog.NewCustomConfirm(fmt.Sprintf("배포 계획 - %s", template.Version), confirmText, "취소",
+		container.NewVScroll(content), func(ok bool) {
+			if ok {
+				onConfirm()
+			}
+		}, d.window)
+	planDialog.Resize(fyne.NewSize(800, 500))
+	planDialog.Show()
+}
+
+// 규칙 변경 내용을 표시용 문자열로 변환합니다.
+func planChangeText(change deploy.RuleChange) string {
+	switch change.Type {
+	case deploy.ChangeAdded:
+		return fmt.Sprintf("  + [%s #%d] %s", change.Table, change.NewIndex, change.Rule)
+	case deploy.ChangeRemoved:
+		return fmt.Sprintf("  - [%s #%d] %s", change.Table, change.OldIndex, change.Rule)
+	default:
+		return fmt.Sprintf("  ~ [%s #%d → #%d] %s", change.Table, change.OldIndex, change.NewIndex, change.Rule)
+	}
+}
+
+// 선택된 템플릿과 체크된 장비를 검증하여 반환합니다.
+// 검증에 실패하면 에러 다이얼로그를 표시하고 false를 반환합니다.
+func (d *DeviceTab) deployTargets() (*model.Template, []*model.Firewall, bool) {
 	// 템플릿 선택 확인
 	if d.templateSelect.Selected == "" {
 		dialog.ShowError(fmt.Errorf("배포할 템플릿을 선택해주세요"), d.window)
-		return
+		return nil, nil, false
 	}
 
 	// 템플릿 가져오기
 	template := d.templateTab.GetTemplate(d.templateSelect.Selected)
 	if template == nil {
 		dialog.ShowError(fmt.Errorf("템플릿을 찾을 수 없습니다: %s", d.templateSelect.Selected), d.window)
-		return
+		return nil, nil, false
+	}
+
+	// 포함 지시문(#include)을 현재 템플릿으로 펼침
+	template, err := model.ResolveIncludes(template, d.store.GetTemplate)
+	if err != nil {
+		dialog.ShowError(err, d.window)
+		return nil, nil, false
+	}
+
+	// 객체 참조(@이름)를 현재 객체 값으로 펼침
+	objects, err := d.store.GetAllObjects()
+	if err != nil {
+		dialog.ShowError(err, d.window)
+		return nil, nil, false
+	}
+	if template, err = deploy.ExpandTemplate(template, objects); err != nil {
+		dialog.ShowError(err, d.window)
+		return nil, nil, false
 	}
 
 	// 템플릿 내용 검증
+	if err := templateRulesError(template.Contents); err != nil {
+		dialog.ShowError(err, d.window)
+		return nil, nil, false
+	}
 	if !template.IsValid() {
 		dialog.ShowError(fmt.Errorf("선택한 템플릿에 내용이 없습니다"), d.window)
-		return
+		return nil, nil, false
+	}
+
+	// 템플릿 기대 결과(# expect:) 검사
+	if err := deploy.CheckExpectations(template); err != nil {
+		dialog.ShowError(err, d.window)
+		return nil, nil, false
 	}
 
 	// 체크된 장비 수집
@@ -505,18 +788,41 @@ func (d *DeviceTab) onDeploy() {
 
 	if len(checkedFirewalls) == 0 {
 		dialog.ShowError(fmt.Errorf("배포할 장비를 선택해주세요"), d.window)
-		return
+		return nil, nil, false
+	}
+
+	// 템플릿 변수(${이름})를 장비별로 적용하여 검사
+	if err := deploy.CheckVariables(checkedFirewalls, template, d.deployVariables); err != nil {
+		dialog.ShowError(err, d.window)
+		return nil, nil, false
 	}
 
-	// 진행률 다이얼로그 표시
+	return template, checkedFirewalls, true
+}
+
+// 장비들에 템플릿을 배포하고 결과를 저장합니다.
+// rolloutOpts가 nil이 아니면 웨이브 단위로 단계별 배포합니다.
+func (d *DeviceTab) runDeploy(template *model.Template, checkedFirewalls []*model.Firewall, rolloutOpts *model.RolloutOptions) {
+	// 배포 취소용 컨텍스트
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// 진행률 다이얼로그 표시 (취소 버튼 포함)
 	progressLabel := widget.NewLabel("배포 준비 중...")
 	progressBar := widget.NewProgressBar()
-	progressContent := container.NewVBox(progressLabel, progressBar)
+	var cancelBtn *widget.Button
+	cancelBtn = widget.NewButtonWithIcon("취소", theme.CancelIcon(), func() {
+		cancel()
+		cancelBtn.Disable()
+		progressLabel.SetText("배포 취소 중... 진행 중인 장비를 정리하고 있습니다.")
+	})
+	progressContent := container.NewVBox(progressLabel, progressBar, container.NewCenter(cancelBtn))
 	progressDialog := dialog.NewCustomWithoutButtons("배포 진행 중", progressContent, d.window)
 	progressDialog.Show()
 
 	// 백그라운드에서 배포 실행
 	go func() {
+		defer cancel()
+
 		config, err := d.store.GetConfig()
 		if err != nil {
 			fyne.Do(func() {
@@ -527,34 +833,68 @@ func (d *DeviceTab) onDeploy() {
 		}
 
 		deployer := deploy.NewDeployer(config)
-		total := len(checkedFirewalls)
+		deployer.SetVariableLookup(d.deployVariables)
 		successCount := 0
 		failCount := 0
-
-		for i, fw := range checkedFirewalls {
-			// 진행률 업데이트 (UI 스레드에서 실행)
-			idx := i
-			fyne.Do(func() {
-				progressLabel.SetText(fmt.Sprintf("배포 중: %s (%d/%d)", fw.DeviceName, idx+1, total))
-				progressBar.SetValue(float64(idx+1) / float64(total))
+		cancelledCount := 0
+		haltedCount := 0
+		rolledBackCount := 0
+
+		var results []*deploy.DeployResult
+		haltReason := ""
+		if rolloutOpts != nil {
+			// 웨이브 단위 배포 (카나리 → 대기 → 상태 확인 → 다음 웨이브)
+			rollout, err := deployer.DeployRollout(ctx, checkedFirewalls, template, rolloutOpts, func(p deploy.RolloutProgress) {
+				fyne.Do(func() {
+					progressLabel.SetText(rolloutProgressText(p))
+					progressBar.SetValue(float64(p.Done) / float64(p.Total))
+				})
 			})
+			if err != nil {
+				fyne.Do(func() {
+					progressDialog.Hide()
+					dialog.ShowError(err, d.window)
+				})
+				return
+			}
+			results = rollout.Results
+			haltReason = rollout.HaltReason
+		} else {
+			// 최대 동시 배포 수만큼 병렬 배포 (진행률은 장비 배포 완료 시마다 갱신)
+			results = deployer.DeployToMultiple(ctx, checkedFirewalls, template, func(done, total int, deviceName string) {
+				fyne.Do(func() {
+					progressLabel.SetText(fmt.Sprintf("배포 중: %s 완료 (%d/%d)", deviceName, done, total))
+					progressBar.SetValue(float64(done) / float64(total))
+				})
+			})
+		}
 
-			// 배포 실행
-			result := deployer.Deploy(fw, template)
-
-			// 결과 처리
-			if result.Success {
+		// 결과 처리 (선택 순서대로 저장하여 이력 순서 유지)
+		for _, result := range results {
+			switch {
+			case result.Success:
 				successCount++
-			} else {
+			case result.History.Status == model.DeployStatusCancelled:
+				cancelledCount++
+			case result.History.Status == model.DeployStatusHalted:
+				haltedCount++
+			default:
 				failCount++
 			}
+			if result.Rollback != nil && result.Rollback.Success {
+				rolledBackCount++
+			}
 
 			// 장비 상태 저장
-			d.store.SaveFirewall(fw)
+			d.store.SaveFirewall(result.Firewall)
 
-			// 이력 저장
+			// 이력 저장 (자동 롤백 이력은 원본 이력 ID를 연결하여 저장)
 			if d.historyTab != nil && result.History != nil {
 				d.historyTab.AddHistory(result.History)
+				if result.Rollback != nil {
+					result.Rollback.History.RollbackOf = result.History.ID
+					d.historyTab.AddHistory(result.Rollback.History)
+				}
 			}
 		}
 
@@ -581,11 +921,39 @@ func (d *DeviceTab) onDeploy() {
 			progressDialog.Hide()
 
 			resultMsg := fmt.Sprintf("배포 완료\n\n템플릿: %s\n성공: %d개\n실패: %d개", template.Version, successCount, failCount)
+			if cancelledCount > 0 {
+				resultMsg = fmt.Sprintf("배포 취소됨\n\n템플릿: %s\n성공: %d개\n실패: %d개\n취소: %d개", template.Version, successCount, failCount, cancelledCount)
+			}
+			if haltedCount > 0 {
+				resultMsg = fmt.Sprintf("단계별 배포 중단\n\n템플릿: %s\n성공: %d개\n실패: %d개\n중단: %d개\n\n사유: %s", template.Version, successCount, failCount, haltedCount, haltReason)
+			}
+			if rolledBackCount > 0 {
+				resultMsg += fmt.Sprintf("\n\n이전 버전으로 자동 롤백: %d개", rolledBackCount)
+			}
 			dialog.ShowInformation("배포 결과", resultMsg, d.window)
 		})
 	}()
 }
 
+// 단계별 배포 진행 상황을 표시용 문자열로 변환합니다.
+func rolloutProgressText(p deploy.RolloutProgress) string {
+	wave := fmt.Sprintf("웨이브 %d/%d", p.Wave, p.TotalWaves)
+	if p.Wave == 1 {
+		wave += " (카나리)"
+	}
+
+	switch p.Phase {
+	case deploy.RolloutPhasePause:
+		return fmt.Sprintf("%s: 상태 확인 전 대기 중 (%d/%d)", wave, p.Done, p.Total)
+	case deploy.RolloutPhaseHealthCheck:
+		return fmt.Sprintf("%s: 배포 후 상태 확인 중 (%d/%d)", wave, p.Done, p.Total)
+	}
+	if p.DeviceName == "" {
+		return fmt.Sprintf("%s: 배포 시작 (%d/%d)", wave, p.Done, p.Total)
+	}
+	return fmt.Sprintf("%s: %s 완료 (%d/%d)", wave, p.DeviceName, p.Done, p.Total)
+}
+
 // 전체 선택/해제 시 호출됩니다.
 func (d *DeviceTab) onSelectAll(selected bool) {
 	for _, fw := range d.firewalls {
@@ -730,15 +1098,26 @@ func (d *DeviceTab) onRefreshAll() {
 		d.refreshBtn.Disable()
 	}
 
-	// 진행 중 다이얼로그 표시
+	// 상태 확인 취소용 컨텍스트
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// 진행 중 다이얼로그 표시 (취소 버튼 포함)
 	progressLabel := widget.NewLabel(fmt.Sprintf("장비 상태 확인 중... (총 %d개)", len(selectedFirewalls)))
 	progressBar := widget.NewProgressBarInfinite()
-	progressContent := container.NewVBox(progressLabel, progressBar)
+	var cancelBtn *widget.Button
+	cancelBtn = widget.NewButtonWithIcon("취소", theme.CancelIcon(), func() {
+		cancel()
+		cancelBtn.Disable()
+		progressLabel.SetText("상태 확인 취소 중...")
+	})
+	progressContent := container.NewVBox(progressLabel, progressBar, container.NewCenter(cancelBtn))
 	progressDialog := dialog.NewCustomWithoutButtons("새로고침 중", progressContent, d.window)
 	progressDialog.Show()
 
 	// 백그라운드에서 선택된 장비 상태 확인 실행
 	go func() {
+		defer cancel()
+
 		config, err := d.store.GetConfig()
 		if err != nil {
 			fyne.Do(func() {
@@ -755,7 +1134,8 @@ func (d *DeviceTab) onRefreshAll() {
 
 		// Agent 모드: 배치 호출 (한번에 선택된 장비 상태 확인)
 		// Direct 모드: 개별 호출 (장비별로 순차 확인)
-		deployer.HealthCheckBatch(selectedFirewalls)
+		deployer.HealthCheckBatch(ctx, selectedFirewalls)
+		cancelled := ctx.Err() != nil
 
 		// 장비 상태 저장
 		for _, fw := range selectedFirewalls {
@@ -780,7 +1160,11 @@ func (d *DeviceTab) onRefreshAll() {
 			}
 
 			// 2초 후 자동으로 사라지는 완료 다이얼로그 표시
-			infoDialog := dialog.NewInformation("완료", fmt.Sprintf("%d개 장비 상태 확인 완료", len(selectedFirewalls)), d.window)
+			infoMsg := fmt.Sprintf("%d개 장비 상태 확인 완료", len(selectedFirewalls))
+			if cancelled {
+				infoMsg = "상태 확인이 취소되었습니다"
+			}
+			infoDialog := dialog.NewInformation("완료", infoMsg, d.window)
 			infoDialog.Show()
 			go func() {
 				time.Sleep(2 * time.Second)
@@ -791,14 +1175,3 @@ func (d *DeviceTab) onRefreshAll() {
 		})
 	}()
 }
-
-// IP 주소 또는 IP:PORT 형식이 유효한지 검사합니다.
-func isValidIPOrHostPort(address string) bool {
-	// IP:PORT 형식인 경우
-	if host, _, err := net.SplitHostPort(address); err == nil {
-		// 호스트 부분이 유효한 IP인지 확인
-		return net.ParseIP(host) != nil
-	}
-	// 순수 IP 주소인 경우
-	return net.ParseIP(address) != nil
-}
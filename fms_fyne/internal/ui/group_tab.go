@@ -0,0 +1,278 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"fms/internal/model"
+	"fms/internal/storage"
+	"fms/internal/themes"
+	"fms/internal/ui/component"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// 변수 입력 예시
+const variablesPlaceHolder = "한 줄에 하나씩 이름=값 형식으로 입력\nMGMT_NET=10.1.0.0/24\nWEB_HOST=10.1.0.10\nLAN_IF=eth1"
+
+// 장비 그룹 선택 목록에서 그룹을 지정하지 않는 항목
+const noGroupOption = "(그룹 없음)"
+
+// 장비 그룹과 그룹 변수 관리 탭을 구현합니다.
+type GroupTab struct {
+	window  fyne.Window
+	store   *storage.JSONStore
+	content fyne.CanvasObject
+
+	// UI 컴포넌트
+	groupList        *widget.RadioGroup // 그룹 목록 (라디오 버튼)
+	nameEntry        *widget.Entry      // 그룹 이름
+	variablesEntry   *widget.Entry      // 변수 (한 줄에 이름=값)
+	descriptionEntry *widget.Entry      // 설명
+	membersLabel     *widget.Label      // 그룹에 속한 장비
+
+	// 데이터
+	groups       []*model.DeviceGroup
+	selectedName string
+}
+
+// 새로운 장비 그룹 관리 탭을 생성합니다.
+func NewGroupTab(window fyne.Window, store *storage.JSONStore) *GroupTab {
+	tab := &GroupTab{
+		window: window,
+		store:  store,
+		groups: []*model.DeviceGroup{},
+	}
+	tab.createUI()
+	tab.loadGroups()
+	return tab
+}
+
+// 그룹 탭의 UI를 생성합니다.
+func (g *GroupTab) createUI() {
+	// 좌측: 그룹 목록
+	g.groupList = widget.NewRadioGroup([]string{}, func(selected string) {
+		g.onGroupSelected(selected)
+	})
+	newBtn := component.NewCustomButton("+ 새 그룹", nil, themes.Colors["black"], themes.Colors["lightgray"], func() {
+		g.ClearSelection()
+	}, 5, 0, 0, 5)
+	leftPanel := container.NewBorder(
+		container.NewBorder(nil, nil, widget.NewLabel("장비 그룹 목록"), newBtn),
+		nil, nil, nil,
+		container.NewVScroll(g.groupList),
+	)
+
+	// 우측: 그룹 편집
+	g.nameEntry = widget.NewEntry()
+	g.nameEntry.SetPlaceHolder("예: seoul-dc")
+	g.variablesEntry = widget.NewMultiLineEntry()
+	g.variablesEntry.Wrapping = fyne.TextWrapOff
+	g.variablesEntry.SetPlaceHolder(variablesPlaceHolder)
+	g.descriptionEntry = widget.NewEntry()
+	g.descriptionEntry.SetPlaceHolder("선택 입력")
+	g.membersLabel = widget.NewLabel("소속 장비: 없음")
+	g.membersLabel.Wrapping = fyne.TextWrapWord
+
+	form := widget.NewForm(
+		widget.NewFormItem("이름", g.nameEntry),
+		widget.NewFormItem("설명", g.descriptionEntry),
+	)
+	saveBtn := widget.NewButton("저장", g.onSaveGroup)
+	saveBtn.Importance = widget.HighImportance
+	deleteBtn := widget.NewButton("삭제", g.onDeleteGroup)
+	deleteBtn.Importance = widget.DangerImportance
+
+	rightPanel := container.NewBorder(
+		container.NewVBox(form, widget.NewLabel("변수 (템플릿의 ${이름}을 배포 시 값으로 바꿈, 장비 변수가 우선)")),
+		container.NewVBox(g.membersLabel, container.NewHBox(saveBtn, deleteBtn)),
+		nil, nil,
+		g.variablesEntry,
+	)
+
+	// 좌우 분할 (25% : 75%)
+	split := container.NewHSplit(leftPanel, rightPanel)
+	split.Offset = 0.25
+	g.content = split
+}
+
+// 탭의 컨텐츠를 반환합니다.
+func (g *GroupTab) Content() fyne.CanvasObject {
+	return g.content
+}
+
+// 저장소에서 그룹 목록을 로드합니다.
+func (g *GroupTab) loadGroups() {
+	groups, err := g.store.GetAllGroups()
+	if err != nil {
+		dialog.ShowError(err, g.window)
+		return
+	}
+	g.groups = groups
+
+	options := make([]string, len(groups))
+	for i, group := range groups {
+		options[i] = group.Name
+	}
+	g.groupList.Options = options
+	g.groupList.Refresh()
+}
+
+// 그룹 목록을 새로고침합니다. (외부에서 호출 가능)
+func (g *GroupTab) RefreshGroups() {
+	g.loadGroups()
+}
+
+// 그룹 선택 상태와 입력을 초기화합니다. (새 그룹, Reset 시 호출)
+func (g *GroupTab) ClearSelection() {
+	g.selectedName = ""
+	g.groupList.SetSelected("")
+	g.nameEntry.SetText("")
+	g.variablesEntry.SetText("")
+	g.descriptionEntry.SetText("")
+	g.membersLabel.SetText("소속 장비: 없음")
+}
+
+// 그룹 선택 시 호출됩니다.
+func (g *GroupTab) onGroupSelected(name string) {
+	if name == "" {
+		return
+	}
+	group, err := g.store.GetGroup(name)
+	if err != nil {
+		dialog.ShowError(err, g.window)
+		return
+	}
+	g.selectedName = name
+	g.nameEntry.SetText(group.Name)
+	g.variablesEntry.SetText(formatVariablesText(group.Variables))
+	g.descriptionEntry.SetText(group.Description)
+	g.updateMembersLabel()
+}
+
+// 선택한 그룹에 속한 장비 목록을 표시합니다.
+func (g *GroupTab) updateMembersLabel() {
+	members := g.groupMembers(g.selectedName)
+	if len(members) == 0 {
+		g.membersLabel.SetText("소속 장비: 없음")
+		return
+	}
+	g.membersLabel.SetText("소속 장비: " + strings.Join(members, ", "))
+}
+
+// 그룹에 속한 장비 이름 목록을 정렬하여 반환합니다.
+func (g *GroupTab) groupMembers(name string) []string {
+	members := []string{}
+	firewalls, err := g.store.GetAllFirewalls()
+	if err != nil {
+		return members
+	}
+	for _, fw := range firewalls {
+		if fw.Group == name {
+			members = append(members, fw.DeviceName)
+		}
+	}
+	sort.Strings(members)
+	return members
+}
+
+// 입력한 그룹을 검증하여 저장합니다.
+// 장비가 속한 그룹의 이름은 바꿀 수 없습니다.
+func (g *GroupTab) onSaveGroup() {
+	variables, err := parseVariablesText(g.variablesEntry.Text)
+	if err != nil {
+		dialog.ShowError(err, g.window)
+		return
+	}
+	group := model.NewDeviceGroup(strings.TrimSpace(g.nameEntry.Text))
+	group.Variables = variables
+	group.Description = strings.TrimSpace(g.descriptionEntry.Text)
+	if err := group.Validate(); err != nil {
+		dialog.ShowError(err, g.window)
+		return
+	}
+
+	renamed := g.selectedName != "" && g.selectedName != group.Name
+	if renamed {
+		if members := g.groupMembers(g.selectedName); len(members) > 0 {
+			dialog.ShowError(fmt.Errorf("장비 그룹 %s에 속한 장비가 있어 이름을 바꿀 수 없습니다: %s", g.selectedName, strings.Join(members, ", ")), g.window)
+			return
+		}
+	}
+
+	if err := g.store.SaveGroup(group); err != nil {
+		dialog.ShowError(err, g.window)
+		return
+	}
+	if renamed {
+		if err := g.store.DeleteGroup(g.selectedName); err != nil {
+			dialog.ShowError(err, g.window)
+			return
+		}
+	}
+
+	g.loadGroups()
+	g.groupList.SetSelected(group.Name)
+	dialog.ShowInformation("알림", "장비 그룹이 저장되었습니다.", g.window)
+}
+
+// 선택한 그룹을 삭제합니다. 장비가 속한 그룹은 삭제하지 않습니다.
+func (g *GroupTab) onDeleteGroup() {
+	if g.selectedName == "" {
+		dialog.ShowInformation("알림", "삭제할 장비 그룹을 선택해주세요.", g.window)
+		return
+	}
+	if members := g.groupMembers(g.selectedName); len(members) > 0 {
+		dialog.ShowError(fmt.Errorf("장비 그룹 %s에 속한 장비가 있어 삭제할 수 없습니다: %s", g.selectedName, strings.Join(members, ", ")), g.window)
+		return
+	}
+
+	dialog.ShowConfirm("확인", "선택한 장비 그룹을 삭제하시겠습니까?", func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := g.store.DeleteGroup(g.selectedName); err != nil {
+			dialog.ShowError(err, g.window)
+			return
+		}
+		g.ClearSelection()
+		g.loadGroups()
+		dialog.ShowInformation("알림", "장비 그룹이 삭제되었습니다.", g.window)
+	}, g.window)
+}
+
+// "이름=값" 줄 목록을 변수 맵으로 변환합니다. (빈 줄은 무시)
+func parseVariablesText(text string) (map[string]string, error) {
+	variables := make(map[string]string)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("변수는 이름=값 형식으로 입력하세요: %s", line)
+		}
+		variables[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return variables, nil
+}
+
+// 변수 맵을 이름순 "이름=값" 줄로 변환합니다.
+func formatVariablesText(variables map[string]string) string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, len(names))
+	for i, name := range names {
+		lines[i] = name + "=" + variables[name]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -1,7 +1,9 @@
 package ui
 
 import (
+	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"fms/internal/model"
@@ -23,11 +25,15 @@ type HistoryTab struct {
 	content   fyne.CanvasObject
 
 	// UI 컴포넌트
-	historyTable *widget.Table // 이력 테이블
-	detailTable  *widget.Table // 상세 결과 테이블
+	historyTable   *widget.Table  // 이력 테이블
+	detailTable    *widget.Table  // 상세 결과 테이블
+	categorySelect *widget.Select // 실패 원인 필터
+	causeLabel     *widget.Label  // 선택한 이력의 템플릿 리비전, 포함 템플릿과 실패 원인
 
 	// 데이터
-	histories            []*model.DeployHistory
+	allHistories         []*model.DeployHistory // 전체 이력
+	histories            []*model.DeployHistory // 필터 적용된 이력
+	categoryFilter       string                 // 실패 원인 필터 (빈 문자열이면 전체)
 	selectedHistoryIndex int
 	selectedHistory      *model.DeployHistory
 }
@@ -63,7 +69,7 @@ func (h *HistoryTab) createUI() {
 // 이력 테이블 패널을 생성합니다.
 func (h *HistoryTab) createHistoryTablePanel() fyne.CanvasObject {
 	// 테이블 헤더
-	headers := []string{"시간", "장비", "템플릿", "결과"}
+	headers := []string{"시간", "장비", "템플릿", "결과", "웨이브"}
 
 	// 테이블 생성
 	h.historyTable = widget.NewTable(
@@ -92,9 +98,15 @@ func (h *HistoryTab) createHistoryTablePanel() fyne.CanvasObject {
 					case 1:
 						label.SetText(history.DeviceIP)
 					case 2:
-						label.SetText(history.TemplateVer)
+						if history.RollbackOf > 0 {
+							label.SetText(fmt.Sprintf("%s (롤백 #%d)", history.TemplateVer, history.RollbackOf))
+						} else {
+							label.SetText(history.TemplateVer)
+						}
 					case 3:
 						label.SetText(model.GetDeployStatusText(history.Status))
+					case 4:
+						label.SetText(history.GetWaveText())
 					}
 				}
 			}
@@ -106,6 +118,7 @@ func (h *HistoryTab) createHistoryTablePanel() fyne.CanvasObject {
 	h.historyTable.SetColumnWidth(1, 150) // 장비
 	h.historyTable.SetColumnWidth(2, 100) // 템플릿
 	h.historyTable.SetColumnWidth(3, 100) // 결과
+	h.historyTable.SetColumnWidth(4, 100) // 웨이브
 
 	// 이력 선택 시 상세 표시
 	h.historyTable.OnSelected = func(id widget.TableCellID) {
@@ -113,6 +126,7 @@ func (h *HistoryTab) createHistoryTablePanel() fyne.CanvasObject {
 			h.selectedHistoryIndex = id.Row - 1
 			if h.selectedHistoryIndex < len(h.histories) {
 				h.selectedHistory = h.histories[h.selectedHistoryIndex]
+				h.updateCauseLabel()
 				h.detailTable.Refresh()
 			}
 		}
@@ -131,8 +145,14 @@ func (h *HistoryTab) createHistoryTablePanel() fyne.CanvasObject {
 	// 스크롤 가능한 테이블
 	scrollableTable := container.NewScroll(h.historyTable)
 
-	// 상단 헤더 (배포 이력 라벨)
-	header := widget.NewLabel("배포 이력")
+	// 실패 원인 필터
+	h.categorySelect = widget.NewSelect([]string{}, func(selected string) {
+		h.onCategoryFilterChanged(selected)
+	})
+
+	// 상단 헤더 (배포 이력 라벨 + 실패 원인 필터)
+	header := container.NewBorder(nil, nil, widget.NewLabel("배포 이력"),
+		container.NewHBox(widget.NewLabel("실패 원인"), h.categorySelect))
 
 	// 하단 버튼 영역 (이력삭제 좌측 + margin, 전체삭제 우측 + margin)
 	bottomButtons := container.NewPadded(container.NewBorder(nil, nil, deleteBtn, clearBtn, nil))
@@ -203,12 +223,15 @@ func (h *HistoryTab) createDetailPanel() fyne.CanvasObject {
 	// 스크롤 가능한 테이블
 	scrollableTable := container.NewScroll(h.detailTable)
 
+	// 선택한 이력의 실패 원인
+	h.causeLabel = widget.NewLabel("")
+
 	return container.NewBorder(
 		widget.NewSeparator(),
 		nil,
 		nil, nil,
 		container.NewBorder(
-			widget.NewLabel("상세 결과 (이력을 선택하면 표시됩니다)"),
+			container.NewBorder(nil, nil, widget.NewLabel("상세 결과 (이력을 선택하면 표시됩니다)"), h.causeLabel),
 			nil, nil, nil,
 			scrollableTable,
 		),
@@ -230,23 +253,100 @@ func (h *HistoryTab) loadHistory() {
 		return
 	}
 
-	h.histories = histories
+	h.allHistories = histories
 
 	// ID 내림차순 정렬 (최신순 - ID가 클수록 최신)
-	sort.Slice(h.histories, func(i, j int) bool {
-		return h.histories[i].ID > h.histories[j].ID
+	sort.Slice(h.allHistories, func(i, j int) bool {
+		return h.allHistories[i].ID > h.allHistories[j].ID
 	})
 
-	h.selectedHistoryIndex = -1
-	h.selectedHistory = nil
-
 	// UI 업데이트는 메인 스레드에서 실행
 	fyne.Do(func() {
-		h.historyTable.Refresh()
-		h.detailTable.Refresh()
+		h.updateCategoryOptions()
+		h.applyFilter()
 	})
 }
 
+// 실패 원인 필터 옵션을 원인별 건수와 함께 갱신합니다.
+func (h *HistoryTab) updateCategoryOptions() {
+	counts := make(map[string]int)
+	for _, history := range h.allHistories {
+		if category := history.GetErrorCategory(); category != "" {
+			counts[category]++
+		}
+	}
+
+	options := []string{fmt.Sprintf("전체 (%d)", len(h.allHistories))}
+	selected := options[0]
+	for _, category := range model.GetErrorCategoryOptions() {
+		option := fmt.Sprintf("%s (%d)", model.GetErrorCategoryText(category), counts[category])
+		options = append(options, option)
+		if category == h.categoryFilter {
+			selected = option
+		}
+	}
+
+	h.categorySelect.Options = options
+	h.categorySelect.SetSelected(selected)
+}
+
+// 실패 원인 필터 선택 시 이력 목록을 다시 필터링합니다.
+func (h *HistoryTab) onCategoryFilterChanged(selected string) {
+	index := -1
+	for i, option := range h.categorySelect.Options {
+		if option == selected {
+			index = i
+			break
+		}
+	}
+
+	category := ""
+	if categories := model.GetErrorCategoryOptions(); index > 0 && index <= len(categories) {
+		category = categories[index-1]
+	}
+	if category == h.categoryFilter {
+		return
+	}
+	h.categoryFilter = category
+	h.applyFilter()
+}
+
+// 실패 원인 필터를 적용하여 이력 테이블을 갱신합니다.
+func (h *HistoryTab) applyFilter() {
+	h.histories = []*model.DeployHistory{}
+	for _, history := range h.allHistories {
+		if h.categoryFilter == "" || history.GetErrorCategory() == h.categoryFilter {
+			h.histories = append(h.histories, history)
+		}
+	}
+
+	h.selectedHistoryIndex = -1
+	h.selectedHistory = nil
+	h.historyTable.UnselectAll()
+	h.updateCauseLabel()
+	h.historyTable.Refresh()
+	h.detailTable.Refresh()
+}
+
+// 선택한 이력의 템플릿 리비전, 포함 템플릿과 실패 원인을 표시합니다.
+func (h *HistoryTab) updateCauseLabel() {
+	if h.selectedHistory == nil {
+		h.causeLabel.SetText("")
+		return
+	}
+	var parts []string
+	if h.selectedHistory.Revision != "" {
+		parts = append(parts, "리비전: "+model.ShortHash(h.selectedHistory.Revision))
+	}
+	if len(h.selectedHistory.Includes) > 0 {
+		parts = append(parts, "포함: "+includeRefsText(h.selectedHistory.Includes, ", "))
+	}
+	if category := h.selectedHistory.GetErrorCategory(); category != "" {
+		parts = append(parts, "실패 원인: "+model.GetErrorCategoryText(category))
+	}
+	h.causeLabel.SetText(strings.Join(parts, " · "))
+}
+
 // 배포 이력을 새로고침합니다.
 func (h *HistoryTab) RefreshHistory() {
 	h.loadHistory()
@@ -297,7 +397,7 @@ func (h *HistoryTab) onDeleteHistory() {
 
 // 모든 이력을 삭제합니다.
 func (h *HistoryTab) onClearHistory() {
-	if len(h.histories) == 0 {
+	if len(h.allHistories) == 0 {
 		dialog.ShowInformation("알림", "삭제할 이력이 없습니다.", h.window)
 		return
 	}
@@ -309,7 +409,7 @@ func (h *HistoryTab) onClearHistory() {
 
 		// 이력에 있는 모든 장비 IP 수집
 		deviceIPs := make(map[string]bool)
-		for _, history := range h.histories {
+		for _, history := range h.allHistories {
 			deviceIPs[history.DeviceIP] = true
 		}
 
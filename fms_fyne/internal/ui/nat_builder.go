@@ -16,7 +16,6 @@ type NATBuilder struct {
 	snatForm *component.SNATForm
 	formTabs *container.AppTabs
 	onChange func()
-	comments []string // 주석 라인 보존
 
 	content *fyne.Container
 }
@@ -25,7 +24,6 @@ type NATBuilder struct {
 func NewNATBuilder(onChange func()) *NATBuilder {
 	builder := &NATBuilder{
 		onChange: onChange,
-		comments: []string{},
 	}
 	builder.createUI()
 	return builder
@@ -87,20 +85,9 @@ func (b *NATBuilder) SetRules(rules []*model.NATRule) {
 	b.natTable.SetRules(rules)
 }
 
-// GetComments 주석 반환
-func (b *NATBuilder) GetComments() []string {
-	return b.comments
-}
-
-// SetComments 주석 설정
-func (b *NATBuilder) SetComments(comments []string) {
-	b.comments = comments
-}
-
 // Clear 초기화
 func (b *NATBuilder) Clear() {
 	b.natTable.Clear()
-	b.comments = []string{}
 }
 
 // Refresh UI 새로고침
@@ -0,0 +1,255 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"fms/internal/model"
+	"fms/internal/parser"
+	"fms/internal/storage"
+	"fms/internal/themes"
+	"fms/internal/ui/component"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// 종류별 멤버 입력 예시
+var objectMemberPlaceHolders = map[model.ObjectKind]string{
+	model.ObjectKindAddress: "한 줄에 하나씩 입력\n10.0.0.1\n10.0.0.0/24\n10.0.0.10-10.0.0.20\n2001:db8::/64",
+	model.ObjectKindService: "한 줄에 하나씩 입력\ntcp/443\nudp/53\ntcp/8000:8100",
+}
+
+// 주소/서비스 객체 관리 탭을 구현합니다.
+type ObjectTab struct {
+	window  fyne.Window
+	store   *storage.JSONStore
+	content fyne.CanvasObject
+
+	// UI 컴포넌트
+	objectList       *widget.RadioGroup // 객체 목록 (라디오 버튼)
+	nameEntry        *widget.Entry      // 객체 이름
+	kindSelect       *widget.Select     // 객체 종류
+	membersEntry     *widget.Entry      // 멤버 (한 줄에 하나)
+	descriptionEntry *widget.Entry      // 설명
+	usageLabel       *widget.Label      // 객체를 참조하는 템플릿
+
+	// 데이터
+	objects      []*model.NamedObject
+	selectedName string
+}
+
+// 새로운 객체 관리 탭을 생성합니다.
+func NewObjectTab(window fyne.Window, store *storage.JSONStore) *ObjectTab {
+	tab := &ObjectTab{
+		window:  window,
+		store:   store,
+		objects: []*model.NamedObject{},
+	}
+	tab.createUI()
+	tab.loadObjects()
+	return tab
+}
+
+// 객체 탭의 UI를 생성합니다.
+func (o *ObjectTab) createUI() {
+	// 좌측: 객체 목록
+	o.objectList = widget.NewRadioGroup([]string{}, func(selected string) {
+		o.onObjectSelected(selected)
+	})
+	newBtn := component.NewCustomButton("+ 새 객체", nil, themes.Colors["black"], themes.Colors["lightgray"], func() {
+		o.ClearSelection()
+	}, 5, 0, 0, 5)
+	leftPanel := container.NewBorder(
+		container.NewBorder(nil, nil, widget.NewLabel("객체 목록"), newBtn),
+		nil, nil, nil,
+		container.NewVScroll(o.objectList),
+	)
+
+	// 우측: 객체 편집
+	o.nameEntry = widget.NewEntry()
+	o.nameEntry.SetPlaceHolder("예: monitoring (템플릿에서 @monitoring으로 참조)")
+	o.membersEntry = widget.NewMultiLineEntry()
+	o.membersEntry.Wrapping = fyne.TextWrapOff
+	o.kindSelect = widget.NewSelect(model.GetObjectKindOptions(), func(selected string) {
+		o.membersEntry.SetPlaceHolder(objectMemberPlaceHolders[model.ObjectKind(selected)])
+	})
+	o.kindSelect.SetSelected(string(model.ObjectKindAddress))
+	o.descriptionEntry = widget.NewEntry()
+	o.descriptionEntry.SetPlaceHolder("선택 입력")
+	o.usageLabel = widget.NewLabel("사용 중인 템플릿: 없음")
+	o.usageLabel.Wrapping = fyne.TextWrapWord
+
+	form := widget.NewForm(
+		widget.NewFormItem("이름", o.nameEntry),
+		widget.NewFormItem("종류", o.kindSelect),
+		widget.NewFormItem("설명", o.descriptionEntry),
+	)
+	saveBtn := widget.NewButton("저장", o.onSaveObject)
+	saveBtn.Importance = widget.HighImportance
+	deleteBtn := widget.NewButton("삭제", o.onDeleteObject)
+	deleteBtn.Importance = widget.DangerImportance
+
+	rightPanel := container.NewBorder(
+		container.NewVBox(form, widget.NewLabel("멤버")),
+		container.NewVBox(o.usageLabel, container.NewHBox(saveBtn, deleteBtn)),
+		nil, nil,
+		o.membersEntry,
+	)
+
+	// 좌우 분할 (25% : 75%)
+	split := container.NewHSplit(leftPanel, rightPanel)
+	split.Offset = 0.25
+	o.content = split
+}
+
+// 탭의 컨텐츠를 반환합니다.
+func (o *ObjectTab) Content() fyne.CanvasObject {
+	return o.content
+}
+
+// 저장소에서 객체 목록을 로드합니다.
+func (o *ObjectTab) loadObjects() {
+	objects, err := o.store.GetAllObjects()
+	if err != nil {
+		dialog.ShowError(err, o.window)
+		return
+	}
+	o.objects = objects
+
+	options := make([]string, len(objects))
+	for i, obj := range objects {
+		options[i] = obj.Name
+	}
+	o.objectList.Options = options
+	o.objectList.Refresh()
+}
+
+// 객체 목록을 새로고침합니다. (외부에서 호출 가능)
+func (o *ObjectTab) RefreshObjects() {
+	o.loadObjects()
+}
+
+// 객체 선택 상태와 입력을 초기화합니다. (새 객체, Reset 시 호출)
+func (o *ObjectTab) ClearSelection() {
+	o.selectedName = ""
+	o.objectList.SetSelected("")
+	o.nameEntry.SetText("")
+	o.kindSelect.SetSelected(string(model.ObjectKindAddress))
+	o.membersEntry.SetText("")
+	o.descriptionEntry.SetText("")
+	o.usageLabel.SetText("사용 중인 템플릿: 없음")
+}
+
+// 객체 선택 시 호출됩니다.
+func (o *ObjectTab) onObjectSelected(name string) {
+	if name == "" {
+		return
+	}
+	obj, err := o.store.GetObject(name)
+	if err != nil {
+		dialog.ShowError(err, o.window)
+		return
+	}
+	o.selectedName = name
+	o.nameEntry.SetText(obj.Name)
+	o.kindSelect.SetSelected(string(obj.Kind))
+	o.membersEntry.SetText(strings.Join(obj.Members, "\n"))
+	o.descriptionEntry.SetText(obj.Description)
+	o.updateUsageLabel()
+}
+
+// 선택한 객체를 참조하는 템플릿 목록을 표시합니다.
+func (o *ObjectTab) updateUsageLabel() {
+	usage := o.objectUsage(o.selectedName)
+	if len(usage) == 0 {
+		o.usageLabel.SetText("사용 중인 템플릿: 없음")
+		return
+	}
+	o.usageLabel.SetText("사용 중인 템플릿: " + strings.Join(usage, ", "))
+}
+
+// 객체를 참조하는 템플릿 버전 목록을 정렬하여 반환합니다.
+func (o *ObjectTab) objectUsage(name string) []string {
+	versions := []string{}
+	templates, err := o.store.GetAllTemplates()
+	if err != nil {
+		return versions
+	}
+	for _, t := range templates {
+		if slices.Contains(parser.ObjectReferences(t.Contents), name) {
+			versions = append(versions, t.Version)
+		}
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// 입력한 객체를 검증하여 저장합니다.
+// 사용 중인 객체의 이름은 바꿀 수 없습니다.
+func (o *ObjectTab) onSaveObject() {
+	var members []string
+	for _, line := range strings.Split(o.membersEntry.Text, "\n") {
+		if member := strings.TrimSpace(line); member != "" {
+			members = append(members, member)
+		}
+	}
+	obj := model.NewNamedObject(strings.TrimSpace(o.nameEntry.Text), model.ObjectKind(o.kindSelect.Selected), members...)
+	obj.Description = strings.TrimSpace(o.descriptionEntry.Text)
+	if err := obj.Validate(); err != nil {
+		dialog.ShowError(err, o.window)
+		return
+	}
+
+	renamed := o.selectedName != "" && o.selectedName != obj.Name
+	if renamed {
+		if usage := o.objectUsage(o.selectedName); len(usage) > 0 {
+			dialog.ShowError(fmt.Errorf("%s%s를 사용하는 템플릿이 있어 이름을 바꿀 수 없습니다: %s", model.ObjectRefPrefix, o.selectedName, strings.Join(usage, ", ")), o.window)
+			return
+		}
+	}
+
+	if err := o.store.SaveObject(obj); err != nil {
+		dialog.ShowError(err, o.window)
+		return
+	}
+	if renamed {
+		if err := o.store.DeleteObject(o.selectedName); err != nil {
+			dialog.ShowError(err, o.window)
+			return
+		}
+	}
+
+	o.loadObjects()
+	o.objectList.SetSelected(obj.Name)
+	dialog.ShowInformation("알림", "객체가 저장되었습니다.", o.window)
+}
+
+// 선택한 객체를 삭제합니다. 템플릿에서 참조 중인 객체는 삭제하지 않습니다.
+func (o *ObjectTab) onDeleteObject() {
+	if o.selectedName == "" {
+		dialog.ShowInformation("알림", "삭제할 객체를 선택해주세요.", o.window)
+		return
+	}
+	if usage := o.objectUsage(o.selectedName); len(usage) > 0 {
+		dialog.ShowError(fmt.Errorf("%s%s를 사용하는 템플릿이 있어 삭제할 수 없습니다: %s", model.ObjectRefPrefix, o.selectedName, strings.Join(usage, ", ")), o.window)
+		return
+	}
+
+	dialog.ShowConfirm("확인", "선택한 객체를 삭제하시겠습니까?", func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := o.store.DeleteObject(o.selectedName); err != nil {
+			dialog.ShowError(err, o.window)
+			return
+		}
+		o.ClearSelection()
+		o.loadObjects()
+		dialog.ShowInformation("알림", "객체가 삭제되었습니다.", o.window)
+	}, o.window)
+}
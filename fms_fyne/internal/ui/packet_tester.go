@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fms/internal/model"
+	"fms/internal/parser"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// 추적 단계 표시 이름
+var packetStageNames = map[string]string{
+	model.StageDNAT:   "DNAT",
+	model.StageWhite:  "White",
+	model.StageBlack:  "Black",
+	model.StageFilter: "필터",
+}
+
+// PacketTester 패킷 테스트 패널
+// 템플릿이 패킷을 어떻게 처리하는지 장비에 접속하지 않고 확인합니다.
+type PacketTester struct {
+	window   fyne.Window
+	contents func() (string, error) // 테스트할 템플릿 내용 (객체 참조를 펼친 내용)
+
+	chainSelect    *widget.Select
+	protocolSelect *widget.Select
+	sipEntry       *widget.Entry
+	dipEntry       *widget.Entry
+	dportEntry     *widget.Entry
+	sportEntry     *widget.Entry
+	flagsEntry     *widget.Entry
+	icmpTypeEntry  *widget.Entry
+	inIfEntry      *widget.Entry
+	outIfEntry     *widget.Entry
+	stateSelect    *widget.Select
+	resultLabel    *widget.Label
+	traceLabel     *widget.Label
+
+	content *fyne.Container
+}
+
+// NewPacketTester 새 패킷 테스트 패널 생성
+func NewPacketTester(window fyne.Window, contents func() (string, error)) *PacketTester {
+	tester := &PacketTester{
+		window:   window,
+		contents: contents,
+	}
+	tester.createUI()
+	return tester
+}
+
+// createUI UI 생성
+func (p *PacketTester) createUI() {
+	p.chainSelect = widget.NewSelect(model.GetChainOptions(), nil)
+	p.chainSelect.SetSelected("INPUT")
+
+	p.sipEntry = widget.NewEntry()
+	p.sipEntry.SetPlaceHolder("예: 10.1.2.3")
+	p.dipEntry = widget.NewEntry()
+	p.dipEntry.SetPlaceHolder("예: 192.168.0.5")
+	p.dportEntry = widget.NewEntry()
+	p.dportEntry.SetPlaceHolder("예: 443")
+	p.sportEntry = widget.NewEntry()
+	p.sportEntry.SetPlaceHolder("예: 53 (비우면 조건 없음)")
+	p.flagsEntry = widget.NewEntry()
+	p.flagsEntry.SetText("syn")
+	p.flagsEntry.SetPlaceHolder("예: syn 또는 syn,ack")
+	p.icmpTypeEntry = widget.NewEntry()
+	p.icmpTypeEntry.SetText("echo-request")
+	p.icmpTypeEntry.SetPlaceHolder("예: echo-request 또는 8")
+	p.inIfEntry = widget.NewEntry()
+	p.inIfEntry.SetPlaceHolder("예: eth0 (DNAT 조건)")
+	p.outIfEntry = widget.NewEntry()
+	p.outIfEntry.SetPlaceHolder("예: eth1")
+	p.stateSelect = widget.NewSelect(model.GetConnStateOptions(), nil)
+	p.stateSelect.SetSelected("NEW")
+
+	// 프로토콜에 맞는 입력만 활성화
+	p.protocolSelect = widget.NewSelect([]string{"tcp", "udp", "icmp"}, func(protocol string) {
+		setEnabled(p.dportEntry, protocol != "icmp")
+		setEnabled(p.sportEntry, protocol != "icmp")
+		setEnabled(p.flagsEntry, protocol == "tcp")
+		setEnabled(p.icmpTypeEntry, protocol == "icmp")
+	})
+	p.protocolSelect.SetSelected("tcp")
+
+	form := widget.NewForm(
+		widget.NewFormItem("Chain", p.chainSelect),
+		widget.NewFormItem("Protocol", p.protocolSelect),
+		widget.NewFormItem("SIP", p.sipEntry),
+		widget.NewFormItem("DIP", p.dipEntry),
+		widget.NewFormItem("DPort", p.dportEntry),
+		widget.NewFormItem("SPort", p.sportEntry),
+		widget.NewFormItem("TCP Flags", p.flagsEntry),
+		widget.NewFormItem("ICMP Type", p.icmpTypeEntry),
+		widget.NewFormItem("InIF", p.inIfEntry),
+		widget.NewFormItem("OutIF", p.outIfEntry),
+		widget.NewFormItem("State", p.stateSelect),
+	)
+
+	testBtn := widget.NewButton("테스트", p.onTest)
+	testBtn.Importance = widget.HighImportance
+
+	p.resultLabel = widget.NewLabel("")
+	p.resultLabel.Wrapping = fyne.TextWrapWord
+	p.resultLabel.TextStyle = fyne.TextStyle{Bold: true}
+	p.traceLabel = widget.NewLabel("")
+	p.traceLabel.Wrapping = fyne.TextWrapWord
+
+	p.content = container.NewBorder(
+		container.NewVBox(form, testBtn, widget.NewSeparator(), p.resultLabel),
+		nil, nil, nil,
+		container.NewVScroll(p.traceLabel),
+	)
+}
+
+// setEnabled 입력 위젯 활성화 상태 변경
+func setEnabled(entry *widget.Entry, enabled bool) {
+	if enabled {
+		entry.Enable()
+	} else {
+		entry.Disable()
+	}
+}
+
+// Content UI 컨테이너 반환
+func (p *PacketTester) Content() *fyne.Container {
+	return p.content
+}
+
+// packet 입력값으로 패킷 생성
+func (p *PacketTester) packet() model.Packet {
+	packet := model.Packet{
+		Chain:        model.StringToChain(p.chainSelect.Selected),
+		Protocol:     model.StringToProtocol(p.protocolSelect.Selected),
+		SIP:          strings.TrimSpace(p.sipEntry.Text),
+		DIP:          strings.TrimSpace(p.dipEntry.Text),
+		InInterface:  strings.TrimSpace(p.inIfEntry.Text),
+		OutInterface: strings.TrimSpace(p.outIfEntry.Text),
+		State:        p.stateSelect.Selected,
+	}
+	switch packet.Protocol {
+	case model.ProtocolTCP:
+		packet.TCPFlags = strings.TrimSpace(p.flagsEntry.Text)
+		fallthrough
+	case model.ProtocolUDP:
+		packet.DPort, _ = strconv.Atoi(strings.TrimSpace(p.dportEntry.Text))
+		packet.SPort, _ = strconv.Atoi(strings.TrimSpace(p.sportEntry.Text))
+	case model.ProtocolICMP:
+		packet.ICMPType = strings.TrimSpace(p.icmpTypeEntry.Text)
+	}
+	return packet
+}
+
+// onTest 현재 템플릿으로 패킷 시뮬레이션 실행
+func (p *PacketTester) onTest() {
+	contents, err := p.contents()
+	if err != nil {
+		dialog.ShowError(err, p.window)
+		return
+	}
+	result, err := parser.SimulateText(contents, p.packet())
+	if err != nil {
+		dialog.ShowError(err, p.window)
+		return
+	}
+
+	summary := "결과: " + result.Summary()
+	if result.Translated {
+		summary += "\nDNAT 적용 후 패킷: " + result.Packet.String()
+	}
+	p.resultLabel.SetText(summary)
+
+	lines := []string{}
+	for _, step := range result.Trace {
+		mark := " "
+		if step.Matched {
+			mark = "▶"
+		}
+		lines = append(lines, fmt.Sprintf("%s [%s] 라인 %d: %s\n    %s", mark, packetStageNames[step.Stage], step.Line, step.Reason, step.Rule))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "비교한 규칙이 없습니다.")
+	}
+	p.traceLabel.SetText(strings.Join(lines, "\n"))
+}
+
+// Clear 결과 초기화
+func (p *PacketTester) Clear() {
+	p.resultLabel.SetText("")
+	p.traceLabel.SetText("")
+}
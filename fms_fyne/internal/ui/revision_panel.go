@@ -0,0 +1,219 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fms/internal/model"
+	"fms/internal/storage"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// RevisionPanel 템플릿 리비전 패널
+// 템플릿을 저장할 때마다 기록된 리비전을 조회하고 이전/현재 리비전과 비교하거나 복원합니다.
+type RevisionPanel struct {
+	window  fyne.Window
+	store   *storage.JSONStore
+	restore func(revision *model.TemplateRevision) error // 리비전 내용을 새 리비전으로 저장
+
+	revisionList *widget.List
+	infoLabel    *widget.Label // 선택한 리비전 정보
+	contentEntry *widget.Entry // 선택한 리비전 내용 또는 비교 결과 (읽기 전용)
+
+	version   string
+	revisions []*model.TemplateRevision // 최신순
+	selected  int
+
+	content fyne.CanvasObject
+}
+
+// NewRevisionPanel 새 리비전 패널 생성
+func NewRevisionPanel(window fyne.Window, store *storage.JSONStore, restore func(revision *model.TemplateRevision) error) *RevisionPanel {
+	panel := &RevisionPanel{
+		window:   window,
+		store:    store,
+		restore:  restore,
+		selected: -1,
+	}
+	panel.createUI()
+	return panel
+}
+
+// createUI UI 생성
+func (r *RevisionPanel) createUI() {
+	r.revisionList = widget.NewList(
+		func() int { return len(r.revisions) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(revisionListText(r.revisions[id], id == 0))
+		},
+	)
+	r.revisionList.OnSelected = r.onSelected
+
+	r.infoLabel = widget.NewLabel("리비전을 선택하면 내용을 표시합니다")
+	r.infoLabel.Wrapping = fyne.TextWrapWord
+	r.contentEntry = widget.NewMultiLineEntry()
+	r.contentEntry.Wrapping = fyne.TextWrapOff
+	r.contentEntry.Disable()
+
+	parentBtn := widget.NewButton("이전 리비전과 비교", r.onCompareParent)
+	headBtn := widget.NewButton("현재 리비전과 비교", r.onCompareHead)
+	restoreBtn := widget.NewButton("복원", r.onRestore)
+	restoreBtn.Importance = widget.HighImportance
+
+	detail := container.NewBorder(
+		container.NewVBox(r.infoLabel, container.NewHBox(parentBtn, headBtn, restoreBtn)),
+		nil, nil, nil,
+		r.contentEntry,
+	)
+
+	split := container.NewHSplit(r.revisionList, detail)
+	split.Offset = 0.35
+	r.content = split
+}
+
+// Content 패널 컨텐츠 반환
+func (r *RevisionPanel) Content() fyne.CanvasObject {
+	return r.content
+}
+
+// SetVersion 템플릿 버전의 리비전 목록을 다시 로드
+func (r *RevisionPanel) SetVersion(version string) {
+	r.version = version
+	r.revisions = nil
+	if version != "" {
+		revisions, err := r.store.GetTemplateRevisions(version)
+		if err != nil {
+			dialog.ShowError(err, r.window)
+		}
+		r.revisions = revisions
+	}
+	r.selected = -1
+	r.revisionList.UnselectAll()
+	r.revisionList.Refresh()
+	r.contentEntry.SetText("")
+	if len(r.revisions) == 0 {
+		r.infoLabel.SetText("저장된 리비전이 없습니다. 템플릿을 저장하면 리비전이 기록됩니다.")
+	} else {
+		r.infoLabel.SetText("리비전을 선택하면 내용을 표시합니다")
+	}
+}
+
+// onSelected 리비전 선택 시 정보와 내용 표시
+func (r *RevisionPanel) onSelected(id widget.ListItemID) {
+	if id < 0 || id >= len(r.revisions) {
+		return
+	}
+	r.selected = id
+	revision := r.revisions[id]
+
+	info := []string{fmt.Sprintf("%s  %s", revision, revision.CreatedAt.Time().Format("2006-01-02 15:04:05"))}
+	if revision.Author != "" {
+		info = append(info, "작성자: "+revision.Author)
+	}
+	if revision.Note != "" {
+		info = append(info, "메모: "+revision.Note)
+	}
+	r.infoLabel.SetText(strings.Join(info, "\n"))
+	r.contentEntry.SetText(revision.Contents)
+}
+
+// selectedRevision 선택한 리비전 반환 (없으면 안내 후 nil)
+func (r *RevisionPanel) selectedRevision() *model.TemplateRevision {
+	if r.selected < 0 || r.selected >= len(r.revisions) {
+		dialog.ShowInformation("알림", "리비전을 선택해주세요.", r.window)
+		return nil
+	}
+	return r.revisions[r.selected]
+}
+
+// onCompareParent 선택한 리비전을 직전 리비전과 비교
+func (r *RevisionPanel) onCompareParent() {
+	revision := r.selectedRevision()
+	if revision == nil {
+		return
+	}
+	if revision.Parent == "" {
+		dialog.ShowInformation("알림", "첫 리비전이라 비교할 이전 리비전이 없습니다.", r.window)
+		return
+	}
+	parent, err := r.store.GetTemplateRevision(r.version, revision.Parent)
+	if err != nil {
+		dialog.ShowError(err, r.window)
+		return
+	}
+	r.showDiff(parent, revision)
+}
+
+// onCompareHead 선택한 리비전을 현재(최신) 리비전과 비교
+func (r *RevisionPanel) onCompareHead() {
+	revision := r.selectedRevision()
+	if revision == nil {
+		return
+	}
+	r.showDiff(revision, r.revisions[0])
+}
+
+// showDiff 두 리비전의 줄 단위 비교 결과 표시
+func (r *RevisionPanel) showDiff(from, to *model.TemplateRevision) {
+	lines := model.DiffContents(from.Contents, to.Contents)
+	r.infoLabel.SetText(fmt.Sprintf("비교: %s → %s", from, to))
+	if !model.HasDiff(lines) {
+		r.contentEntry.SetText("변경된 줄이 없습니다")
+		return
+	}
+	text := make([]string, len(lines))
+	for i, l := range lines {
+		text[i] = l.String()
+	}
+	r.contentEntry.SetText(strings.Join(text, "\n"))
+}
+
+// onRestore 선택한 리비전의 내용을 새 리비전으로 저장
+func (r *RevisionPanel) onRestore() {
+	revision := r.selectedRevision()
+	if revision == nil {
+		return
+	}
+	if r.selected == 0 {
+		dialog.ShowInformation("알림", "현재 리비전입니다.", r.window)
+		return
+	}
+
+	dialog.ShowConfirm("확인", fmt.Sprintf("%s의 내용을 새 리비전으로 저장하시겠습니까?", revision), func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := r.restore(revision); err != nil {
+			dialog.ShowError(err, r.window)
+			return
+		}
+		r.SetVersion(r.version)
+		dialog.ShowInformation("알림", "리비전이 복원되었습니다.", r.window)
+	}, r.window)
+}
+
+// revisionListText 리비전 목록 항목 텍스트
+func revisionListText(revision *model.TemplateRevision, head bool) string {
+	text := revision.String()
+	if head {
+		text += " [현재]"
+	}
+	if revision.Note != "" {
+		text += " " + revision.Note
+	}
+	return text
+}
+
+// includeRefsText 포함 기록(버전@해시) 목록을 "버전@짧은해시" 형식으로 이어 붙인 텍스트
+func includeRefsText(refs []string, sep string) string {
+	texts := make([]string, len(refs))
+	for i, ref := range refs {
+		texts[i] = model.IncludeRefText(ref)
+	}
+	return strings.Join(texts, sep)
+}
@@ -15,7 +15,6 @@ type RuleBuilder struct {
 	blackWhiteForm *component.BlackWhiteForm // Black/White 폼
 	formTabs       *container.AppTabs        // 폼 전환 탭
 	onChange       func()
-	comments       []string // 주석 라인 보존
 
 	content *fyne.Container
 }
@@ -24,7 +23,6 @@ type RuleBuilder struct {
 func NewRuleBuilder(onChange func()) *RuleBuilder {
 	builder := &RuleBuilder{
 		onChange: onChange,
-		comments: []string{},
 	}
 	builder.createUI()
 	return builder
@@ -82,20 +80,9 @@ func (b *RuleBuilder) SetRules(rules []*model.FirewallRule) {
 	b.ruleTable.SetRules(rules)
 }
 
-// GetComments 주석 반환
-func (b *RuleBuilder) GetComments() []string {
-	return b.comments
-}
-
-// SetComments 주석 설정
-func (b *RuleBuilder) SetComments(comments []string) {
-	b.comments = comments
-}
-
 // Clear 초기화
 func (b *RuleBuilder) Clear() {
 	b.ruleTable.Clear()
-	b.comments = []string{}
 }
 
 // Refresh UI 새로고침
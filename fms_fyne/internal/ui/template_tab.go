@@ -1,13 +1,18 @@
 package ui
 
 import (
+	"fmt"
+	"os"
+	"slices"
 	"sort"
+	"strings"
 
 	"fms/internal/model"
 	"fms/internal/parser"
 	"fms/internal/storage"
 	"fms/internal/themes"
 	"fms/internal/ui/component"
+	"fms/internal/utils"
 	"fms/internal/version"
 
 	"fyne.io/fyne/v2"
@@ -26,9 +31,15 @@ type TemplateTab struct {
 	// UI 컴포넌트
 	templateList    *widget.RadioGroup // 템플릿 목록 (라디오 버튼)
 	templateContent *widget.Entry      // 템플릿 내용 편집기
+	diagnosticLabel *widget.Label      // 규칙 검증 결과
 	ruleBuilder     *RuleBuilder       // 규칙 빌더
 	natBuilder      *NATBuilder        // NAT 규칙 빌더
-	subTabs         *container.AppTabs // 서브 탭 (텍스트 편집 / 규칙 빌더 / NAT 규칙)
+	packetTester    *PacketTester      // 패킷 테스트
+	effectiveEntry  *widget.Entry      // 유효 템플릿 (포함 지시문을 펼친 내용, 읽기 전용)
+	effectiveLabel  *widget.Label      // 유효 템플릿의 포함 템플릿 목록 또는 오류
+	revisionPanel   *RevisionPanel     // 리비전 조회/비교/복원
+	subTabs         *container.AppTabs // 서브 탭 (텍스트 편집 / 규칙 빌더 / NAT 규칙 / 패킷 테스트 / 유효 템플릿 / 리비전)
+	lastSubTab      string             // 직전에 선택된 서브 탭 이름
 
 	// 데이터
 	templates       []*model.Template
@@ -88,10 +99,15 @@ func (t *TemplateTab) createTemplateListPanel() fyne.CanvasObject {
 		t.onNewTemplate()
 	}, 5, 0, 0, 5)
 
-	// 헤더: "템플릿 목록" + 오른쪽에 새 템플릿 버튼
+	// 규칙 가져오기 버튼 (iptables-save / smartfw)
+	importBtn := component.NewCustomButton("규칙 가져오기", nil, themes.Colors["black"], themes.Colors["lightgray"], func() {
+		t.onImportRuleset()
+	}, 5, 0, 0, 5)
+
+	// 헤더: "템플릿 목록" + 오른쪽에 가져오기/새 템플릿 버튼
 	header := container.NewBorder(nil, nil,
 		widget.NewLabel("템플릿 목록"),
-		newBtn,
+		container.NewHBox(importBtn, newBtn),
 	)
 
 	// 패널 레이아웃
@@ -109,6 +125,12 @@ func (t *TemplateTab) createTemplateContentPanel() fyne.CanvasObject {
 	t.templateContent = widget.NewMultiLineEntry()
 	t.templateContent.SetPlaceHolder("템플릿 내용을 입력하세요...\n\n예시:\nagent -m=insert -c=INPUT -p=tcp --dport=9010 -a=DROP")
 	t.templateContent.Wrapping = fyne.TextWrapOff
+	t.templateContent.OnChanged = t.updateDiagnostics
+
+	// 규칙 검증 결과 (오류/경고가 있을 때만 표시)
+	t.diagnosticLabel = widget.NewLabel("")
+	t.diagnosticLabel.Wrapping = fyne.TextWrapWord
+	t.diagnosticLabel.Hide()
 
 	// 규칙 빌더
 	t.ruleBuilder = NewRuleBuilder(nil)
@@ -117,7 +139,7 @@ func (t *TemplateTab) createTemplateContentPanel() fyne.CanvasObject {
 	t.natBuilder = NewNATBuilder(nil)
 
 	// 텍스트 편집 탭
-	textEditTab := container.NewTabItem("텍스트 편집", t.templateContent)
+	textEditTab := container.NewTabItem("텍스트 편집", container.NewBorder(nil, t.diagnosticLabel, nil, nil, t.templateContent))
 
 	// 규칙 빌더 탭
 	ruleBuilderTab := container.NewTabItem("규칙 빌더", t.ruleBuilder.Content())
@@ -125,8 +147,27 @@ func (t *TemplateTab) createTemplateContentPanel() fyne.CanvasObject {
 	// NAT 규칙 탭
 	natBuilderTab := container.NewTabItem("NAT 규칙", t.natBuilder.Content())
 
+	// 패킷 테스트 탭 (텍스트 편집기 내용 기준, 포함 템플릿과 객체 참조는 펼쳐서 평가)
+	t.packetTester = NewPacketTester(t.window, func() (string, error) {
+		return t.effectiveContents(t.selectedVersion, t.templateContent.Text)
+	})
+	packetTesterTab := container.NewTabItem("패킷 테스트", t.packetTester.Content())
+
+	// 유효 템플릿 탭 (포함 지시문을 펼친 내용, 읽기 전용)
+	t.effectiveEntry = widget.NewMultiLineEntry()
+	t.effectiveEntry.Wrapping = fyne.TextWrapOff
+	t.effectiveEntry.Disable()
+	t.effectiveLabel = widget.NewLabel("")
+	t.effectiveLabel.Wrapping = fyne.TextWrapWord
+	effectiveTab := container.NewTabItem("유효 템플릿", container.NewBorder(t.effectiveLabel, nil, nil, nil, t.effectiveEntry))
+
+	// 리비전 탭 (선택한 템플릿의 저장 이력)
+	t.revisionPanel = NewRevisionPanel(t.window, t.store, t.restoreRevision)
+	revisionTab := container.NewTabItem("리비전", t.revisionPanel.Content())
+
 	// 서브 탭 생성
-	t.subTabs = container.NewAppTabs(textEditTab, ruleBuilderTab, natBuilderTab)
+	t.subTabs = container.NewAppTabs(textEditTab, ruleBuilderTab, natBuilderTab, packetTesterTab, effectiveTab, revisionTab)
+	t.lastSubTab = textEditTab.Text
 	t.subTabs.OnSelected = t.onSubTabChanged
 
 	// 저장, 삭제 버튼
@@ -136,9 +177,15 @@ func (t *TemplateTab) createTemplateContentPanel() fyne.CanvasObject {
 	deleteBtn := component.NewCustomButton("삭제", theme.DeleteIcon(), nil, themes.Colors["red"], func() {
 		t.onDeleteTemplate()
 	}, 5, 5, 5, 5)
-	buttons := container.NewHBox(saveBtn, deleteBtn)
 
-	// 헤더: "템플릿 내용" + 저장/삭제 버튼
+	// 내보내기 버튼 (iptables-restore / ip6tables-restore / nftables / smartfw 형식 선택)
+	var exportBtn fyne.CanvasObject
+	exportBtn = component.NewCustomButton("내보내기", theme.DocumentSaveIcon(), nil, themes.Colors["darkgray"], func() {
+		t.showExportMenu(exportBtn)
+	}, 5, 5, 5, 5)
+	buttons := container.NewHBox(saveBtn, exportBtn, deleteBtn)
+
+	// 헤더: "템플릿 내용" + 저장/내보내기/삭제 버튼
 	header := container.NewBorder(nil, nil, widget.NewLabel("템플릿 내용"), buttons, nil)
 
 	// 제목과 함께 반환
@@ -151,45 +198,58 @@ func (t *TemplateTab) createTemplateContentPanel() fyne.CanvasObject {
 
 // onSubTabChanged 서브 탭 전환 시 호출
 func (t *TemplateTab) onSubTabChanged(tab *container.TabItem) {
+	// 빌더에서 벗어날 때만 빌더의 내용을 텍스트로 통합
+	// (다른 탭에서 온 경우 빌더 내용이 최신 텍스트보다 오래되었을 수 있음)
+	if t.lastSubTab == "규칙 빌더" || t.lastSubTab == "NAT 규칙" {
+		t.syncBuildersToText()
+	}
+	t.lastSubTab = tab.Text
+
 	switch tab.Text {
 	case "규칙 빌더":
 		// 텍스트 -> 규칙 빌더로 변환 (필터 규칙만)
-		rules, comments, _ := parser.ParseTextToRules(t.templateContent.Text)
-		t.ruleBuilder.SetRules(rules)
-		t.ruleBuilder.SetComments(comments)
+		t.ruleBuilder.SetRules(parser.ParseDocument(t.templateContent.Text).Rules())
 	case "NAT 규칙":
 		// 텍스트 -> NAT 빌더로 변환 (NAT 규칙만)
-		natRules, comments, _ := parser.ParseTextToNATRules(t.templateContent.Text)
-		t.natBuilder.SetRules(natRules)
-		t.natBuilder.SetComments(comments)
-	case "텍스트 편집":
-		// 모든 빌더의 내용을 텍스트로 통합
-		t.syncBuildersToText()
+		t.natBuilder.SetRules(parser.ParseDocument(t.templateContent.Text).NATRules())
+	case "유효 템플릿":
+		t.updateEffectiveTemplate()
+	case "리비전":
+		t.revisionPanel.SetVersion(t.selectedVersion)
 	}
 }
 
-// syncBuildersToText 빌더 내용을 텍스트로 동기화
-func (t *TemplateTab) syncBuildersToText() {
-	// 필터 규칙 (주석 포함)
-	filterRules := t.ruleBuilder.GetRules()
-	filterComments := t.ruleBuilder.GetComments()
-	filterText := parser.RulesToText(filterRules, filterComments)
-
-	// NAT 규칙 (주석 제외 - 필터 규칙에서 이미 포함됨)
-	natRules := t.natBuilder.GetRules()
-	natText := parser.NATRulesToText(natRules, nil) // nil - 주석 중복 방지
-
-	// 통합
-	var finalText string
-	if filterText != "" && natText != "" {
-		finalText = filterText + "\n" + natText
-	} else if filterText != "" {
-		finalText = filterText
+// 편집 중인 템플릿의 포함 지시문(#include)을 현재 저장된 템플릿으로 펼쳐 표시합니다.
+// 객체 참조와 템플릿 변수는 배포 시 펼쳐지므로 그대로 둡니다.
+func (t *TemplateTab) updateEffectiveTemplate() {
+	template, err := model.ResolveIncludes(model.NewTemplate(t.selectedVersion, t.templateContent.Text), t.store.GetTemplate)
+	if err != nil {
+		t.effectiveLabel.Importance = widget.DangerImportance
+		t.effectiveLabel.SetText(fmt.Sprintf("포함 템플릿을 펼칠 수 없습니다: %v", err))
+		t.effectiveEntry.SetText("")
+		return
+	}
+
+	t.effectiveLabel.Importance = widget.MediumImportance
+	if len(template.Includes) == 0 {
+		t.effectiveLabel.SetText("포함한 템플릿 없음")
 	} else {
-		finalText = natText
+		t.effectiveLabel.SetText("포함: " + includeRefsText(template.Includes, " → "))
 	}
+	t.effectiveEntry.SetText(template.Contents)
+}
 
-	t.templateContent.SetText(finalText)
+// syncBuildersToText 빌더 내용을 텍스트로 동기화
+// 현재 텍스트를 문서로 파싱한 뒤 빌더의 규칙을 반영하므로
+// 주석, 빈 줄, 규칙 순서는 그대로 유지됩니다.
+func (t *TemplateTab) syncBuildersToText() {
+	doc := parser.ParseDocument(t.templateContent.Text)
+	doc.SetRules(t.ruleBuilder.GetRules())
+	doc.SetNATRules(t.natBuilder.GetRules())
+
+	if text := doc.String(); text != t.templateContent.Text {
+		t.templateContent.SetText(text)
+	}
 }
 
 // 하단 버튼/입력 영역을 생성합니다.
@@ -291,15 +351,10 @@ func (t *TemplateTab) onTemplateSelected(version string) {
 		if tmpl.Version == version {
 			t.templateContent.SetText(tmpl.Contents)
 
-			// 규칙 빌더도 동기화 (필터 규칙)
-			rules, comments, _ := parser.ParseTextToRules(tmpl.Contents)
-			t.ruleBuilder.SetRules(rules)
-			t.ruleBuilder.SetComments(comments)
-
-			// NAT 빌더도 동기화 (NAT 규칙)
-			natRules, natComments, _ := parser.ParseTextToNATRules(tmpl.Contents)
-			t.natBuilder.SetRules(natRules)
-			t.natBuilder.SetComments(natComments)
+			// 규칙 빌더와 NAT 빌더도 동기화
+			doc := parser.ParseDocument(tmpl.Contents)
+			t.ruleBuilder.SetRules(doc.Rules())
+			t.natBuilder.SetRules(doc.NATRules())
 			return
 		}
 	}
@@ -320,9 +375,60 @@ func (t *TemplateTab) onNewTemplate() {
 	t.resetAllTabs()
 }
 
+// onImportRuleset iptables-save 출력 또는 smartfw 덤프 파일을 새 템플릿으로 가져오기
+func (t *TemplateTab) onImportRuleset() {
+	openDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, t.window)
+			return
+		}
+		if reader == nil {
+			return
+		}
+		defer reader.Close()
+
+		data, err := os.ReadFile(reader.URI().Path())
+		if err != nil {
+			dialog.ShowError(err, t.window)
+			return
+		}
+
+		result, err := parser.ImportRuleset(string(data))
+		if err != nil {
+			dialog.ShowError(err, t.window)
+			return
+		}
+		if result.Text == "" {
+			dialog.ShowInformation("알림", "가져올 수 있는 규칙이 없습니다.", t.window)
+			return
+		}
+
+		// 새 템플릿으로 열기 (저장 시 버전명 입력)
+		t.onNewTemplate()
+		t.templateContent.SetText(result.Text)
+		t.ruleBuilder.SetRules(result.Rules)
+		t.natBuilder.SetRules(result.NATRules)
+
+		message := fmt.Sprintf("필터 규칙 %d개, NAT 규칙 %d개를 가져왔습니다.", len(result.Rules), len(result.NATRules))
+		if len(result.Skipped) > 0 {
+			lines := []string{}
+			for i, skipped := range result.Skipped {
+				if i == 10 {
+					lines = append(lines, fmt.Sprintf("... 외 %d개", len(result.Skipped)-10))
+					break
+				}
+				lines = append(lines, fmt.Sprintf("라인 %d: %s", skipped.Line, skipped.Reason))
+			}
+			message += fmt.Sprintf("\n\n변환하지 못한 규칙 %d개:\n%s", len(result.Skipped), strings.Join(lines, "\n"))
+		}
+		dialog.ShowInformation("규칙 가져오기", message, t.window)
+	}, t.window)
+	openDialog.Show()
+}
+
 // resetAllTabs 모든 탭 위치를 첫 번째 탭으로 초기화
 func (t *TemplateTab) resetAllTabs() {
-	// 서브 탭 (텍스트 편집 / 규칙 빌더 / NAT 규칙) 초기화
+	// 서브 탭 (텍스트 편집 / 규칙 빌더 / NAT 규칙 / 패킷 테스트 / 유효 템플릿 / 리비전) 초기화
 	if len(t.subTabs.Items) > 0 {
 		t.subTabs.SelectIndex(0)
 	}
@@ -332,6 +438,9 @@ func (t *TemplateTab) resetAllTabs() {
 
 	// NAT 빌더 내부 폼 탭 초기화
 	t.natBuilder.ResetTabs()
+
+	// 이전 템플릿의 패킷 테스트 결과 초기화
+	t.packetTester.Clear()
 }
 
 // getCurrentContents 현재 활성 탭에서 내용 가져오기
@@ -362,6 +471,12 @@ func (t *TemplateTab) onSaveTemplate() {
 		return
 	}
 
+	// 규칙 오류가 있으면 저장하지 않음 (엄격 모드와 같은 규칙: 알 수 없는 옵션, 중복 옵션 등)
+	if err := templateRulesError(contents); err != nil {
+		dialog.ShowError(err, t.window)
+		return
+	}
+
 	// 버전명 입력 다이얼로그
 	versionEntry := widget.NewEntry()
 	versionEntry.SetPlaceHolder("예: v1.0")
@@ -371,8 +486,13 @@ func (t *TemplateTab) onSaveTemplate() {
 		versionEntry.SetText(t.selectedVersion)
 	}
 
+	// 변경 메모 (리비전에 작성자와 함께 기록)
+	noteEntry := widget.NewEntry()
+	noteEntry.SetPlaceHolder("선택 입력")
+
 	formItems := []*widget.FormItem{
 		widget.NewFormItem("버전명", versionEntry),
+		widget.NewFormItem("변경 메모", noteEntry),
 	}
 
 	dialog.ShowForm("템플릿 저장", "저장", "취소", formItems, func(ok bool) {
@@ -391,7 +511,13 @@ func (t *TemplateTab) onSaveTemplate() {
 			Contents: contents,
 		}
 
-		if err := t.store.SaveTemplate(template); err != nil {
+		// 포함 지시문이 순환하면 배포할 수 없으므로 저장하지 않음
+		if err := model.CheckIncludeCycle(template, t.store.GetTemplate); err != nil {
+			dialog.ShowError(err, t.window)
+			return
+		}
+
+		if _, err := t.store.CommitTemplate(template, utils.CurrentUser(), strings.TrimSpace(noteEntry.Text)); err != nil {
 			dialog.ShowError(err, t.window)
 			return
 		}
@@ -402,12 +528,171 @@ func (t *TemplateTab) onSaveTemplate() {
 	}, t.window)
 }
 
+// 이전 리비전의 내용을 저장 시와 같은 검사를 거쳐 새 리비전으로 저장합니다.
+func (t *TemplateTab) restoreRevision(revision *model.TemplateRevision) error {
+	template := revision.Template()
+	if err := templateRulesError(template.Contents); err != nil {
+		return err
+	}
+	if err := model.CheckIncludeCycle(template, t.store.GetTemplate); err != nil {
+		return err
+	}
+	if _, err := t.store.CommitTemplate(template, utils.CurrentUser(), fmt.Sprintf("%s 복원", revision)); err != nil {
+		return err
+	}
+
+	// 복원한 내용을 편집기에 반영 (리비전 탭은 유지)
+	t.loadTemplates()
+	t.templateContent.SetText(template.Contents)
+	doc := parser.ParseDocument(template.Contents)
+	t.ruleBuilder.SetRules(doc.Rules())
+	t.natBuilder.SetRules(doc.NATRules())
+	return nil
+}
+
+// 내보내기 형식 선택 메뉴를 버튼 아래에 표시합니다.
+func (t *TemplateTab) showExportMenu(anchor fyne.CanvasObject) {
+	menu := fyne.NewMenu("",
+		fyne.NewMenuItem("iptables-restore (.rules)", func() {
+			t.onExportTemplate(parser.ExportIptables)
+		}),
+		fyne.NewMenuItem("ip6tables-restore (.v6.rules)", func() {
+			t.onExportTemplate(parser.ExportIp6tables)
+		}),
+		fyne.NewMenuItem("nftables (.nft)", func() {
+			t.onExportTemplate(parser.ExportNftables)
+		}),
+		fyne.NewMenuItem("smartfw (.smartfw)", func() {
+			t.onExportTemplate(parser.ExportSmartfw)
+		}),
+	)
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(anchor)
+	widget.ShowPopUpMenuAtPosition(menu, t.window.Canvas(), pos.AddXY(0, anchor.Size().Height))
+}
+
+// 템플릿 내용의 포함 지시문(#include)과 객체 참조(@이름)를 현재 저장된 값으로 펼칩니다.
+func (t *TemplateTab) effectiveContents(version, contents string) (string, error) {
+	template, err := model.ResolveIncludes(model.NewTemplate(version, contents), t.store.GetTemplate)
+	if err != nil {
+		return "", err
+	}
+	objects, err := t.store.GetAllObjects()
+	if err != nil {
+		return "", err
+	}
+	return parser.ExpandObjects(template.Contents, objects)
+}
+
+// 현재 템플릿 내용을 지정한 형식의 룰셋 파일로 내보냅니다.
+func (t *TemplateTab) onExportTemplate(format parser.ExportFormat) {
+	contents, err := t.effectiveContents(t.selectedVersion, t.getCurrentContents())
+	if err != nil {
+		dialog.ShowError(err, t.window)
+		return
+	}
+	ruleset, err := parser.ExportText(contents, format)
+	if err != nil {
+		dialog.ShowError(err, t.window)
+		return
+	}
+
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, t.window)
+			return
+		}
+		if writer == nil {
+			return
+		}
+		defer writer.Close()
+
+		if _, err := writer.Write([]byte(ruleset)); err != nil {
+			dialog.ShowError(err, t.window)
+			return
+		}
+		dialog.ShowInformation("성공", "템플릿이 내보내기 되었습니다.", t.window)
+	}, t.window)
+
+	// 기본 파일명: 버전명 + 형식별 확장자
+	fileName := t.selectedVersion
+	if fileName == "" {
+		fileName = "template"
+	}
+	switch format {
+	case parser.ExportIp6tables:
+		saveDialog.SetFileName(fileName + ".v6.rules")
+	case parser.ExportNftables:
+		saveDialog.SetFileName(fileName + ".nft")
+	case parser.ExportSmartfw:
+		saveDialog.SetFileName(fileName + ".smartfw")
+	default:
+		saveDialog.SetFileName(fileName + ".rules")
+	}
+	saveDialog.Show()
+}
+
+// 템플릿 내용을 검증하여 결과를 표시합니다.
+// 가려진/불필요한/충돌하는 규칙은 경고로 함께 표시합니다.
+func (t *TemplateTab) updateDiagnostics(contents string) {
+	diagnostics := model.ValidateTemplate(contents)
+	diagnostics = append(diagnostics, model.FindingDiagnostics(parser.AnalyzeText(contents))...)
+	if len(diagnostics) == 0 {
+		t.diagnosticLabel.Hide()
+		return
+	}
+
+	t.diagnosticLabel.Importance = widget.WarningImportance
+	if model.HasErrors(diagnostics) {
+		t.diagnosticLabel.Importance = widget.DangerImportance
+	}
+	t.diagnosticLabel.SetText(formatDiagnostics(diagnostics, 5))
+	t.diagnosticLabel.Show()
+}
+
+// 진단 목록을 최대 max줄의 텍스트로 변환합니다.
+func formatDiagnostics(diagnostics []model.Diagnostic, max int) string {
+	errorCount := 0
+	for _, d := range diagnostics {
+		if d.IsError() {
+			errorCount++
+		}
+	}
+
+	lines := []string{fmt.Sprintf("규칙 검증: 오류 %d개, 경고 %d개", errorCount, len(diagnostics)-errorCount)}
+	for i, d := range diagnostics {
+		if i == max {
+			lines = append(lines, fmt.Sprintf("... 외 %d개", len(diagnostics)-max))
+			break
+		}
+		lines = append(lines, d.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// 템플릿 내용에 규칙 오류가 있으면 오류 목록을 담은 에러를 반환합니다.
+func templateRulesError(contents string) error {
+	var errs []model.Diagnostic
+	for _, d := range model.ValidateTemplate(contents) {
+		if d.IsError() {
+			errs = append(errs, d)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("규칙 오류를 수정해주세요.\n%s", formatDiagnostics(errs, 5))
+}
+
 // 템플릿 삭제 시 호출됩니다.
 func (t *TemplateTab) onDeleteTemplate() {
 	if t.selectedVersion == "" {
 		dialog.ShowInformation("알림", "삭제할 템플릿을 선택해주세요.", t.window)
 		return
 	}
+	if includers := t.templateIncluders(t.selectedVersion); len(includers) > 0 {
+		dialog.ShowError(fmt.Errorf("템플릿 %s를 포함하는 템플릿이 있어 삭제할 수 없습니다: %s", t.selectedVersion, strings.Join(includers, ", ")), t.window)
+		return
+	}
 
 	dialog.ShowConfirm("확인", "선택한 템플릿을 삭제하시겠습니까?", func(ok bool) {
 		if !ok {
@@ -429,3 +714,19 @@ func (t *TemplateTab) onDeleteTemplate() {
 		dialog.ShowInformation("알림", "템플릿이 삭제되었습니다.", t.window)
 	}, t.window)
 }
+
+// 해당 버전을 직접 포함(#include)하는 템플릿 버전 목록을 정렬하여 반환합니다.
+func (t *TemplateTab) templateIncluders(version string) []string {
+	includers := []string{}
+	templates, err := t.store.GetAllTemplates()
+	if err != nil {
+		return includers
+	}
+	for _, template := range templates {
+		if slices.Contains(model.TemplateIncludes(template.Contents), version) {
+			includers = append(includers, template.Version)
+		}
+	}
+	sort.Strings(includers)
+	return includers
+}
@@ -0,0 +1,18 @@
+package utils
+
+import (
+	"os"
+	"os/user"
+)
+
+// 현재 OS 사용자 이름을 반환합니다. (템플릿 리비전 작성자 기록용)
+// 사용자를 확인할 수 없으면 빈 문자열을 반환합니다.
+func CurrentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
@@ -277,3 +277,58 @@ func TestICMPCodeNumberToName(t *testing.T) {
 		})
 	}
 }
+
+func TestICMPTypeNumber_ByFamily(t *testing.T) {
+	tests := []struct {
+		family   model.Family
+		name     string
+		expected int
+		wantErr  bool
+	}{
+		{model.FamilyIPv4, "echo-request", 8, false},
+		{model.FamilyIPv6, "echo-request", 128, false},
+		{model.FamilyIPv6, "neighbour-solicitation", 135, false},
+		{model.FamilyIPv6, "neighbor-solicitation", 135, false},
+		{model.FamilyIPv6, "source-quench", 0, true},
+		{model.FamilyIPv4, "packet-too-big", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(model.FamilyToString(tt.family)+"/"+tt.name, func(t *testing.T) {
+			num, err := model.ICMPTypeNumber(tt.family, tt.name)
+			if (err != nil) != tt.wantErr || num != tt.expected {
+				t.Errorf("ICMPTypeNumber(%s) = %d, %v, want %d", tt.name, num, err, tt.expected)
+			}
+		})
+	}
+
+	if name := model.ICMPTypeName(model.FamilyIPv6, 129); name != "echo-reply" {
+		t.Errorf("ICMPTypeName(IPv6, 129) = %q, want echo-reply", name)
+	}
+}
+
+func TestSplitDeviceAddress(t *testing.T) {
+	tests := []struct {
+		address  string
+		wantHost string
+		wantPort string
+		wantErr  bool
+	}{
+		{"192.168.1.1", "192.168.1.1", "", false},
+		{"192.168.1.1:8080", "192.168.1.1", "8080", false},
+		{"2001:db8::1", "2001:db8::1", "", false},
+		{"[2001:db8::1]:8080", "2001:db8::1", "8080", false},
+		{"[192.168.1.1]", "", "", true},
+		{"192.168.1.1:0", "", "", true},
+		{"firewall01", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			host, port, err := model.SplitDeviceAddress(tt.address)
+			if (err != nil) != tt.wantErr || host != tt.wantHost || port != tt.wantPort {
+				t.Errorf("SplitDeviceAddress(%q) = %q, %q, %v", tt.address, host, port, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"fms/internal/model"
+)
+
+// TestNewTemplateRevision 리비전 번호, 부모, 내용 해시 테스트
+func TestNewTemplateRevision(t *testing.T) {
+	first := model.NewTemplateRevision(model.NewTemplate("v1", "agent -m=insert -c=INPUT -a=DROP"), nil, "admin", "최초 작성")
+	if first.Number != 1 || first.Parent != "" || first.Hash != model.TemplateHash(first.Contents) || len(first.Hash) != 64 {
+		t.Fatalf("first = %+v, want r1, 부모 없음, 내용 해시", first)
+	}
+
+	second := model.NewTemplateRevision(model.NewTemplate("v1", "agent -m=insert -c=INPUT -a=ACCEPT"), first, "", "")
+	if second.Number != 2 || second.Parent != first.Hash || second.Hash == first.Hash {
+		t.Errorf("second = %+v, want r2, 부모 %s", second, model.ShortHash(first.Hash))
+	}
+	if got := second.String(); got != "r2 ("+second.Hash[:12]+")" {
+		t.Errorf("String() = %s", got)
+	}
+	if tmpl := second.Template(); tmpl.Version != "v1" || tmpl.Revision != second.Hash || tmpl.Contents != second.Contents {
+		t.Errorf("Template() = %+v", tmpl)
+	}
+}
+
+// TestDiffContents 줄 단위 내용 비교 테스트
+func TestDiffContents(t *testing.T) {
+	oldContents := "# 기본 정책\nagent -m=insert -c=INPUT -p=tcp --dport=22 -a=ACCEPT\nagent -m=insert -c=INPUT -a=DROP\n"
+	newContents := "# 기본 정책\r\nagent -m=insert -c=INPUT -p=tcp --dport=443 -a=ACCEPT\r\nagent -m=insert -c=INPUT -a=DROP"
+
+	lines := model.DiffContents(oldContents, newContents)
+	got := make([]string, len(lines))
+	for i, l := range lines {
+		got[i] = l.String()
+	}
+	want := []string{
+		"  # 기본 정책",
+		"- agent -m=insert -c=INPUT -p=tcp --dport=22 -a=ACCEPT",
+		"+ agent -m=insert -c=INPUT -p=tcp --dport=443 -a=ACCEPT",
+		"  agent -m=insert -c=INPUT -a=DROP",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("DiffContents() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if lines[1].OldLine != 2 || lines[1].NewLine != 0 || lines[2].OldLine != 0 || lines[2].NewLine != 2 || lines[3].OldLine != 3 || lines[3].NewLine != 3 {
+		t.Errorf("DiffContents() 줄 번호 = %+v", lines)
+	}
+	if !model.HasDiff(lines) {
+		t.Error("HasDiff() = false, want true")
+	}
+
+	// 줄바꿈 형식만 다르면 변경 없음
+	if model.HasDiff(model.DiffContents(oldContents, strings.ReplaceAll(oldContents, "\n", "\r\n"))) {
+		t.Error("HasDiff(CRLF) = true, want false")
+	}
+	if lines := model.DiffContents("", "a"); len(lines) != 1 || lines[0].Type != model.DiffAdded {
+		t.Errorf("DiffContents(빈 내용) = %+v, want 추가 1줄", lines)
+	}
+}
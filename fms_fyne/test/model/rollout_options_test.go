@@ -0,0 +1,68 @@
+package model_test
+
+import (
+	"reflect"
+	"testing"
+
+	"fms/internal/model"
+)
+
+func TestRolloutOptions_WaveSizes(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     *model.RolloutOptions
+		total    int
+		expected []int
+	}{
+		{
+			name:     "no devices",
+			opts:     &model.RolloutOptions{CanarySize: 1, WaveSize: 2},
+			total:    0,
+			expected: nil,
+		},
+		{
+			name:     "canary only",
+			opts:     &model.RolloutOptions{CanarySize: 3, WaveSize: 2},
+			total:    2,
+			expected: []int{2},
+		},
+		{
+			name:     "canary and waves",
+			opts:     &model.RolloutOptions{CanarySize: 1, WaveSize: 3},
+			total:    8,
+			expected: []int{1, 3, 3, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.opts.WaveSizes(tt.total)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("WaveSizes(%d) = %v, want %v", tt.total, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRolloutOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *model.RolloutOptions
+		wantErr bool
+	}{
+		{"default", model.DefaultRolloutOptions(), false},
+		{"zero canary", &model.RolloutOptions{CanarySize: 0, WaveSize: 1}, true},
+		{"zero wave", &model.RolloutOptions{CanarySize: 1, WaveSize: 0}, true},
+		{"negative pause", &model.RolloutOptions{CanarySize: 1, WaveSize: 1, PauseSeconds: -1}, true},
+		{"failure over 100", &model.RolloutOptions{CanarySize: 1, WaveSize: 1, MaxFailurePercent: 101}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package model_test
+
+import (
+	"testing"
+
+	"fms/internal/model"
+)
+
+func TestValidateTemplate_Diagnostics(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		expected []string // 진단 코드 목록
+	}{
+		{
+			name:     "valid template",
+			contents: "# 주석\nagent -m=insert -c=INPUT -p=tcp --dport=22 -a=ACCEPT\nagent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=10.0.0.1:8080",
+			expected: nil,
+		},
+		{
+			name:     "bad ip and port",
+			contents: "agent -m=insert -c=INPUT -a=DROP --sip=10.0.0.999 --dport=99999",
+			expected: []string{model.DiagInvalidIP, model.DiagInvalidPort},
+		},
+		{
+			name:     "icmp options on tcp",
+			contents: "agent -m=insert -c=INPUT -p=tcp?type=echo-request -a=DROP",
+			expected: []string{model.DiagOptionProtocolMismatch},
+		},
+		{
+			name:     "tcp flags on udp",
+			contents: "agent -m=insert -c=INPUT -p=udp?flags=syn/syn -a=DROP",
+			expected: []string{model.DiagOptionProtocolMismatch},
+		},
+		{
+			name:     "unknown chain, protocol and action",
+			contents: "agent -m=insert -c=INPT -p=tcpp -a=DENY",
+			expected: []string{model.DiagInvalidChain, model.DiagInvalidProtocol, model.DiagInvalidAction},
+		},
+		{
+			name:     "ipv6 rule and nat target",
+			contents: "agent -m=insert -c=INPUT -p=icmp?type=neighbour-solicitation -a=ACCEPT --sip=2001:db8::/32\nagent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=[2001:db8::10]:8080",
+			expected: nil,
+		},
+		{
+			name:     "mixed address family",
+			contents: "agent -m=insert -c=INPUT -a=DROP --sip=10.0.0.1 --dip=2001:db8::1",
+			expected: []string{model.DiagFamilyMismatch},
+		},
+		{
+			name:     "ipv4 icmp type on ipv6 rule",
+			contents: "agent -m=insert -c=INPUT -p=icmp?type=source-quench -a=DROP --family=ipv6",
+			expected: []string{model.DiagInvalidICMPType},
+		},
+		{
+			name:     "object references",
+			contents: "agent -m=insert -c=INPUT -p=any -a=ACCEPT --dport=@web,8080 --sip=@monitoring --dip=2001:db8::1",
+			expected: nil,
+		},
+		{
+			name:     "invalid object name and object in nat rule",
+			contents: "agent -m=insert -c=INPUT -a=DROP --sip=@1st\nagent -m=insert -t=nat --nat-type=masquerade -s=@office -o=eth0",
+			expected: []string{model.DiagInvalidObjectRef, model.DiagInvalidObjectRef},
+		},
+		{
+			name:     "template variables",
+			contents: "agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=${SSH_PORT} --sip=${MGMT_NET},10.0.0.1 -i=${LAN_IF}\nagent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=${WEB_HOST}:8080",
+			expected: nil,
+		},
+		{
+			name:     "invalid variable name and unclosed variable",
+			contents: "agent -m=insert -c=INPUT -a=DROP --sip=${MGMT-NET}\nagent -m=insert -t=nat --nat-type=dnat --to-dest=${WEB_HOST",
+			expected: []string{model.DiagInvalidVariable, model.DiagInvalidVariable},
+		},
+		{
+			name:     "interface names",
+			contents: "agent -m=insert -c=FORWARD -a=ACCEPT -i=ppp+ -o=eth0.100\nagent -m=insert -t=nat --nat-type=masquerade -o=${WAN_IF}",
+			expected: nil,
+		},
+		{
+			name:     "shell metacharacters in interface names",
+			contents: "agent -m=insert -c=FORWARD -a=DROP -i=$(reboot)\nagent -m=insert -c=FORWARD -a=DROP -i=eth0;reboot\nagent -m=insert -c=FORWARD -a=DROP -o=eth0|nc\nagent -m=insert -t=nat --nat-type=masquerade -o=eth0>/tmp/x",
+			expected: []string{model.DiagInvalidInterface, model.DiagInvalidInterface, model.DiagInvalidInterface, model.DiagInvalidInterface},
+		},
+		{
+			name:     "invalid mode",
+			contents: "agent -m=insert;reboot -c=INPUT -a=DROP\nagent -m=list -t=nat --nat-type=masquerade -o=eth0",
+			expected: []string{model.DiagInvalidMode, model.DiagInvalidMode},
+		},
+		{
+			name:     "duplicate options and unexpected token",
+			contents: "agent -m=insert -c=INPUT -a=DROP -a=ACCEPT\nagent -m=insert -t=nat --nat-type=snat --match-ip=10.0.0.0/8 -s=10.0.0.1 --to-source=1.1.1.1\nagent -m=insert -c=INPUT -a=DROP now",
+			expected: []string{model.DiagDuplicateOption, model.DiagDuplicateOption, model.DiagUnexpectedToken},
+		},
+		{
+			name:     "include directives",
+			contents: "#include baseline-v3\nagent -m=insert -c=INPUT -a=DROP\n  #include",
+			expected: []string{model.DiagInvalidInclude},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics := model.ValidateTemplate(tt.contents)
+			if len(diagnostics) != len(tt.expected) {
+				t.Fatalf("ValidateTemplate() = %v, want codes %v", diagnostics, tt.expected)
+			}
+			for i, d := range diagnostics {
+				if d.Code != tt.expected[i] || d.Severity != model.SeverityError || d.Line < 1 || d.Column < 1 {
+					t.Errorf("diagnostics[%d] = %+v, want error %s", i, d, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTemplate_IsValidWithRuleErrors(t *testing.T) {
+	if !model.NewTemplate("v1", "agent -m=insert -c=INPUT -a=DROP").IsValid() {
+		t.Error("IsValid() = false, want true")
+	}
+	if model.NewTemplate("v1", "agent -m=insert -c=INPUT -a=DROP --dport=0").IsValid() {
+		t.Error("IsValid() = true, want false")
+	}
+}
+
+func TestValidateTemplate_TCPFlagsPresets(t *testing.T) {
+	for _, preset := range model.GetTCPFlagsPresets() {
+		flags := preset.ToFlagsString()
+		if flags == "" {
+			continue
+		}
+		line := "agent -m=insert -c=INPUT -p=tcp?flags=" + flags + " -a=DROP"
+		if diagnostics := model.ValidateTemplate(line); len(diagnostics) != 0 {
+			t.Errorf("%s preset: ValidateTemplate(%q) = %v, want none", preset.Name, line, diagnostics)
+		}
+	}
+}
+
+func TestValidateProtocol(t *testing.T) {
+	tests := []struct {
+		value  string
+		family model.Family
+		code   string
+	}{
+		{"tcp?flags=syn,rst,ack,fin,psh,urg/", model.FamilyIPv4, ""},
+		{"icmp?type=echo-request&code=0", model.FamilyIPv4, ""},
+		{"icmp?type=neighbour-solicitation", model.FamilyIPv6, ""},
+		{"icmp?type=neighbour-solicitation", model.FamilyIPv4, model.DiagInvalidICMPType},
+		{"sctp", model.FamilyIPv4, model.DiagInvalidProtocol},
+		{"udp?flags=syn/syn", model.FamilyIPv4, model.DiagOptionProtocolMismatch},
+		{"tcp?flags=syn/syn&flags=ack/ack", model.FamilyIPv4, model.DiagDuplicateOption},
+		{"tcp?mss=1400", model.FamilyIPv4, model.DiagUnknownProtocolOption},
+	}
+	for _, tt := range tests {
+		var code string
+		for _, d := range model.ValidateProtocol(tt.value, tt.family) {
+			if d.IsError() {
+				code = d.Code
+				break
+			}
+		}
+		if code != tt.code {
+			t.Errorf("ValidateProtocol(%q, %d) = %q, want %q", tt.value, tt.family, code, tt.code)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"fms/internal/model"
+)
+
+// TestRenderVariables 템플릿 변수 치환 테스트
+func TestRenderVariables(t *testing.T) {
+	contents := "agent -m=insert -c=INPUT -a=ACCEPT --sip=${MGMT_NET} --dport=${SSH_PORT}\n# ${MGMT_NET} 관리망"
+	got, err := model.RenderVariables(contents, map[string]string{"MGMT_NET": "10.1.0.0/24", "SSH_PORT": "22", "UNUSED": "x"})
+	if err != nil {
+		t.Fatalf("RenderVariables() error: %v", err)
+	}
+	want := "agent -m=insert -c=INPUT -a=ACCEPT --sip=10.1.0.0/24 --dport=22\n# 10.1.0.0/24 관리망"
+	if got != want {
+		t.Errorf("RenderVariables() =\n%s\nwant\n%s", got, want)
+	}
+
+	// 누락된 변수는 모두 에러에 포함
+	_, err = model.RenderVariables(contents, map[string]string{})
+	if err == nil || !strings.Contains(err.Error(), "${MGMT_NET}, ${SSH_PORT}") {
+		t.Errorf("RenderVariables() error = %v, want 누락 변수 목록", err)
+	}
+
+	if names := strings.Join(model.TemplateVariables(contents), ","); names != "MGMT_NET,SSH_PORT" {
+		t.Errorf("TemplateVariables() = %s, want MGMT_NET,SSH_PORT", names)
+	}
+}
+
+// TestValidateVariables 변수 이름/값 검증 테스트
+func TestValidateVariables(t *testing.T) {
+	tests := []struct {
+		name      string
+		variables map[string]string
+		wantErr   bool
+	}{
+		{"올바른 변수", map[string]string{"MGMT_NET": "10.0.0.0/8,192.168.0.0/16", "_port2": "22"}, false},
+		{"없음", nil, false},
+		{"잘못된 이름", map[string]string{"MGMT-NET": "10.0.0.0/8"}, true},
+		{"숫자로 시작하는 이름", map[string]string{"1NET": "10.0.0.0/8"}, true},
+		{"빈 값", map[string]string{"MGMT_NET": ""}, true},
+		{"공백이 있는 값", map[string]string{"MGMT_NET": "10.0.0.0/8 -a=ACCEPT"}, true},
+		{"변수를 참조하는 값", map[string]string{"MGMT_NET": "${OTHER}"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := model.ValidateVariables(tt.variables); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateVariables() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestMergeVariables 장비 변수가 그룹 변수보다 우선하는지 테스트
+func TestMergeVariables(t *testing.T) {
+	group := model.NewDeviceGroup("seoul")
+	group.Variables["MGMT_NET"] = "10.1.0.0/24"
+	group.Variables["WEB_HOST"] = "10.1.0.10"
+
+	fw := model.NewFirewall("10.1.0.1")
+	fw.Group = "seoul"
+	fw.Variables = map[string]string{"WEB_HOST": "10.1.0.20"}
+
+	merged := model.MergeVariables(group, fw)
+	if merged["MGMT_NET"] != "10.1.0.0/24" || merged["WEB_HOST"] != "10.1.0.20" {
+		t.Errorf("MergeVariables() = %v", merged)
+	}
+
+	// 복사본 수정이 원본에 영향을 주지 않음
+	clone := fw.Clone()
+	clone.Variables["WEB_HOST"] = "changed"
+	if fw.Variables["WEB_HOST"] != "10.1.0.20" {
+		t.Errorf("Clone() 후 원본 Variables가 변경되었습니다: %v", fw.Variables)
+	}
+}
@@ -0,0 +1,55 @@
+package parser_test
+
+import (
+	"strings"
+	"testing"
+
+	"fms/internal/model"
+	"fms/internal/parser"
+)
+
+func TestAnalyzeText(t *testing.T) {
+	text := `agent -m=insert -c=INPUT -p=tcp -a=DROP --dport=1:1024
+agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=22,80
+agent -m=insert -c=INPUT -p=tcp -a=DROP --dport=443
+
+# 중복
+agent -m=insert -c=INPUT -p=tcp -a=DROP --dport=443
+agent -m=insert -c=FORWARD -p=any -a=ACCEPT --sip=192.168.0.0/16
+agent -m=insert -c=FORWARD -p=any -a=DROP --sip=192.168.0.0/16
+agent -m=insert -c=FORWARD -p=tcp -a=DROP --sip=10.0.0.1 --dport=22`
+
+	expected := []model.RuleFinding{
+		{Kind: model.FindingShadowed, Line: 2, CoverLine: 1},
+		{Kind: model.FindingRedundant, Line: 3, CoverLine: 1},
+		{Kind: model.FindingRedundant, Line: 6, CoverLine: 1},
+		{Kind: model.FindingConflict, Line: 8, CoverLine: 7},
+	}
+
+	findings := parser.AnalyzeText(text)
+	if len(findings) != len(expected) {
+		t.Fatalf("AnalyzeText() = %+v, want %d findings", findings, len(expected))
+	}
+	for i, want := range expected {
+		got := findings[i]
+		if got.Kind != want.Kind || got.Line != want.Line || got.CoverLine != want.CoverLine {
+			t.Errorf("findings[%d] = %+v, want %+v", i, got, want)
+		}
+		if d := got.Diagnostic(); d.Line != want.Line || d.Severity != model.SeverityWarning {
+			t.Errorf("Diagnostic() = %+v, want line %d warning", d, want.Line)
+		}
+	}
+}
+
+func TestAnalyzeText_BlackRules(t *testing.T) {
+	text := `agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=22 --black
+agent -m=insert -c=INPUT -p=tcp -a=DROP --dport=22 --sip=10.0.0.1 --black`
+
+	findings := parser.AnalyzeText(text)
+	if len(findings) != 1 {
+		t.Fatalf("AnalyzeText() = %+v, want 1 finding", findings)
+	}
+	if got := findings[0]; got.Kind != model.FindingRedundant || got.Line != 2 || got.CoverLine != 1 || !strings.Contains(got.Message, "DROP") {
+		t.Errorf("findings[0] = %+v, want line 2 redundant to line 1 DROP", got)
+	}
+}
@@ -0,0 +1,60 @@
+package parser_test
+
+import (
+	"testing"
+
+	"fms/internal/model"
+	"fms/internal/parser"
+)
+
+const documentText = `# 관리 접속
+agent -m=insert -c=INPUT -p=tcp --dport=22 -a=ACCEPT
+
+# 포트 포워딩
+agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=10.0.0.1:8080
+
+# 웹 차단
+agent -m=insert  -c=INPUT -p=tcp --dport=80 -a=DROP
+`
+
+func TestDocumentRoundTrip(t *testing.T) {
+	texts := []string{
+		"",
+		documentText,
+		"agent -m=insert -c=INPUT -a=DROP\r\n# 주석\r\n\r\nnot a rule\r\n",
+	}
+
+	for _, text := range texts {
+		doc := parser.ParseDocument(text)
+		doc.SetRules(doc.Rules())
+		doc.SetNATRules(doc.NATRules())
+		if got := doc.String(); got != text {
+			t.Errorf("round trip = %q, want %q", got, text)
+		}
+	}
+}
+
+func TestDocumentEdit(t *testing.T) {
+	doc := parser.ParseDocument(documentText)
+
+	// 빌더에서 첫 규칙 수정, 마지막 규칙 삭제, NAT 규칙 추가
+	rules := doc.Rules()
+	rules[0].Action = model.ActionDROP
+	doc.SetRules(rules[:1])
+
+	masquerade := model.NewMASQUERADERule()
+	masquerade.OutInterface = "eth0"
+	doc.SetNATRules(append(doc.NATRules(), masquerade))
+
+	want := `# 관리 접속
+agent -m=insert -c=INPUT -p=tcp -a=DROP --dport=22
+
+# 포트 포워딩
+agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=10.0.0.1:8080
+agent -m=insert -t=nat --nat-type=masquerade -p=tcp -o=eth0
+
+`
+	if got := doc.String(); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,97 @@
+package parser_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"fms/internal/model"
+	"fms/internal/parser"
+)
+
+var updateGolden = flag.Bool("update", false, "골든 파일 갱신")
+
+func TestExportText(t *testing.T) {
+	template, err := os.ReadFile(filepath.Join("testdata", "export_template.txt"))
+	if err != nil {
+		t.Fatalf("템플릿 읽기 실패: %v", err)
+	}
+
+	tests := []struct {
+		format parser.ExportFormat
+		golden string
+	}{
+		{parser.ExportIptables, "export.iptables"},
+		{parser.ExportIp6tables, "export.ip6tables"},
+		{parser.ExportNftables, "export.nft"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			got, err := parser.ExportText(string(template), tt.format)
+			if err != nil {
+				t.Fatalf("ExportText() error = %v", err)
+			}
+
+			path := filepath.Join("testdata", tt.golden)
+			if *updateGolden {
+				if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+					t.Fatalf("골든 파일 저장 실패: %v", err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("골든 파일 읽기 실패: %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("ExportText() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestExportText_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		format  parser.ExportFormat
+		wantErr string
+	}{
+		{"empty", "# 주석만 있음", parser.ExportIptables, "내보낼 규칙이 없습니다"},
+		{"unknown format", "agent -m=insert -c=INPUT -a=DROP", parser.ExportFormat("pf"), "알 수 없는 내보내기 형식"},
+		{"nat chain in filter", "agent -m=insert -c=PREROUTING -a=DROP", parser.ExportNftables, "PREROUTING 체인은 filter 테이블에서 사용할 수 없습니다"},
+		{"dnat without target", "agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80", parser.ExportIptables, "변환할 IP"},
+		{"ipv6 only", "agent -m=insert -c=INPUT -a=DROP --sip=2001:db8::1", parser.ExportIptables, "내보낼 IPv4 규칙이 없습니다"},
+		{"ipv4 only", "agent -m=insert -c=INPUT -a=DROP --sip=10.0.0.1", parser.ExportIp6tables, "내보낼 IPv6 규칙이 없습니다"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parser.ExportText(tt.text, tt.format)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ExportText() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExportIptablesRestore_ImportRoundTrip(t *testing.T) {
+	rule := model.NewFirewallRule()
+	rule.DPort = "22"
+	rule.SIP = "10.0.0.5"
+	rule.Options = &model.ProtocolOptions{TCPFlags: "syn,ack/syn"}
+
+	exported, err := parser.ExportIptablesRestore([]*model.FirewallRule{rule}, nil)
+	if err != nil {
+		t.Fatalf("ExportIptablesRestore() error = %v", err)
+	}
+	imported, err := parser.ImportIptablesSave(exported)
+	if err != nil {
+		t.Fatalf("ImportIptablesSave() error = %v", err)
+	}
+	if len(imported.Rules) != 1 || parser.RuleToLine(imported.Rules[0]) != parser.RuleToLine(rule) {
+		t.Errorf("round trip = %v, want %s", imported.Rules, parser.RuleToLine(rule))
+	}
+}
@@ -0,0 +1,116 @@
+package parser_test
+
+import (
+	"strings"
+	"testing"
+
+	"fms/internal/model"
+	"fms/internal/parser"
+)
+
+// 테스트용 객체 목록
+func testObjects() []*model.NamedObject {
+	return []*model.NamedObject{
+		model.NewNamedObject("monitoring", model.ObjectKindAddress, "10.1.1.10", "10.1.2.0/24", "10.1.3.1-10.1.3.4"),
+		model.NewNamedObject("web", model.ObjectKindService, "tcp/80", "tcp/443"),
+		model.NewNamedObject("dns", model.ObjectKindService, "udp/53", "tcp/53"),
+		model.NewNamedObject("mixed", model.ObjectKindAddress, "10.0.0.1", "2001:db8::1"),
+	}
+}
+
+// TestExpandObjects 객체 참조 펼침 테스트
+func TestExpandObjects(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			"주소 객체 (범위는 CIDR)",
+			"agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=22 --sip=@monitoring",
+			"agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=22 --sip=10.1.1.10,10.1.2.0/24,10.1.3.1,10.1.3.2/31,10.1.3.4",
+		},
+		{
+			"직접 입력한 값과 함께 사용",
+			"agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=@web,8080 --sip=192.168.0.1,@monitoring",
+			"agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=80,443,8080 --sip=192.168.0.1,10.1.1.10,10.1.2.0/24,10.1.3.1,10.1.3.2/31,10.1.3.4",
+		},
+		{
+			"지정한 프로토콜의 포트만 사용",
+			"agent -m=insert -c=INPUT -p=udp -a=ACCEPT --dport=@dns",
+			"agent -m=insert -c=INPUT -p=udp -a=ACCEPT --dport=53",
+		},
+		{
+			"any 규칙은 프로토콜별로 나눔",
+			"agent -m=insert -c=INPUT -p=any -a=ACCEPT --dport=@dns",
+			"agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=53\nagent -m=insert -c=INPUT -p=udp -a=ACCEPT --dport=53",
+		},
+		{
+			"주소 체계별로 나눔",
+			"agent -m=insert -c=INPUT -p=any -a=DROP --sip=@mixed",
+			"agent -m=insert -c=INPUT -p=any -a=DROP --sip=10.0.0.1\nagent -m=insert -c=INPUT -p=any -a=DROP --sip=2001:db8::1",
+		},
+		{
+			"--family 지정 시 해당 주소 체계만 사용",
+			"agent -m=insert -c=INPUT -p=any -a=DROP --family=ipv6 --sip=@mixed",
+			"agent -m=insert -c=INPUT -p=any -a=DROP --family=ipv6 --sip=2001:db8::1",
+		},
+		{
+			"참조가 없는 라인과 주석은 그대로 유지",
+			"# @monitoring 주석\nagent  -m=insert -c=INPUT -a=DROP\nagent -m=insert -t=nat --nat-type=masquerade -o=eth0",
+			"# @monitoring 주석\nagent  -m=insert -c=INPUT -a=DROP\nagent -m=insert -t=nat --nat-type=masquerade -o=eth0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parser.ExpandObjects(tt.text, testObjects())
+			if err != nil {
+				t.Fatalf("parser.ExpandObjects() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("parser.ExpandObjects() =\n%s\nwant\n%s", got, tt.want)
+			}
+			if diags := model.ValidateTemplate(got); model.HasErrors(diags) {
+				t.Errorf("ValidateTemplate() = %v", diags)
+			}
+		})
+	}
+}
+
+// TestExpandObjects_Errors 펼칠 수 없는 객체 참조 테스트 (라인 번호는 첫 줄 주석 포함)
+func TestExpandObjects_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantErr string
+	}{
+		{"없는 객체", "agent -m=insert -c=INPUT -a=DROP --sip=@unknown", "라인 2: --sip: 객체를 찾을 수 없습니다: @unknown"},
+		{"종류가 다른 객체", "agent -m=insert -c=INPUT -a=DROP --dport=@monitoring", "@monitoring는 service 객체가 아닙니다"},
+		{"프로토콜 포트 없음", "agent -m=insert -c=INPUT -p=udp -a=DROP --dport=@web", "@web에 udp 포트가 없습니다"},
+		{"icmp 규칙", "agent -m=insert -c=INPUT -p=icmp -a=DROP --dport=@web", "icmp 규칙에는 서비스 객체를 사용할 수 없습니다"},
+		{"주소 체계 불일치", "agent -m=insert -c=INPUT -a=DROP --sip=@monitoring --dip=2001:db8::1", "맞는 객체 멤버가 없습니다"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parser.ExpandObjects("# 템플릿\n"+tt.text, testObjects())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parser.ExpandObjects() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestObjectReferences 템플릿이 참조하는 객체 이름 테스트
+func TestObjectReferences(t *testing.T) {
+	text := `# @commented
+agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=@web --sip=@monitoring
+agent -m=insert -c=INPUT -p=udp -a=ACCEPT --dport=@dns --sip=@monitoring,10.0.0.1
+agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=10.0.0.1`
+
+	got := strings.Join(parser.ObjectReferences(text), ",")
+	if got != "web,monitoring,dns" {
+		t.Errorf("parser.ObjectReferences() = %s, want web,monitoring,dns", got)
+	}
+}
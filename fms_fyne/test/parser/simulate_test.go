@@ -0,0 +1,272 @@
+package parser_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"fms/internal/model"
+	"fms/internal/parser"
+)
+
+const simulateTemplate = `# 관리 접속
+agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=22 --sip=10.0.0.0/8
+agent -m=insert -c=INPUT -p=tcp -a=DROP --dport=22
+agent -m=insert -c=INPUT -p=tcp?flags=syn,rst,ack,fin/syn -a=REJECT --dport=8000:8100
+agent -m=insert -c=INPUT -p=icmp?type=echo-request -a=DROP
+agent -m=insert -c=INPUT -p=any -a=DROP --sip=1.2.3.4 --black
+agent -m=insert -c=INPUT -p=any -a=ACCEPT --sip=1.2.3.4 --white
+agent -m=insert -c=FORWARD -p=tcp -a=DROP --dip=192.168.0.10 --dport=8080
+agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 -i=eth0 --to-dest=192.168.0.10:8080`
+
+// TestSimulateText 패킷별 최종 동작과 일치 규칙 테스트
+func TestSimulateText(t *testing.T) {
+	tests := []struct {
+		name        string
+		packet      model.Packet
+		wantVerdict string
+		wantLine    int
+	}{
+		{
+			"허용 대역 SSH",
+			model.Packet{Chain: model.ChainINPUT, Protocol: model.ProtocolTCP, SIP: "10.1.2.3", DIP: "192.168.0.5", DPort: 22},
+			"ACCEPT", 2,
+		},
+		{
+			"그 외 SSH",
+			model.Packet{Chain: model.ChainINPUT, Protocol: model.ProtocolTCP, SIP: "172.16.0.1", DIP: "192.168.0.5", DPort: 22},
+			"DROP", 3,
+		},
+		{
+			"새 연결 SYN",
+			model.Packet{Chain: model.ChainINPUT, Protocol: model.ProtocolTCP, SIP: "172.16.0.1", DIP: "192.168.0.5", DPort: 8080, TCPFlags: "syn"},
+			"REJECT", 4,
+		},
+		{
+			"ACK 패킷은 flags 불일치",
+			model.Packet{Chain: model.ChainINPUT, Protocol: model.ProtocolTCP, SIP: "172.16.0.1", DIP: "192.168.0.5", DPort: 8080, TCPFlags: "ack"},
+			"ACCEPT", 0,
+		},
+		{
+			"ping",
+			model.Packet{Chain: model.ChainINPUT, Protocol: model.ProtocolICMP, SIP: "172.16.0.1", DIP: "192.168.0.5", ICMPType: "8"},
+			"DROP", 5,
+		},
+		{
+			"White가 Black보다 먼저",
+			model.Packet{Chain: model.ChainINPUT, Protocol: model.ProtocolTCP, SIP: "1.2.3.4", DIP: "192.168.0.5", DPort: 22},
+			"ACCEPT", 7,
+		},
+		{
+			"OUTPUT은 기본 정책",
+			model.Packet{Chain: model.ChainOUTPUT, Protocol: model.ProtocolTCP, SIP: "192.168.0.5", DIP: "8.8.8.8", DPort: 22},
+			"ACCEPT", 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parser.SimulateText(simulateTemplate, tt.packet)
+			if err != nil {
+				t.Fatalf("SimulateText() error = %v", err)
+			}
+			if result.Verdict != tt.wantVerdict || result.MatchedLine != tt.wantLine {
+				t.Errorf("SimulateText() = %s, want %s (라인 %d)", result.Summary(), tt.wantVerdict, tt.wantLine)
+			}
+		})
+	}
+}
+
+// TestSimulateText_TCPFlags TCP flags를 생략한 패킷은 새 연결 요청(syn)으로 비교
+func TestSimulateText_TCPFlags(t *testing.T) {
+	text := `agent -m=insert -c=INPUT -p=tcp?flags=syn,rst,ack,fin,psh,urg/ -a=DROP
+agent -m=insert -c=INPUT -p=tcp?flags=all/none -a=DROP
+agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=80`
+
+	tests := []struct {
+		name     string
+		flags    string
+		wantLine int
+	}{
+		{"flags 생략 (syn)", "", 3},
+		{"syn,ack", "syn,ack", 3},
+		{"플래그 없음 (NULL 스캔)", "none", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packet := model.Packet{Chain: model.ChainINPUT, Protocol: model.ProtocolTCP, SIP: "10.0.0.1", DIP: "10.0.0.2", DPort: 80, TCPFlags: tt.flags}
+			result, err := parser.SimulateText(text, packet)
+			if err != nil {
+				t.Fatalf("SimulateText() error = %v", err)
+			}
+			if result.MatchedLine != tt.wantLine {
+				t.Errorf("SimulateText() = %s, want 라인 %d", result.Summary(), tt.wantLine)
+			}
+		})
+	}
+}
+
+// TestSimulateText_DNAT DNAT 변환 후 필터 규칙 비교 및 추적 테스트
+func TestSimulateText_DNAT(t *testing.T) {
+	packet := model.Packet{Chain: model.ChainFORWARD, Protocol: model.ProtocolTCP, SIP: "8.8.8.8", DIP: "203.0.113.1", DPort: 80, InInterface: "eth0"}
+
+	result, err := parser.SimulateText(simulateTemplate, packet)
+	if err != nil {
+		t.Fatalf("SimulateText() error = %v", err)
+	}
+	if !result.Translated || result.Packet.DIP != "192.168.0.10" || result.Packet.DPort != 8080 {
+		t.Errorf("Packet = %+v, want DNAT 192.168.0.10:8080", result.Packet)
+	}
+	if result.Verdict != "DROP" || result.MatchedLine != 8 {
+		t.Errorf("SimulateText() = %s, want DROP (라인 8)", result.Summary())
+	}
+
+	want := []string{"dnat 9 true", "filter 8 true"}
+	var got []string
+	for _, step := range result.Trace {
+		got = append(got, fmt.Sprintf("%s %d %t", step.Stage, step.Line, step.Matched))
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Trace = %v, want %v", got, want)
+	}
+
+	// 인터페이스가 다르면 DNAT 미적용
+	packet.InInterface = "eth1"
+	result, err = parser.SimulateText(simulateTemplate, packet)
+	if err != nil {
+		t.Fatalf("SimulateText() error = %v", err)
+	}
+	if result.Translated || result.Verdict != "ACCEPT" || !strings.Contains(result.Trace[0].Reason, "입력 인터페이스") {
+		t.Errorf("SimulateText() = %+v, want DNAT 미적용 기본 정책", result)
+	}
+}
+
+// TestSimulateText_MatchOptions 출발지 포트, 인터페이스, 연결 상태 조건 테스트
+func TestSimulateText_MatchOptions(t *testing.T) {
+	text := `agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --state=ESTABLISHED,RELATED
+agent -m=insert -c=FORWARD -p=udp -a=ACCEPT -i=eth1 -o=eth0 --sport=53
+agent -m=insert -c=INPUT -p=any -a=DROP`
+
+	tests := []struct {
+		name        string
+		packet      model.Packet
+		wantVerdict string
+		wantLine    int
+	}{
+		{
+			"응답 패킷",
+			model.Packet{Chain: model.ChainINPUT, Protocol: model.ProtocolTCP, SIP: "8.8.8.8", DIP: "10.0.0.1", DPort: 40000, State: "established"},
+			"ACCEPT", 1,
+		},
+		{
+			"새 연결",
+			model.Packet{Chain: model.ChainINPUT, Protocol: model.ProtocolTCP, SIP: "8.8.8.8", DIP: "10.0.0.1", DPort: 22},
+			"DROP", 3,
+		},
+		{
+			"DNS 응답 전달",
+			model.Packet{Chain: model.ChainFORWARD, Protocol: model.ProtocolUDP, SIP: "8.8.8.8", DIP: "10.0.0.1", SPort: 53, DPort: 40000, InInterface: "eth1", OutInterface: "eth0"},
+			"ACCEPT", 2,
+		},
+		{
+			"출력 인터페이스 불일치",
+			model.Packet{Chain: model.ChainFORWARD, Protocol: model.ProtocolUDP, SIP: "8.8.8.8", DIP: "10.0.0.1", SPort: 53, DPort: 40000, InInterface: "eth1", OutInterface: "eth2"},
+			"ACCEPT", 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parser.SimulateText(text, tt.packet)
+			if err != nil {
+				t.Fatalf("SimulateText() error = %v", err)
+			}
+			if result.Verdict != tt.wantVerdict || result.MatchedLine != tt.wantLine {
+				t.Errorf("SimulateText() = %s, want %s (라인 %d)", result.Summary(), tt.wantVerdict, tt.wantLine)
+			}
+		})
+	}
+}
+
+// TestSimulateText_IPv6 IPv6 패킷은 IPv6 규칙과만 비교
+func TestSimulateText_IPv6(t *testing.T) {
+	text := `agent -m=insert -c=INPUT -p=any -a=ACCEPT --sip=10.0.0.0/8
+agent -m=insert -c=INPUT -p=icmp?type=echo-request -a=DROP --family=ipv6
+agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=22 --sip=2001:db8::/32
+agent -m=insert -c=INPUT -p=any -a=DROP --family=ipv6
+agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=8080 --to-dest=[2001:db8::10]:80`
+
+	tests := []struct {
+		name        string
+		packet      model.Packet
+		wantVerdict string
+		wantLine    int
+	}{
+		{
+			"ICMPv6 echo-request",
+			model.Packet{Chain: model.ChainINPUT, Protocol: model.ProtocolICMP, SIP: "2001:db8::5", DIP: "2001:db8::1", ICMPType: "128"},
+			"DROP", 2,
+		},
+		{
+			"허용 대역 SSH",
+			model.Packet{Chain: model.ChainINPUT, Protocol: model.ProtocolTCP, SIP: "2001:db8::5", DIP: "2001:db8::1", DPort: 22},
+			"ACCEPT", 3,
+		},
+		{
+			"그 외 IPv6",
+			model.Packet{Chain: model.ChainINPUT, Protocol: model.ProtocolTCP, SIP: "2001:db9::5", DIP: "2001:db8::1", DPort: 22},
+			"DROP", 4,
+		},
+		{
+			"IPv4 패킷은 IPv6 규칙과 불일치",
+			model.Packet{Chain: model.ChainINPUT, Protocol: model.ProtocolICMP, SIP: "172.16.0.1", DIP: "192.168.0.5", ICMPType: "echo-request"},
+			"ACCEPT", 0,
+		},
+		{
+			"IPv6 DNAT",
+			model.Packet{Chain: model.ChainFORWARD, Protocol: model.ProtocolTCP, SIP: "2001:db8::5", DIP: "2001:db8::1", DPort: 8080},
+			"ACCEPT", 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parser.SimulateText(text, tt.packet)
+			if err != nil {
+				t.Fatalf("SimulateText() error = %v", err)
+			}
+			if result.Verdict != tt.wantVerdict || result.MatchedLine != tt.wantLine {
+				t.Errorf("parser.SimulateText() = %s, want %s (라인 %d)", result.Summary(), tt.wantVerdict, tt.wantLine)
+			}
+		})
+	}
+
+	// DNAT 변환 대상 확인
+	result, err := parser.SimulateText(text, model.Packet{Chain: model.ChainFORWARD, Protocol: model.ProtocolTCP, SIP: "2001:db8::5", DIP: "2001:db8::1", DPort: 8080})
+	if err != nil {
+		t.Fatalf("SimulateText() error = %v", err)
+	}
+	if !result.Translated || result.Packet.DIP != "2001:db8::10" || result.Packet.DPort != 80 {
+		t.Errorf("DNAT 결과 = %s, want tcp 2001:db8::5 → [2001:db8::10]:80", result.Packet)
+	}
+}
+
+// TestSimulateText_InvalidPacket 잘못된 패킷 입력 테스트
+func TestSimulateText_InvalidPacket(t *testing.T) {
+	packets := []model.Packet{
+		{Chain: model.ChainPREROUTING, Protocol: model.ProtocolTCP, SIP: "1.1.1.1", DIP: "2.2.2.2", DPort: 80},
+		{Chain: model.ChainINPUT, Protocol: model.ProtocolANY, SIP: "1.1.1.1", DIP: "2.2.2.2"},
+		{Chain: model.ChainINPUT, Protocol: model.ProtocolTCP, SIP: "1.1.1", DIP: "2.2.2.2", DPort: 80},
+		{Chain: model.ChainINPUT, Protocol: model.ProtocolTCP, SIP: "1.1.1.1", DIP: "2.2.2.2"},
+		{Chain: model.ChainINPUT, Protocol: model.ProtocolTCP, SIP: "1.1.1.1", DIP: "2.2.2.2", DPort: 80, TCPFlags: "syn,foo"},
+		{Chain: model.ChainINPUT, Protocol: model.ProtocolICMP, SIP: "1.1.1.1", DIP: "2.2.2.2"},
+		{Chain: model.ChainINPUT, Protocol: model.ProtocolTCP, SIP: "1.1.1.1", DIP: "2.2.2.2", DPort: 80, State: "closed"},
+		{Chain: model.ChainINPUT, Protocol: model.ProtocolTCP, SIP: "1.1.1.1", DIP: "2001:db8::1", DPort: 80},
+	}
+	for _, packet := range packets {
+		if _, err := parser.SimulateText(simulateTemplate, packet); err == nil {
+			t.Errorf("SimulateText(%+v) error = nil, want error", packet)
+		}
+	}
+}
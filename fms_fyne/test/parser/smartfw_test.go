@@ -0,0 +1,141 @@
+package parser_test
+
+import (
+	"strings"
+	"testing"
+
+	"fms/internal/parser"
+)
+
+func TestRuleToSmartfw(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{
+			"agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=80 --sip=192.168.1.0/24",
+			"req|INSERT|123|INPUT|ACCEPT|TCP|192.168.1.0/24|ANY|80||",
+		},
+		{
+			"agent -m=insert -c=INPUT -p=tcp?flags=syn,ack/syn -a=REJECT --dport=22",
+			"req|INSERT|123|INPUT|REJECT|TCP?flags=syn,ack/syn|ANY|ANY|22||",
+		},
+		{
+			"agent -m=insert -c=INPUT -p=icmp?type=echo-request -a=DROP --black",
+			"req|INSERT|123|INPUT|BLACK|ICMP?type=echo-request|ANY|ANY|||",
+		},
+		{
+			"agent -m=insert -c=FORWARD -p=tcp -a=ACCEPT --dport=443 -i=eth1 -o=eth0",
+			"req|INSERT|123|FORWARD|ACCEPT|TCP|ANY|ANY|443|eth1|eth0",
+		},
+	}
+
+	for _, tt := range tests {
+		rule, err := parser.ParseLine(tt.line)
+		if err != nil {
+			t.Fatalf("ParseLine(%q) error = %v", tt.line, err)
+		}
+
+		got := parser.RuleToSmartfw(rule, "123")
+		if got != tt.want {
+			t.Errorf("RuleToSmartfw() = %s, want %s", got, tt.want)
+		}
+
+		parsed, err := parser.ParseSmartfwLine(got)
+		if err != nil || parsed == nil || parsed.Rule == nil {
+			t.Fatalf("ParseSmartfwLine(%q) = %+v, %v", got, parsed, err)
+		}
+		if line := parser.RuleToLine(parsed.Rule); line != parser.RuleToLine(rule) {
+			t.Errorf("역변환 = %s, want %s", line, parser.RuleToLine(rule))
+		}
+	}
+}
+
+func TestParseSmartfwLine_NAT(t *testing.T) {
+	lines := []string{
+		"agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=10.0.0.1:8080",
+		"agent -m=insert -t=nat --nat-type=snat -p=any -s=10.0.0.0/8 --to-source=1.1.1.1 -o=eth0",
+		"agent -m=insert -t=nat --nat-type=masquerade -p=any -s=192.168.0.0/24 -o=eth0",
+	}
+
+	for _, line := range lines {
+		rule, err := parser.ParseNATLine(line)
+		if err != nil {
+			t.Fatalf("ParseNATLine(%q) error = %v", line, err)
+		}
+
+		smartfw := parser.NATRuleToSmartfw(rule, "123")
+		parsed, err := parser.ParseSmartfwLine(smartfw)
+		if err != nil || parsed == nil || parsed.NATRule == nil {
+			t.Fatalf("ParseSmartfwLine(%q) = %+v, %v", smartfw, parsed, err)
+		}
+		if got := parser.NATRuleToLine(parsed.NATRule); got != line {
+			t.Errorf("역변환(%s) = %s, want %s", smartfw, got, line)
+		}
+	}
+}
+
+func TestParseSmartfwLine_InvalidProtocol(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"req|INSERT|1|INPUT|DROP|TCP?mss=1400|ANY|ANY|||", "mss"},
+		{"req|INSERT|1|INPUT|DROP|UDP?flags=syn/syn|ANY|ANY|||", "tcp 프로토콜에서만"},
+		{"req|INSERT|1|INPUT|DROP|ICMP?type=neighbour-solicitation|10.0.0.1|ANY|||", "알 수 없는 ICMP type"},
+	}
+	for _, tt := range tests {
+		_, err := parser.ParseSmartfwLine(tt.line)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseSmartfwLine(%q) error = %v, want %q", tt.line, err, tt.want)
+		}
+	}
+
+	if _, err := parser.ParseSmartfwLine("req|INSERT|1|INPUT|DROP|ICMP?type=neighbour-solicitation|2001:db8::1|ANY|||"); err != nil {
+		t.Errorf("ParseSmartfwLine(ICMPv6) error = %v", err)
+	}
+}
+
+func TestImportRuleset_Smartfw(t *testing.T) {
+	dump := `req|INSERT|3813792919|INPUT|FLUSH|ANY|ANY|ANY|||
+req|INSERT|3813792919|ANY|NAT|ANY|TCP?DNAT|10.0.0.1|80,8080||
+req|INSERT|3813792919|INPUT|ACCEPT|TCP|192.168.1.0/24|ANY|80||
+req|INSERT|3813792919|INPUT|LOG|TCP|ANY|ANY|||`
+
+	result, err := parser.ImportRuleset(dump)
+	if err != nil {
+		t.Fatalf("ImportRuleset() error = %v", err)
+	}
+
+	wantText := `agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=80 --sip=192.168.1.0/24
+agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=10.0.0.1:8080`
+	if result.Text != wantText {
+		t.Errorf("Text =\n%s\nwant\n%s", result.Text, wantText)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Line != 4 {
+		t.Errorf("Skipped = %v, want 라인 4", result.Skipped)
+	}
+
+	// 내보낸 smartfw 요청을 다시 가져오면 같은 규칙
+	exported, err := parser.ExportText(result.Text, parser.ExportSmartfw)
+	if err != nil {
+		t.Fatalf("ExportText() error = %v", err)
+	}
+	reimported, err := parser.ImportRuleset(exported)
+	if err != nil {
+		t.Fatalf("ImportRuleset(exported) error = %v", err)
+	}
+	if reimported.Text != result.Text {
+		t.Errorf("재가져오기 =\n%s\nwant\n%s", reimported.Text, result.Text)
+	}
+
+	if _, err := parser.TextToSmartfw("agent -m=insert -c=INPUT -a=DROP --foo=1", "1"); err == nil || !strings.HasPrefix(err.Error(), "라인 1:") {
+		t.Errorf("TextToSmartfw() error = %v, want 라인 1 오류", err)
+	}
+
+	// 출발지 포트와 연결 상태는 smartfw 필드가 없어 변환 불가
+	_, err = parser.TextToSmartfw("# 상태\nagent -m=insert -c=INPUT -p=any -a=ACCEPT --state=ESTABLISHED", "1")
+	if err == nil || !strings.HasPrefix(err.Error(), "라인 2:") || !strings.Contains(err.Error(), "--state") {
+		t.Errorf("TextToSmartfw() error = %v, want 라인 2 연결 상태 오류", err)
+	}
+}
@@ -0,0 +1,124 @@
+package parser_test
+
+import (
+	"strings"
+	"testing"
+
+	"fms/internal/model"
+	"fms/internal/parser"
+)
+
+func TestParseLineWithMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string // 엄격 모드 에러 (빈 값이면 성공)
+	}{
+		{"valid rule", "agent -m=insert -c=INPUT -p=tcp --dport=22 -a=DROP", ""},
+		{"typo option", "agent -m=insert -c=INPUT -a=DROP --dprot=22", "알 수 없는 옵션"},
+		{"duplicate option", "agent -m=insert -c=INPUT -c=OUTPUT -a=DROP", "-c 옵션이 중복되었습니다"},
+		{"invalid chain", "agent -m=insert -c=INPT -a=DROP", "알 수 없는 체인"},
+		{"trailing garbage", "agent -m=insert -c=INPUT -a=DROP now", "불필요한 토큰"},
+		{"wildcard interface", "agent -m=insert -c=FORWARD -a=ACCEPT -i=ppp+ -o=eth0.100", ""},
+		{"interface command substitution", "agent -m=insert -c=FORWARD -a=DROP -i=$(reboot)", "잘못된 인터페이스 이름"},
+		{"interface semicolon", "agent -m=insert -c=FORWARD -a=DROP -i=eth0;reboot", "잘못된 인터페이스 이름"},
+		{"interface pipe", "agent -m=insert -c=FORWARD -a=DROP -o=eth0|nc", "잘못된 인터페이스 이름"},
+		{"interface redirect", "agent -m=insert -c=FORWARD -a=DROP -o=eth0>/tmp/x", "잘못된 인터페이스 이름"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parser.ParseLineWithMode(tt.input, parser.ModeLenient); err != nil {
+				t.Errorf("lenient error = %v", err)
+			}
+
+			_, err := parser.ParseLineWithMode(tt.input, parser.ModeStrict)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("strict error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("strict error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckText_StrictValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"mode", "agent -m=insert;reboot -c=INPUT -a=DROP", "알 수 없는 처리 방식"},
+		{"dport", "agent -m=insert -c=INPUT -a=DROP --dport=22`reboot`", "잘못된 포트"},
+		{"sport", "agent -m=insert -c=INPUT -p=udp -a=DROP --sport=53;reboot", "잘못된 포트"},
+		{"sip", "agent -m=insert -c=INPUT -a=DROP --sip=1.2.3.4|reboot", "잘못된 IP 주소"},
+		{"dip", "agent -m=insert -c=INPUT -a=DROP --dip=10.0.0.1>/tmp/x", "잘못된 IP 주소"},
+		{"nat mode", "agent -m=delete -t=nat --nat-type=masquerade -o=eth0", "알 수 없는 처리 방식"},
+		{"nat match-port", "agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80$(reboot) --to-dest=10.0.0.1", "잘못된 포트"},
+		{"nat source", "agent -m=insert -t=nat --nat-type=masquerade -s=10.0.0.0/8|nc -o=eth0", "잘못된 IP 주소"},
+		{"nat to-dest", "agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=10.0.0.1:8080|nc", "잘못된 포트"},
+		{"nat to-source", "agent -m=insert -t=nat --nat-type=snat -s=10.0.0.0/8 --to-source=1.1.1.1;reboot", "잘못된 IP 주소"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := parser.CheckText(tt.input, parser.ModeStrict)
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tt.wantErr) {
+				t.Errorf("CheckText(strict) = %v, want %q", errs, tt.wantErr)
+			}
+			if errs := parser.CheckText(tt.input, parser.ModeLenient); len(errs) != 0 {
+				t.Errorf("CheckText(lenient) = %v, want 오류 없음", errs)
+			}
+		})
+	}
+}
+
+func TestParseLineWithMode_LenientDefaults(t *testing.T) {
+	rule, err := parser.ParseLine("agent -m=insert -c=INPT -p=tcpp -a=DENY")
+	if err != nil {
+		t.Fatalf("ParseLine() error = %v", err)
+	}
+	if rule.Chain != model.ChainINPUT || rule.Protocol != model.ProtocolTCP || rule.Action != model.ActionDROP {
+		t.Errorf("ParseLine() = %+v, want INPUT/TCP/DROP", rule)
+	}
+}
+
+func TestCheckText(t *testing.T) {
+	text := "# 규칙\nagent -m=insert -c=INPUT -a=DROP\nagent -m=insert -t=nat --nat-type=snat --to-source=1.1.1.1 --to-src=2.2.2.2"
+
+	errs := parser.CheckText(text, parser.ModeStrict)
+	if len(errs) != 1 || !strings.HasPrefix(errs[0].Error(), "라인 3:") {
+		t.Errorf("CheckText(strict) = %v, want 라인 3 오류", errs)
+	}
+	if errs := parser.CheckText(text, parser.ModeLenient); len(errs) != 0 {
+		t.Errorf("CheckText(lenient) = %v, want 오류 없음", errs)
+	}
+}
+
+func TestCheckText_MatchesValidator(t *testing.T) {
+	lines := []string{
+		"agent -m=insert -c=INPUT -p=tcp --dport=22 -a=ACCEPT",
+		"agent -m=insert -c=INPUT -a=DROP -a=ACCEPT",
+		"agent -m=insert -c=INPUT -a=DROP now",
+		"agent -m=insert -c=INPUT -p=tcp?flags=syn/syn&flags=ack/ack -a=DROP",
+		"agent -m=insert -c=FORWARD -p=any -a=DROP --sip=10.0.0.1 --dip=2001:db8::1",
+		"agent -m=insert -t=nat --nat-type=masquerade -o=eth0 --desc=외부 인터넷 -p=x",
+		"agent -m=insert -t=nat --nat-type=snat --match-ip=10.0.0.0/8 -s=10.0.0.1 --to-source=1.1.1.1",
+		"agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80",
+		"iptables -A INPUT -j DROP",
+		"  #include",
+		"# 주석",
+	}
+
+	for _, line := range lines {
+		strictErr := len(parser.CheckText(line, parser.ModeStrict)) > 0
+		validatorErr := model.HasErrors(model.ValidateTemplate(line))
+		if strictErr != validatorErr {
+			t.Errorf("%q: CheckText(strict) 오류 %v, ValidateTemplate 오류 %v, want 같음", line, strictErr, validatorErr)
+		}
+	}
+}
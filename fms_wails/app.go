@@ -7,11 +7,16 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 
 	"fms_wails/internal/deploy"
 	"fms_wails/internal/model"
 	"fms_wails/internal/parser"
 	"fms_wails/internal/storage"
+	"fms_wails/internal/utils"
 	"fms_wails/internal/version"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
@@ -23,6 +28,11 @@ type App struct {
 	store    *storage.JSONStore
 	deployer *deploy.Deployer
 	config   *model.Config
+
+	// 배포/상태 확인 작업 취소용
+	opMu     sync.Mutex
+	opCtx    context.Context
+	opCancel context.CancelFunc
 }
 
 // NewApp creates a new App application struct
@@ -67,6 +77,7 @@ func (a *App) startup(ctx context.Context) {
 
 	// Deployer 초기화
 	a.deployer = deploy.NewDeployer(a.config)
+	a.deployer.SetVariableLookup(a.deployVariables)
 
 	log.Printf("저장소 초기화 완료: %s", configDir)
 }
@@ -82,7 +93,8 @@ func (a *App) GetConfig() *model.Config {
 }
 
 // SaveConfig는 설정을 저장합니다.
-func (a *App) SaveConfig(configJSON string) error {
+// 인증 비밀값은 JSON에 포함되지 않으므로 authSecret으로 전달하며, 빈 문자열이면 기존 값을 유지합니다.
+func (a *App) SaveConfig(configJSON string, authSecret string) error {
 	if a.store == nil {
 		return nil
 	}
@@ -90,6 +102,13 @@ func (a *App) SaveConfig(configJSON string) error {
 	if err := json.Unmarshal([]byte(configJSON), &config); err != nil {
 		return err
 	}
+	config.Auth.Secret = authSecret
+	if authSecret == "" && a.config != nil {
+		config.Auth.Secret = a.config.Auth.Secret
+	}
+	if !config.Auth.IsEnabled() {
+		config.Auth.Secret = ""
+	}
 	a.config = &config
 	a.deployer.UpdateConfig(&config)
 	return a.store.SaveConfig(&config)
@@ -119,25 +138,120 @@ func (a *App) GetTemplate(version string) *model.Template {
 }
 
 // SaveTemplate는 템플릿을 저장합니다.
-func (a *App) SaveTemplate(version, contents string) error {
+// 내용이 바뀌었으면 현재 OS 사용자와 변경 메모(note)를 담은 새 리비전이 기록됩니다.
+func (a *App) SaveTemplate(version, contents, note string) error {
 	if a.store == nil {
 		return nil
 	}
 	template := model.NewTemplate(version, contents)
+	// 저장하는 템플릿은 엄격 모드와 같은 규칙으로 검사 (데이터 가져오기는 검사하지 않음)
+	if err := checkTemplateRules(template); err != nil {
+		return err
+	}
 	if !template.IsValid() {
 		return fmt.Errorf("유효하지 않은 템플릿입니다. 버전과 내용을 확인해주세요.")
 	}
-	return a.store.SaveTemplate(template)
+	if err := model.CheckIncludeCycle(template, a.store.GetTemplate); err != nil {
+		return err
+	}
+	_, err := a.store.CommitTemplate(template, utils.CurrentUser(), strings.TrimSpace(note))
+	return err
+}
+
+// checkTemplateRules는 템플릿 규칙에 오류 진단이 있으면 첫 번째 오류를 포함한 에러를 반환합니다.
+func checkTemplateRules(template *model.Template) error {
+	var errs []model.Diagnostic
+	for _, d := range template.Validate() {
+		if d.IsError() {
+			errs = append(errs, d)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("템플릿 규칙 오류 %d개: %s", len(errs), errs[0])
 }
 
-// DeleteTemplate는 템플릿을 삭제합니다.
+// DeleteTemplate는 템플릿을 삭제합니다. 다른 템플릿이 포함(#include)하는 템플릿은 삭제하지 않습니다.
 func (a *App) DeleteTemplate(version string) error {
 	if a.store == nil {
 		return nil
 	}
+	if includers := a.GetTemplateIncluders(version); len(includers) > 0 {
+		return fmt.Errorf("템플릿 %s를 포함하는 템플릿이 있어 삭제할 수 없습니다: %s", version, strings.Join(includers, ", "))
+	}
 	return a.store.DeleteTemplate(version)
 }
 
+// GetTemplateIncluders는 해당 버전을 직접 포함(#include)하는 템플릿 버전 목록을 정렬하여 반환합니다.
+func (a *App) GetTemplateIncluders(version string) []string {
+	includers := []string{}
+	if a.store == nil {
+		return includers
+	}
+	templates, err := a.store.GetAllTemplates()
+	if err != nil {
+		return includers
+	}
+	for _, t := range templates {
+		if slices.Contains(model.TemplateIncludes(t.Contents), version) {
+			includers = append(includers, t.Version)
+		}
+	}
+	sort.Strings(includers)
+	return includers
+}
+
+// GetEffectiveTemplate는 편집 중인 템플릿 내용의 포함 지시문(#include)을 현재 저장된 템플릿으로 펼친
+// 유효 템플릿을 반환합니다. 객체 참조와 템플릿 변수는 그대로 둡니다.
+func (a *App) GetEffectiveTemplate(version, contents string) (*model.Template, error) {
+	template := model.NewTemplate(version, contents)
+	if a.store == nil {
+		return template, nil
+	}
+	return model.ResolveIncludes(template, a.store.GetTemplate)
+}
+
+// GetTemplateRevisions는 템플릿 버전의 리비전 목록을 최신순으로 반환합니다.
+func (a *App) GetTemplateRevisions(version string) []*model.TemplateRevision {
+	if a.store == nil {
+		return []*model.TemplateRevision{}
+	}
+	revisions, _ := a.store.GetTemplateRevisions(version)
+	return revisions
+}
+
+// GetTemplateRevision은 템플릿 버전의 리비전을 해시(또는 해시 앞부분)로 찾아 반환합니다.
+func (a *App) GetTemplateRevision(version, hash string) (*model.TemplateRevision, error) {
+	if a.store == nil {
+		return nil, fmt.Errorf("저장소가 초기화되지 않았습니다")
+	}
+	return a.store.GetTemplateRevision(version, hash)
+}
+
+// RestoreTemplateRevision은 이전 리비전의 내용을 템플릿의 새 리비전으로 저장합니다.
+// 기존 리비전은 바꾸지 않으며, 저장 시와 같은 규칙 검사를 거칩니다.
+func (a *App) RestoreTemplateRevision(version, hash string) error {
+	revision, err := a.GetTemplateRevision(version, hash)
+	if err != nil {
+		return err
+	}
+	return a.SaveTemplate(version, revision.Contents, fmt.Sprintf("%s 복원", revision))
+}
+
+// CompareTemplateRevisions는 같은 템플릿 버전의 두 리비전 내용을 줄 단위로 비교합니다.
+func (a *App) CompareTemplateRevisions(version, fromHash, toHash string) ([]model.DiffLine, error) {
+	from, err := a.GetTemplateRevision(version, fromHash)
+	if err != nil {
+		return nil, err
+	}
+	to, err := a.GetTemplateRevision(version, toHash)
+	if err != nil {
+		return nil, err
+	}
+	return model.DiffContents(from.Contents, to.Contents), nil
+}
+
 // DeleteAllTemplates는 모든 템플릿을 삭제합니다.
 func (a *App) DeleteAllTemplates() error {
 	if a.store == nil {
@@ -170,7 +284,8 @@ func (a *App) GetFirewall(index int) *model.Firewall {
 }
 
 // SaveFirewall는 장비를 저장합니다.
-func (a *App) SaveFirewall(firewallJSON string) error {
+// 장비별 인증 비밀값은 authSecret으로 전달하며, 빈 문자열이면 기존 값을 유지합니다.
+func (a *App) SaveFirewall(firewallJSON string, authSecret string) error {
 	if a.store == nil {
 		return nil
 	}
@@ -178,9 +293,39 @@ func (a *App) SaveFirewall(firewallJSON string) error {
 	if err := json.Unmarshal([]byte(firewallJSON), &firewall); err != nil {
 		return err
 	}
+	if err := model.ValidateDeviceAddress(firewall.DeviceName); err != nil {
+		return err
+	}
+	if err := model.ValidateVariables(firewall.Variables); err != nil {
+		return err
+	}
+	if firewall.Auth != nil {
+		firewall.Auth.Secret = authSecret
+		if authSecret == "" && firewall.Index >= 0 {
+			if existing, err := a.store.GetFirewall(firewall.Index); err == nil && existing.Auth != nil {
+				firewall.Auth.Secret = existing.Auth.Secret
+			}
+		}
+		if !firewall.Auth.IsEnabled() {
+			firewall.Auth.Secret = ""
+		}
+	}
 	return a.store.SaveFirewall(&firewall)
 }
 
+// HasAuthSecret는 인증 비밀값이 저장되어 있는지 반환합니다.
+// firewallIndex가 0보다 작으면 전역 설정을 확인합니다.
+func (a *App) HasAuthSecret(firewallIndex int) bool {
+	if firewallIndex < 0 {
+		return a.GetConfig().Auth.Secret != ""
+	}
+	if a.store == nil {
+		return false
+	}
+	firewall, err := a.store.GetFirewall(firewallIndex)
+	return err == nil && firewall.Auth != nil && firewall.Auth.Secret != ""
+}
+
 // DeleteFirewall는 장비를 삭제합니다.
 func (a *App) DeleteFirewall(index int) error {
 	if a.store == nil {
@@ -208,7 +353,7 @@ func (a *App) CheckServerStatus(index int) string {
 		return model.ServerStatusStop
 	}
 
-	a.deployer.HealthCheck(firewall)
+	a.deployer.HealthCheck(a.operationContext(), firewall)
 
 	// 상태 업데이트
 	a.store.SaveFirewall(firewall)
@@ -228,10 +373,11 @@ func (a *App) CheckAllServerStatus() {
 	}
 
 	// Agent 모드면 배치 호출, 아니면 개별 호출
+	ctx := a.operationContext()
 	if a.config.IsAgentMode() {
-		a.deployer.HealthCheckBatch(firewalls)
+		a.deployer.HealthCheckBatch(ctx, firewalls)
 	} else {
-		a.deployer.HealthCheckMultiple(firewalls, nil)
+		a.deployer.HealthCheckMultiple(ctx, firewalls, nil)
 	}
 
 	// 상태 저장
@@ -260,10 +406,11 @@ func (a *App) CheckSelectedServerStatus(indexes []int) {
 	}
 
 	// Agent 모드면 배치 호출, 아니면 병렬 개별 호출
+	ctx := a.operationContext()
 	if a.config.IsAgentMode() {
-		a.deployer.HealthCheckBatch(selectedFirewalls)
+		a.deployer.HealthCheckBatch(ctx, selectedFirewalls)
 	} else {
-		a.deployer.HealthCheckMultiple(selectedFirewalls, nil)
+		a.deployer.HealthCheckMultiple(ctx, selectedFirewalls, nil)
 	}
 
 	// 상태 저장
@@ -285,20 +432,219 @@ func (a *App) Deploy(firewallIndex int, templateVersion string) (*model.DeployHi
 		return nil, err
 	}
 
-	template, err := a.store.GetTemplate(templateVersion)
+	template, err := a.deployTemplate(templateVersion)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTemplateRules(template); err != nil {
+		return nil, err
+	}
+	if err := deploy.CheckExpectations(template); err != nil {
+		return nil, err
+	}
+	if err := deploy.CheckVariables([]*model.Firewall{firewall}, template, a.deployVariables); err != nil {
+		return nil, err
+	}
+
+	result := a.deployer.Deploy(a.operationContext(), firewall, template)
+
+	return a.saveDeployResults([]*deploy.DeployResult{result})[0], nil
+}
+
+// PlanDeploy는 배포 전 장비별 규칙 변경 내용(추가/삭제/순서 변경)을 계산합니다.
+// 각 장비에 기록된 현재 버전의 템플릿과 배포할 템플릿을 비교합니다.
+func (a *App) PlanDeploy(firewallIndexes []int, templateVersion string) (*deploy.DeployPlan, error) {
+	if a.store == nil {
+		return nil, nil
+	}
+
+	template, firewalls, err := a.loadDeployTargets(firewallIndexes, templateVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return deploy.BuildPlan(firewalls, template, a.deployTemplate, a.deployVariables)
+}
+
+// DeployMultiple은 템플릿을 여러 장비에 병렬로 배포합니다.
+// 진행 상황은 "deploy:progress" 이벤트로 전달되며, 이력은 장비 순서대로 반환됩니다.
+// force가 false이면 변경되는 규칙이 없을 때 배포하지 않습니다.
+func (a *App) DeployMultiple(firewallIndexes []int, templateVersion string, force bool) ([]*model.DeployHistory, error) {
+	if a.store == nil || a.deployer == nil {
+		return nil, nil
+	}
+
+	template, firewalls, err := a.loadDeployTargets(firewallIndexes, templateVersion)
 	if err != nil {
 		return nil, err
 	}
+	if err := a.checkPlan(firewalls, template, force); err != nil {
+		return nil, err
+	}
 
-	result := a.deployer.Deploy(firewall, template)
+	results := a.deployer.DeployToMultiple(a.operationContext(), firewalls, template, func(done, total int, deviceName string) {
+		runtime.EventsEmit(a.ctx, "deploy:progress", map[string]interface{}{
+			"done":       done,
+			"total":      total,
+			"deviceName": deviceName,
+		})
+	})
 
-	// 이력 저장
-	a.store.SaveHistory(result.History)
+	return a.saveDeployResults(results), nil
+}
 
-	// 장비 상태 업데이트
-	a.store.SaveFirewall(firewall)
+// DeployRollout은 템플릿을 웨이브 단위로 나누어 단계별(카나리) 배포합니다.
+// 진행 상황은 "rollout:progress" 이벤트로 전달되며,
+// 웨이브 실패율이 허용치를 넘으면 남은 장비는 중단 상태로 이력에 기록됩니다.
+// force가 false이면 변경되는 규칙이 없을 때 배포하지 않습니다.
+func (a *App) DeployRollout(firewallIndexes []int, templateVersion string, optionsJSON string, force bool) (*DeployRolloutResult, error) {
+	if a.store == nil || a.deployer == nil {
+		return nil, nil
+	}
+
+	var opts model.RolloutOptions
+	if err := json.Unmarshal([]byte(optionsJSON), &opts); err != nil {
+		return nil, fmt.Errorf("단계별 배포 설정 파싱 실패: %v", err)
+	}
+
+	template, firewalls, err := a.loadDeployTargets(firewallIndexes, templateVersion)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.checkPlan(firewalls, template, force); err != nil {
+		return nil, err
+	}
+
+	rollout, err := a.deployer.DeployRollout(a.operationContext(), firewalls, template, &opts, func(p deploy.RolloutProgress) {
+		runtime.EventsEmit(a.ctx, "rollout:progress", p)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeployRolloutResult{
+		Rollout:   rollout,
+		Histories: a.saveDeployResults(rollout.Results),
+	}, nil
+}
+
+// DeployRolloutResult는 단계별 배포 결과입니다.
+type DeployRolloutResult struct {
+	Rollout   *deploy.RolloutResult  `json:"rollout"`
+	Histories []*model.DeployHistory `json:"histories"`
+}
+
+// deployTemplate은 배포할 템플릿을 조회하고 객체 참조(@이름)를 현재 객체 값으로 펼칩니다.
+func (a *App) deployTemplate(version string) (*model.Template, error) {
+	return deploy.ExpandingLookup(a.store.GetTemplate, a.store.GetAllObjects)(version)
+}
+
+// deployVariables는 장비 그룹의 변수 위에 장비의 변수를 덮어쓴 템플릿 변수 값을 반환합니다.
+func (a *App) deployVariables(fw *model.Firewall) (map[string]string, error) {
+	return deploy.GroupVariables(a.store.GetGroup)(fw)
+}
+
+// effectiveContents는 편집 중인 템플릿 내용의 포함 지시문과 객체 참조를 현재 템플릿과 객체 값으로 펼칩니다.
+func (a *App) effectiveContents(version, contents string) (string, error) {
+	if a.store == nil {
+		return contents, nil
+	}
+	template, err := a.GetEffectiveTemplate(version, contents)
+	if err != nil {
+		return "", err
+	}
+	objects, err := a.store.GetAllObjects()
+	if err != nil {
+		return "", err
+	}
+	return parser.ExpandObjects(template.Contents, objects)
+}
 
-	return result.History, nil
+// loadDeployTargets는 배포할 템플릿과 장비 목록을 조회합니다.
+func (a *App) loadDeployTargets(firewallIndexes []int, templateVersion string) (*model.Template, []*model.Firewall, error) {
+	template, err := a.deployTemplate(templateVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkTemplateRules(template); err != nil {
+		return nil, nil, err
+	}
+	if err := deploy.CheckExpectations(template); err != nil {
+		return nil, nil, err
+	}
+
+	var firewalls []*model.Firewall
+	for _, idx := range firewallIndexes {
+		fw, err := a.store.GetFirewall(idx)
+		if err != nil {
+			return nil, nil, err
+		}
+		firewalls = append(firewalls, fw)
+	}
+	// 모든 대상 장비에 템플릿 변수가 정의되어 있는지 배포 전에 확인
+	if err := deploy.CheckVariables(firewalls, template, a.deployVariables); err != nil {
+		return nil, nil, err
+	}
+	return template, firewalls, nil
+}
+
+// checkPlan은 배포 계획에 변경 사항이 없으면 에러를 반환합니다. (force 시 검사 생략)
+func (a *App) checkPlan(firewalls []*model.Firewall, template *model.Template, force bool) error {
+	if force {
+		return nil
+	}
+	plan, err := deploy.BuildPlan(firewalls, template, a.deployTemplate, a.deployVariables)
+	if err != nil {
+		return err
+	}
+	if plan.IsEmpty() {
+		return deploy.ErrEmptyPlan
+	}
+	return nil
+}
+
+// saveDeployResults는 배포 결과의 이력과 장비 상태를 순서대로 저장하고 이력을 반환합니다.
+// 자동 롤백 이력은 원본 이력 ID를 연결하여 함께 저장하며, 반환 목록에는 포함하지 않습니다.
+func (a *App) saveDeployResults(results []*deploy.DeployResult) []*model.DeployHistory {
+	histories := make([]*model.DeployHistory, 0, len(results))
+	for _, result := range results {
+		// 이력 저장
+		a.store.SaveHistory(result.History)
+		if result.Rollback != nil {
+			result.Rollback.History.RollbackOf = result.History.ID
+			a.store.SaveHistory(result.Rollback.History)
+		}
+
+		// 장비 상태 업데이트
+		a.store.SaveFirewall(result.Firewall)
+
+		histories = append(histories, result.History)
+	}
+	return histories
+}
+
+// CancelOperation은 진행 중인 배포 및 상태 확인 작업을 모두 취소합니다.
+// 아직 배포되지 않은 장비는 취소 상태로 이력에 기록됩니다.
+func (a *App) CancelOperation() {
+	a.opMu.Lock()
+	defer a.opMu.Unlock()
+
+	if a.opCancel != nil {
+		a.opCancel()
+	}
+	a.opCtx = nil
+	a.opCancel = nil
+}
+
+// operationContext는 배포/상태 확인 작업에 사용할 취소 가능한 컨텍스트를 반환합니다.
+func (a *App) operationContext() context.Context {
+	a.opMu.Lock()
+	defer a.opMu.Unlock()
+
+	if a.opCtx == nil {
+		a.opCtx, a.opCancel = context.WithCancel(a.ctx)
+	}
+	return a.opCtx
 }
 
 // ===== 이력 API =====
@@ -340,6 +686,120 @@ func (a *App) SaveHistory(historyJSON string) error {
 	return a.store.SaveHistory(&history)
 }
 
+// ===== 객체 API =====
+
+// GetAllObjects는 모든 주소/서비스 객체를 이름순으로 반환합니다.
+func (a *App) GetAllObjects() []*model.NamedObject {
+	if a.store == nil {
+		return []*model.NamedObject{}
+	}
+	objects, _ := a.store.GetAllObjects()
+	return objects
+}
+
+// SaveObject는 주소/서비스 객체를 검증하여 저장합니다.
+func (a *App) SaveObject(objectJSON string) error {
+	if a.store == nil {
+		return nil
+	}
+	var object model.NamedObject
+	if err := json.Unmarshal([]byte(objectJSON), &object); err != nil {
+		return fmt.Errorf("객체 파싱 실패: %v", err)
+	}
+	if err := object.Validate(); err != nil {
+		return err
+	}
+	return a.store.SaveObject(&object)
+}
+
+// DeleteObject는 객체를 삭제합니다. 템플릿에서 참조 중인 객체는 삭제하지 않습니다.
+func (a *App) DeleteObject(name string) error {
+	if a.store == nil {
+		return nil
+	}
+	if versions := a.GetObjectUsage(name); len(versions) > 0 {
+		return fmt.Errorf("%s%s를 사용하는 템플릿이 있어 삭제할 수 없습니다: %s", model.ObjectRefPrefix, name, strings.Join(versions, ", "))
+	}
+	return a.store.DeleteObject(name)
+}
+
+// GetObjectUsage는 객체를 참조하는 템플릿 버전 목록을 정렬하여 반환합니다.
+func (a *App) GetObjectUsage(name string) []string {
+	versions := []string{}
+	if a.store == nil {
+		return versions
+	}
+	templates, _ := a.store.GetAllTemplates()
+	for _, t := range templates {
+		if slices.Contains(parser.ObjectReferences(t.Contents), name) {
+			versions = append(versions, t.Version)
+		}
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// GetObjectKindOptions는 객체 종류 옵션을 반환합니다.
+func (a *App) GetObjectKindOptions() []string {
+	return model.GetObjectKindOptions()
+}
+
+// ===== 장비 그룹 API =====
+
+// GetAllGroups는 모든 장비 그룹을 이름순으로 반환합니다.
+func (a *App) GetAllGroups() []*model.DeviceGroup {
+	if a.store == nil {
+		return []*model.DeviceGroup{}
+	}
+	groups, _ := a.store.GetAllGroups()
+	return groups
+}
+
+// SaveGroup은 장비 그룹과 그룹의 템플릿 변수 값을 검증하여 저장합니다.
+func (a *App) SaveGroup(groupJSON string) error {
+	if a.store == nil {
+		return nil
+	}
+	var group model.DeviceGroup
+	if err := json.Unmarshal([]byte(groupJSON), &group); err != nil {
+		return fmt.Errorf("장비 그룹 파싱 실패: %v", err)
+	}
+	if err := group.Validate(); err != nil {
+		return err
+	}
+	return a.store.SaveGroup(&group)
+}
+
+// DeleteGroup은 장비 그룹을 삭제합니다. 그룹에 속한 장비가 있으면 삭제하지 않습니다.
+func (a *App) DeleteGroup(name string) error {
+	if a.store == nil {
+		return nil
+	}
+	firewalls, err := a.store.GetAllFirewalls()
+	if err != nil {
+		return err
+	}
+	var members []string
+	for _, fw := range firewalls {
+		if fw.Group == name {
+			members = append(members, fw.DeviceName)
+		}
+	}
+	if len(members) > 0 {
+		return fmt.Errorf("장비 그룹 %s에 속한 장비가 있어 삭제할 수 없습니다: %s", name, strings.Join(members, ", "))
+	}
+	return a.store.DeleteGroup(name)
+}
+
+// GetTemplateVariables는 템플릿 내용에서 참조하는 변수 이름을 처음 나온 순서대로 반환합니다.
+func (a *App) GetTemplateVariables(contents string) []string {
+	names := model.TemplateVariables(contents)
+	if names == nil {
+		return []string{}
+	}
+	return names
+}
+
 // ===== Export/Import API =====
 
 // ExportData는 모든 데이터를 JSON으로 내보냅니다.
@@ -364,6 +824,53 @@ func (a *App) ImportData(jsonData string) error {
 	return a.store.ImportAll(&data)
 }
 
+// ImportRuleset은 iptables-save 출력 또는 smartfw 덤프를 템플릿 규칙으로 변환합니다.
+// 변환할 수 없는 규칙은 결과의 Skipped에 사유와 함께 담깁니다.
+func (a *App) ImportRuleset(text string) (*parser.ImportResult, error) {
+	return parser.ImportRuleset(text)
+}
+
+// ExportTemplateAs는 템플릿 내용을 iptables-restore, ip6tables-restore, nftables 룰셋 또는 smartfw 요청 목록으로 변환하여 파일로 저장합니다.
+// 저장한 파일 경로를 반환하며, 다이얼로그를 취소하면 빈 문자열을 반환합니다.
+func (a *App) ExportTemplateAs(version, contents, format string) (string, error) {
+	contents, err := a.effectiveContents(version, contents)
+	if err != nil {
+		return "", err
+	}
+	ruleset, err := parser.ExportText(contents, parser.ExportFormat(format))
+	if err != nil {
+		return "", err
+	}
+
+	filter := runtime.FileFilter{DisplayName: "iptables-restore (*.rules)", Pattern: "*.rules"}
+	extension := ".rules"
+	switch parser.ExportFormat(format) {
+	case parser.ExportIp6tables:
+		filter = runtime.FileFilter{DisplayName: "ip6tables-restore (*.rules)", Pattern: "*.rules"}
+		extension = ".v6.rules"
+	case parser.ExportNftables:
+		filter = runtime.FileFilter{DisplayName: "nftables (*.nft)", Pattern: "*.nft"}
+		extension = ".nft"
+	case parser.ExportSmartfw:
+		filter = runtime.FileFilter{DisplayName: "smartfw (*.smartfw)", Pattern: "*.smartfw"}
+		extension = ".smartfw"
+	}
+
+	filePath, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "템플릿 내보내기",
+		DefaultFilename: version + extension,
+		Filters:         []runtime.FileFilter{filter},
+	})
+	if err != nil || filePath == "" {
+		return "", err
+	}
+
+	if err := os.WriteFile(filePath, []byte(ruleset), 0644); err != nil {
+		return "", fmt.Errorf("파일 저장 실패: %v", err)
+	}
+	return filePath, nil
+}
+
 // ===== Reset API =====
 
 // ResetAll은 모든 데이터를 초기화합니다.
@@ -471,18 +978,37 @@ func (a *App) ParseRules(text string) *ParseRulesResult {
 		}
 	}
 
+	findings := parser.AnalyzeText(text)
+
 	return &ParseRulesResult{
-		Rules:    rules,
-		Comments: comments,
-		Errors:   errorMessages,
+		Rules:       rules,
+		Comments:    comments,
+		Errors:      errorMessages,
+		Diagnostics: append(model.ValidateTemplate(text), model.FindingDiagnostics(findings)...),
+		Findings:    findings,
 	}
 }
 
 // ParseRulesResult는 규칙 파싱 결과입니다.
+// Diagnostics는 NAT 규칙을 포함한 전체 텍스트의 줄 단위 검증 결과이며,
+// Findings(가려진/불필요한/충돌하는 규칙)도 경고로 포함합니다.
 type ParseRulesResult struct {
-	Rules    []*model.FirewallRule `json:"rules"`
-	Comments []string              `json:"comments"`
-	Errors   []string              `json:"errors"`
+	Rules       []*model.FirewallRule `json:"rules"`
+	Comments    []string              `json:"comments"`
+	Errors      []string              `json:"errors"`
+	Diagnostics []model.Diagnostic    `json:"diagnostics"`
+	Findings    []model.RuleFinding   `json:"findings"`
+}
+
+// SimulatePacket은 템플릿 내용에서 패킷과 일치하는 규칙과 최종 동작을 계산합니다.
+// 장비에 접속하지 않으며, 평가한 규칙 순서를 Trace로 반환합니다.
+// 포함 지시문과 객체 참조는 현재 템플릿과 객체 값으로 펼친 뒤 평가하므로 Trace의 라인 번호는 펼친 내용 기준입니다.
+func (a *App) SimulatePacket(contents string, packet model.Packet) (*model.SimulationResult, error) {
+	contents, err := a.effectiveContents("", contents)
+	if err != nil {
+		return nil, err
+	}
+	return parser.SimulateText(contents, packet)
 }
 
 // RulesToText는 규칙 배열을 텍스트로 변환합니다.
@@ -502,6 +1028,24 @@ func (a *App) RulesToText(rulesJSON string, commentsJSON string) string {
 	return parser.RulesToText(rules, comments)
 }
 
+// SyncTemplateText는 빌더에서 편집한 규칙을 원본 텍스트에 반영합니다.
+// 주석, 빈 줄, 규칙 순서를 유지하며 변경되지 않은 줄은 원문 그대로 반환합니다.
+func (a *App) SyncTemplateText(text string, rulesJSON string, natRulesJSON string) (string, error) {
+	var rules []*model.FirewallRule
+	if err := json.Unmarshal([]byte(rulesJSON), &rules); err != nil {
+		return "", fmt.Errorf("규칙 파싱 실패: %v", err)
+	}
+	var natRules []*model.NATRule
+	if err := json.Unmarshal([]byte(natRulesJSON), &natRules); err != nil {
+		return "", fmt.Errorf("NAT 규칙 파싱 실패: %v", err)
+	}
+
+	doc := parser.ParseDocument(text)
+	doc.SetRules(rules)
+	doc.SetNATRules(natRules)
+	return doc.String(), nil
+}
+
 // GetChainOptions는 Chain 옵션 목록을 반환합니다.
 func (a *App) GetChainOptions() []string {
 	return model.GetChainOptions()
@@ -532,6 +1076,21 @@ func (a *App) GetICMPTypeOptions() []string {
 	return model.GetICMPTypeOptions()
 }
 
+// GetICMPv6TypeOptions는 IPv6 규칙의 ICMPv6 Type 옵션 목록을 반환합니다.
+func (a *App) GetICMPv6TypeOptions() []string {
+	return model.GetICMPv6TypeOptions()
+}
+
+// GetFamilyOptions는 주소 체계(IPv4, IPv6) 옵션 목록을 반환합니다.
+func (a *App) GetFamilyOptions() []string {
+	return model.GetFamilyOptions()
+}
+
+// GetConnStateOptions는 연결 상태(--state) 옵션 목록을 반환합니다.
+func (a *App) GetConnStateOptions() []string {
+	return model.GetConnStateOptions()
+}
+
 // GetICMPCodeOptions는 ICMP Code 옵션 목록을 반환합니다.
 func (a *App) GetICMPCodeOptions() []string {
 	return model.GetICMPCodeOptions()
@@ -4,6 +4,8 @@ import { APP_HELP } from './constants/helpTexts';
 import TemplateTab, { TemplateTabRef } from './components/TemplateTab';
 import DeviceTab, { DeviceTabRef } from './components/DeviceTab';
 import HistoryTab, { HistoryTabRef } from './components/HistoryTab';
+import ObjectTab, { ObjectTabRef } from './components/ObjectTab';
+import GroupTab, { GroupTabRef } from './components/GroupTab';
 import {
     AreaChart,
     Area,
@@ -22,9 +24,15 @@ import {
     GetAllTemplates,
     GetAllFirewalls,
     GetAllHistory,
+    GetAllObjects,
+    GetAllGroups,
     SaveTemplate,
     SaveFirewall,
     SaveHistory,
+    SaveObject,
+    DeleteObject,
+    SaveGroup,
+    DeleteGroup,
     DeleteAllTemplates,
     DeleteAllFirewalls,
     DeleteAllHistory,
@@ -32,17 +40,54 @@ import {
     WriteFileContent,
     ConfirmDialog,
     AlertDialog,
-    GetAppVersion
+    GetAppVersion,
+    HasAuthSecret
 } from '../wailsjs/go/main/App';
 
-type TabType = 'template' | 'device' | 'history';
+type TabType = 'template' | 'object' | 'group' | 'device' | 'history';
 type MenuType = 'file' | 'tools' | 'help' | null;
 
+// TLS 설정 인터페이스
+interface TLSConfig {
+    caFile: string;
+    certFile: string;
+    keyFile: string;
+    serverName: string;
+    pinnedSha256: string[] | null;
+}
+
+// SSH 접속 설정 인터페이스
+interface SSHConfig {
+    port: number;
+    username: string;
+    keyFile: string;
+    knownHostsFile: string;
+    hostKeySha256: string[] | null;
+    ruleFormat: string;
+}
+
+// 인증 설정 인터페이스 (비밀값은 별도 전달)
+interface AuthConfig {
+    type: string;
+    username: string;
+}
+
+const emptyTLSConfig: TLSConfig = { caFile: '', certFile: '', keyFile: '', serverName: '', pinnedSha256: null };
+const emptySSHConfig: SSHConfig = { port: 22, username: '', keyFile: '', knownHostsFile: '', hostKeySha256: null, ruleFormat: 'agent' };
+
 // Config 인터페이스
 interface Config {
     connectionMode: string;
     agentServerURL: string;
     timeoutSeconds: number;
+    maxConcurrentDeploys: number;
+    disableAutoRollback: boolean;
+    agentBatchSize: number;
+    deviceScheme: string;
+    agentTLS: TLSConfig;
+    deviceTLS: TLSConfig;
+    auth: AuthConfig;
+    ssh: SSHConfig;
 }
 
 function App() {
@@ -53,8 +98,18 @@ function App() {
     const [config, setConfig] = useState<Config>({
         connectionMode: 'agent',
         agentServerURL: 'http://172.24.10.6:8080',
-        timeoutSeconds: 10
+        timeoutSeconds: 10,
+        maxConcurrentDeploys: 5,
+        disableAutoRollback: false,
+        agentBatchSize: 20,
+        deviceScheme: 'http',
+        agentTLS: emptyTLSConfig,
+        deviceTLS: emptyTLSConfig,
+        auth: { type: 'none', username: '' },
+        ssh: emptySSHConfig
     });
+    const [authSecret, setAuthSecret] = useState('');
+    const [hasAuthSecret, setHasAuthSecret] = useState(false);
     const [configDir, setConfigDir] = useState('');
     const [appVersion, setAppVersion] = useState('');
     const [showChartModal, setShowChartModal] = useState(false);
@@ -96,6 +151,8 @@ function App() {
     const templateTabRef = useRef<TemplateTabRef>(null);
     const deviceTabRef = useRef<DeviceTabRef>(null);
     const historyTabRef = useRef<HistoryTabRef>(null);
+    const objectTabRef = useRef<ObjectTabRef>(null);
+    const groupTabRef = useRef<GroupTabRef>(null);
 
     // Import 파일 입력 ref
     const importInputRef = useRef<HTMLInputElement>(null);
@@ -116,6 +173,8 @@ function App() {
             // 탭별 데이터 타입명
             const tabNames: Record<TabType, string> = {
                 template: '템플릿',
+                object: '객체',
+                group: '장비 그룹',
                 device: '장비',
                 history: '배포 이력'
             };
@@ -147,7 +206,7 @@ function App() {
                 for (const item of data) {
                     if (item.version && item.contents && item.contents.trim()) {
                         try {
-                            await SaveTemplate(item.version, item.contents);
+                            await SaveTemplate(item.version, item.contents, '가져온 템플릿');
                             importedCount++;
                         } catch (err) {
                             console.error(`템플릿 저장 실패: ${item.version}`, err);
@@ -161,6 +220,52 @@ function App() {
                 if (skippedCount > 0) {
                     console.log(`${skippedCount}개 템플릿이 유효하지 않아 건너뛰었습니다.`);
                 }
+            } else if (activeTab === 'object') {
+                if (!Array.isArray(data)) {
+                    alert('유효한 객체 데이터가 아닙니다.');
+                    return;
+                }
+                // 기존 데이터 모두 삭제
+                for (const obj of (await GetAllObjects()) || []) {
+                    await DeleteObject(obj.name);
+                }
+
+                for (const item of data) {
+                    if (item.name && item.kind) {
+                        try {
+                            await SaveObject(JSON.stringify(item));
+                            importedCount++;
+                        } catch (err) {
+                            console.error(`객체 저장 실패: ${item.name}`, err);
+                        }
+                    }
+                }
+                objectTabRef.current?.refresh();
+            } else if (activeTab === 'group') {
+                if (!Array.isArray(data)) {
+                    alert('유효한 장비 그룹 데이터가 아닙니다.');
+                    return;
+                }
+                // 기존 데이터 모두 삭제 (장비가 속한 그룹은 삭제되지 않으며 같은 이름이면 덮어씀)
+                for (const group of (await GetAllGroups()) || []) {
+                    try {
+                        await DeleteGroup(group.name);
+                    } catch (err) {
+                        console.error(`장비 그룹 삭제 실패: ${group.name}`, err);
+                    }
+                }
+
+                for (const item of data) {
+                    if (item.name) {
+                        try {
+                            await SaveGroup(JSON.stringify(item));
+                            importedCount++;
+                        } catch (err) {
+                            console.error(`장비 그룹 저장 실패: ${item.name}`, err);
+                        }
+                    }
+                }
+                groupTabRef.current?.refresh();
             } else if (activeTab === 'device') {
                 if (!Array.isArray(data)) {
                     alert('유효한 장비 데이터가 아닙니다.');
@@ -171,7 +276,7 @@ function App() {
 
                 for (const item of data) {
                     if (item.deviceName) {
-                        await SaveFirewall(JSON.stringify(item));
+                        await SaveFirewall(JSON.stringify(item), '');
                         importedCount++;
                     }
                 }
@@ -215,6 +320,12 @@ function App() {
         if (activeTab === 'template') {
             data = await GetAllTemplates();
             filename = 'templates.json';
+        } else if (activeTab === 'object') {
+            data = await GetAllObjects();
+            filename = 'objects.json';
+        } else if (activeTab === 'group') {
+            data = await GetAllGroups();
+            filename = 'groups.json';
         } else if (activeTab === 'device') {
             data = await GetAllFirewalls();
             filename = 'firewalls.json';
@@ -242,7 +353,7 @@ function App() {
 
     // Reset 처리
     const handleReset = async () => {
-        const result = await ConfirmDialog('초기화', '모든 데이터(템플릿, 장비, 배포이력)를 초기화하시겠습니까?');
+        const result = await ConfirmDialog('초기화', '모든 데이터(템플릿, 객체, 장비 그룹, 장비, 배포이력)를 초기화하시겠습니까?');
         // Windows에서는 "Yes", "예", "확인" 등 다양한 값이 반환될 수 있음
         if (result !== '확인' && result !== 'Yes' && result !== '예') {
             return;
@@ -251,6 +362,8 @@ function App() {
         try {
             await ResetAll();
             templateTabRef.current?.refresh();
+            objectTabRef.current?.refresh();
+            groupTabRef.current?.refresh();
             deviceTabRef.current?.refresh();
             historyTabRef.current?.refresh();
             await AlertDialog('완료', '모든 데이터가 초기화되었습니다.');
@@ -265,7 +378,19 @@ function App() {
         try {
             const cfg = await GetConfig();
             const dir = await GetConfigDir();
-            setConfig(cfg as Config);
+            const loaded = cfg as Config;
+            setConfig({
+                ...loaded,
+                maxConcurrentDeploys: loaded.maxConcurrentDeploys || 5,
+                agentBatchSize: loaded.agentBatchSize || 20,
+                deviceScheme: loaded.deviceScheme || 'http',
+                agentTLS: { ...emptyTLSConfig, ...loaded.agentTLS },
+                deviceTLS: { ...emptyTLSConfig, ...loaded.deviceTLS },
+                auth: { type: loaded.auth?.type || 'none', username: loaded.auth?.username || '' },
+                ssh: { ...emptySSHConfig, ...loaded.ssh, port: loaded.ssh?.port || 22 }
+            });
+            setAuthSecret('');
+            setHasAuthSecret(await HasAuthSecret(-1));
             setConfigDir(dir);
             setShowSettingsModal(true);
         } catch (err) {
@@ -284,9 +409,53 @@ function App() {
             alert('타임아웃은 5~120 사이의 숫자를 입력해주세요.');
             return;
         }
+        if (config.maxConcurrentDeploys < 1 || config.maxConcurrentDeploys > 50) {
+            alert('동시 배포 수는 1~50 사이의 숫자를 입력해주세요.');
+            return;
+        }
+        if (config.agentBatchSize < 1 || config.agentBatchSize > 500) {
+            alert('Agent 일괄 배포 수는 1~500 사이의 숫자를 입력해주세요.');
+            return;
+        }
+        if (config.auth.type !== 'none' && !authSecret && !hasAuthSecret) {
+            alert('인증 비밀값(토큰/비밀번호/HMAC 키)을 입력해주세요.');
+            return;
+        }
+        if (config.auth.type === 'basic' && !config.auth.username) {
+            alert('Basic 인증 사용자명을 입력해주세요.');
+            return;
+        }
+        if (config.connectionMode === 'ssh' && (!config.ssh.username || !config.ssh.keyFile)) {
+            alert('SSH 사용자와 개인키 경로를 입력해주세요.');
+            return;
+        }
+        if (config.ssh.port < 1 || config.ssh.port > 65535) {
+            alert('SSH 포트는 1~65535 사이의 숫자를 입력해주세요.');
+            return;
+        }
+        for (const tls of [config.agentTLS, config.deviceTLS]) {
+            if (!tls.certFile !== !tls.keyFile) {
+                alert('클라이언트 인증서와 개인키를 모두 입력해주세요.');
+                return;
+            }
+        }
+
+        // 공개키 고정 값의 빈 줄 제거
+        const cleanPins = (tls: TLSConfig): TLSConfig => ({
+            ...tls,
+            pinnedSha256: (tls.pinnedSha256 || []).map(pin => pin.trim()).filter(pin => pin !== '')
+        });
 
         try {
-            await SaveConfig(JSON.stringify(config));
+            await SaveConfig(JSON.stringify({
+                ...config,
+                agentTLS: cleanPins(config.agentTLS),
+                deviceTLS: cleanPins(config.deviceTLS),
+                ssh: {
+                    ...config.ssh,
+                    hostKeySha256: (config.ssh.hostKeySha256 || []).map(fp => fp.trim()).filter(fp => fp !== '')
+                }
+            }), authSecret);
             setShowSettingsModal(false);
             alert('설정이 저장되었습니다.');
         } catch (err) {
@@ -295,6 +464,61 @@ function App() {
         }
     };
 
+    // TLS 설정 필드 변경
+    const updateTLS = (key: 'agentTLS' | 'deviceTLS', patch: Partial<TLSConfig>) => {
+        setConfig({ ...config, [key]: { ...config[key], ...patch } });
+    };
+
+    // TLS 설정 입력 필드 렌더링
+    const renderTLSFields = (title: string, key: 'agentTLS' | 'deviceTLS') => {
+        const tls = config[key];
+        return (
+            <fieldset className="form-group">
+                <legend>{title}</legend>
+                <input
+                    type="text"
+                    className="input"
+                    value={tls.caFile}
+                    onChange={(e) => updateTLS(key, { caFile: e.target.value })}
+                    placeholder="CA 인증서 경로 (미입력 시 시스템 CA)"
+                />
+                <input
+                    type="text"
+                    className="input"
+                    value={tls.certFile}
+                    onChange={(e) => updateTLS(key, { certFile: e.target.value })}
+                    placeholder="클라이언트 인증서 경로 (mTLS)"
+                />
+                <input
+                    type="text"
+                    className="input"
+                    value={tls.keyFile}
+                    onChange={(e) => updateTLS(key, { keyFile: e.target.value })}
+                    placeholder="클라이언트 개인키 경로 (mTLS)"
+                />
+                <input
+                    type="text"
+                    className="input"
+                    value={tls.serverName}
+                    onChange={(e) => updateTLS(key, { serverName: e.target.value })}
+                    placeholder="서버 이름 (인증서 호스트 이름)"
+                />
+                <textarea
+                    className="input"
+                    rows={2}
+                    value={(tls.pinnedSha256 || []).join('\n')}
+                    onChange={(e) => updateTLS(key, { pinnedSha256: e.target.value.split('\n') })}
+                    placeholder="공개키 고정 sha256/base64 (한 줄에 하나)"
+                />
+            </fieldset>
+        );
+    };
+
+    // SSH 설정 필드 변경
+    const updateSSH = (patch: Partial<SSHConfig>) => {
+        setConfig({ ...config, ssh: { ...config.ssh, ...patch } });
+    };
+
     // 메뉴 토글
     const toggleMenu = (menu: MenuType, e: React.MouseEvent) => {
         e.stopPropagation();
@@ -374,6 +598,18 @@ function App() {
                 >
                     템플릿 관리
                 </button>
+                <button
+                    className={`tab-btn ${activeTab === 'object' ? 'active' : ''}`}
+                    onClick={() => setActiveTab('object')}
+                >
+                    객체 관리
+                </button>
+                <button
+                    className={`tab-btn ${activeTab === 'group' ? 'active' : ''}`}
+                    onClick={() => setActiveTab('group')}
+                >
+                    장비 그룹
+                </button>
                 <button
                     className={`tab-btn ${activeTab === 'device' ? 'active' : ''}`}
                     onClick={() => setActiveTab('device')}
@@ -393,6 +629,12 @@ function App() {
                 <div style={{ display: activeTab === 'template' ? 'block' : 'none', height: '100%' }}>
                     <TemplateTab ref={templateTabRef} />
                 </div>
+                <div style={{ display: activeTab === 'object' ? 'block' : 'none', height: '100%' }}>
+                    <ObjectTab ref={objectTabRef} />
+                </div>
+                <div style={{ display: activeTab === 'group' ? 'block' : 'none', height: '100%' }}>
+                    <GroupTab ref={groupTabRef} />
+                </div>
                 <div style={{ display: activeTab === 'device' ? 'block' : 'none', height: '100%' }}>
                     <DeviceTab ref={deviceTabRef} onDeployComplete={() => historyTabRef.current?.refresh()} />
                 </div>
@@ -554,9 +796,70 @@ function App() {
                                     />
                                     Direct
                                 </label>
+                                <label className="radio-label">
+                                    <input
+                                        type="radio"
+                                        name="connectionMode"
+                                        value="ssh"
+                                        checked={config.connectionMode === 'ssh'}
+                                        onChange={(e) => setConfig({ ...config, connectionMode: e.target.value })}
+                                    />
+                                    SSH
+                                </label>
                             </div>
                         </div>
 
+                        {config.connectionMode === 'ssh' && (
+                            <fieldset className="form-group">
+                                <legend>SSH</legend>
+                                <input
+                                    type="number"
+                                    className="input"
+                                    value={config.ssh.port}
+                                    onChange={(e) => updateSSH({ port: parseInt(e.target.value) || 22 })}
+                                    min={1}
+                                    max={65535}
+                                    placeholder="포트 (기본 22)"
+                                />
+                                <input
+                                    type="text"
+                                    className="input"
+                                    value={config.ssh.username}
+                                    onChange={(e) => updateSSH({ username: e.target.value })}
+                                    placeholder="사용자"
+                                />
+                                <input
+                                    type="text"
+                                    className="input"
+                                    value={config.ssh.keyFile}
+                                    onChange={(e) => updateSSH({ keyFile: e.target.value })}
+                                    placeholder="개인키 경로 (암호 없는 키)"
+                                />
+                                <input
+                                    type="text"
+                                    className="input"
+                                    value={config.ssh.knownHostsFile}
+                                    onChange={(e) => updateSSH({ knownHostsFile: e.target.value })}
+                                    placeholder="known_hosts 경로 (미입력 시 ~/.ssh/known_hosts)"
+                                />
+                                <textarea
+                                    className="input"
+                                    rows={2}
+                                    value={(config.ssh.hostKeySha256 || []).join('\n')}
+                                    onChange={(e) => updateSSH({ hostKeySha256: e.target.value.split('\n') })}
+                                    placeholder="호스트 키 지문 SHA256:... (한 줄에 하나, 입력 시 known_hosts 대신 사용)"
+                                />
+                                <select
+                                    className="select"
+                                    value={config.ssh.ruleFormat || 'agent'}
+                                    onChange={(e) => updateSSH({ ruleFormat: e.target.value })}
+                                >
+                                    <option value="agent">agent 명령 실행</option>
+                                    <option value="smartfw">smartfw 요청 기록 (/proc/smartfw)</option>
+                                </select>
+                            </fieldset>
+                        )}
+
                         <div className="form-group">
                             <label>Agent Server URL</label>
                             <input
@@ -569,6 +872,56 @@ function App() {
                             />
                         </div>
 
+                        <div className="form-group">
+                            <label>장비 연결 스킴</label>
+                            <select
+                                className="select"
+                                value={config.deviceScheme}
+                                onChange={(e) => setConfig({ ...config, deviceScheme: e.target.value })}
+                            >
+                                <option value="http">http</option>
+                                <option value="https">https</option>
+                            </select>
+                        </div>
+
+                        <div className="form-group">
+                            <label>인증 방식</label>
+                            <select
+                                className="select"
+                                value={config.auth.type}
+                                onChange={(e) => setConfig({ ...config, auth: { ...config.auth, type: e.target.value } })}
+                            >
+                                <option value="none">없음</option>
+                                <option value="bearer">Bearer 토큰</option>
+                                <option value="basic">Basic 인증</option>
+                                <option value="hmac">HMAC 서명</option>
+                            </select>
+                        </div>
+
+                        {config.auth.type !== 'none' && (
+                            <div className="form-group">
+                                {config.auth.type !== 'bearer' && (
+                                    <input
+                                        type="text"
+                                        className="input"
+                                        value={config.auth.username}
+                                        onChange={(e) => setConfig({ ...config, auth: { ...config.auth, username: e.target.value } })}
+                                        placeholder={config.auth.type === 'basic' ? '사용자명' : 'HMAC 키 ID (선택)'}
+                                    />
+                                )}
+                                <input
+                                    type="password"
+                                    className="input"
+                                    value={authSecret}
+                                    onChange={(e) => setAuthSecret(e.target.value)}
+                                    placeholder={hasAuthSecret ? '저장됨 (변경 시에만 입력)' : '토큰 / 비밀번호 / HMAC 키'}
+                                />
+                            </div>
+                        )}
+
+                        {renderTLSFields('Agent Server TLS', 'agentTLS')}
+                        {renderTLSFields('장비 직접 연결 TLS', 'deviceTLS')}
+
                         <div className="form-group">
                             <label>Timeout (초)</label>
                             <input
@@ -581,6 +934,42 @@ function App() {
                             />
                         </div>
 
+                        <div className="form-group">
+                            <label>동시 배포 수</label>
+                            <input
+                                type="number"
+                                className="input"
+                                value={config.maxConcurrentDeploys}
+                                onChange={(e) => setConfig({ ...config, maxConcurrentDeploys: parseInt(e.target.value) || 5 })}
+                                min={1}
+                                max={50}
+                            />
+                        </div>
+
+                        <div className="form-group">
+                            <label>Agent 일괄 배포 수</label>
+                            <input
+                                type="number"
+                                className="input"
+                                value={config.agentBatchSize}
+                                onChange={(e) => setConfig({ ...config, agentBatchSize: parseInt(e.target.value) || 20 })}
+                                min={1}
+                                max={500}
+                                disabled={config.connectionMode !== 'agent'}
+                            />
+                        </div>
+
+                        <div className="form-group">
+                            <label>
+                                <input
+                                    type="checkbox"
+                                    checked={!config.disableAutoRollback}
+                                    onChange={(e) => setConfig({ ...config, disableAutoRollback: !e.target.checked })}
+                                />
+                                {' '}배포 실패 시 이전 버전으로 자동 롤백
+                            </label>
+                        </div>
+
                         <div className="form-group">
                             <label>설정 저장 경로</label>
                             <input
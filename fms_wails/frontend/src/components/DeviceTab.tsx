@@ -5,9 +5,16 @@ import {
     DeleteFirewall,
     CheckSelectedServerStatus,
     GetAllTemplates,
-    Deploy,
-    ConfirmDialog
+    DeployMultiple,
+    DeployRollout,
+    PlanDeploy,
+    CancelOperation,
+    ConfirmDialog,
+    HasAuthSecret,
+    GetAllGroups,
+    GetTemplateVariables
 } from '../../wailsjs/go/main/App';
+import { parseVariables, formatVariables, variablesPlaceholder } from './GroupTab';
 
 // 간소화된 Firewall 인터페이스 (HTTP 방식)
 interface Firewall {
@@ -16,6 +23,15 @@ interface Firewall {
     serverStatus: string;
     deployStatus: string;
     version: string;
+    auth?: AuthConfig | null;   // 장비별 인증 설정 (없으면 전역 설정 사용)
+    group?: string;             // 장비 그룹 (그룹 변수를 기본값으로 사용)
+    variables?: Record<string, string> | null;  // 장비별 템플릿 변수 (그룹 변수보다 우선)
+}
+
+// 인증 설정 (비밀값은 별도 전달)
+interface AuthConfig {
+    type: string;
+    username: string;
 }
 
 interface Template {
@@ -23,6 +39,43 @@ interface Template {
     contents: string;
 }
 
+// 배포 계획 (장비별 규칙 변경 내용)
+interface RuleChange {
+    type: string;       // added/removed/moved
+    table: string;      // filter/nat
+    rule: string;
+    oldIndex: number;
+    newIndex: number;
+}
+
+interface DevicePlan {
+    deviceName: string;
+    currentVersion: string;
+    targetVersion: string;
+    changes: RuleChange[] | null;
+    warning: string;
+}
+
+interface DeployPlan {
+    templateVersion: string;
+    devices: DevicePlan[] | null;
+}
+
+// 단계별(카나리) 배포 설정
+interface RolloutOptions {
+    canarySize: number;
+    waveSize: number;
+    pauseSeconds: number;
+    maxFailurePercent: number;
+}
+
+const defaultRolloutOptions: RolloutOptions = {
+    canarySize: 1,
+    waveSize: 10,
+    pauseSeconds: 30,
+    maxFailurePercent: 0,
+};
+
 export interface DeviceTabRef {
     refresh: () => void;
 }
@@ -39,8 +92,16 @@ const DeviceTab = forwardRef<DeviceTabRef, DeviceTabProps>(({ onDeployComplete }
     const [showDeployModal, setShowDeployModal] = useState(false);
     const [selectedTemplate, setSelectedTemplate] = useState('');
     const [editingFirewall, setEditingFirewall] = useState<Firewall | null>(null);
+    const [authSecret, setAuthSecret] = useState('');
+    const [hasAuthSecret, setHasAuthSecret] = useState(false);
     const [isDeploying, setIsDeploying] = useState(false);
     const [isChecking, setIsChecking] = useState(false);
+    const [useRollout, setUseRollout] = useState(false);
+    const [rolloutOptions, setRolloutOptions] = useState<RolloutOptions>(defaultRolloutOptions);
+    const [plan, setPlan] = useState<DeployPlan | null>(null);
+    const [groupNames, setGroupNames] = useState<string[]>([]);
+    const [variablesText, setVariablesText] = useState('');
+    const [templateVariables, setTemplateVariables] = useState<string[]>([]);
 
     const emptyFirewall: Firewall = {
         index: -1,
@@ -55,6 +116,16 @@ const DeviceTab = forwardRef<DeviceTabRef, DeviceTabProps>(({ onDeployComplete }
         loadTemplates();
     }, []);
 
+    // 선택한 템플릿이 참조하는 변수 표시
+    useEffect(() => {
+        const template = templates.find((t) => t.version === selectedTemplate);
+        if (!template) {
+            setTemplateVariables([]);
+            return;
+        }
+        GetTemplateVariables(template.contents).then((names) => setTemplateVariables(names || []));
+    }, [selectedTemplate, templates]);
+
     const loadFirewalls = async () => {
         const data = await GetAllFirewalls();
         setFirewalls((data || []) as Firewall[]);
@@ -65,6 +136,11 @@ const DeviceTab = forwardRef<DeviceTabRef, DeviceTabProps>(({ onDeployComplete }
         setTemplates((data || []) as Template[]);
     };
 
+    const loadGroupNames = async () => {
+        const data = await GetAllGroups();
+        setGroupNames((data || []).map((g) => g.name));
+    };
+
     // 부모 컴포넌트에서 호출할 수 있도록 refresh 메서드 노출
     useImperativeHandle(ref, () => ({
         refresh: () => {
@@ -77,13 +153,21 @@ const DeviceTab = forwardRef<DeviceTabRef, DeviceTabProps>(({ onDeployComplete }
         }
     }));
 
-    const handleAdd = () => {
+    const handleAdd = async () => {
         setEditingFirewall({ ...emptyFirewall });
+        setAuthSecret('');
+        setHasAuthSecret(false);
+        setVariablesText('');
+        await loadGroupNames();
         setShowModal(true);
     };
 
-    const handleEdit = (fw: Firewall) => {
+    const handleEdit = async (fw: Firewall) => {
         setEditingFirewall({ ...fw });
+        setAuthSecret('');
+        setHasAuthSecret(await HasAuthSecret(fw.index));
+        setVariablesText(formatVariables(fw.variables));
+        await loadGroupNames();
         setShowModal(true);
     };
 
@@ -93,7 +177,28 @@ const DeviceTab = forwardRef<DeviceTabRef, DeviceTabProps>(({ onDeployComplete }
             alert('장비명(IP)을 입력하세요.');
             return;
         }
-        await SaveFirewall(JSON.stringify(editingFirewall));
+        const auth = editingFirewall.auth;
+        if (auth && auth.type !== 'none' && !authSecret && !hasAuthSecret) {
+            alert('인증 비밀값(토큰/비밀번호/HMAC 키)을 입력하세요.');
+            return;
+        }
+        if (auth && auth.type === 'basic' && !auth.username) {
+            alert('Basic 인증 사용자명을 입력하세요.');
+            return;
+        }
+        let variables: Record<string, string>;
+        try {
+            variables = parseVariables(variablesText);
+        } catch (err) {
+            alert((err as Error).message);
+            return;
+        }
+        try {
+            await SaveFirewall(JSON.stringify({ ...editingFirewall, variables }), authSecret);
+        } catch (err) {
+            alert(`장비 저장 실패: ${err}`);
+            return;
+        }
         await loadFirewalls();
         setShowModal(false);
         setEditingFirewall(null);
@@ -173,20 +278,72 @@ const DeviceTab = forwardRef<DeviceTabRef, DeviceTabProps>(({ onDeployComplete }
             return;
         }
 
+        if (useRollout) {
+            const { canarySize, waveSize, pauseSeconds, maxFailurePercent } = rolloutOptions;
+            if (canarySize < 1 || waveSize < 1) {
+                alert('카나리 장비 수와 웨이브 크기는 1 이상이어야 합니다.');
+                return;
+            }
+            if (pauseSeconds < 0 || pauseSeconds > 3600) {
+                alert('대기 시간은 0~3600초 사이여야 합니다.');
+                return;
+            }
+            if (maxFailurePercent < 0 || maxFailurePercent > 100) {
+                alert('허용 실패율은 0~100 사이여야 합니다.');
+                return;
+            }
+        }
+
+        // 배포 전 변경 내용 계획 확인
+        try {
+            const result = await PlanDeploy(selectedIndexes, selectedTemplate);
+            setShowDeployModal(false);
+            setPlan(result as DeployPlan);
+        } catch (e) {
+            alert(`배포 계획 생성 실패: ${e}`);
+        }
+    };
+
+    // 계획에 변경 사항이 있는지 확인
+    const isPlanEmpty = (p: DeployPlan | null) => {
+        return !p || (p.devices || []).every((d) => !d.changes || d.changes.length === 0);
+    };
+
+    const executeDeploy = async (force: boolean) => {
         setIsDeploying(true);
-        setShowDeployModal(false);
+        setPlan(null);
 
         let successCount = 0;
         let failCount = 0;
+        let cancelledCount = 0;
+        let haltedCount = 0;
+        let haltReason = '';
 
-        for (const idx of selectedIndexes) {
-            try {
-                await Deploy(idx, selectedTemplate);
-                successCount++;
-            } catch (e) {
-                console.error(`배포 실패 (장비 ${idx}):`, e);
-                failCount++;
+        try {
+            let histories;
+            if (useRollout) {
+                // 카나리 → 대기 → 상태 확인 → 다음 웨이브 순으로 단계별 배포
+                const result = await DeployRollout(selectedIndexes, selectedTemplate, JSON.stringify(rolloutOptions), force);
+                histories = result?.histories;
+                haltReason = result?.rollout?.haltReason || '';
+            } else {
+                // 선택된 장비에 병렬 배포 (동시 배포 수는 설정값 사용)
+                histories = await DeployMultiple(selectedIndexes, selectedTemplate, force);
+            }
+            for (const history of histories || []) {
+                if (history.status === 'success') {
+                    successCount++;
+                } else if (history.status === 'cancelled') {
+                    cancelledCount++;
+                } else if (history.status === 'halted') {
+                    haltedCount++;
+                } else {
+                    failCount++;
+                }
             }
+        } catch (e) {
+            console.error('배포 실패:', e);
+            failCount = selectedIndexes.length;
         }
 
         await loadFirewalls();
@@ -197,13 +354,26 @@ const DeviceTab = forwardRef<DeviceTabRef, DeviceTabProps>(({ onDeployComplete }
             onDeployComplete();
         }
 
-        if (failCount === 0) {
+        if (haltedCount > 0) {
+            alert(`단계별 배포 중단: 성공 ${successCount}개, 실패 ${failCount}개, 중단 ${haltedCount}개\n사유: ${haltReason}`);
+        } else if (cancelledCount > 0) {
+            alert(`배포 취소됨: 성공 ${successCount}개, 실패 ${failCount}개, 취소 ${cancelledCount}개`);
+        } else if (failCount === 0) {
             alert(`${successCount}개 장비에 배포가 완료되었습니다.`);
         } else {
             alert(`배포 완료: 성공 ${successCount}개, 실패 ${failCount}개`);
         }
     };
 
+    const getChangeBadge = (type: string) => {
+        if (type === 'added') {
+            return <span className="badge badge-success">추가</span>;
+        } else if (type === 'removed') {
+            return <span className="badge badge-danger">삭제</span>;
+        }
+        return <span className="badge badge-warning">순서 변경</span>;
+    };
+
     const getStatusBadge = (status: string) => {
         if (status === 'running' || status === 'success') {
             return <span className="badge badge-success">{status === 'running' ? '정상' : '성공'}</span>;
@@ -211,6 +381,12 @@ const DeviceTab = forwardRef<DeviceTabRef, DeviceTabProps>(({ onDeployComplete }
             return <span className="badge badge-danger">{status === 'stop' ? '정지' : '실패'}</span>;
         } else if (status === 'error') {
             return <span className="badge badge-warning">확인요망</span>;
+        } else if (status === 'cancelled') {
+            return <span className="badge badge-info">취소</span>;
+        } else if (status === 'halted') {
+            return <span className="badge badge-warning">중단</span>;
+        } else if (status === 'rollback') {
+            return <span className="badge badge-warning">롤백</span>;
         }
         return <span className="badge badge-info">{status || '-'}</span>;
     };
@@ -233,6 +409,11 @@ const DeviceTab = forwardRef<DeviceTabRef, DeviceTabProps>(({ onDeployComplete }
                     <button className="btn btn-danger" onClick={handleDelete} disabled={isDeploying || isChecking}>
                         삭제
                     </button>
+                    {(isDeploying || isChecking) && (
+                        <button className="btn btn-secondary" onClick={() => CancelOperation()}>
+                            취소
+                        </button>
+                    )}
                 </div>
 
                 <table className="table">
@@ -246,6 +427,7 @@ const DeviceTab = forwardRef<DeviceTabRef, DeviceTabProps>(({ onDeployComplete }
                                 />
                             </th>
                             <th>장비명(IP)</th>
+                            <th>그룹</th>
                             <th>서버상태</th>
                             <th>배포상태</th>
                             <th>버전</th>
@@ -255,7 +437,7 @@ const DeviceTab = forwardRef<DeviceTabRef, DeviceTabProps>(({ onDeployComplete }
                     <tbody>
                         {firewalls.length === 0 ? (
                             <tr>
-                                <td colSpan={6} style={{ textAlign: 'center', color: '#666' }}>
+                                <td colSpan={7} style={{ textAlign: 'center', color: '#666' }}>
                                     등록된 장비가 없습니다
                                 </td>
                             </tr>
@@ -270,6 +452,7 @@ const DeviceTab = forwardRef<DeviceTabRef, DeviceTabProps>(({ onDeployComplete }
                                         />
                                     </td>
                                     <td>{fw.deviceName}</td>
+                                    <td>{fw.group || '-'}</td>
                                     <td>{getStatusBadge(fw.serverStatus)}</td>
                                     <td>{getStatusBadge(fw.deployStatus)}</td>
                                     <td>{fw.version || '-'}</td>
@@ -289,7 +472,7 @@ const DeviceTab = forwardRef<DeviceTabRef, DeviceTabProps>(({ onDeployComplete }
                 </table>
             </div>
 
-            {/* 장비 편집 모달 - 간소화 (IP만 입력) */}
+            {/* 장비 편집 모달 (IP 및 장비별 인증) */}
             {showModal && editingFirewall && (
                 <div className="modal-overlay" onClick={() => setShowModal(false)}>
                     <div className="modal" onClick={(e) => e.stopPropagation()}>
@@ -315,6 +498,79 @@ const DeviceTab = forwardRef<DeviceTabRef, DeviceTabProps>(({ onDeployComplete }
                             />
                         </div>
 
+                        <div className="form-group">
+                            <label>인증</label>
+                            <select
+                                className="select"
+                                value={editingFirewall.auth ? editingFirewall.auth.type : ''}
+                                onChange={(e) =>
+                                    setEditingFirewall({
+                                        ...editingFirewall,
+                                        auth: e.target.value
+                                            ? { type: e.target.value, username: editingFirewall.auth?.username || '' }
+                                            : null
+                                    })
+                                }
+                            >
+                                <option value="">전역 설정 사용</option>
+                                <option value="none">인증 없음</option>
+                                <option value="bearer">Bearer 토큰</option>
+                                <option value="basic">Basic 인증</option>
+                                <option value="hmac">HMAC 서명</option>
+                            </select>
+                        </div>
+
+                        {editingFirewall.auth && editingFirewall.auth.type !== 'none' && (
+                            <div className="form-group">
+                                {editingFirewall.auth.type !== 'bearer' && (
+                                    <input
+                                        type="text"
+                                        className="input"
+                                        value={editingFirewall.auth.username}
+                                        onChange={(e) =>
+                                            setEditingFirewall({
+                                                ...editingFirewall,
+                                                auth: { ...editingFirewall.auth!, username: e.target.value }
+                                            })
+                                        }
+                                        placeholder={editingFirewall.auth.type === 'basic' ? '사용자명' : 'HMAC 키 ID (선택)'}
+                                    />
+                                )}
+                                <input
+                                    type="password"
+                                    className="input"
+                                    value={authSecret}
+                                    onChange={(e) => setAuthSecret(e.target.value)}
+                                    placeholder={hasAuthSecret ? '저장됨 (변경 시에만 입력)' : '토큰 / 비밀번호 / HMAC 키'}
+                                />
+                            </div>
+                        )}
+
+                        <div className="form-group">
+                            <label>장비 그룹</label>
+                            <select
+                                className="select"
+                                value={editingFirewall.group || ''}
+                                onChange={(e) => setEditingFirewall({ ...editingFirewall, group: e.target.value })}
+                            >
+                                <option value="">그룹 없음</option>
+                                {groupNames.map((name) => (
+                                    <option key={name} value={name}>{name}</option>
+                                ))}
+                            </select>
+                        </div>
+
+                        <div className="form-group">
+                            <label>템플릿 변수 (그룹 변수보다 우선)</label>
+                            <textarea
+                                className="textarea"
+                                value={variablesText}
+                                onChange={(e) => setVariablesText(e.target.value)}
+                                placeholder={variablesPlaceholder}
+                                style={{ minHeight: '80px' }}
+                            />
+                        </div>
+
                         <div className="modal-footer">
                             <button className="btn btn-secondary" onClick={() => setShowModal(false)}>
                                 취소
@@ -355,14 +611,141 @@ const DeviceTab = forwardRef<DeviceTabRef, DeviceTabProps>(({ onDeployComplete }
                                     </option>
                                 ))}
                             </select>
+                            {templateVariables.length > 0 && (
+                                <div style={{ fontSize: '0.8rem', color: '#888', marginTop: '4px' }}>
+                                    사용 변수: {templateVariables.map((name) => `\${${name}}`).join(', ')} (장비별 값으로 바꿔 배포)
+                                </div>
+                            )}
+                        </div>
+
+                        <div className="form-group">
+                            <label>
+                                <input
+                                    type="checkbox"
+                                    checked={useRollout}
+                                    onChange={(e) => setUseRollout(e.target.checked)}
+                                />
+                                {' '}단계별(카나리) 배포
+                            </label>
                         </div>
 
+                        {useRollout && (
+                            <>
+                                <div className="form-group">
+                                    <label>카나리 장비 수</label>
+                                    <input
+                                        type="number"
+                                        className="input"
+                                        min={1}
+                                        value={rolloutOptions.canarySize}
+                                        onChange={(e) => setRolloutOptions({ ...rolloutOptions, canarySize: parseInt(e.target.value) || 0 })}
+                                    />
+                                </div>
+                                <div className="form-group">
+                                    <label>웨이브 크기</label>
+                                    <input
+                                        type="number"
+                                        className="input"
+                                        min={1}
+                                        value={rolloutOptions.waveSize}
+                                        onChange={(e) => setRolloutOptions({ ...rolloutOptions, waveSize: parseInt(e.target.value) || 0 })}
+                                    />
+                                </div>
+                                <div className="form-group">
+                                    <label>대기 시간 (초)</label>
+                                    <input
+                                        type="number"
+                                        className="input"
+                                        min={0}
+                                        value={rolloutOptions.pauseSeconds}
+                                        onChange={(e) => setRolloutOptions({ ...rolloutOptions, pauseSeconds: parseInt(e.target.value) || 0 })}
+                                    />
+                                </div>
+                                <div className="form-group">
+                                    <label>허용 실패율 (%)</label>
+                                    <input
+                                        type="number"
+                                        className="input"
+                                        min={0}
+                                        max={100}
+                                        value={rolloutOptions.maxFailurePercent}
+                                        onChange={(e) => setRolloutOptions({ ...rolloutOptions, maxFailurePercent: parseFloat(e.target.value) || 0 })}
+                                    />
+                                </div>
+                            </>
+                        )}
+
                         <div className="modal-footer">
                             <button className="btn btn-secondary" onClick={() => setShowDeployModal(false)}>
                                 취소
                             </button>
                             <button className="btn btn-primary" onClick={handleDeploy}>
-                                배포 실행
+                                변경 내용 확인
+                            </button>
+                        </div>
+                    </div>
+                </div>
+            )}
+
+            {/* 배포 계획 모달 */}
+            {plan && (
+                <div className="modal-overlay" onClick={() => setPlan(null)}>
+                    <div className="modal" onClick={(e) => e.stopPropagation()}>
+                        <div className="modal-header">
+                            <h3 className="modal-title">배포 계획 - {plan.templateVersion}</h3>
+                            <button className="modal-close" onClick={() => setPlan(null)}>
+                                ×
+                            </button>
+                        </div>
+
+                        {isPlanEmpty(plan) && (
+                            <p style={{ marginBottom: '16px' }}>
+                                변경되는 규칙이 없습니다. 그래도 배포하려면 강제 배포를 선택하세요.
+                            </p>
+                        )}
+
+                        <div style={{ maxHeight: '400px', overflowY: 'auto' }}>
+                            {(plan.devices || []).map((d) => (
+                                <div key={d.deviceName} style={{ marginBottom: '16px' }}>
+                                    <div style={{ fontWeight: 500 }}>
+                                        {d.deviceName} ({d.currentVersion || '-'} → {d.targetVersion})
+                                    </div>
+                                    {d.warning && (
+                                        <div style={{ fontSize: '0.8rem', color: '#888' }}>{d.warning}</div>
+                                    )}
+                                    {!d.changes || d.changes.length === 0 ? (
+                                        <div style={{ fontSize: '0.85rem' }}>변경 없음</div>
+                                    ) : (
+                                        <table className="table">
+                                            <tbody>
+                                                {d.changes.map((c, i) => (
+                                                    <tr key={i}>
+                                                        <td style={{ width: '90px' }}>{getChangeBadge(c.type)}</td>
+                                                        <td style={{ width: '60px' }}>{c.table}</td>
+                                                        <td style={{ width: '80px' }}>
+                                                            {c.type === 'moved' ? `${c.oldIndex} → ${c.newIndex}` : (c.newIndex || c.oldIndex)}
+                                                        </td>
+                                                        <td style={{ fontFamily: 'monospace', fontSize: '0.85rem', wordBreak: 'break-all' }}>
+                                                            {c.rule}
+                                                        </td>
+                                                    </tr>
+                                                ))}
+                                            </tbody>
+                                        </table>
+                                    )}
+                                </div>
+                            ))}
+                        </div>
+
+                        <div className="modal-footer">
+                            <button className="btn btn-secondary" onClick={() => setPlan(null)}>
+                                취소
+                            </button>
+                            <button
+                                className={isPlanEmpty(plan) ? 'btn btn-danger' : 'btn btn-primary'}
+                                onClick={() => executeDeploy(isPlanEmpty(plan))}
+                            >
+                                {isPlanEmpty(plan) ? '강제 배포' : '배포'}
                             </button>
                         </div>
                     </div>
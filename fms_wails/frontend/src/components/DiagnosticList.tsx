@@ -0,0 +1,45 @@
+import { model } from '../../wailsjs/go/models';
+
+// 진단을 "라인 3:12 메시지" 형식으로 변환
+export const formatDiagnostic = (d: model.Diagnostic): string =>
+    `라인 ${d.line}:${d.column} ${d.message}`;
+
+interface DiagnosticListProps {
+    diagnostics: model.Diagnostic[];
+    maxItems?: number;
+}
+
+// 템플릿 규칙 검증 결과 (오류는 빨간색, 경고는 주황색)
+const DiagnosticList = ({ diagnostics, maxItems = 10 }: DiagnosticListProps) => {
+    if (diagnostics.length === 0) {
+        return null;
+    }
+
+    const errorCount = diagnostics.filter((d) => d.severity === 'error').length;
+    const warningCount = diagnostics.length - errorCount;
+    const color = errorCount > 0 ? '#e74c3c' : '#e67e22';
+
+    return (
+        <div className="protocol-options" style={{ marginTop: '8px', marginBottom: '16px', borderColor: color }}>
+            <div className="protocol-options-title" style={{ color }}>
+                규칙 검증: 오류 {errorCount}개, 경고 {warningCount}개
+            </div>
+            <ul style={{ fontSize: '0.8rem', paddingLeft: '20px' }}>
+                {diagnostics.slice(0, maxItems).map((d, i) => (
+                    <li
+                        key={i}
+                        style={{ color: d.severity === 'error' ? '#e74c3c' : '#e67e22' }}
+                        title={d.code}
+                    >
+                        {formatDiagnostic(d)}
+                    </li>
+                ))}
+                {diagnostics.length > maxItems && (
+                    <li>... 외 {diagnostics.length - maxItems}개</li>
+                )}
+            </ul>
+        </div>
+    );
+};
+
+export default DiagnosticList;
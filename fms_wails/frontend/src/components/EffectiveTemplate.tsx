@@ -0,0 +1,53 @@
+import { useState, useEffect } from 'react';
+import { GetEffectiveTemplate } from '../../wailsjs/go/main/App';
+import { includeRefText } from './RevisionHistory';
+
+interface EffectiveTemplateProps {
+    version: string;
+    contents: string;
+}
+
+// 유효 템플릿: 포함 지시문(#include)을 현재 저장된 템플릿으로 펼쳐 실제 배포될 순서대로 표시
+const EffectiveTemplate = ({ version, contents }: EffectiveTemplateProps) => {
+    const [effective, setEffective] = useState('');
+    const [includes, setIncludes] = useState<string[]>([]);
+    const [error, setError] = useState('');
+
+    useEffect(() => {
+        GetEffectiveTemplate(version.trim(), contents)
+            .then((template) => {
+                setEffective(template.contents);
+                setIncludes(template.includes || []);
+                setError('');
+            })
+            .catch((err) => {
+                setEffective('');
+                setIncludes([]);
+                setError(`${err}`);
+            });
+    }, [version, contents]);
+
+    return (
+        <div className="form-group flex-grow">
+            <label>
+                유효 템플릿
+                {includes.length > 0 ? ` (포함: ${includes.map(includeRefText).join(' → ')})` : ' (포함한 템플릿 없음)'}
+            </label>
+            {error ? (
+                <div className="protocol-options" style={{ borderColor: '#e74c3c' }}>
+                    <div className="protocol-options-title" style={{ color: '#e74c3c' }}>
+                        포함 템플릿을 펼칠 수 없습니다
+                    </div>
+                    <p style={{ fontSize: '0.8rem', color: '#e74c3c' }}>{error}</p>
+                </div>
+            ) : (
+                <textarea className="textarea" value={effective} readOnly />
+            )}
+            <div style={{ fontSize: '0.8rem', color: '#888', marginTop: '4px' }}>
+                읽기 전용입니다. 객체 참조(@이름)와 템플릿 변수({'${이름}'})는 배포 시 펼쳐집니다.
+            </div>
+        </div>
+    );
+};
+
+export default EffectiveTemplate;
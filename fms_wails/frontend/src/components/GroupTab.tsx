@@ -0,0 +1,275 @@
+import { useState, useEffect, forwardRef, useImperativeHandle } from 'react';
+import {
+    GetAllGroups,
+    SaveGroup,
+    DeleteGroup,
+    GetAllFirewalls,
+    ConfirmDialog
+} from '../../wailsjs/go/main/App';
+
+// Go model.DeviceGroup와 동일한 구조
+interface DeviceGroup {
+    name: string;
+    variables?: Record<string, string>;
+    description?: string;
+}
+
+// 그룹 소속 확인에 필요한 장비 필드
+interface GroupMember {
+    deviceName: string;
+    group?: string;
+}
+
+// 변수 입력 예시
+export const variablesPlaceholder = '한 줄에 하나씩 이름=값 형식으로 입력\nMGMT_NET=10.1.0.0/24\nWEB_HOST=10.1.0.10\nLAN_IF=eth1';
+
+// "이름=값" 줄 목록을 변수 맵으로 변환합니다. (빈 줄 무시, 형식 오류는 예외)
+export const parseVariables = (text: string): Record<string, string> => {
+    const variables: Record<string, string> = {};
+    for (const raw of text.split('\n')) {
+        const line = raw.trim();
+        if (!line) continue;
+        const eq = line.indexOf('=');
+        if (eq <= 0) {
+            throw new Error(`변수는 이름=값 형식으로 입력하세요: ${line}`);
+        }
+        variables[line.slice(0, eq).trim()] = line.slice(eq + 1).trim();
+    }
+    return variables;
+};
+
+// 변수 맵을 이름순 "이름=값" 줄로 변환합니다.
+export const formatVariables = (variables?: Record<string, string> | null): string =>
+    Object.keys(variables || {})
+        .sort()
+        .map((name) => `${name}=${variables![name]}`)
+        .join('\n');
+
+export interface GroupTabRef {
+    refresh: () => void;
+}
+
+const GroupTab = forwardRef<GroupTabRef>((_, ref) => {
+    const [groups, setGroups] = useState<DeviceGroup[]>([]);
+    const [firewalls, setFirewalls] = useState<GroupMember[]>([]);
+    const [selectedName, setSelectedName] = useState('');
+    const [isNew, setIsNew] = useState(false);
+    const [name, setName] = useState('');
+    const [variables, setVariables] = useState('');
+    const [description, setDescription] = useState('');
+
+    useEffect(() => {
+        loadGroups();
+    }, []);
+
+    const loadGroups = async () => {
+        const [groupData, firewallData] = await Promise.all([GetAllGroups(), GetAllFirewalls()]);
+        setGroups((groupData || []) as DeviceGroup[]);
+        setFirewalls((firewallData || []) as GroupMember[]);
+    };
+
+    const resetForm = () => {
+        setSelectedName('');
+        setIsNew(false);
+        setName('');
+        setVariables('');
+        setDescription('');
+    };
+
+    // 부모 컴포넌트에서 호출할 수 있도록 refresh 메서드 노출
+    useImperativeHandle(ref, () => ({
+        refresh: () => {
+            loadGroups();
+            resetForm();
+        }
+    }));
+
+    // 그룹에 속한 장비명 목록
+    const membersOf = (groupName: string) =>
+        firewalls.filter((fw) => fw.group === groupName).map((fw) => fw.deviceName);
+
+    const members = selectedName ? membersOf(selectedName) : [];
+
+    const handleSelect = async (group: DeviceGroup) => {
+        // 장비 탭에서 바뀐 소속을 반영하기 위해 장비 목록을 다시 조회
+        setFirewalls(((await GetAllFirewalls()) || []) as GroupMember[]);
+        setSelectedName(group.name);
+        setIsNew(false);
+        setName(group.name);
+        setVariables(formatVariables(group.variables));
+        setDescription(group.description || '');
+    };
+
+    const handleNew = () => {
+        resetForm();
+        setIsNew(true);
+    };
+
+    const handleSave = async () => {
+        let parsed: Record<string, string>;
+        try {
+            parsed = parseVariables(variables);
+        } catch (err) {
+            alert((err as Error).message);
+            return;
+        }
+        const group: DeviceGroup = {
+            name: name.trim(),
+            variables: parsed,
+            description: description.trim()
+        };
+        if (!group.name) {
+            alert('그룹 이름을 입력하세요.');
+            return;
+        }
+        // 이름을 바꾸면 새 그룹으로 저장되므로 기존 그룹에 장비가 있으면 막음
+        if (!isNew && selectedName && selectedName !== group.name && members.length > 0) {
+            alert(`장비 그룹 ${selectedName}에 속한 장비가 있어 이름을 바꿀 수 없습니다: ${members.join(', ')}`);
+            return;
+        }
+
+        try {
+            await SaveGroup(JSON.stringify(group));
+            if (!isNew && selectedName && selectedName !== group.name) {
+                await DeleteGroup(selectedName);
+            }
+            await loadGroups();
+            await handleSelect(group);
+            alert('장비 그룹이 저장되었습니다.');
+        } catch (err) {
+            console.error('장비 그룹 저장 실패:', err);
+            alert(`장비 그룹 저장 실패: ${err}`);
+        }
+    };
+
+    const handleDelete = async () => {
+        if (!selectedName) {
+            alert('삭제할 장비 그룹이 선택되지 않았습니다.');
+            return;
+        }
+        const result = await ConfirmDialog('삭제 확인', `"${selectedName}" 장비 그룹을 삭제하시겠습니까?`);
+        if (result !== '확인' && result !== 'Yes' && result !== '예') {
+            return;
+        }
+
+        try {
+            await DeleteGroup(selectedName);
+            await loadGroups();
+            resetForm();
+            alert('장비 그룹이 삭제되었습니다.');
+        } catch (err) {
+            console.error('장비 그룹 삭제 실패:', err);
+            alert(`장비 그룹 삭제 실패: ${err}`);
+        }
+    };
+
+    return (
+        <div className="split-layout">
+            {/* 왼쪽: 그룹 목록 */}
+            <div className="card">
+                <div style={{ display: 'flex', justifyContent: 'space-between', alignItems: 'center', marginBottom: '16px' }}>
+                    <div className="card-title" style={{ marginBottom: 0 }}>장비 그룹 목록</div>
+                    <button
+                        className="btn btn-primary"
+                        onClick={handleNew}
+                        style={{ padding: '4px 12px', fontSize: '0.85rem' }}
+                    >
+                        새 그룹
+                    </button>
+                </div>
+                <ul className="list">
+                    {groups.length === 0 ? (
+                        <li className="list-item" style={{ color: '#666' }}>
+                            장비 그룹이 없습니다
+                        </li>
+                    ) : (
+                        groups.map((group) => (
+                            <li
+                                key={group.name}
+                                className={`list-item ${selectedName === group.name ? 'active' : ''}`}
+                                onClick={() => handleSelect(group)}
+                            >
+                                <div style={{ fontWeight: 500 }}>{group.name}</div>
+                                <div style={{ fontSize: '0.8rem', color: '#888' }}>
+                                    변수 {Object.keys(group.variables || {}).length}개 · 장비 {membersOf(group.name).length}대
+                                    {group.description ? ` · ${group.description}` : ''}
+                                </div>
+                            </li>
+                        ))
+                    )}
+                </ul>
+            </div>
+
+            {/* 오른쪽: 그룹 편집 */}
+            <div className="card">
+                {(selectedName || isNew) ? (
+                    <>
+                        <div className="card-title">{isNew ? '새 장비 그룹' : selectedName}</div>
+                        <div className="form-group">
+                            <label>이름</label>
+                            <input
+                                type="text"
+                                className="input"
+                                value={name}
+                                onChange={(e) => setName(e.target.value)}
+                                placeholder="예: seoul-dc"
+                            />
+                        </div>
+                        <div className="form-group">
+                            <label>변수</label>
+                            <textarea
+                                className="textarea"
+                                value={variables}
+                                onChange={(e) => setVariables(e.target.value)}
+                                placeholder={variablesPlaceholder}
+                                style={{ minHeight: '160px' }}
+                            />
+                            <div style={{ fontSize: '0.8rem', color: '#888', marginTop: '4px' }}>
+                                템플릿의 {'${이름}'}이 배포 시 값으로 바뀝니다. 장비에 같은 이름의 변수가 있으면 장비 값을 사용합니다.
+                            </div>
+                        </div>
+                        <div className="form-group">
+                            <label>설명</label>
+                            <input
+                                type="text"
+                                className="input"
+                                value={description}
+                                onChange={(e) => setDescription(e.target.value)}
+                                placeholder="선택 입력"
+                            />
+                        </div>
+                        {!isNew && (
+                            <div className="form-group">
+                                <label>소속 장비</label>
+                                <div style={{ fontSize: '0.85rem', color: '#888' }}>
+                                    {members.length > 0 ? members.join(', ') : '없음'}
+                                </div>
+                            </div>
+                        )}
+                        <div style={{ display: 'flex', gap: '8px', marginTop: '20px' }}>
+                            <button className="btn btn-primary" onClick={handleSave}>
+                                저장
+                            </button>
+                            {!isNew && (
+                                <button
+                                    className="btn btn-danger"
+                                    onClick={handleDelete}
+                                    disabled={members.length > 0}
+                                >
+                                    삭제
+                                </button>
+                            )}
+                        </div>
+                    </>
+                ) : (
+                    <div className="empty-state">
+                        <div className="empty-state-icon">🗂️</div>
+                        <p>왼쪽에서 장비 그룹을 선택하거나 새 그룹을 만드세요</p>
+                    </div>
+                )}
+            </div>
+        </div>
+    );
+});
+
+export default GroupTab;
@@ -1,5 +1,6 @@
 import { useState, useEffect, forwardRef, useImperativeHandle } from 'react';
 import { GetAllHistory, DeleteHistory, ConfirmDialog } from '../../wailsjs/go/main/App';
+import { shortHash, includeRefText } from './RevisionHistory';
 
 // Go model과 동일한 구조
 interface RuleResult {
@@ -7,6 +8,7 @@ interface RuleResult {
     text: string;
     status: string;  // ok/error/unfind/validation
     reason: string;
+    category?: string; // 연결 실패 원인 분류
 }
 
 interface DeployHistory {
@@ -14,10 +16,32 @@ interface DeployHistory {
     timestamp: string;       // Go time.Time은 JSON으로 문자열 변환
     deviceIp: string;
     templateVersion: string;
-    status: string;          // success/fail/error
+    revision?: string;       // 배포한 템플릿 리비전 해시
+    includes?: string[];     // 템플릿이 포함한 템플릿 버전과 리비전 (버전@해시, 펼친 순서)
+    status: string;          // success/fail/error/cancelled/halted
     results: RuleResult[];
+    rolloutId?: string;      // 단계별 배포 ID
+    wave?: number;           // 단계별 배포 웨이브 번호 (1 = 카나리)
+    rollbackOf?: number;     // 자동 롤백 이력인 경우 원본(실패) 이력 ID
 }
 
+// 연결 실패 원인 분류 표시 텍스트 (model.GetErrorCategoryText와 동일)
+const errorCategoryLabels: Record<string, string> = {
+    timeout: '시간 초과',
+    refused: '연결 거부',
+    dns: 'DNS 실패',
+    tls: 'TLS 오류',
+    auth: '인증 실패',
+    http: 'HTTP 오류',
+    protocol: '응답 형식 오류',
+    missing: '결과 없음',
+    network: '네트워크 오류'
+};
+
+// 이력의 연결 실패 원인 분류 (연결 실패가 아니면 빈 문자열)
+const getErrorCategory = (h: DeployHistory) =>
+    (h.results || []).find(r => r.category)?.category || '';
+
 export interface HistoryTabRef {
     refresh: () => void;
 }
@@ -25,6 +49,7 @@ export interface HistoryTabRef {
 const HistoryTab = forwardRef<HistoryTabRef>((_, ref) => {
     const [history, setHistory] = useState<DeployHistory[]>([]);
     const [selectedHistory, setSelectedHistory] = useState<DeployHistory | null>(null);
+    const [categoryFilter, setCategoryFilter] = useState('');
 
     useEffect(() => {
         loadHistory();
@@ -86,10 +111,22 @@ const HistoryTab = forwardRef<HistoryTabRef>((_, ref) => {
             return <span className="badge badge-danger">실패</span>;
         } else if (status === 'error') {
             return <span className="badge badge-warning">오류</span>;
+        } else if (status === 'cancelled') {
+            return <span className="badge badge-info">취소</span>;
+        } else if (status === 'halted') {
+            return <span className="badge badge-warning">중단</span>;
         }
         return <span className="badge badge-info">{status || '-'}</span>;
     };
 
+    // 웨이브 텍스트
+    const getWaveText = (wave?: number) => {
+        if (!wave) {
+            return '-';
+        }
+        return wave === 1 ? '1 (카나리)' : String(wave);
+    };
+
     // 규칙 결과 배지
     const getRuleStatusBadge = (status: string) => {
         const lowerStatus = status?.toLowerCase() || '';
@@ -99,10 +136,27 @@ const HistoryTab = forwardRef<HistoryTabRef>((_, ref) => {
             return <span className="badge badge-danger">실패</span>;
         } else if (lowerStatus === 'write') {
             return <span className="badge badge-warning">진행중</span>;
+        } else if (lowerStatus === 'cancelled') {
+            return <span className="badge badge-info">취소</span>;
+        } else if (lowerStatus === 'halted') {
+            return <span className="badge badge-warning">중단</span>;
         }
         return <span className="badge badge-info">{status || '-'}</span>;
     };
 
+    // 원인별 연결 실패 건수
+    const categoryCounts = history.reduce<Record<string, number>>((counts, h) => {
+        const category = getErrorCategory(h);
+        if (category) {
+            counts[category] = (counts[category] || 0) + 1;
+        }
+        return counts;
+    }, {});
+
+    const filteredHistory = categoryFilter
+        ? history.filter(h => getErrorCategory(h) === categoryFilter)
+        : history;
+
     // 규칙 결과 통계 계산
     const getResultStats = (results: RuleResult[]) => {
         if (!results) return { total: 0, success: 0, fail: 0 };
@@ -127,13 +181,27 @@ const HistoryTab = forwardRef<HistoryTabRef>((_, ref) => {
                         전체 삭제
                     </button>
                 </div>
+                <div style={{ marginBottom: '12px' }}>
+                    <select
+                        className="select"
+                        value={categoryFilter}
+                        onChange={(e) => setCategoryFilter(e.target.value)}
+                    >
+                        <option value="">전체 ({history.length})</option>
+                        {Object.keys(errorCategoryLabels).map((category) => (
+                            <option key={category} value={category}>
+                                {errorCategoryLabels[category]} ({categoryCounts[category] || 0})
+                            </option>
+                        ))}
+                    </select>
+                </div>
                 <ul className="list">
-                    {history.length === 0 ? (
+                    {filteredHistory.length === 0 ? (
                         <li className="list-item" style={{ color: '#666' }}>
                             배포 이력이 없습니다
                         </li>
                     ) : (
-                        history.map((h) => (
+                        filteredHistory.map((h) => (
                             <li
                                 key={h.id}
                                 className={`list-item ${selectedHistory?.id === h.id ? 'active' : ''}`}
@@ -144,6 +212,9 @@ const HistoryTab = forwardRef<HistoryTabRef>((_, ref) => {
                                         <div style={{ fontWeight: 500 }}>{h.deviceIp}</div>
                                         <div style={{ fontSize: '0.8rem', color: '#888' }}>
                                             {formatDate(h.timestamp)}
+                                            {h.wave ? ` · 웨이브 ${getWaveText(h.wave)}` : ''}
+                                            {h.rollbackOf ? ` · 자동 롤백 (#${h.rollbackOf})` : ''}
+                                            {getErrorCategory(h) ? ` · ${errorCategoryLabels[getErrorCategory(h)] || getErrorCategory(h)}` : ''}
                                         </div>
                                     </div>
                                     {getStatusBadge(h.status)}
@@ -171,6 +242,18 @@ const HistoryTab = forwardRef<HistoryTabRef>((_, ref) => {
                                         <th>템플릿 버전</th>
                                         <td>{selectedHistory.templateVersion}</td>
                                     </tr>
+                                    {selectedHistory.revision && (
+                                        <tr>
+                                            <th>리비전</th>
+                                            <td title={selectedHistory.revision}>{shortHash(selectedHistory.revision)}</td>
+                                        </tr>
+                                    )}
+                                    {selectedHistory.includes && selectedHistory.includes.length > 0 && (
+                                        <tr>
+                                            <th>포함 템플릿</th>
+                                            <td title={selectedHistory.includes.join('\n')}>{selectedHistory.includes.map(includeRefText).join(', ')}</td>
+                                        </tr>
+                                    )}
                                     <tr>
                                         <th>배포 시간</th>
                                         <td>{formatDate(selectedHistory.timestamp)}</td>
@@ -179,6 +262,24 @@ const HistoryTab = forwardRef<HistoryTabRef>((_, ref) => {
                                         <th>상태</th>
                                         <td>{getStatusBadge(selectedHistory.status)}</td>
                                     </tr>
+                                    {getErrorCategory(selectedHistory) ? (
+                                        <tr>
+                                            <th>실패 원인</th>
+                                            <td>{errorCategoryLabels[getErrorCategory(selectedHistory)] || getErrorCategory(selectedHistory)}</td>
+                                        </tr>
+                                    ) : null}
+                                    {selectedHistory.rollbackOf ? (
+                                        <tr>
+                                            <th>자동 롤백</th>
+                                            <td>이력 #{selectedHistory.rollbackOf} 실패로 인한 이전 버전 재배포</td>
+                                        </tr>
+                                    ) : null}
+                                    {selectedHistory.wave ? (
+                                        <tr>
+                                            <th>웨이브</th>
+                                            <td>{getWaveText(selectedHistory.wave)} ({selectedHistory.rolloutId})</td>
+                                        </tr>
+                                    ) : null}
                                     <tr>
                                         <th>결과</th>
                                         <td>
@@ -0,0 +1,268 @@
+import { useState, useEffect, forwardRef, useImperativeHandle } from 'react';
+import {
+    GetAllObjects,
+    SaveObject,
+    DeleteObject,
+    GetObjectUsage,
+    GetObjectKindOptions,
+    ConfirmDialog
+} from '../../wailsjs/go/main/App';
+
+// Go model.NamedObject와 동일한 구조
+interface NamedObject {
+    name: string;
+    kind: string;        // address/service
+    members: string[];
+    description?: string;
+}
+
+// 객체 종류 표시 텍스트
+const kindLabels: Record<string, string> = {
+    address: '주소',
+    service: '서비스'
+};
+
+// 종류별 멤버 입력 예시
+const memberPlaceholders: Record<string, string> = {
+    address: '한 줄에 하나씩 입력\n10.0.0.1\n10.0.0.0/24\n10.0.0.10-10.0.0.20\n2001:db8::/64',
+    service: '한 줄에 하나씩 입력\ntcp/443\nudp/53\ntcp/8000:8100'
+};
+
+export interface ObjectTabRef {
+    refresh: () => void;
+}
+
+const ObjectTab = forwardRef<ObjectTabRef>((_, ref) => {
+    const [objects, setObjects] = useState<NamedObject[]>([]);
+    const [kindOptions, setKindOptions] = useState<string[]>(['address', 'service']);
+    const [selectedName, setSelectedName] = useState('');
+    const [isNew, setIsNew] = useState(false);
+    const [name, setName] = useState('');
+    const [kind, setKind] = useState('address');
+    const [members, setMembers] = useState('');
+    const [description, setDescription] = useState('');
+    const [usage, setUsage] = useState<string[]>([]);
+
+    useEffect(() => {
+        loadObjects();
+        GetObjectKindOptions().then((options) => {
+            if (options && options.length > 0) {
+                setKindOptions(options);
+            }
+        });
+    }, []);
+
+    const loadObjects = async () => {
+        const data = await GetAllObjects();
+        setObjects((data || []) as NamedObject[]);
+    };
+
+    const resetForm = () => {
+        setSelectedName('');
+        setIsNew(false);
+        setName('');
+        setKind('address');
+        setMembers('');
+        setDescription('');
+        setUsage([]);
+    };
+
+    // 부모 컴포넌트에서 호출할 수 있도록 refresh 메서드 노출
+    useImperativeHandle(ref, () => ({
+        refresh: () => {
+            loadObjects();
+            resetForm();
+        }
+    }));
+
+    const handleSelect = async (obj: NamedObject) => {
+        setSelectedName(obj.name);
+        setIsNew(false);
+        setName(obj.name);
+        setKind(obj.kind);
+        setMembers((obj.members || []).join('\n'));
+        setDescription(obj.description || '');
+        setUsage((await GetObjectUsage(obj.name)) || []);
+    };
+
+    const handleNew = () => {
+        resetForm();
+        setIsNew(true);
+    };
+
+    const handleSave = async () => {
+        const object: NamedObject = {
+            name: name.trim(),
+            kind,
+            members: members.split('\n').map(m => m.trim()).filter(m => m !== ''),
+            description: description.trim()
+        };
+        if (!object.name) {
+            alert('객체 이름을 입력하세요.');
+            return;
+        }
+        // 이름을 바꾸면 새 객체로 저장되므로 기존 객체가 사용 중이면 막음
+        if (!isNew && selectedName && selectedName !== object.name && usage.length > 0) {
+            alert(`@${selectedName}를 사용하는 템플릿이 있어 이름을 바꿀 수 없습니다: ${usage.join(', ')}`);
+            return;
+        }
+
+        try {
+            await SaveObject(JSON.stringify(object));
+            if (!isNew && selectedName && selectedName !== object.name) {
+                await DeleteObject(selectedName);
+            }
+            await loadObjects();
+            await handleSelect(object);
+            alert('객체가 저장되었습니다.');
+        } catch (err) {
+            console.error('객체 저장 실패:', err);
+            alert(`객체 저장 실패: ${err}`);
+        }
+    };
+
+    const handleDelete = async () => {
+        if (!selectedName) {
+            alert('삭제할 객체가 선택되지 않았습니다.');
+            return;
+        }
+        const result = await ConfirmDialog('삭제 확인', `"@${selectedName}" 객체를 삭제하시겠습니까?`);
+        if (result !== '확인' && result !== 'Yes' && result !== '예') {
+            return;
+        }
+
+        try {
+            await DeleteObject(selectedName);
+            await loadObjects();
+            resetForm();
+            alert('객체가 삭제되었습니다.');
+        } catch (err) {
+            console.error('객체 삭제 실패:', err);
+            alert(`객체 삭제 실패: ${err}`);
+        }
+    };
+
+    return (
+        <div className="split-layout">
+            {/* 왼쪽: 객체 목록 */}
+            <div className="card">
+                <div style={{ display: 'flex', justifyContent: 'space-between', alignItems: 'center', marginBottom: '16px' }}>
+                    <div className="card-title" style={{ marginBottom: 0 }}>객체 목록</div>
+                    <button
+                        className="btn btn-primary"
+                        onClick={handleNew}
+                        style={{ padding: '4px 12px', fontSize: '0.85rem' }}
+                    >
+                        새 객체
+                    </button>
+                </div>
+                <ul className="list">
+                    {objects.length === 0 ? (
+                        <li className="list-item" style={{ color: '#666' }}>
+                            객체가 없습니다
+                        </li>
+                    ) : (
+                        objects.map((obj) => (
+                            <li
+                                key={obj.name}
+                                className={`list-item ${selectedName === obj.name ? 'active' : ''}`}
+                                onClick={() => handleSelect(obj)}
+                            >
+                                <div style={{ display: 'flex', justifyContent: 'space-between', alignItems: 'center' }}>
+                                    <div>
+                                        <div style={{ fontWeight: 500 }}>@{obj.name}</div>
+                                        <div style={{ fontSize: '0.8rem', color: '#888' }}>
+                                            {(obj.members || []).length}개 멤버
+                                            {obj.description ? ` · ${obj.description}` : ''}
+                                        </div>
+                                    </div>
+                                    <span className="badge badge-info">{kindLabels[obj.kind] || obj.kind}</span>
+                                </div>
+                            </li>
+                        ))
+                    )}
+                </ul>
+            </div>
+
+            {/* 오른쪽: 객체 편집 */}
+            <div className="card">
+                {(selectedName || isNew) ? (
+                    <>
+                        <div className="card-title">{isNew ? '새 객체' : `@${selectedName}`}</div>
+                        <div className="form-group">
+                            <label>이름</label>
+                            <input
+                                type="text"
+                                className="input"
+                                value={name}
+                                onChange={(e) => setName(e.target.value)}
+                                placeholder="예: monitoring (템플릿에서 @monitoring으로 참조)"
+                            />
+                        </div>
+                        <div className="form-group">
+                            <label>종류</label>
+                            <select
+                                className="select"
+                                value={kind}
+                                onChange={(e) => setKind(e.target.value)}
+                            >
+                                {kindOptions.map((k) => (
+                                    <option key={k} value={k}>{kindLabels[k] || k}</option>
+                                ))}
+                            </select>
+                        </div>
+                        <div className="form-group">
+                            <label>멤버</label>
+                            <textarea
+                                className="textarea"
+                                value={members}
+                                onChange={(e) => setMembers(e.target.value)}
+                                placeholder={memberPlaceholders[kind]}
+                                style={{ minHeight: '160px' }}
+                            />
+                        </div>
+                        <div className="form-group">
+                            <label>설명</label>
+                            <input
+                                type="text"
+                                className="input"
+                                value={description}
+                                onChange={(e) => setDescription(e.target.value)}
+                                placeholder="선택 입력"
+                            />
+                        </div>
+                        {!isNew && (
+                            <div className="form-group">
+                                <label>사용 중인 템플릿</label>
+                                <div style={{ fontSize: '0.85rem', color: '#888' }}>
+                                    {usage.length > 0 ? usage.join(', ') : '없음'}
+                                </div>
+                            </div>
+                        )}
+                        <div style={{ display: 'flex', gap: '8px', marginTop: '20px' }}>
+                            <button className="btn btn-primary" onClick={handleSave}>
+                                저장
+                            </button>
+                            {!isNew && (
+                                <button
+                                    className="btn btn-danger"
+                                    onClick={handleDelete}
+                                    disabled={usage.length > 0}
+                                >
+                                    삭제
+                                </button>
+                            )}
+                        </div>
+                    </>
+                ) : (
+                    <div className="empty-state">
+                        <div className="empty-state-icon">🏷️</div>
+                        <p>왼쪽에서 객체를 선택하거나 새 객체를 만드세요</p>
+                    </div>
+                )}
+            </div>
+        </div>
+    );
+});
+
+export default ObjectTab;
@@ -0,0 +1,173 @@
+import { useState } from 'react';
+import { SimulatePacket } from '../../wailsjs/go/main/App';
+import { model } from '../../wailsjs/go/models';
+import { CHAIN_INPUT, CHAIN_OUTPUT, CHAIN_FORWARD, CHAIN_NAMES } from '../constants/ruleConstants';
+
+// 패킷 프로토콜 (Go model.Protocol 값)
+const PACKET_PROTOCOLS: Record<string, number> = {
+    tcp: 6,
+    udp: 17,
+    icmp: 1,
+};
+
+// 연결 상태 (Go model.GetConnStateOptions 값)
+const CONN_STATES = ['NEW', 'ESTABLISHED', 'RELATED', 'INVALID'];
+
+// 추적 단계 이름
+const STAGE_NAMES: Record<string, string> = {
+    dnat: 'DNAT',
+    white: 'White',
+    black: 'Black',
+    filter: '필터',
+};
+
+interface PacketTesterProps {
+    contents: string;
+}
+
+// 패킷 테스트: 템플릿이 패킷을 어떻게 처리하는지 장비 없이 확인
+const PacketTester = ({ contents }: PacketTesterProps) => {
+    const [chain, setChain] = useState(CHAIN_INPUT);
+    const [protocol, setProtocol] = useState('tcp');
+    const [sip, setSip] = useState('');
+    const [dip, setDip] = useState('');
+    const [dport, setDport] = useState('');
+    const [tcpFlags, setTcpFlags] = useState('syn');
+    const [icmpType, setIcmpType] = useState('echo-request');
+    const [inInterface, setInInterface] = useState('');
+    const [outInterface, setOutInterface] = useState('');
+    const [sport, setSport] = useState('');
+    const [state, setState] = useState('NEW');
+    const [result, setResult] = useState<model.SimulationResult | null>(null);
+
+    const handleTest = async () => {
+        const packet = new model.Packet();
+        packet.chain = chain;
+        packet.protocol = PACKET_PROTOCOLS[protocol];
+        packet.sip = sip.trim();
+        packet.dip = dip.trim();
+        packet.dport = parseInt(dport) || 0;
+        packet.sport = parseInt(sport) || 0;
+        packet.tcpFlags = protocol === 'tcp' ? tcpFlags.trim() : '';
+        packet.icmpType = protocol === 'icmp' ? icmpType.trim() : '';
+        packet.icmpCode = '';
+        packet.inInterface = inInterface.trim();
+        packet.outInterface = outInterface.trim();
+        packet.state = state;
+
+        try {
+            setResult(await SimulatePacket(contents, packet));
+        } catch (err) {
+            setResult(null);
+            alert(`패킷 테스트 실패: ${err}`);
+        }
+    };
+
+    const verdictColor = (verdict: string) => (verdict === 'ACCEPT' ? '#27ae60' : '#e74c3c');
+
+    return (
+        <div className="rule-builder-container">
+            <div className="rule-form">
+                <div className="rule-form-header">
+                    <span className="rule-form-title">패킷 테스트</span>
+                    <div className="rule-form-header-buttons">
+                        <button className="btn btn-primary btn-sm" onClick={handleTest}>
+                            테스트
+                        </button>
+                    </div>
+                </div>
+
+                <div className="rule-form-grid">
+                    <div className="rule-form-group">
+                        <label>Chain</label>
+                        <select className="select" value={chain} onChange={(e) => setChain(parseInt(e.target.value))}>
+                            {[CHAIN_INPUT, CHAIN_OUTPUT, CHAIN_FORWARD].map((c) => (
+                                <option key={c} value={c}>{CHAIN_NAMES[c]}</option>
+                            ))}
+                        </select>
+                    </div>
+                    <div className="rule-form-group">
+                        <label>Protocol</label>
+                        <select className="select" value={protocol} onChange={(e) => setProtocol(e.target.value)}>
+                            {Object.keys(PACKET_PROTOCOLS).map((p) => (
+                                <option key={p} value={p}>{p}</option>
+                            ))}
+                        </select>
+                    </div>
+                    <div className="rule-form-group">
+                        <label>SIP</label>
+                        <input type="text" className="input" value={sip} onChange={(e) => setSip(e.target.value)} placeholder="예: 10.1.2.3" />
+                    </div>
+                    <div className="rule-form-group">
+                        <label>DIP</label>
+                        <input type="text" className="input" value={dip} onChange={(e) => setDip(e.target.value)} placeholder="예: 192.168.0.5" />
+                    </div>
+                    {protocol !== 'icmp' && (
+                        <div className="rule-form-group">
+                            <label>DPort</label>
+                            <input type="text" className="input" value={dport} onChange={(e) => setDport(e.target.value)} placeholder="예: 443" />
+                        </div>
+                    )}
+                    {protocol !== 'icmp' && (
+                        <div className="rule-form-group">
+                            <label>SPort</label>
+                            <input type="text" className="input" value={sport} onChange={(e) => setSport(e.target.value)} placeholder="예: 53 (비우면 조건 없음)" />
+                        </div>
+                    )}
+                    {protocol === 'tcp' && (
+                        <div className="rule-form-group">
+                            <label>TCP Flags</label>
+                            <input type="text" className="input" value={tcpFlags} onChange={(e) => setTcpFlags(e.target.value)} placeholder="예: syn 또는 syn,ack" />
+                        </div>
+                    )}
+                    {protocol === 'icmp' && (
+                        <div className="rule-form-group">
+                            <label>ICMP Type</label>
+                            <input type="text" className="input" value={icmpType} onChange={(e) => setIcmpType(e.target.value)} placeholder="예: echo-request 또는 8" />
+                        </div>
+                    )}
+                    <div className="rule-form-group">
+                        <label>InIF</label>
+                        <input type="text" className="input" value={inInterface} onChange={(e) => setInInterface(e.target.value)} placeholder="예: eth0 (DNAT 조건)" />
+                    </div>
+                    <div className="rule-form-group">
+                        <label>OutIF</label>
+                        <input type="text" className="input" value={outInterface} onChange={(e) => setOutInterface(e.target.value)} placeholder="예: eth1" />
+                    </div>
+                    <div className="rule-form-group">
+                        <label>State</label>
+                        <select className="select" value={state} onChange={(e) => setState(e.target.value)}>
+                            {CONN_STATES.map((s) => (
+                                <option key={s} value={s}>{s}</option>
+                            ))}
+                        </select>
+                    </div>
+                </div>
+            </div>
+
+            {result && (
+                <div className="protocol-options" style={{ marginTop: '16px', borderColor: verdictColor(result.verdict) }}>
+                    <div className="protocol-options-title" style={{ color: verdictColor(result.verdict) }}>
+                        결과: {result.verdict}
+                        {result.matchedLine > 0 ? ` (라인 ${result.matchedLine})` : ' (일치하는 규칙 없음, 기본 정책)'}
+                    </div>
+                    {result.matchedRule && <p style={{ fontSize: '0.8rem' }}><code>{result.matchedRule}</code></p>}
+                    {result.translated && (
+                        <p style={{ fontSize: '0.8rem' }}>
+                            DNAT 적용 후 목적지: {result.packet.dip}{result.packet.protocol !== PACKET_PROTOCOLS.icmp ? `:${result.packet.dport}` : ''}
+                        </p>
+                    )}
+                    <ul style={{ fontSize: '0.8rem', paddingLeft: '20px' }}>
+                        {(result.trace || []).map((step, i) => (
+                            <li key={i} style={{ color: step.matched ? verdictColor(result.verdict) : undefined }}>
+                                [{STAGE_NAMES[step.stage] || step.stage}] 라인 {step.line}: {step.reason}
+                            </li>
+                        ))}
+                    </ul>
+                </div>
+            )}
+        </div>
+    );
+};
+
+export default PacketTester;
@@ -0,0 +1,199 @@
+import { useState, useEffect } from 'react';
+import {
+    GetTemplateRevisions,
+    CompareTemplateRevisions,
+    RestoreTemplateRevision,
+    ConfirmDialog
+} from '../../wailsjs/go/main/App';
+
+// Go model.TemplateRevision과 동일한 구조
+interface TemplateRevision {
+    hash: string;
+    version: string;
+    number: number;
+    contents: string;
+    createdAt: string;
+    author?: string;
+    note?: string;
+    parent?: string;
+}
+
+// Go model.DiffLine과 동일한 구조
+interface DiffLine {
+    type: string;       // same/added/removed
+    text: string;
+    oldLine: number;
+    newLine: number;
+}
+
+interface RevisionHistoryProps {
+    version: string;
+    onRestored: () => void;
+}
+
+// 화면 표시용으로 줄인 리비전 해시 (model.ShortHash와 동일)
+export const shortHash = (hash?: string) => (hash || '').slice(0, 12);
+
+// 포함 기록(버전@해시)을 "버전@짧은해시"로 표시 (model.IncludeRefText와 동일)
+export const includeRefText = (ref: string) => {
+    const i = ref.lastIndexOf('@');
+    return i === -1 ? ref : `${ref.slice(0, i)}@${shortHash(ref.slice(i + 1))}`;
+};
+
+const diffColors: Record<string, string> = {
+    added: '#27ae60',
+    removed: '#e74c3c',
+};
+
+// 리비전 이력: 저장할 때마다 기록된 리비전을 조회, 비교, 복원
+const RevisionHistory = ({ version, onRestored }: RevisionHistoryProps) => {
+    const [revisions, setRevisions] = useState<TemplateRevision[]>([]);
+    const [viewing, setViewing] = useState<TemplateRevision | null>(null);
+    const [fromHash, setFromHash] = useState('');
+    const [toHash, setToHash] = useState('');
+    const [diff, setDiff] = useState<DiffLine[] | null>(null);
+
+    useEffect(() => {
+        loadRevisions();
+    }, [version]);
+
+    const loadRevisions = async () => {
+        const data = ((await GetTemplateRevisions(version)) || []) as TemplateRevision[];
+        setRevisions(data);
+        setViewing(null);
+        setDiff(null);
+        // 기본 비교: 직전 리비전 → 최신 리비전
+        setToHash(data.length > 0 ? data[0].hash : '');
+        setFromHash(data.length > 1 ? data[1].hash : '');
+    };
+
+    const handleCompare = async (from: string, to: string) => {
+        if (!from || !to) {
+            alert('비교할 리비전을 선택하세요.');
+            return;
+        }
+        try {
+            setDiff(((await CompareTemplateRevisions(version, from, to)) || []) as DiffLine[]);
+            setViewing(null);
+            setFromHash(from);
+            setToHash(to);
+        } catch (err) {
+            alert(`리비전 비교 실패: ${err}`);
+        }
+    };
+
+    const handleRestore = async (revision: TemplateRevision) => {
+        const result = await ConfirmDialog(
+            '복원 확인',
+            `r${revision.number} (${shortHash(revision.hash)})의 내용을 새 리비전으로 저장하시겠습니까?`
+        );
+        if (result !== '확인' && result !== 'Yes' && result !== '예') {
+            return;
+        }
+        try {
+            await RestoreTemplateRevision(version, revision.hash);
+            await loadRevisions();
+            onRestored();
+            alert('리비전이 복원되었습니다.');
+        } catch (err) {
+            alert(`리비전 복원 실패: ${err}`);
+        }
+    };
+
+    const revisionLabel = (r: TemplateRevision) => `r${r.number} (${shortHash(r.hash)})`;
+
+    if (revisions.length === 0) {
+        return (
+            <div className="empty-state">
+                <p>저장된 리비전이 없습니다. 템플릿을 저장하면 리비전이 기록됩니다.</p>
+            </div>
+        );
+    }
+
+    return (
+        <div className="form-group flex-grow" style={{ overflow: 'auto' }}>
+            <table className="table">
+                <thead>
+                    <tr>
+                        <th>리비전</th>
+                        <th>시간</th>
+                        <th>작성자</th>
+                        <th>메모</th>
+                        <th style={{ width: '170px' }}></th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {revisions.map((r, i) => (
+                        <tr key={`${r.number}-${r.hash}`}>
+                            <td title={r.hash}>
+                                {revisionLabel(r)}
+                                {i === 0 && <span className="badge badge-success" style={{ marginLeft: '6px' }}>현재</span>}
+                            </td>
+                            <td>{r.createdAt}</td>
+                            <td>{r.author || '-'}</td>
+                            <td>{r.note || '-'}</td>
+                            <td>
+                                <div style={{ display: 'flex', gap: '4px' }}>
+                                    <button className="btn btn-secondary btn-sm" onClick={() => { setViewing(r); setDiff(null); }}>
+                                        보기
+                                    </button>
+                                    {r.parent && (
+                                        <button className="btn btn-secondary btn-sm" onClick={() => handleCompare(r.parent!, r.hash)}>
+                                            변경
+                                        </button>
+                                    )}
+                                    {i > 0 && (
+                                        <button className="btn btn-primary btn-sm" onClick={() => handleRestore(r)}>
+                                            복원
+                                        </button>
+                                    )}
+                                </div>
+                            </td>
+                        </tr>
+                    ))}
+                </tbody>
+            </table>
+
+            <div style={{ display: 'flex', gap: '8px', alignItems: 'center', margin: '12px 0' }}>
+                <label style={{ marginBottom: 0 }}>비교</label>
+                <select className="select select-sm" value={fromHash} onChange={(e) => setFromHash(e.target.value)}>
+                    <option value="">선택</option>
+                    {revisions.map((r) => (
+                        <option key={`from-${r.number}`} value={r.hash}>{revisionLabel(r)}</option>
+                    ))}
+                </select>
+                <span>→</span>
+                <select className="select select-sm" value={toHash} onChange={(e) => setToHash(e.target.value)}>
+                    <option value="">선택</option>
+                    {revisions.map((r) => (
+                        <option key={`to-${r.number}`} value={r.hash}>{revisionLabel(r)}</option>
+                    ))}
+                </select>
+                <button className="btn btn-secondary btn-sm" onClick={() => handleCompare(fromHash, toHash)}>
+                    비교
+                </button>
+            </div>
+
+            {viewing && (
+                <>
+                    <label>{revisionLabel(viewing)} 내용</label>
+                    <textarea className="textarea" value={viewing.contents} readOnly style={{ minHeight: '200px' }} />
+                </>
+            )}
+
+            {diff && (
+                <pre className="textarea" style={{ minHeight: '200px', whiteSpace: 'pre', margin: 0 }}>
+                    {!diff.some((l) => l.type !== 'same') && '변경된 줄이 없습니다'}
+                    {diff.some((l) => l.type !== 'same') && diff.map((l, i) => (
+                        <div key={i} style={{ color: diffColors[l.type] }}>
+                            {l.type === 'added' ? '+ ' : l.type === 'removed' ? '- ' : '  '}
+                            {l.text}
+                        </div>
+                    ))}
+                </pre>
+            )}
+        </div>
+    );
+};
+
+export default RevisionHistory;
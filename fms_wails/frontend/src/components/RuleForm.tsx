@@ -6,13 +6,17 @@ import {
     GetActionOptions,
     GetTCPFlagsPresets,
     GetTCPFlagsList,
-    GetICMPTypeOptions
+    GetICMPTypeOptions,
+    GetICMPv6TypeOptions,
+    GetFamilyOptions,
+    GetConnStateOptions
 } from '../../wailsjs/go/main/App';
 import { TCP_FLAGS_HELP, ICMP_HELP } from '../constants/helpTexts';
 import {
     CHAIN_INPUT, CHAIN_OUTPUT, CHAIN_FORWARD,
     PROTOCOL_TCP, PROTOCOL_UDP, PROTOCOL_ICMP, PROTOCOL_ANY,
     ACTION_DROP, ACTION_ACCEPT,
+    FAMILY_IPV4, FAMILY_IPV6,
 } from '../constants/ruleConstants';
 
 // 문자열을 Chain 값으로 변환
@@ -61,14 +65,22 @@ const RuleForm = ({ onAdd, editRule, editIndex, onUpdate, onCancel }: RuleFormPr
     const [tcpFlagsPresets, setTcpFlagsPresets] = useState<model.TCPFlagsPreset[]>([]);
     const [tcpFlagsList, setTcpFlagsList] = useState<string[]>([]);
     const [icmpTypeOptions, setIcmpTypeOptions] = useState<string[]>([]);
+    const [icmpv6TypeOptions, setIcmpv6TypeOptions] = useState<string[]>([]);
+    const [familyOptions, setFamilyOptions] = useState<string[]>([]);
+    const [stateOptions, setStateOptions] = useState<string[]>([]);
 
     // 폼 상태
     const [chain, setChain] = useState('INPUT');
     const [protocol, setProtocol] = useState('tcp');
     const [action, setAction] = useState('DROP');
+    const [family, setFamily] = useState('ipv4');
     const [dport, setDport] = useState('');
     const [sip, setSip] = useState('');
     const [dip, setDip] = useState('');
+    const [sport, setSport] = useState('');
+    const [inInterface, setInInterface] = useState('');
+    const [outInterface, setOutInterface] = useState('');
+    const [states, setStates] = useState<string[]>([]);
 
     // TCP Flags 상태
     const [tcpFlagsPreset, setTcpFlagsPreset] = useState('None');
@@ -85,13 +97,16 @@ const RuleForm = ({ onAdd, editRule, editIndex, onUpdate, onCancel }: RuleFormPr
     // 옵션 로드
     useEffect(() => {
         const loadOptions = async () => {
-            const [chains, protocols, actions, presets, flags, types] = await Promise.all([
+            const [chains, protocols, actions, presets, flags, types, v6Types, families, connStates] = await Promise.all([
                 GetChainOptions(),
                 GetProtocolOptions(),
                 GetActionOptions(),
                 GetTCPFlagsPresets(),
                 GetTCPFlagsList(),
-                GetICMPTypeOptions()
+                GetICMPTypeOptions(),
+                GetICMPv6TypeOptions(),
+                GetFamilyOptions(),
+                GetConnStateOptions()
             ]);
             setChainOptions(chains);
             setProtocolOptions(protocols);
@@ -99,6 +114,9 @@ const RuleForm = ({ onAdd, editRule, editIndex, onUpdate, onCancel }: RuleFormPr
             setTcpFlagsPresets(presets);
             setTcpFlagsList(flags);
             setIcmpTypeOptions(types);
+            setIcmpv6TypeOptions(v6Types);
+            setFamilyOptions(families);
+            setStateOptions(connStates);
 
             // 플래그 초기화
             const initMask: Record<string, boolean> = {};
@@ -119,9 +137,14 @@ const RuleForm = ({ onAdd, editRule, editIndex, onUpdate, onCancel }: RuleFormPr
             setChain(chainOptions[editRule.chain] || 'INPUT');
             setProtocol(protocolOptions[editRule.protocol] || 'tcp');
             setAction(actionOptions[editRule.action] || 'DROP');
+            setFamily(familyOptions[editRule.family || FAMILY_IPV4] || 'ipv4');
             setDport(editRule.dport || '');
             setSip(editRule.sip || '');
             setDip(editRule.dip || '');
+            setSport(editRule.sport || '');
+            setInInterface(editRule.inInterface || '');
+            setOutInterface(editRule.outInterface || '');
+            setStates(editRule.state ? editRule.state.split(',') : []);
 
             // 프로토콜 옵션
             if (editRule.options?.tcpFlags) {
@@ -156,7 +179,7 @@ const RuleForm = ({ onAdd, editRule, editIndex, onUpdate, onCancel }: RuleFormPr
                 setIcmpType('None');
             }
         }
-    }, [editRule, chainOptions, protocolOptions, actionOptions, tcpFlagsPresets, tcpFlagsList]);
+    }, [editRule, chainOptions, protocolOptions, actionOptions, familyOptions, tcpFlagsPresets, tcpFlagsList]);
 
     // 플래그 초기화
     const resetFlags = () => {
@@ -175,9 +198,14 @@ const RuleForm = ({ onAdd, editRule, editIndex, onUpdate, onCancel }: RuleFormPr
         setChain('INPUT');
         setProtocol('tcp');
         setAction('DROP');
+        setFamily('ipv4');
         setDport('');
         setSip('');
         setDip('');
+        setSport('');
+        setInInterface('');
+        setOutInterface('');
+        setStates([]);
         setTcpFlagsPreset('None');
         resetFlags();
         setIcmpType('None');
@@ -223,6 +251,11 @@ const RuleForm = ({ onAdd, editRule, editIndex, onUpdate, onCancel }: RuleFormPr
         setTcpFlagsPreset('Custom');
     };
 
+    // 연결 상태 체크박스 변경
+    const handleStateChange = (state: string, checked: boolean) => {
+        setStates(prev => checked ? [...prev, state] : prev.filter(s => s !== state));
+    };
+
     // TCP flags 문자열 생성
     const getTCPFlags = (): string => {
         const maskArr = tcpFlagsList.filter(f => maskFlags[f]);
@@ -238,9 +271,15 @@ const RuleForm = ({ onAdd, editRule, editIndex, onUpdate, onCancel }: RuleFormPr
         rule.chain = stringToChain(chain);
         rule.protocol = stringToProtocol(protocol);
         rule.action = stringToAction(action);
+        rule.family = family === 'ipv6' ? FAMILY_IPV6 : FAMILY_IPV4;
         rule.dport = dport || undefined;
         rule.sip = sip || undefined;
         rule.dip = dip || undefined;
+        rule.sport = sport || undefined;
+        // INPUT은 출력 인터페이스, OUTPUT은 입력 인터페이스를 사용할 수 없음
+        rule.inInterface = (chain !== 'OUTPUT' && inInterface) || undefined;
+        rule.outInterface = (chain !== 'INPUT' && outInterface) || undefined;
+        rule.state = states.length > 0 ? stateOptions.filter(s => states.includes(s)).join(',') : undefined;
         rule.black = false;
         rule.white = false;
 
@@ -291,6 +330,8 @@ const RuleForm = ({ onAdd, editRule, editIndex, onUpdate, onCancel }: RuleFormPr
     const showIcmpOptions = protocol === 'icmp';
     const tcpOptionsEnabled = protocol === 'tcp';
     const portEnabled = protocol !== 'icmp';
+    // IPv6 규칙은 ICMPv6 type 목록 사용
+    const icmpTypeChoices = family === 'ipv6' ? icmpv6TypeOptions : icmpTypeOptions;
 
     return (
         <div className="rule-form">
@@ -309,7 +350,7 @@ const RuleForm = ({ onAdd, editRule, editIndex, onUpdate, onCancel }: RuleFormPr
                 </div>
             </div>
 
-            {/* 첫 번째 행: Chain, Proto, Action, Port */}
+            {/* 첫 번째 행: Chain, Proto, Family, Action, Port */}
             <div className="rule-form-row">
                 <div className="rule-form-field">
                     <label>Chain:</label>
@@ -336,6 +377,21 @@ const RuleForm = ({ onAdd, editRule, editIndex, onUpdate, onCancel }: RuleFormPr
                         ))}
                     </select>
                 </div>
+                <div className="rule-form-field">
+                    <label>Family:</label>
+                    <select
+                        className="select select-sm"
+                        value={family}
+                        onChange={(e) => {
+                            setFamily(e.target.value);
+                            setIcmpType('None');
+                        }}
+                    >
+                        {familyOptions.map((opt) => (
+                            <option key={opt} value={opt}>{opt}</option>
+                        ))}
+                    </select>
+                </div>
                 <div className="rule-form-field">
                     <label>Action:</label>
                     <select className="select select-sm" value={action} onChange={(e) => setAction(e.target.value)}>
@@ -355,6 +411,17 @@ const RuleForm = ({ onAdd, editRule, editIndex, onUpdate, onCancel }: RuleFormPr
                         disabled={!portEnabled}
                     />
                 </div>
+                <div className="rule-form-field">
+                    <label>SPort:</label>
+                    <input
+                        type="text"
+                        className="input input-sm"
+                        value={sport}
+                        onChange={(e) => setSport(e.target.value)}
+                        placeholder="출발지 포트"
+                        disabled={!portEnabled}
+                    />
+                </div>
             </div>
 
             {/* 두 번째 행: SIP, DIP */}
@@ -383,6 +450,45 @@ const RuleForm = ({ onAdd, editRule, editIndex, onUpdate, onCancel }: RuleFormPr
                 </div>
             </div>
 
+            {/* 세 번째 행: 입력/출력 인터페이스, 연결 상태 */}
+            <div className="rule-form-row">
+                <div className="rule-form-field">
+                    <label>InIF:</label>
+                    <input
+                        type="text"
+                        className="input input-sm"
+                        value={inInterface}
+                        onChange={(e) => setInInterface(e.target.value)}
+                        placeholder="예: eth0"
+                        disabled={chain === 'OUTPUT'}
+                    />
+                </div>
+                <div className="rule-form-field">
+                    <label>OutIF:</label>
+                    <input
+                        type="text"
+                        className="input input-sm"
+                        value={outInterface}
+                        onChange={(e) => setOutInterface(e.target.value)}
+                        placeholder="예: eth1"
+                        disabled={chain === 'INPUT'}
+                    />
+                </div>
+                <div className="rule-form-field">
+                    <label>State:</label>
+                </div>
+                {stateOptions.map(state => (
+                    <label key={`state-${state}`} className="checkbox-inline">
+                        <input
+                            type="checkbox"
+                            checked={states.includes(state)}
+                            onChange={(e) => handleStateChange(state, e.target.checked)}
+                        />
+                        {state}
+                    </label>
+                ))}
+            </div>
+
             {/* TCP Flags 옵션 (tcp, udp, any 모두 표시, tcp만 활성화) */}
             {showTcpOptions && (
                 <div className={`tcp-flags-section ${!tcpOptionsEnabled ? 'disabled' : ''}`}>
@@ -485,7 +591,7 @@ const RuleForm = ({ onAdd, editRule, editIndex, onUpdate, onCancel }: RuleFormPr
             {showIcmpOptions && (
                 <div className="icmp-section">
                     <div className="tcp-flags-header">
-                        <span>ICMP Options</span>
+                        <span>{family === 'ipv6' ? 'ICMPv6 Options' : 'ICMP Options'}</span>
                         <button
                             className="help-btn-inline"
                             onClick={() => setShowIcmpHelp(!showIcmpHelp)}
@@ -520,7 +626,7 @@ const RuleForm = ({ onAdd, editRule, editIndex, onUpdate, onCancel }: RuleFormPr
                                 onChange={(e) => setIcmpType(e.target.value)}
                                 style={{ width: '200px' }}
                             >
-                                {icmpTypeOptions.map((opt) => (
+                                {icmpTypeChoices.map((opt) => (
                                     <option key={opt} value={opt}>{opt}</option>
                                 ))}
                             </select>
@@ -34,6 +34,12 @@ const formatOptions = (rule: model.FirewallRule): string => {
     return parts.join(', ');
 };
 
+// 인터페이스 조건 문자열 생성 (입력 -> 출력)
+const formatInterfaces = (rule: model.FirewallRule): string => {
+    if (!rule.inInterface && !rule.outInterface) return '';
+    return `${rule.inInterface || '*'} → ${rule.outInterface || '*'}`;
+};
+
 interface RuleTableProps {
     rules: model.FirewallRule[];
     onDelete: (index: number) => void;
@@ -52,8 +58,11 @@ const RuleTable = ({ rules, onDelete, onEdit }: RuleTableProps) => {
                         <th style={{ width: '150px' }}>옵션</th>
                         <th style={{ width: '80px' }}>Action</th>
                         <th style={{ width: '80px' }}>DPort</th>
+                        <th style={{ width: '80px' }}>SPort</th>
                         <th style={{ width: '120px' }}>SIP</th>
                         <th style={{ width: '120px' }}>DIP</th>
+                        <th style={{ width: '100px' }}>IF</th>
+                        <th style={{ width: '100px' }}>State</th>
                         <th style={{ width: '60px' }}>Black</th>
                         <th style={{ width: '60px' }}>White</th>
                     </tr>
@@ -61,7 +70,7 @@ const RuleTable = ({ rules, onDelete, onEdit }: RuleTableProps) => {
                 <tbody>
                     {rules.length === 0 ? (
                         <tr>
-                            <td colSpan={10} style={{ textAlign: 'center', color: '#666', padding: '20px' }}>
+                            <td colSpan={13} style={{ textAlign: 'center', color: '#666', padding: '20px' }}>
                                 규칙이 없습니다. 아래에서 규칙을 추가하세요.
                             </td>
                         </tr>
@@ -95,8 +104,11 @@ const RuleTable = ({ rules, onDelete, onEdit }: RuleTableProps) => {
                                     </span>
                                 </td>
                                 <td>{rule.dport || '-'}</td>
+                                <td>{rule.sport || '-'}</td>
                                 <td className="ip-cell" title={rule.sip || ''}>{rule.sip || '-'}</td>
                                 <td className="ip-cell" title={rule.dip || ''}>{rule.dip || '-'}</td>
+                                <td>{formatInterfaces(rule) || '-'}</td>
+                                <td className="options-cell" title={rule.state || ''}>{rule.state || '-'}</td>
                                 <td>{rule.black ? 'Y' : '-'}</td>
                                 <td>{rule.white ? 'Y' : '-'}</td>
                             </tr>
@@ -1,4 +1,4 @@
-import { useState, useEffect, forwardRef, useImperativeHandle } from 'react';
+import { useState, useEffect, useRef, forwardRef, useImperativeHandle } from 'react';
 import {
     GetAllTemplates,
     GetTemplate,
@@ -8,7 +8,10 @@ import {
     ParseRules,
     RulesToText,
     ParseNATRules,
-    NATRulesToText
+    NATRulesToText,
+    SyncTemplateText,
+    ImportRuleset,
+    ExportTemplateAs
 } from '../../wailsjs/go/main/App';
 import { model } from '../../wailsjs/go/models';
 import RuleTable from './RuleTable';
@@ -17,15 +20,20 @@ import BlackWhiteForm from './BlackWhiteForm';
 import NATTable from './NATTable';
 import DNATForm from './DNATForm';
 import SNATForm from './SNATForm';
+import DiagnosticList, { formatDiagnostic } from './DiagnosticList';
+import PacketTester from './PacketTester';
+import EffectiveTemplate from './EffectiveTemplate';
+import RevisionHistory from './RevisionHistory';
 
 interface Template {
     version: string;
     contents: string;
 }
 
-type SubTabType = 'text' | 'builder' | 'nat';
+type SubTabType = 'text' | 'builder' | 'nat' | 'packet' | 'effective' | 'revisions';
 type NATFormType = 'dnat' | 'snat';
 type RuleFormType = 'general' | 'blackwhite';
+type ExportFormat = 'iptables' | 'ip6tables' | 'nftables' | 'smartfw';
 
 export interface TemplateTabRef {
     refresh: () => void;
@@ -36,6 +44,7 @@ const TemplateTab = forwardRef<TemplateTabRef>((_, ref) => {
     const [selectedVersion, setSelectedVersion] = useState<string>('');
     const [version, setVersion] = useState('');
     const [contents, setContents] = useState('');
+    const [note, setNote] = useState('');
     const [isNew, setIsNew] = useState(false);
     const [subTab, setSubTab] = useState<SubTabType>('text');
 
@@ -43,6 +52,7 @@ const TemplateTab = forwardRef<TemplateTabRef>((_, ref) => {
     const [rules, setRules] = useState<model.FirewallRule[]>([]);
     const [comments, setComments] = useState<string[]>([]);
     const [parseErrors, setParseErrors] = useState<string[]>([]);
+    const [diagnostics, setDiagnostics] = useState<model.Diagnostic[]>([]);
     const [editRule, setEditRule] = useState<model.FirewallRule | null>(null);
     const [editIndex, setEditIndex] = useState<number | undefined>(undefined);
 
@@ -55,10 +65,42 @@ const TemplateTab = forwardRef<TemplateTabRef>((_, ref) => {
     const [natFormType, setNatFormType] = useState<NATFormType>('dnat');
     const [ruleFormType, setRuleFormType] = useState<RuleFormType>('general');
 
+    // 규칙 가져오기 파일 입력 ref
+    const importInputRef = useRef<HTMLInputElement>(null);
+
+    // 내보내기 메뉴 열림 상태
+    const [exportMenuOpen, setExportMenuOpen] = useState(false);
+
     useEffect(() => {
         loadTemplates();
     }, []);
 
+    // 텍스트 편집 중 규칙 검증 (입력이 멈추면 실행)
+    useEffect(() => {
+        if (subTab !== 'text') {
+            return;
+        }
+        const timer = setTimeout(async () => {
+            if (!contents.trim()) {
+                setDiagnostics([]);
+                return;
+            }
+            const result = await ParseRules(contents);
+            setDiagnostics(result.diagnostics || []);
+        }, 300);
+        return () => clearTimeout(timer);
+    }, [contents, subTab]);
+
+    // 내보내기 메뉴 외부 클릭 시 닫기
+    useEffect(() => {
+        if (!exportMenuOpen) {
+            return;
+        }
+        const close = () => setExportMenuOpen(false);
+        document.addEventListener('click', close);
+        return () => document.removeEventListener('click', close);
+    }, [exportMenuOpen]);
+
     const loadTemplates = async () => {
         const data = await GetAllTemplates();
         setTemplates(data || []);
@@ -75,6 +117,7 @@ const TemplateTab = forwardRef<TemplateTabRef>((_, ref) => {
             setRules([]);
             setComments([]);
             setParseErrors([]);
+            setDiagnostics([]);
             setNatRules([]);
             setNatComments([]);
             setNatParseErrors([]);
@@ -88,6 +131,7 @@ const TemplateTab = forwardRef<TemplateTabRef>((_, ref) => {
             setRules([]);
             setComments([]);
             setParseErrors([]);
+            setDiagnostics([]);
             return;
         }
 
@@ -95,6 +139,7 @@ const TemplateTab = forwardRef<TemplateTabRef>((_, ref) => {
         setRules(result.rules || []);
         setComments(result.comments || []);
         setParseErrors(result.errors || []);
+        setDiagnostics(result.diagnostics || []);
     };
 
     // 규칙 → 텍스트 변환
@@ -130,30 +175,15 @@ const TemplateTab = forwardRef<TemplateTabRef>((_, ref) => {
         setContents(text);
     };
 
-    // 빌더 내용을 텍스트로 통합 (fyne의 syncBuildersToText와 동일)
+    // 빌더 내용을 텍스트에 반영 (fyne의 syncBuildersToText와 동일)
+    // 주석, 빈 줄, 규칙 순서를 유지하며 변경되지 않은 줄은 원문 그대로 유지
     const syncBuildersToText = async (): Promise<string> => {
-        // 필터 규칙 (주석 포함)
-        const filterText = await RulesToText(
+        const finalText = await SyncTemplateText(
+            contents,
             JSON.stringify(rules),
-            JSON.stringify(comments)
+            JSON.stringify(natRules)
         );
 
-        // NAT 규칙 (주석 제외 - 필터 규칙에서 이미 포함됨)
-        const natText = await NATRulesToText(
-            JSON.stringify(natRules),
-            JSON.stringify([])  // 빈 배열 - 주석 중복 방지
-        );
-
-        // 통합
-        let finalText = '';
-        if (filterText && natText) {
-            finalText = filterText + '\n' + natText;
-        } else if (filterText) {
-            finalText = filterText;
-        } else {
-            finalText = natText;
-        }
-
         setContents(finalText);
         return finalText;
     };
@@ -180,6 +210,13 @@ const TemplateTab = forwardRef<TemplateTabRef>((_, ref) => {
         } else if (tab === 'builder' && subTab === 'nat') {
             // NAT → 규칙 빌더로 전환: 현재 NAT 규칙 유지, 일반 규칙은 이미 있음
             // 별도 처리 불필요 (각 빌더가 독립적으로 규칙 유지)
+        } else if ((tab === 'packet' || tab === 'effective' || tab === 'revisions') && (subTab === 'builder' || subTab === 'nat')) {
+            // 빌더 → 패킷 테스트/유효 템플릿/리비전으로 전환: 양쪽 빌더 내용을 텍스트로 통합한 뒤 표시
+            await syncBuildersToText();
+        } else if ((tab === 'builder' || tab === 'nat') && (subTab === 'packet' || subTab === 'effective' || subTab === 'revisions')) {
+            // 패킷 테스트/유효 템플릿/리비전 → 빌더로 전환: 파싱
+            await parseContentsToRules(contents);
+            await parseContentsToNATRules(contents);
         }
         setSubTab(tab);
         setEditRule(null);
@@ -191,6 +228,7 @@ const TemplateTab = forwardRef<TemplateTabRef>((_, ref) => {
     const handleSelect = async (ver: string) => {
         setSelectedVersion(ver);
         setIsNew(false);
+        setNote('');
         const template = await GetTemplate(ver);
         if (template) {
             setVersion(template.version);
@@ -210,9 +248,14 @@ const TemplateTab = forwardRef<TemplateTabRef>((_, ref) => {
         setIsNew(true);
         setVersion('');
         setContents('');
+        setNote('');
+        if (subTab === 'revisions') {
+            setSubTab('text');
+        }
         setRules([]);
         setComments([]);
         setParseErrors([]);
+        setDiagnostics([]);
         setNatRules([]);
         setNatComments([]);
         setNatParseErrors([]);
@@ -222,6 +265,46 @@ const TemplateTab = forwardRef<TemplateTabRef>((_, ref) => {
         setEditNatIndex(undefined);
     };
 
+    // iptables-save 출력 또는 smartfw 덤프 파일을 새 템플릿으로 가져오기
+    const handleImport = () => {
+        importInputRef.current?.click();
+    };
+
+    const handleImportFile = async (e: React.ChangeEvent<HTMLInputElement>) => {
+        const file = e.target.files?.[0];
+        e.target.value = '';
+        if (!file) return;
+
+        try {
+            const result = await ImportRuleset(await file.text());
+            if (!result.text) {
+                alert('가져올 수 있는 규칙이 없습니다.');
+                return;
+            }
+
+            // 새 템플릿으로 열기 (버전 입력 후 저장)
+            handleNew();
+            setContents(result.text);
+            setSubTab('text');
+            await parseContentsToRules(result.text);
+            await parseContentsToNATRules(result.text);
+
+            const skipped = result.skipped || [];
+            let message = `필터 규칙 ${result.rules.length}개, NAT 규칙 ${result.natRules.length}개를 가져왔습니다.`;
+            if (skipped.length > 0) {
+                const lines = skipped.slice(0, 10).map((s) => `라인 ${s.line}: ${s.reason}`);
+                if (skipped.length > 10) {
+                    lines.push(`... 외 ${skipped.length - 10}개`);
+                }
+                message += `\n\n변환하지 못한 규칙 ${skipped.length}개:\n${lines.join('\n')}`;
+            }
+            alert(message);
+        } catch (err) {
+            console.error('규칙 가져오기 실패:', err);
+            alert(`규칙 가져오기 실패: ${err}`);
+        }
+    };
+
     const handleSave = async () => {
         if (!version.trim()) {
             alert('버전을 입력하세요.');
@@ -239,13 +322,53 @@ const TemplateTab = forwardRef<TemplateTabRef>((_, ref) => {
             return;
         }
 
-        await SaveTemplate(version, contentsToSave);
+        // 규칙 오류가 있으면 저장하지 않음
+        const result = await ParseRules(contentsToSave);
+        const saveDiagnostics = result.diagnostics || [];
+        setDiagnostics(saveDiagnostics);
+        const errors = saveDiagnostics.filter((d) => d.severity === 'error');
+        if (errors.length > 0) {
+            const lines = errors.slice(0, 5).map(formatDiagnostic);
+            if (errors.length > 5) {
+                lines.push(`... 외 ${errors.length - 5}개`);
+            }
+            alert(`규칙 오류 ${errors.length}개를 수정한 후 저장하세요.\n\n${lines.join('\n')}`);
+            return;
+        }
+
+        try {
+            await SaveTemplate(version, contentsToSave, note);
+        } catch (err) {
+            alert(`템플릿 저장 실패: ${err}`);
+            return;
+        }
         await loadTemplates();
         setSelectedVersion(version);
         setIsNew(false);
+        setNote('');
         alert('템플릿이 저장되었습니다.');
     };
 
+    // 현재 내용을 iptables-restore / ip6tables-restore / nftables 룰셋 / smartfw 요청 파일로 내보내기
+    const handleExport = async (format: ExportFormat) => {
+        setExportMenuOpen(false);
+
+        let contentsToExport = contents;
+        if (subTab === 'builder' || subTab === 'nat') {
+            contentsToExport = await syncBuildersToText();
+        }
+
+        try {
+            const filePath = await ExportTemplateAs(version.trim() || 'template', contentsToExport, format);
+            if (filePath) {
+                alert(`파일이 저장되었습니다.\n${filePath}`);
+            }
+        } catch (err) {
+            console.error('템플릿 내보내기 실패:', err);
+            alert(`템플릿 내보내기 실패: ${err}`);
+        }
+    };
+
     const handleDelete = async () => {
         if (!selectedVersion) {
             alert('삭제할 템플릿이 선택되지 않았습니다.');
@@ -266,6 +389,7 @@ const TemplateTab = forwardRef<TemplateTabRef>((_, ref) => {
             setRules([]);
             setComments([]);
             setParseErrors([]);
+            setDiagnostics([]);
             setNatRules([]);
             setNatComments([]);
             setNatParseErrors([]);
@@ -355,9 +479,18 @@ const TemplateTab = forwardRef<TemplateTabRef>((_, ref) => {
             {/* 왼쪽: 템플릿 목록 */}
             <div className="card">
                 <div className="card-title">템플릿 목록</div>
-                <button className="btn btn-primary" onClick={handleNew} style={{ width: '100%', marginBottom: '16px' }}>
+                <button className="btn btn-primary" onClick={handleNew} style={{ width: '100%', marginBottom: '8px' }}>
                     + 새 템플릿
                 </button>
+                <button className="btn btn-secondary" onClick={handleImport} style={{ width: '100%', marginBottom: '16px' }}>
+                    규칙 가져오기
+                </button>
+                <input
+                    type="file"
+                    ref={importInputRef}
+                    style={{ display: 'none' }}
+                    onChange={handleImportFile}
+                />
                 <ul className="list">
                     {templates.length === 0 ? (
                         <li className="list-item" style={{ color: '#666' }}>
@@ -390,10 +523,41 @@ const TemplateTab = forwardRef<TemplateTabRef>((_, ref) => {
                                 onChange={(e) => setVersion(e.target.value)}
                                 placeholder="예: v1.0.0"
                             />
+                            <input
+                                type="text"
+                                className="input template-version-input"
+                                value={note}
+                                onChange={(e) => setNote(e.target.value)}
+                                placeholder="변경 메모 (선택)"
+                            />
                             <div className="template-header-buttons">
                                 <button className="btn btn-primary btn-sm" onClick={handleSave}>
                                     저장
                                 </button>
+                                <div className="menu-item">
+                                    <button
+                                        className="btn btn-secondary btn-sm"
+                                        onClick={(e) => { e.stopPropagation(); setExportMenuOpen(!exportMenuOpen); }}
+                                    >
+                                        내보내기 ▾
+                                    </button>
+                                    {exportMenuOpen && (
+                                        <div className="menu-dropdown" style={{ left: 'auto', right: 0 }}>
+                                            <button className="menu-dropdown-item" onClick={() => handleExport('iptables')}>
+                                                iptables-restore (.rules)
+                                            </button>
+                                            <button className="menu-dropdown-item" onClick={() => handleExport('ip6tables')}>
+                                                ip6tables-restore (.v6.rules)
+                                            </button>
+                                            <button className="menu-dropdown-item" onClick={() => handleExport('nftables')}>
+                                                nftables (.nft)
+                                            </button>
+                                            <button className="menu-dropdown-item" onClick={() => handleExport('smartfw')}>
+                                                smartfw (.smartfw)
+                                            </button>
+                                        </div>
+                                    )}
+                                </div>
                                 {!isNew && (
                                     <button className="btn btn-danger btn-sm" onClick={handleDelete}>
                                         삭제
@@ -422,6 +586,26 @@ const TemplateTab = forwardRef<TemplateTabRef>((_, ref) => {
                             >
                                 NAT 규칙
                             </button>
+                            <button
+                                className={`sub-tab-btn ${subTab === 'packet' ? 'active' : ''}`}
+                                onClick={() => handleSubTabChange('packet')}
+                            >
+                                패킷 테스트
+                            </button>
+                            <button
+                                className={`sub-tab-btn ${subTab === 'effective' ? 'active' : ''}`}
+                                onClick={() => handleSubTabChange('effective')}
+                            >
+                                유효 템플릿
+                            </button>
+                            {!isNew && (
+                                <button
+                                    className={`sub-tab-btn ${subTab === 'revisions' ? 'active' : ''}`}
+                                    onClick={() => handleSubTabChange('revisions')}
+                                >
+                                    리비전
+                                </button>
+                            )}
                         </div>
 
                         {/* 텍스트 편집 탭 */}
@@ -434,6 +618,7 @@ const TemplateTab = forwardRef<TemplateTabRef>((_, ref) => {
                                     onChange={(e) => setContents(e.target.value)}
                                     placeholder="방화벽 규칙을 입력하세요..."
                                 />
+                                <DiagnosticList diagnostics={diagnostics} />
                             </div>
                         )}
 
@@ -457,6 +642,8 @@ const TemplateTab = forwardRef<TemplateTabRef>((_, ref) => {
                                     </div>
                                 )}
 
+                                <DiagnosticList diagnostics={diagnostics} />
+
                                 {/* 규칙 테이블 */}
                                 <RuleTable
                                     rules={rules}
@@ -512,6 +699,23 @@ const TemplateTab = forwardRef<TemplateTabRef>((_, ref) => {
                             </div>
                         )}
 
+                        {/* 패킷 테스트 탭 */}
+                        {subTab === 'packet' && <PacketTester contents={contents} />}
+
+                        {/* 유효 템플릿 탭 (포함 지시문 펼침) */}
+                        {subTab === 'effective' && <EffectiveTemplate version={version} contents={contents} />}
+
+                        {/* 리비전 탭 (저장된 리비전 조회/비교/복원) */}
+                        {subTab === 'revisions' && !isNew && (
+                            <RevisionHistory
+                                version={selectedVersion}
+                                onRestored={async () => {
+                                    await loadTemplates();
+                                    await handleSelect(selectedVersion);
+                                }}
+                            />
+                        )}
+
                         {/* NAT 규칙 탭 */}
                         {subTab === 'nat' && (
                             <div className="rule-builder-container">
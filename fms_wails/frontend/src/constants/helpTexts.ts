@@ -8,7 +8,25 @@ export const APP_HELP = {
     sections: [
         {
             name: '템플릿 관리',
-            items: ['방화벽 규칙 템플릿을 생성/수정/삭제합니다'],
+            items: [
+                '방화벽 규칙 템플릿을 생성/수정/삭제합니다',
+                '#include 버전으로 다른 템플릿을 포함하면 배포 시 포함한 템플릿 내용으로 펼쳐집니다 (유효 템플릿 탭에서 확인)',
+                '저장할 때마다 내용이 바뀌면 리비전(작성자, 변경 메모)이 기록되며 리비전 탭에서 비교/복원합니다',
+            ],
+        },
+        {
+            name: '객체 관리',
+            items: [
+                '여러 템플릿에서 재사용할 주소/서비스 객체를 관리합니다',
+                '템플릿의 --sip/--dip/--dport/--sport에 @이름으로 참조하면 배포 시 객체 멤버로 펼쳐집니다',
+            ],
+        },
+        {
+            name: '장비 그룹',
+            items: [
+                '장비 그룹과 그룹 공통 템플릿 변수(이름=값)를 관리합니다',
+                '템플릿에 ${이름}으로 참조하면 배포 시 장비별 값으로 바뀝니다 (장비 변수가 그룹 변수보다 우선)',
+            ],
         },
         {
             name: '장비 관리',
@@ -46,6 +64,15 @@ export const APP_HELP = {
                 '배포: POST http://{장비IP}/deploy',
             ],
         },
+        {
+            name: 'SSH',
+            desc: '각 장비에 SSH로 접속하여 템플릿의 agent 명령을 실행 (공개키 인증)',
+            endpoints: [
+                '상태확인: command -v agent',
+                '배포: 템플릿의 agent ... 줄을 순서대로 실행',
+                '규칙 조회: agent -m=list',
+            ],
+        },
     ],
     ruleFormat: {
         pattern: 'req|INSERT|{ID}|{CHAIN}|{ACTION}|{PROTOCOL}|{SRC}|{DST}|{옵션들}',
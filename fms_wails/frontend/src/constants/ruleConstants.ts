@@ -16,6 +16,10 @@ export const PROTOCOL_ANY = 3;
 export const ACTION_DROP = 0;
 export const ACTION_ACCEPT = 1;
 
+// Family 상수 (주소 체계)
+export const FAMILY_IPV4 = 0;
+export const FAMILY_IPV6 = 1;
+
 // NAT Type 상수
 export const NAT_TYPE_DNAT = 0;
 export const NAT_TYPE_SNAT = 1;
@@ -2,47 +2,244 @@
 package deploy
 
 import (
+	"context"
+	"fmt"
 	"sync"
 
 	"fms_wails/internal/http"
 	"fms_wails/internal/model"
 )
 
+// 버전으로 템플릿을 조회하는 함수입니다. (배포 계획 비교, 포함 템플릿과 객체 펼침 시 사용)
+type TemplateLookup func(version string) (*model.Template, error)
+
 // 배포를 관리합니다.
 type Deployer struct {
-	mu     sync.Mutex
-	config *model.Config
-	client *http.Client
+	mu        sync.Mutex
+	config    *model.Config
+	transport Transport
+	variables VariableLookup
 }
 
 // 새로운 Deployer를 생성합니다.
+// 전송 방식은 설정의 연결 모드에 따라 선택됩니다.
 func NewDeployer(config *model.Config) *Deployer {
+	return NewDeployerWithTransport(config, NewTransport(config))
+}
+
+// 지정한 전송 방식을 사용하는 Deployer를 생성합니다.
+func NewDeployerWithTransport(config *model.Config, transport Transport) *Deployer {
 	return &Deployer{
-		config: config,
-		client: http.NewClient(config),
+		config:    config,
+		transport: transport,
 	}
 }
 
+// 장비별 템플릿 변수 값을 조회할 함수를 설정합니다.
+// 설정하지 않으면 변수를 참조하는 템플릿은 배포할 수 없습니다.
+func (d *Deployer) SetVariableLookup(lookup VariableLookup) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.variables = lookup
+}
+
 // 단일 장비의 배포 결과를 나타냅니다.
 type DeployResult struct {
-	Firewall *model.Firewall
-	History  *model.DeployHistory
-	Success  bool
-	ErrorMsg string
+	Firewall        *model.Firewall
+	History         *model.DeployHistory
+	Success         bool
+	ErrorMsg        string
+	PreviousVersion string        // 배포 전 마지막 정상 버전
+	Rollback        *DeployResult // 자동 롤백 결과 (롤백하지 않은 경우 nil)
+
+	responded bool            // 장비(또는 Agent)가 배포 결과를 응답했는지 여부
+	previous  *model.Template // 배포 전 마지막 정상 배포 내용 (자동 롤백 대상, 기록이 없으면 nil)
 }
 
 // 단일 장비에 템플릿을 배포합니다.
-func (d *Deployer) Deploy(fw *model.Firewall, template *model.Template) *DeployResult {
-	d.mu.Lock()
-	defer d.mu.Unlock()
+// ctx가 취소되면 배포를 중단하고 이력에 취소 상태로 기록합니다.
+// 장비가 실패를 응답하거나 배포 후 상태 확인에 실패하면 마지막 정상 배포 내용으로 자동 롤백합니다.
+func (d *Deployer) Deploy(ctx context.Context, fw *model.Firewall, template *model.Template) *DeployResult {
+	result := d.deployWithRollback(ctx, fw, template)
+	d.rollbackUnhealthy(ctx, []*DeployResult{result})
+	return result
+}
+
+// 단일 장비에 템플릿을 배포하고, 장비가 실패를 응답하면 자동 롤백합니다. (배포 후 상태 확인 없음)
+func (d *Deployer) deployWithRollback(ctx context.Context, fw *model.Firewall, template *model.Template) *DeployResult {
+	result := d.deploy(ctx, fw, template)
+	d.rollbackIfFailed(ctx, result)
+	return result
+}
 
-	result := &DeployResult{
-		Firewall: fw,
-		History:  model.NewDeployHistory(fw.DeviceName, template.Version),
+// 롤백 없이 단일 장비에 템플릿을 배포합니다.
+func (d *Deployer) deploy(ctx context.Context, fw *model.Firewall, template *model.Template) *DeployResult {
+	result := newDeployResult(fw, template)
+
+	// 이미 취소된 경우 요청하지 않음
+	if ctx.Err() != nil {
+		markCancelled(result, ctx.Err())
+		return result
 	}
 
-	// 템플릿 전체를 배포
-	deployResult, err := d.client.DeployTemplate(fw, template.Contents)
+	// 장비의 변수 값을 적용한 템플릿 전체를 배포
+	rendered, err := RenderTemplate(template, fw, d.currentVariables())
+	if err != nil {
+		markRenderFailed(result, err)
+		return result
+	}
+	deployResult, err := d.currentTransport().DeployTemplate(ctx, fw, rendered.Contents)
+	applyDeployResponse(ctx, result, rendered, deployResult, err)
+	return result
+}
+
+// Agent 서버를 통해 여러 장비에 한번의 요청으로 템플릿을 배포합니다.
+// 응답에 결과가 없는 장비는 배포 여부를 알 수 없으므로 확인요망(error) 상태로 기록합니다.
+func (d *Deployer) deployBatch(ctx context.Context, firewalls []*model.Firewall, template *model.Template) []*DeployResult {
+	results := make([]*DeployResult, len(firewalls))
+	deviceIPs := make([]string, len(firewalls))
+	for i, fw := range firewalls {
+		results[i] = newDeployResult(fw, template)
+		deviceIPs[i] = fw.DeviceName
+	}
+
+	// 이미 취소된 경우 요청하지 않음
+	if ctx.Err() != nil {
+		for _, result := range results {
+			markCancelled(result, ctx.Err())
+		}
+		return results
+	}
+
+	responses, err := d.currentTransport().(batchTransport).DeployBatchViaAgent(ctx, deviceIPs, template.Contents)
+	for _, result := range results {
+		if err != nil {
+			applyDeployResponse(ctx, result, template, nil, err)
+			continue
+		}
+		response, ok := responses[result.Firewall.DeviceName]
+		if !ok {
+			markMissing(result)
+			continue
+		}
+		applyDeployResponse(ctx, result, template, response, nil)
+	}
+
+	for _, result := range results {
+		d.rollbackIfFailed(ctx, result)
+	}
+	return results
+}
+
+// 새로운 배포 결과를 생성합니다.
+// 이력에는 배포한 템플릿 리비전과 템플릿이 포함한 템플릿 버전도 함께 기록합니다.
+func newDeployResult(fw *model.Firewall, template *model.Template) *DeployResult {
+	history := model.NewDeployHistory(fw.DeviceName, template.Version)
+	history.Revision = template.Revision
+	history.Includes = append([]string(nil), template.Includes...)
+	return &DeployResult{
+		Firewall:        fw,
+		History:         history,
+		PreviousVersion: lastGoodVersion(fw),
+		previous:        lastGoodTemplate(fw),
+	}
+}
+
+// 장비가 실패를 응답한 경우 마지막 정상 버전으로 자동 롤백합니다.
+func (d *Deployer) rollbackIfFailed(ctx context.Context, result *DeployResult) {
+	if !result.responded {
+		return
+	}
+	if result.History.Status == model.DeployStatusFail || result.History.Status == model.DeployStatusError {
+		d.rollback(ctx, result)
+	}
+}
+
+// 배포에 성공한 장비의 상태를 확인하고, 응답이 없는 장비는 실패로 기록한 뒤 마지막 정상 배포 내용으로 자동 롤백합니다.
+func (d *Deployer) rollbackUnhealthy(ctx context.Context, results []*DeployResult) {
+	var deployed []*model.Firewall
+	for _, result := range results {
+		if result.Success {
+			deployed = append(deployed, result.Firewall)
+		}
+	}
+	if len(deployed) == 0 || ctx.Err() != nil {
+		return
+	}
+
+	d.HealthCheckBatch(ctx, deployed)
+	if ctx.Err() != nil {
+		return
+	}
+	for _, result := range markUnhealthy(results) {
+		d.rollback(ctx, result)
+	}
+}
+
+// 배포 후 상태 확인에서 응답이 없는 장비를 실패로 기록하고 해당 결과를 반환합니다.
+// 배포한 내용은 정상 배포로 볼 수 없으므로 장비의 마지막 정상 배포 기록을 배포 전으로 되돌립니다.
+func markUnhealthy(results []*DeployResult) []*DeployResult {
+	var unhealthy []*DeployResult
+	for _, result := range results {
+		if !result.Success || result.Firewall.ServerStatus == model.ServerStatusRunning {
+			continue
+		}
+		result.Success = false
+		result.ErrorMsg = "배포 후 상태 확인 실패"
+		result.History.Status = model.DeployStatusError
+		result.Firewall.DeployStatus = model.DeployStatusError
+		restoreLastGood(result)
+		result.History.Results = append(result.History.Results, model.RuleResult{
+			Rule:   "-",
+			Text:   "-",
+			Status: model.RuleStatusError,
+			Reason: "배포 후 상태 확인 실패",
+		})
+		unhealthy = append(unhealthy, result)
+	}
+	return unhealthy
+}
+
+// Agent 응답에 결과가 없는 장비를 확인요망 상태로 기록합니다.
+func markMissing(result *DeployResult) {
+	result.Success = false
+	result.ErrorMsg = fmt.Sprintf("장비 %s의 배포 결과를 찾을 수 없습니다", result.Firewall.DeviceName)
+	result.History.Status = model.DeployStatusError
+	result.Firewall.DeployStatus = model.DeployStatusError
+	result.Firewall.Version = "-"
+	result.History.Results = append(result.History.Results, model.RuleResult{
+		Rule:     "-",
+		Text:     "-",
+		Status:   model.RuleStatusError,
+		Reason:   "Agent 응답에 장비 결과 없음",
+		Category: model.ErrorCategoryMissing,
+	})
+}
+
+// 템플릿 변수를 적용하지 못해 요청하지 않은 배포를 실패로 기록합니다.
+// 장비에 아무것도 배포하지 않았으므로 장비의 버전은 변경하지 않습니다.
+func markRenderFailed(result *DeployResult, err error) {
+	result.Success = false
+	result.ErrorMsg = err.Error()
+	result.History.Status = model.DeployStatusFail
+	result.Firewall.DeployStatus = model.DeployStatusFail
+	result.History.Results = append(result.History.Results, model.RuleResult{
+		Rule:   "-",
+		Text:   "-",
+		Status: model.RuleStatusError,
+		Reason: fmt.Sprintf("템플릿 변수 적용 실패: %v", err),
+	})
+}
+
+// 장비(또는 Agent)의 배포 응답을 배포 결과와 장비 상태에 반영합니다.
+// template은 장비에 전송한 내용(변수 적용 후)이며, 성공하면 자동 롤백용으로 장비에 기록합니다.
+func applyDeployResponse(ctx context.Context, result *DeployResult, template *model.Template, deployResult *model.DeployResult, err error) {
+	fw := result.Firewall
+
+	if err != nil && ctx.Err() != nil {
+		markCancelled(result, err)
+		return
+	}
 	if err != nil {
 		result.Success = false
 		result.ErrorMsg = err.Error()
@@ -50,20 +247,21 @@ func (d *Deployer) Deploy(fw *model.Firewall, template *model.Template) *DeployR
 		fw.DeployStatus = model.DeployStatusFail
 		fw.Version = "-"
 
-		// 연결 에러 분석하여 Results에 사유 추가
-		errorReason := http.AnalyzeConnectionError(err)
+		// 연결 에러 분석하여 Results에 사유와 원인 분류 추가
 		result.History.Results = append(result.History.Results, model.RuleResult{
-			Rule:   "-",
-			Text:   "-",
-			Status: model.RuleStatusError,
-			Reason: errorReason,
+			Rule:     "-",
+			Text:     "-",
+			Status:   model.RuleStatusError,
+			Reason:   http.AnalyzeConnectionError(err),
+			Category: http.CategoryOf(err),
 		})
 
-		return result
+		return
 	}
 
 	// DeployResult를 Firewall에 저장
 	fw.DeployResult = deployResult
+	result.responded = true
 
 	// DeployResult.Info를 RuleResult로 변환하여 History에 저장
 	for _, info := range deployResult.Info {
@@ -82,6 +280,9 @@ func (d *Deployer) Deploy(fw *model.Firewall, template *model.Template) *DeployR
 		fw.DeployStatus = model.DeployStatusSuccess
 		fw.ServerStatus = model.ServerStatusRunning // 배포 성공 시 서버 상태도 running으로 변경
 		fw.Version = template.Version
+		fw.LastGoodVersion = template.Version
+		fw.LastGoodRevision = template.Revision
+		fw.LastGoodContents = template.Contents
 		result.Success = true
 	} else {
 		// error 체크
@@ -102,36 +303,239 @@ func (d *Deployer) Deploy(fw *model.Firewall, template *model.Template) *DeployR
 		fw.Version = "-"
 		result.Success = false
 	}
+}
 
-	return result
+// 실패한 배포 결과에 대해 배포 전 마지막으로 정상 배포된 내용을 그대로 재배포합니다.
+// 그 사이 같은 버전의 템플릿, 포함 템플릿, 객체 또는 변수가 바뀌었더라도 장비에 실제로 전송했던 내용으로 되돌립니다.
+// 롤백 이력은 result.Rollback에 기록되며, 성공 시 장비 상태는 롤백으로 표시됩니다.
+func (d *Deployer) rollback(ctx context.Context, result *DeployResult) {
+	if result.PreviousVersion == "" || ctx.Err() != nil {
+		return
+	}
+
+	d.mu.Lock()
+	enabled := d.config.IsAutoRollbackEnabled()
+	d.mu.Unlock()
+	if !enabled {
+		return
+	}
+
+	previous := result.previous
+	if previous == nil {
+		// 배포 내용을 기록하기 전의 장비 데이터는 버전만 알 수 있어 롤백하지 않음
+		result.History.Results = append(result.History.Results, model.RuleResult{
+			Rule:   "-",
+			Text:   "-",
+			Status: model.RuleStatusError,
+			Reason: fmt.Sprintf("자동 롤백 실패: 버전 %s의 배포 내용이 기록되어 있지 않습니다", result.PreviousVersion),
+		})
+		return
+	}
+	if previous.Version == result.History.TemplateVer && previous.Revision == result.History.Revision {
+		// 실패한 배포와 같은 리비전은 다시 배포해도 결과가 같음
+		return
+	}
+
+	fw := result.Firewall
+	rollback := d.deploy(ctx, fw, previous)
+	rollback.PreviousVersion = result.PreviousVersion
+	result.Rollback = rollback
+
+	if rollback.Success {
+		fw.DeployStatus = model.DeployStatusRolledBack
+	}
+}
+
+// 장비의 마지막 정상 배포 버전을 반환합니다.
+// 이전 데이터와의 호환을 위해 기록이 없으면 성공 상태의 현재 버전을 사용합니다.
+func lastGoodVersion(fw *model.Firewall) string {
+	if fw.LastGoodVersion != "" {
+		return fw.LastGoodVersion
+	}
+	if fw.DeployStatus == model.DeployStatusSuccess && fw.Version != "-" {
+		return fw.Version
+	}
+	return ""
+}
+
+// 장비에 마지막으로 정상 배포된 내용을 템플릿으로 반환합니다.
+// 배포 내용이 기록되지 않은 장비(이전 데이터)는 nil을 반환합니다.
+func lastGoodTemplate(fw *model.Firewall) *model.Template {
+	if fw.LastGoodContents == "" {
+		return nil
+	}
+	template := model.NewTemplate(lastGoodVersion(fw), fw.LastGoodContents)
+	template.Revision = fw.LastGoodRevision
+	return template
+}
+
+// 장비의 마지막 정상 배포 기록을 배포 전 기록으로 되돌립니다.
+func restoreLastGood(result *DeployResult) {
+	fw := result.Firewall
+	fw.LastGoodVersion = result.PreviousVersion
+	fw.LastGoodRevision, fw.LastGoodContents = "", ""
+	if result.previous != nil {
+		fw.LastGoodRevision = result.previous.Revision
+		fw.LastGoodContents = result.previous.Contents
+	}
+}
+
+// 배포 결과를 취소 상태로 기록합니다.
+// 장비의 버전은 변경하지 않습니다.
+func markCancelled(result *DeployResult, err error) {
+	result.Success = false
+	result.ErrorMsg = err.Error()
+	result.History.Status = model.DeployStatusCancelled
+	result.Firewall.DeployStatus = model.DeployStatusCancelled
+	result.History.Results = append(result.History.Results, model.RuleResult{
+		Rule:   "-",
+		Text:   "-",
+		Status: model.RuleStatusCancelled,
+		Reason: "배포 취소",
+	})
 }
 
 // 여러 장비에 템플릿을 배포합니다.
-func (d *Deployer) DeployToMultiple(firewalls []*model.Firewall, template *model.Template, progressCb func(int, int, string)) []*DeployResult {
-	results := make([]*DeployResult, 0, len(firewalls))
+// 설정된 최대 동시 배포 수만큼 병렬로 배포하며, 결과는 입력 순서대로 반환합니다.
+// Agent 모드에서는 설정된 일괄 배포 크기만큼 장비를 묶어 한번의 요청으로 배포합니다.
+// 템플릿 변수를 참조하는 템플릿은 장비마다 내용이 다르므로 장비별로 요청합니다.
+// progressCb는 장비 하나의 배포가 끝날 때마다 (완료 수, 전체 수, 장비명)으로 순차 호출됩니다.
+// 배포에 성공한 장비는 바로 상태를 확인하여 응답이 없으면 마지막 정상 배포 내용으로 자동 롤백합니다.
+// ctx가 취소되면 남은 장비는 요청 없이 취소 상태로 기록됩니다.
+func (d *Deployer) DeployToMultiple(ctx context.Context, firewalls []*model.Firewall, template *model.Template, progressCb func(int, int, string)) []*DeployResult {
+	return d.deployToMultiple(ctx, firewalls, template, true, progressCb)
+}
+
+// 여러 장비에 템플릿을 배포합니다. healthCheck가 true이면 요청 단위로 배포 직후 상태를 확인합니다.
+// 단계별 배포는 대기 시간 후에 웨이브 전체의 상태를 확인하므로 healthCheck 없이 호출합니다.
+func (d *Deployer) deployToMultiple(ctx context.Context, firewalls []*model.Firewall, template *model.Template, healthCheck bool, progressCb func(int, int, string)) []*DeployResult {
 	total := len(firewalls)
+	results := make([]*DeployResult, total)
+	if total == 0 {
+		return results
+	}
 
-	for i, fw := range firewalls {
-		if progressCb != nil {
-			progressCb(i+1, total, fw.DeviceName)
+	// 요청 단위로 장비 분할 (Direct 모드는 장비당 1회 요청)
+	batchSize := d.agentBatchSize()
+	if model.HasTemplateVariables(template.Contents) {
+		batchSize = 1
+	}
+	var chunks [][2]int
+	for start := 0; start < total; start += batchSize {
+		end := start + batchSize
+		if end > total {
+			end = total
 		}
+		chunks = append(chunks, [2]int{start, end})
+	}
 
-		result := d.Deploy(fw, template)
-		results = append(results, result)
+	workers := d.maxConcurrentDeploys()
+	if workers > len(chunks) {
+		workers = len(chunks)
 	}
 
+	jobs := make(chan [2]int)
+	var wg sync.WaitGroup
+	var progressMu sync.Mutex
+	completed := 0
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range jobs {
+				chunkFirewalls := firewalls[chunk[0]:chunk[1]]
+				var chunkResults []*DeployResult
+				if batchSize > 1 {
+					chunkResults = d.deployBatch(ctx, chunkFirewalls, template)
+				} else {
+					chunkResults = []*DeployResult{d.deployWithRollback(ctx, chunkFirewalls[0], template)}
+				}
+				if healthCheck {
+					d.rollbackUnhealthy(ctx, chunkResults)
+				}
+				copy(results[chunk[0]:chunk[1]], chunkResults)
+
+				// 진행률 콜백은 동시에 호출되지 않도록 직렬화
+				progressMu.Lock()
+				for _, fw := range chunkFirewalls {
+					completed++
+					if progressCb != nil {
+						progressCb(completed, total, fw.DeviceName)
+					}
+				}
+				progressMu.Unlock()
+			}
+		}()
+	}
+
+	for _, chunk := range chunks {
+		jobs <- chunk
+	}
+	close(jobs)
+	wg.Wait()
+
 	return results
 }
 
+// 현재 설정의 전송 방식을 반환합니다.
+func (d *Deployer) currentTransport() Transport {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.transport
+}
+
+// 현재 설정된 변수 조회 함수를 반환합니다.
+func (d *Deployer) currentVariables() VariableLookup {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.variables
+}
+
+// Agent 일괄 처리를 사용할지 확인합니다.
+// Agent 모드이고 전송 방식이 일괄 처리를 지원해야 합니다.
+func (d *Deployer) useAgentBatch() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, ok := d.transport.(batchTransport)
+	return ok && d.config.IsAgentMode()
+}
+
+// 요청 1회에 배포할 장비 수를 반환합니다. (Agent 일괄 처리를 사용하지 않으면 1)
+func (d *Deployer) agentBatchSize() int {
+	if !d.useAgentBatch() {
+		return 1
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.config.GetAgentBatchSize()
+}
+
+// 현재 설정의 최대 동시 배포 수를 반환합니다.
+func (d *Deployer) maxConcurrentDeploys() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.config.GetMaxConcurrentDeploys()
+}
+
 // 장비의 연결 상태를 확인합니다.
-func (d *Deployer) HealthCheck(fw *model.Firewall) error {
-	status, err := d.client.CheckHealth(fw)
+// ctx가 취소된 경우 기존 서버 상태를 유지합니다.
+func (d *Deployer) HealthCheck(ctx context.Context, fw *model.Firewall) error {
+	status, err := d.currentTransport().CheckHealth(ctx, fw)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
 	fw.ServerStatus = status
 	return err
 }
 
+// 장비에 적용된 규칙을 조회합니다.
+func (d *Deployer) FetchRules(ctx context.Context, fw *model.Firewall) (string, error) {
+	return d.currentTransport().FetchRules(ctx, fw)
+}
+
 // 여러 장비의 연결 상태를 확인합니다. (Direct 모드용 - 개별 호출)
-func (d *Deployer) HealthCheckMultiple(firewalls []*model.Firewall, progressCb func(int, int, string)) map[int]error {
+func (d *Deployer) HealthCheckMultiple(ctx context.Context, firewalls []*model.Firewall, progressCb func(int, int, string)) map[int]error {
 	errors := make(map[int]error)
 	total := len(firewalls)
 
@@ -140,7 +544,12 @@ func (d *Deployer) HealthCheckMultiple(firewalls []*model.Firewall, progressCb f
 			progressCb(i+1, total, fw.DeviceName)
 		}
 
-		err := d.HealthCheck(fw)
+		if ctx.Err() != nil {
+			errors[fw.Index] = ctx.Err()
+			continue
+		}
+
+		err := d.HealthCheck(ctx, fw)
 		if err != nil {
 			errors[fw.Index] = err
 		}
@@ -150,23 +559,23 @@ func (d *Deployer) HealthCheckMultiple(firewalls []*model.Firewall, progressCb f
 }
 
 // 여러 장비의 연결 상태를 한번에 확인합니다. (Agent 모드용 - 배치 호출, Direct 모드는 병렬 처리)
-func (d *Deployer) HealthCheckBatch(firewalls []*model.Firewall) error {
+func (d *Deployer) HealthCheckBatch(ctx context.Context, firewalls []*model.Firewall) error {
 	if len(firewalls) == 0 {
 		return nil
 	}
 
-	// Agent 모드가 아니면 병렬로 개별 호출 처리
-	if !d.config.IsAgentMode() {
+	// Agent 일괄 처리를 사용하지 않으면 병렬로 개별 호출 처리
+	if !d.useAgentBatch() {
 		var wg sync.WaitGroup
 		for _, fw := range firewalls {
 			wg.Add(1)
 			go func(f *model.Firewall) {
 				defer wg.Done()
-				d.HealthCheck(f)
+				d.HealthCheck(ctx, f)
 			}(fw)
 		}
 		wg.Wait()
-		return nil
+		return ctx.Err()
 	}
 
 	// 모든 장비의 IP 주소 수집
@@ -176,7 +585,11 @@ func (d *Deployer) HealthCheckBatch(firewalls []*model.Firewall) error {
 	}
 
 	// Agent 서버에 한번에 요청
-	results, err := d.client.CheckHealthViaAgent(ipAddrs)
+	results, err := d.currentTransport().(batchTransport).CheckHealthViaAgent(ctx, ipAddrs)
+	if err != nil && ctx.Err() != nil {
+		// 취소 시 기존 상태 유지
+		return ctx.Err()
+	}
 	if err != nil {
 		// 에러 시 모든 장비를 stop 상태로 설정
 		for _, fw := range firewalls {
@@ -202,5 +615,5 @@ func (d *Deployer) UpdateConfig(config *model.Config) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	d.config = config
-	d.client = http.NewClient(config)
+	d.transport = NewTransport(config)
 }
@@ -0,0 +1,504 @@
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"fms_wails/internal/model"
+)
+
+// newTestDeviceServer 배포 요청을 처리하는 테스트 장비 서버 생성
+// 동시에 처리 중인 요청 수의 최대값을 maxInFlight에 기록
+func newTestDeviceServer(t *testing.T, delay time.Duration, maxInFlight *int) *httptest.Server {
+	t.Helper()
+
+	var mu sync.Mutex
+	inFlight := 0
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > *maxInFlight {
+			*maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(delay)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []model.DeployResult{
+				{IP: r.Host, Status: model.DeployStatusSuccess},
+			},
+		})
+	}))
+}
+
+// TestDeployToMultipleConcurrency 동시 배포 수 제한 및 결과 순서 테스트
+func TestDeployToMultipleConcurrency(t *testing.T) {
+	maxInFlight := 0
+	server := newTestDeviceServer(t, 50*time.Millisecond, &maxInFlight)
+	defer server.Close()
+
+	config := model.DefaultConfig()
+	config.MaxConcurrentDeploys = 3
+	deployer := NewDeployer(config)
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	firewalls := make([]*model.Firewall, 8)
+	for i := range firewalls {
+		firewalls[i] = model.NewFirewall(host)
+		firewalls[i].Index = i + 1
+	}
+	template := model.NewTemplate("v1", "agent -m=insert -c=INPUT -p=tcp --dport=22 -a=DROP")
+
+	var progress []int
+	results := deployer.DeployToMultiple(context.Background(), firewalls, template, func(done, total int, deviceName string) {
+		if total != len(firewalls) {
+			t.Errorf("progress total = %d, want %d", total, len(firewalls))
+		}
+		progress = append(progress, done)
+	})
+
+	if len(results) != len(firewalls) {
+		t.Fatalf("len(results) = %d, want %d", len(results), len(firewalls))
+	}
+	for i, result := range results {
+		if result.Firewall != firewalls[i] {
+			t.Errorf("results[%d].Firewall 순서 불일치", i)
+		}
+		if !result.Success {
+			t.Errorf("results[%d].Success = false, error: %s", i, result.ErrorMsg)
+		}
+		if result.History.Status != model.DeployStatusSuccess {
+			t.Errorf("results[%d].History.Status = %s, want success", i, result.History.Status)
+		}
+	}
+
+	if maxInFlight > 3 {
+		t.Errorf("maxInFlight = %d, want <= 3", maxInFlight)
+	}
+	if maxInFlight < 2 {
+		t.Errorf("maxInFlight = %d, 병렬 배포가 수행되지 않았습니다", maxInFlight)
+	}
+
+	if len(progress) != len(firewalls) {
+		t.Fatalf("progress 호출 수 = %d, want %d", len(progress), len(firewalls))
+	}
+	for i, done := range progress {
+		if done != i+1 {
+			t.Errorf("progress[%d] = %d, want %d", i, done, i+1)
+		}
+	}
+}
+
+// TestDeployToMultipleEmpty 빈 장비 목록 배포 테스트
+func TestDeployToMultipleEmpty(t *testing.T) {
+	deployer := NewDeployer(model.DefaultConfig())
+	results := deployer.DeployToMultiple(context.Background(), nil, model.NewTemplate("v1", "agent"), nil)
+	if len(results) != 0 {
+		t.Errorf("len(results) = %d, want 0", len(results))
+	}
+}
+
+// TestDeployToMultipleCancel 배포 취소 시 취소 상태 기록 테스트
+func TestDeployToMultipleCancel(t *testing.T) {
+	arrived := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		select {
+		case arrived <- struct{}{}:
+		default:
+		}
+		// 응답하지 않는 장비 흉내
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	config := model.DefaultConfig()
+	config.MaxConcurrentDeploys = 1
+	deployer := NewDeployer(config)
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	firewalls := make([]*model.Firewall, 3)
+	for i := range firewalls {
+		firewalls[i] = model.NewFirewall(host)
+		firewalls[i].Version = "v0"
+	}
+	template := model.NewTemplate("v1", "agent -m=insert -c=INPUT -p=tcp --dport=22 -a=DROP")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-arrived
+		cancel()
+	}()
+
+	results := deployer.DeployToMultiple(ctx, firewalls, template, nil)
+
+	for i, result := range results {
+		if result.Success {
+			t.Errorf("results[%d].Success = true, want false", i)
+		}
+		if result.History.Status != model.DeployStatusCancelled {
+			t.Errorf("results[%d].History.Status = %s, want %s", i, result.History.Status, model.DeployStatusCancelled)
+		}
+		if firewalls[i].DeployStatus != model.DeployStatusCancelled {
+			t.Errorf("firewalls[%d].DeployStatus = %s, want %s", i, firewalls[i].DeployStatus, model.DeployStatusCancelled)
+		}
+		if firewalls[i].Version != "v0" {
+			t.Errorf("firewalls[%d].Version = %s, want v0", i, firewalls[i].Version)
+		}
+	}
+}
+
+// newRollbackTestServer 특정 규칙이 포함된 템플릿만 실패로 응답하는 테스트 장비 서버 생성
+// 배포 요청의 템플릿 내용을 requests에 기록하며, 상태 확인 요청은 기록하지 않음
+func newRollbackTestServer(failRule string, requests *[]string) *httptest.Server {
+	var mu sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/respCheck" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		var req struct {
+			Template string `json:"template"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		mu.Lock()
+		*requests = append(*requests, req.Template)
+		mu.Unlock()
+
+		result := model.DeployResult{IP: r.Host, Status: model.DeployStatusSuccess}
+		if strings.Contains(req.Template, failRule) {
+			result.Status = model.DeployStatusFail
+			result.Info = []model.ResultInfo{{Rule: failRule, Status: model.RuleStatusError, Reason: "invalid"}}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []model.DeployResult{result},
+		})
+	}))
+}
+
+// TestDeployRollback 배포 실패 시 마지막 정상 배포 내용으로 자동 롤백 테스트
+func TestDeployRollback(t *testing.T) {
+	var requests []string
+	server := newRollbackTestServer("--dport=23", &requests)
+	defer server.Close()
+
+	good := model.NewTemplate("v1", "agent -m=insert -c=INPUT -p=tcp --dport=22 -a=DROP")
+	good.Revision = model.TemplateHash(good.Contents)
+	bad := model.NewTemplate("v2", "agent -m=insert -c=INPUT -p=tcp --dport=23 -a=DROP")
+
+	deployer := NewDeployer(model.DefaultConfig())
+
+	fw := model.NewFirewall(strings.TrimPrefix(server.URL, "http://"))
+	if result := deployer.Deploy(context.Background(), fw, good); !result.Success {
+		t.Fatalf("v1 배포 실패: %s", result.ErrorMsg)
+	}
+	if fw.LastGoodVersion != "v1" || fw.LastGoodRevision != good.Revision || fw.LastGoodContents != good.Contents {
+		t.Fatalf("fw = {LastGoodVersion: %q, LastGoodRevision: %q, LastGoodContents: %q}, want v1 배포 내용",
+			fw.LastGoodVersion, fw.LastGoodRevision, fw.LastGoodContents)
+	}
+
+	// 배포 후 v1 템플릿을 수정해도 롤백은 장비에 배포했던 내용을 재배포
+	deployed := good.Contents
+	good.Contents = "agent -m=insert -c=INPUT -p=tcp --dport=2222 -a=DROP"
+
+	result := deployer.Deploy(context.Background(), fw, bad)
+	if result.Success {
+		t.Fatal("v2 배포가 성공으로 처리되었습니다")
+	}
+	if result.History.Status != model.DeployStatusError {
+		t.Errorf("History.Status = %s, want %s", result.History.Status, model.DeployStatusError)
+	}
+	if result.Rollback == nil {
+		t.Fatal("Rollback = nil, 자동 롤백이 수행되지 않았습니다")
+	}
+	if !result.Rollback.Success || result.Rollback.History.TemplateVer != "v1" {
+		t.Errorf("Rollback = {Success: %v, TemplateVer: %s}, want {true, v1}", result.Rollback.Success, result.Rollback.History.TemplateVer)
+	}
+	if result.Rollback.History.Revision != model.TemplateHash(deployed) {
+		t.Errorf("Rollback.History.Revision = %q, want v1 배포 리비전", result.Rollback.History.Revision)
+	}
+	if fw.Version != "v1" || fw.DeployStatus != model.DeployStatusRolledBack {
+		t.Errorf("fw = {Version: %s, DeployStatus: %s}, want {v1, %s}", fw.Version, fw.DeployStatus, model.DeployStatusRolledBack)
+	}
+	if len(requests) != 3 {
+		t.Fatalf("요청 수 = %d, want 3", len(requests))
+	}
+	if requests[2] != deployed {
+		t.Errorf("롤백 요청 템플릿 = %q, want %q", requests[2], deployed)
+	}
+}
+
+// TestDeployRollbackWithoutContents 배포 내용이 기록되지 않은 이전 장비 데이터는 롤백하지 않는지 테스트
+func TestDeployRollbackWithoutContents(t *testing.T) {
+	var requests []string
+	server := newRollbackTestServer("--dport=23", &requests)
+	defer server.Close()
+
+	fw := model.NewFirewall(strings.TrimPrefix(server.URL, "http://"))
+	fw.LastGoodVersion = "v1"
+
+	deployer := NewDeployer(model.DefaultConfig())
+	result := deployer.Deploy(context.Background(), fw, model.NewTemplate("v2", "agent -m=insert -c=INPUT -p=tcp --dport=23 -a=DROP"))
+	if result.Rollback != nil {
+		t.Error("Rollback != nil, 배포 내용이 없으면 롤백하지 않아야 합니다")
+	}
+	if len(requests) != 1 {
+		t.Errorf("요청 수 = %d, want 1", len(requests))
+	}
+	last := result.History.Results[len(result.History.Results)-1]
+	if !strings.Contains(last.Reason, "자동 롤백 실패") {
+		t.Errorf("마지막 결과 사유 = %q, want 자동 롤백 실패", last.Reason)
+	}
+}
+
+// TestDeployHealthCheckRollback 배포 후 상태 확인 실패 시 자동 롤백 테스트
+func TestDeployHealthCheckRollback(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	healthy := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/respCheck" {
+			if !healthy {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+			return
+		}
+		var req struct {
+			Template string `json:"template"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		requests = append(requests, req.Template)
+		// --dport=23 규칙을 적용하면 장비가 응답하지 않음
+		healthy = !strings.Contains(req.Template, "--dport=23")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []model.DeployResult{{IP: r.Host, Status: model.DeployStatusSuccess}},
+		})
+	}))
+	defer server.Close()
+
+	good := model.NewTemplate("v1", "agent -m=insert -c=INPUT -p=tcp --dport=22 -a=DROP")
+	bad := model.NewTemplate("v2", "agent -m=insert -c=INPUT -p=tcp --dport=23 -a=DROP")
+	deployer := NewDeployer(model.DefaultConfig())
+
+	fw := model.NewFirewall(strings.TrimPrefix(server.URL, "http://"))
+	fw.Index = 1
+	if results := deployer.DeployToMultiple(context.Background(), []*model.Firewall{fw}, good, nil); !results[0].Success {
+		t.Fatalf("v1 배포 실패: %s", results[0].ErrorMsg)
+	}
+
+	result := deployer.DeployToMultiple(context.Background(), []*model.Firewall{fw}, bad, nil)[0]
+	if result.Success || result.History.Status != model.DeployStatusError {
+		t.Errorf("result = {Success: %v, Status: %s}, want {false, %s}", result.Success, result.History.Status, model.DeployStatusError)
+	}
+	if result.Rollback == nil || !result.Rollback.Success {
+		t.Fatalf("Rollback = %+v, 상태 확인 실패 후 자동 롤백이 수행되지 않았습니다", result.Rollback)
+	}
+	if fw.Version != "v1" || fw.LastGoodVersion != "v1" || fw.LastGoodContents != good.Contents || fw.DeployStatus != model.DeployStatusRolledBack {
+		t.Errorf("fw = {Version: %s, LastGoodVersion: %s, DeployStatus: %s}, want {v1, v1, %s}",
+			fw.Version, fw.LastGoodVersion, fw.DeployStatus, model.DeployStatusRolledBack)
+	}
+	if len(requests) != 3 || requests[2] != good.Contents {
+		t.Errorf("배포 요청 = %q, want v1, v2, v1", requests)
+	}
+}
+
+// TestDeployRollbackDisabled 자동 롤백 비활성화 테스트
+func TestDeployRollbackDisabled(t *testing.T) {
+	var requests []string
+	server := newRollbackTestServer("--dport=23", &requests)
+	defer server.Close()
+
+	good := model.NewTemplate("v1", "agent -m=insert -c=INPUT -p=tcp --dport=22 -a=DROP")
+	bad := model.NewTemplate("v2", "agent -m=insert -c=INPUT -p=tcp --dport=23 -a=DROP")
+
+	config := model.DefaultConfig()
+	config.DisableAutoRollback = true
+	deployer := NewDeployer(config)
+
+	fw := model.NewFirewall(strings.TrimPrefix(server.URL, "http://"))
+	fw.LastGoodVersion = "v1"
+	fw.LastGoodContents = good.Contents
+
+	result := deployer.Deploy(context.Background(), fw, bad)
+	if result.Rollback != nil {
+		t.Error("Rollback != nil, 비활성화 시 롤백하지 않아야 합니다")
+	}
+	if fw.Version != "-" || fw.LastGoodVersion != "v1" {
+		t.Errorf("fw = {Version: %s, LastGoodVersion: %s}, want {-, v1}", fw.Version, fw.LastGoodVersion)
+	}
+}
+
+// TestDeployToMultipleAgentBatch Agent 일괄 배포 및 응답 누락 장비 처리 테스트
+func TestDeployToMultipleAgentBatch(t *testing.T) {
+	const missingIP = "10.0.0.5"
+
+	var mu sync.Mutex
+	var batches [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 배포 후 상태 확인 요청은 모든 장비를 정상으로 응답
+		if r.URL.Path == "/agent/req-respCheck" {
+			var req struct {
+				IPAddrs []string `json:"ipAddrs"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			running := make(map[string]bool)
+			for _, ip := range req.IPAddrs {
+				running[ip] = true
+			}
+			json.NewEncoder(w).Encode(running)
+			return
+		}
+		if r.URL.Path != "/agent/req-deploy" {
+			t.Errorf("요청 경로 = %s, want /agent/req-deploy", r.URL.Path)
+		}
+		var req struct {
+			Template string   `json:"template"`
+			IPAddrs  []string `json:"ipAddrs"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		mu.Lock()
+		batches = append(batches, req.IPAddrs)
+		mu.Unlock()
+
+		// 응답 순서를 뒤집고 한 장비는 누락
+		var data []model.DeployResult
+		for i := len(req.IPAddrs) - 1; i >= 0; i-- {
+			if req.IPAddrs[i] == missingIP {
+				continue
+			}
+			data = append(data, model.DeployResult{IP: req.IPAddrs[i], Status: model.DeployStatusSuccess})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+	defer server.Close()
+
+	config := model.DefaultConfig()
+	config.ConnectionMode = model.ConnectionModeAgent
+	config.AgentServerURL = server.URL
+	config.AgentBatchSize = 4
+	deployer := NewDeployer(config)
+
+	firewalls := make([]*model.Firewall, 10)
+	for i := range firewalls {
+		firewalls[i] = model.NewFirewall(fmt.Sprintf("10.0.0.%d", i+1))
+	}
+	template := model.NewTemplate("v1", "agent -m=insert -c=INPUT -p=tcp --dport=22 -a=DROP")
+
+	progressCount := 0
+	results := deployer.DeployToMultiple(context.Background(), firewalls, template, func(done, total int, deviceName string) {
+		progressCount++
+	})
+
+	if len(batches) != 3 {
+		t.Errorf("Agent 요청 수 = %d, want 3", len(batches))
+	}
+	for _, batch := range batches {
+		if len(batch) > 4 {
+			t.Errorf("요청당 장비 수 = %d, want <= 4", len(batch))
+		}
+	}
+	if progressCount != len(firewalls) {
+		t.Errorf("progress 호출 수 = %d, want %d", progressCount, len(firewalls))
+	}
+
+	for i, result := range results {
+		if result.Firewall != firewalls[i] {
+			t.Errorf("results[%d].Firewall 순서 불일치", i)
+		}
+		if result.Firewall.DeviceName == missingIP {
+			if result.Success || result.History.Status != model.DeployStatusError {
+				t.Errorf("누락 장비 = {Success: %v, Status: %s}, want {false, %s}", result.Success, result.History.Status, model.DeployStatusError)
+			}
+			if result.Firewall.Version != "-" {
+				t.Errorf("누락 장비 Version = %s, want -", result.Firewall.Version)
+			}
+			if category := result.History.GetErrorCategory(); category != model.ErrorCategoryMissing {
+				t.Errorf("누락 장비 원인 분류 = %q, want %q", category, model.ErrorCategoryMissing)
+			}
+			continue
+		}
+		if !result.Success {
+			t.Errorf("results[%d].Success = false, error: %s", i, result.ErrorMsg)
+		}
+		if result.Firewall.DeployResult == nil || result.Firewall.DeployResult.IP != result.Firewall.DeviceName {
+			t.Errorf("results[%d] 장비에 다른 장비의 결과가 저장되었습니다", i)
+		}
+	}
+}
+
+// fakeTransport 장비별 호출을 기록하는 테스트용 전송 방식
+type fakeTransport struct {
+	mu        sync.Mutex
+	deployed  []string
+	templates map[string]string // 장비별로 전송한 템플릿 내용
+}
+
+func (f *fakeTransport) CheckHealth(ctx context.Context, fw *model.Firewall) (string, error) {
+	return model.ServerStatusRunning, nil
+}
+
+func (f *fakeTransport) DeployTemplate(ctx context.Context, fw *model.Firewall, template string) (*model.DeployResult, error) {
+	f.mu.Lock()
+	f.deployed = append(f.deployed, fw.DeviceName)
+	if f.templates == nil {
+		f.templates = make(map[string]string)
+	}
+	f.templates[fw.DeviceName] = template
+	f.mu.Unlock()
+	return &model.DeployResult{IP: fw.DeviceName, Status: model.DeployStatusSuccess}, nil
+}
+
+func (f *fakeTransport) FetchRules(ctx context.Context, fw *model.Firewall) (string, error) {
+	return "", nil
+}
+
+// TestDeployWithTransport 일괄 처리를 지원하지 않는 전송 방식은 장비별로 배포하는지 테스트
+func TestDeployWithTransport(t *testing.T) {
+	config := model.DefaultConfig()
+	config.ConnectionMode = model.ConnectionModeAgent
+	transport := &fakeTransport{}
+	deployer := NewDeployerWithTransport(config, transport)
+
+	firewalls := []*model.Firewall{model.NewFirewall("10.0.0.1"), model.NewFirewall("10.0.0.2")}
+	template := &model.Template{Version: "v1", Contents: "agent -m=insert -c=INPUT -a=DROP"}
+	results := deployer.DeployToMultiple(context.Background(), firewalls, template, nil)
+
+	if len(transport.deployed) != 2 {
+		t.Fatalf("장비별 배포 호출 = %v, want 2회", transport.deployed)
+	}
+	for i, result := range results {
+		if !result.Success {
+			t.Errorf("results[%d].Success = false, ErrorMsg = %s", i, result.ErrorMsg)
+		}
+	}
+
+	if err := deployer.HealthCheckBatch(context.Background(), firewalls); err != nil {
+		t.Fatalf("HealthCheckBatch() error: %v", err)
+	}
+	for _, fw := range firewalls {
+		if fw.ServerStatus != model.ServerStatusRunning {
+			t.Errorf("%s ServerStatus = %s, want running", fw.DeviceName, fw.ServerStatus)
+		}
+	}
+}
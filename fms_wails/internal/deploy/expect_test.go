@@ -0,0 +1,35 @@
+package deploy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"fms_wails/internal/model"
+)
+
+// TestCheckExpectations 기대 결과 실패 시 배포 차단 테스트
+func TestCheckExpectations(t *testing.T) {
+	template := &model.Template{
+		Version: "v1",
+		Contents: `agent -m=insert -c=INPUT -p=tcp -a=DROP --dport=22
+# expect: tcp 10.0.0.1 -> 10.0.0.2:22 INPUT = DROP`,
+	}
+	if err := CheckExpectations(template); err != nil {
+		t.Fatalf("CheckExpectations() error = %v, want nil", err)
+	}
+
+	template.Contents += "\n# expect: tcp 10.0.0.1 -> 10.0.0.2:23 INPUT = DROP"
+	err := CheckExpectations(template)
+
+	var expectErr *ExpectationError
+	if !errors.As(err, &expectErr) {
+		t.Fatalf("CheckExpectations() error = %v, want *ExpectationError", err)
+	}
+	if len(expectErr.Failures) != 1 || expectErr.Failures[0].Line != 3 {
+		t.Errorf("Failures = %v, want 라인 3", expectErr.Failures)
+	}
+	if !strings.Contains(err.Error(), "라인 3: tcp 10.0.0.1 -> 10.0.0.2:23 INPUT = DROP (실제 ACCEPT") {
+		t.Errorf("Error() = %s", err)
+	}
+}
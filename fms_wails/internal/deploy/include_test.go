@@ -0,0 +1,63 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"fms_wails/internal/model"
+)
+
+// TestDeployRecordsIncludes 포함 템플릿을 펼쳐 배포하고 이력에 리비전과 포함 버전을 기록하는지 테스트
+func TestDeployRecordsIncludes(t *testing.T) {
+	stored := map[string]*model.Template{
+		"baseline-v3": model.NewTemplate("baseline-v3", "agent -m=insert -c=INPUT -p=tcp?flags=ALL/NONE -a=DROP --sip=@scanners"),
+		"site-a":      model.NewTemplate("site-a", "#include baseline-v3\nagent -m=insert -c=INPUT -p=tcp --dport=22 -a=ACCEPT"),
+	}
+	templates := func(version string) (*model.Template, error) {
+		if t, ok := stored[version]; ok {
+			return t.Clone(), nil
+		}
+		return nil, fmt.Errorf("템플릿을 찾을 수 없습니다: %s", version)
+	}
+	stored["site-a"].Revision = model.TemplateHash(stored["site-a"].Contents)
+	objects := []*model.NamedObject{model.NewNamedObject("scanners", model.ObjectKindAddress, "203.0.113.0/24")}
+	lookup := ExpandingLookup(templates, func() ([]*model.NamedObject, error) { return objects, nil })
+
+	// 포함한 템플릿의 객체 참조까지 펼침
+	template, err := lookup("site-a")
+	if err != nil {
+		t.Fatalf("lookup(site-a) error: %v", err)
+	}
+	if !strings.Contains(template.Contents, "-p=tcp?flags=ALL/NONE -a=DROP --sip=203.0.113.0/24") {
+		t.Errorf("lookup(site-a) = %s, want 펼친 baseline-v3 규칙", template.Contents)
+	}
+
+	config := model.DefaultConfig()
+	config.ConnectionMode = model.ConnectionModeAgent
+	transport := &fakeTransport{}
+	deployer := NewDeployerWithTransport(config, transport)
+	result := deployer.Deploy(context.Background(), model.NewFirewall("10.0.0.1"), template)
+
+	if !result.Success {
+		t.Fatalf("Deploy() 실패: %s", result.ErrorMsg)
+	}
+	if !strings.Contains(transport.templates["10.0.0.1"], "--sip=203.0.113.0/24") {
+		t.Errorf("전송한 템플릿 = %q, want 포함 템플릿 규칙", transport.templates["10.0.0.1"])
+	}
+	// 포함 템플릿은 배포한 리비전과 함께 기록
+	wantInclude := model.IncludeRef("baseline-v3", model.TemplateHash(stored["baseline-v3"].Contents))
+	if result.History.TemplateVer != "site-a" || strings.Join(result.History.Includes, ",") != wantInclude {
+		t.Errorf("History = %s %v, want site-a [%s]", result.History.TemplateVer, result.History.Includes, wantInclude)
+	}
+	if result.History.Revision != stored["site-a"].Revision {
+		t.Errorf("History.Revision = %q, want site-a 리비전 %q", result.History.Revision, stored["site-a"].Revision)
+	}
+
+	// 포함할 템플릿이 없으면 조회 실패
+	stored["site-b"] = model.NewTemplate("site-b", "#include baseline-v4")
+	if _, err := lookup("site-b"); err == nil || !strings.Contains(err.Error(), "baseline-v4") {
+		t.Errorf("lookup(site-b) error = %v, want 없는 포함 템플릿", err)
+	}
+}
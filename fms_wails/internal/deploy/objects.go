@@ -0,0 +1,42 @@
+package deploy
+
+import (
+	"fmt"
+
+	"fms_wails/internal/model"
+	"fms_wails/internal/parser"
+)
+
+// 주소/서비스 객체 목록을 조회하는 함수입니다.
+type ObjectLookup func() ([]*model.NamedObject, error)
+
+// 템플릿의 객체 참조(@이름)를 펼친 배포용 복사본을 반환합니다.
+// 객체를 참조하지 않는 템플릿은 내용이 그대로 유지됩니다.
+func ExpandTemplate(template *model.Template, objects []*model.NamedObject) (*model.Template, error) {
+	contents, err := parser.ExpandObjects(template.Contents, objects)
+	if err != nil {
+		return nil, fmt.Errorf("템플릿 %s: %v", template.Version, err)
+	}
+	expanded := template.Clone()
+	expanded.Contents = contents
+	return expanded, nil
+}
+
+// 조회한 템플릿의 포함 지시문(#include)과 객체 참조를 현재 템플릿과 객체 값으로 펼치는 TemplateLookup을 반환합니다.
+// 배포, 배포 계획, 자동 롤백이 모두 배포 시점의 포함 템플릿과 객체 값을 사용하도록 합니다.
+func ExpandingLookup(templates TemplateLookup, objects ObjectLookup) TemplateLookup {
+	return func(version string) (*model.Template, error) {
+		template, err := templates(version)
+		if err != nil {
+			return nil, err
+		}
+		if template, err = model.ResolveIncludes(template, templates); err != nil {
+			return nil, err
+		}
+		list, err := objects()
+		if err != nil {
+			return nil, err
+		}
+		return ExpandTemplate(template, list)
+	}
+}
@@ -0,0 +1,53 @@
+package deploy
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"fms_wails/internal/model"
+)
+
+// TestExpandingLookup 배포 시 템플릿의 객체 참조를 현재 객체 값으로 펼침
+func TestExpandingLookup(t *testing.T) {
+	stored := map[string]*model.Template{
+		"v1": model.NewTemplate("v1", "agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=@web --sip=@jump"),
+		"v2": model.NewTemplate("v2", "agent -m=insert -c=INPUT -a=DROP --sip=@missing"),
+	}
+	templates := func(version string) (*model.Template, error) {
+		if t, ok := stored[version]; ok {
+			return t.Clone(), nil
+		}
+		return nil, fmt.Errorf("템플릿을 찾을 수 없습니다: %s", version)
+	}
+	objects := []*model.NamedObject{
+		model.NewNamedObject("web", model.ObjectKindService, "tcp/80", "tcp/443"),
+		model.NewNamedObject("jump", model.ObjectKindAddress, "10.0.0.5"),
+	}
+	lookup := ExpandingLookup(templates, func() ([]*model.NamedObject, error) { return objects, nil })
+
+	template, err := lookup("v1")
+	if err != nil {
+		t.Fatalf("lookup(v1) error = %v", err)
+	}
+	want := "agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=80,443 --sip=10.0.0.5"
+	if template.Version != "v1" || template.Contents != want {
+		t.Errorf("lookup(v1) = %+v, want %s", template, want)
+	}
+	if stored["v1"].Contents == want {
+		t.Error("저장된 템플릿이 변경되었습니다")
+	}
+
+	// 객체 변경은 다음 조회부터 반영
+	objects[1].Members = []string{"10.0.0.6"}
+	if template, _ := lookup("v1"); !strings.HasSuffix(template.Contents, "--sip=10.0.0.6") {
+		t.Errorf("lookup(v1) = %s, want --sip=10.0.0.6", template.Contents)
+	}
+
+	if _, err := lookup("v2"); err == nil || !strings.Contains(err.Error(), "템플릿 v2") || !strings.Contains(err.Error(), "@missing") {
+		t.Errorf("lookup(v2) error = %v, want 없는 객체 에러", err)
+	}
+	if _, err := lookup("v3"); err == nil {
+		t.Error("lookup(v3) error = nil, want 템플릿 없음")
+	}
+}
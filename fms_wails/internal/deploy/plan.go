@@ -0,0 +1,306 @@
+package deploy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"fms_wails/internal/model"
+	"fms_wails/internal/parser"
+)
+
+// 배포 계획에 변경 사항이 없을 때 반환되는 에러입니다.
+var ErrEmptyPlan = errors.New("변경되는 규칙이 없어 배포하지 않습니다")
+
+// 규칙 변경 유형 상수
+const (
+	ChangeAdded   = "added"   // 새로 추가되는 규칙
+	ChangeRemoved = "removed" // 삭제되는 규칙
+	ChangeMoved   = "moved"   // 순서가 바뀌는 규칙
+)
+
+// 규칙 테이블 상수
+const (
+	TableFilter = "filter"
+	TableNAT    = "nat"
+)
+
+// 규칙 하나의 변경 내용을 나타냅니다.
+type RuleChange struct {
+	Type     string `json:"type"`     // 변경 유형 (added/removed/moved)
+	Table    string `json:"table"`    // 규칙 테이블 (filter/nat)
+	Rule     string `json:"rule"`     // 규칙 내용
+	OldIndex int    `json:"oldIndex"` // 현재 버전에서의 순서 (1부터, 추가된 규칙은 0)
+	NewIndex int    `json:"newIndex"` // 배포할 버전에서의 순서 (1부터, 삭제된 규칙은 0)
+}
+
+// 장비 하나의 배포 계획을 나타냅니다.
+type DevicePlan struct {
+	Firewall       *model.Firewall `json:"-"`              // 대상 장비
+	DeviceName     string          `json:"deviceName"`     // 장비 IP
+	CurrentVersion string          `json:"currentVersion"` // 장비에 기록된 현재 버전
+	TargetVersion  string          `json:"targetVersion"`  // 배포할 버전
+	Changes        []RuleChange    `json:"changes"`        // 규칙 변경 목록
+	Warning        string          `json:"warning"`        // 현재 버전을 비교할 수 없는 경우 사유
+}
+
+// 변경 사항이 있는지 확인합니다.
+func (p *DevicePlan) HasChanges() bool {
+	return len(p.Changes) > 0
+}
+
+// 변경 유형별 규칙 수를 반환합니다.
+func (p *DevicePlan) CountChanges() (added, removed, moved int) {
+	for _, c := range p.Changes {
+		switch c.Type {
+		case ChangeAdded:
+			added++
+		case ChangeRemoved:
+			removed++
+		case ChangeMoved:
+			moved++
+		}
+	}
+	return added, removed, moved
+}
+
+// 여러 장비에 대한 배포 계획을 나타냅니다.
+type DeployPlan struct {
+	TemplateVersion string        `json:"templateVersion"` // 배포할 템플릿 버전
+	Devices         []*DevicePlan `json:"devices"`         // 장비별 계획 (입력 순서)
+}
+
+// 모든 장비에 변경 사항이 없는지 확인합니다.
+func (p *DeployPlan) IsEmpty() bool {
+	for _, device := range p.Devices {
+		if device.HasChanges() {
+			return false
+		}
+	}
+	return true
+}
+
+// 장비별 현재 버전과 배포할 템플릿을 비교하여 배포 계획을 생성합니다.
+// 현재 버전을 알 수 없거나 템플릿을 찾을 수 없으면 모든 규칙을 추가로 간주합니다.
+// 템플릿 변수는 양쪽 모두 장비의 변수 값으로 바꾼 뒤 비교합니다.
+func BuildPlan(firewalls []*model.Firewall, template *model.Template, lookup TemplateLookup, variables VariableLookup) (*DeployPlan, error) {
+	target, err := planRules(template.Contents)
+	if err != nil {
+		return nil, fmt.Errorf("템플릿 %s 파싱 실패: %v", template.Version, err)
+	}
+
+	plan := &DeployPlan{TemplateVersion: template.Version}
+
+	// 같은 버전의 비교 결과 재사용 (변수를 참조하는 버전은 장비마다 다르므로 제외)
+	cache := make(map[string]*planSource)
+	perDevice := model.HasTemplateVariables(template.Contents)
+
+	for _, fw := range firewalls {
+		device := &DevicePlan{
+			Firewall:       fw,
+			DeviceName:     fw.DeviceName,
+			CurrentVersion: fw.Version,
+			TargetVersion:  template.Version,
+		}
+
+		deviceTarget := target
+		if perDevice {
+			rendered, err := RenderTemplate(template, fw, variables)
+			if err != nil {
+				return nil, fmt.Errorf("장비 %s: %v", fw.DeviceName, err)
+			}
+			if deviceTarget, err = planRules(rendered.Contents); err != nil {
+				return nil, fmt.Errorf("장비 %s: 템플릿 %s 파싱 실패: %v", fw.DeviceName, template.Version, err)
+			}
+		}
+
+		current, ok := cache[fw.Version]
+		if !ok {
+			current = loadPlanSource(fw, lookup, variables)
+			if !current.perDevice {
+				cache[fw.Version] = current
+			}
+		}
+		device.Warning = current.warning
+
+		device.Changes = append(device.Changes, diffRules(TableFilter, current.rules.filter, deviceTarget.filter)...)
+		device.Changes = append(device.Changes, diffRules(TableNAT, current.rules.nat, deviceTarget.nat)...)
+
+		plan.Devices = append(plan.Devices, device)
+	}
+
+	return plan, nil
+}
+
+// 테이블별로 정규화된 규칙 목록입니다.
+type planRuleSet struct {
+	filter []string
+	nat    []string
+}
+
+// 장비의 현재 버전 규칙과 비교 불가 사유입니다.
+type planSource struct {
+	rules     planRuleSet
+	warning   string
+	perDevice bool // 템플릿 변수를 장비 값으로 바꾼 결과인지 여부
+}
+
+// 장비의 현재 버전 템플릿을 조회하여 비교 대상 규칙을 만듭니다.
+func loadPlanSource(fw *model.Firewall, lookup TemplateLookup, variables VariableLookup) *planSource {
+	version := fw.Version
+	if version == "" || version == "-" {
+		return &planSource{warning: "현재 배포 버전 정보가 없어 모든 규칙을 추가로 표시합니다"}
+	}
+	if lookup == nil {
+		return &planSource{warning: fmt.Sprintf("현재 버전 %s의 템플릿을 조회할 수 없어 모든 규칙을 추가로 표시합니다", version)}
+	}
+
+	current, err := lookup(version)
+	if err != nil {
+		return &planSource{warning: fmt.Sprintf("현재 버전 %s의 템플릿을 찾을 수 없어 모든 규칙을 추가로 표시합니다", version)}
+	}
+
+	perDevice := model.HasTemplateVariables(current.Contents)
+	if perDevice {
+		if current, err = RenderTemplate(current, fw, variables); err != nil {
+			return &planSource{warning: fmt.Sprintf("현재 버전 %s의 변수를 적용할 수 없어 모든 규칙을 추가로 표시합니다: %v", version, err), perDevice: true}
+		}
+	}
+
+	// 현재 버전은 이미 배포된 내용이므로 파싱할 수 없는 라인은 무시
+	rules, _ := planRules(current.Contents)
+	return &planSource{rules: rules, perDevice: perDevice}
+}
+
+// 템플릿 내용을 정규화된 규칙 목록으로 변환합니다.
+// 같은 규칙은 표기 방식과 관계없이 같은 문자열이 됩니다.
+func planRules(contents string) (planRuleSet, error) {
+	var set planRuleSet
+
+	rules, _, errs := parser.ParseTextToRules(contents)
+	for _, rule := range rules {
+		set.filter = append(set.filter, parser.RuleToLine(rule))
+	}
+
+	natRules, _, natErrs := parser.ParseTextToNATRules(contents)
+	for _, rule := range natRules {
+		set.nat = append(set.nat, parser.NATRuleToLine(rule))
+	}
+
+	errs = append(errs, natErrs...)
+	if len(errs) > 0 {
+		msgs := make([]string, len(errs))
+		for i, err := range errs {
+			msgs[i] = err.Error()
+		}
+		return set, errors.New(strings.Join(msgs, "; "))
+	}
+	return set, nil
+}
+
+// 두 규칙 목록을 비교하여 추가/삭제/순서 변경을 계산합니다.
+// 양쪽에 모두 있는 규칙 중 최장 공통 부분 수열에 속하지 않는 규칙을 순서 변경으로 봅니다.
+func diffRules(table string, oldRules, newRules []string) []RuleChange {
+	oldCount := make(map[string]int)
+	for _, r := range oldRules {
+		oldCount[r]++
+	}
+	newCount := make(map[string]int)
+	for _, r := range newRules {
+		newCount[r]++
+	}
+
+	var changes []RuleChange
+
+	// 삭제된 규칙 (중복 규칙은 개수 기준)
+	var oldCommon []int
+	seen := make(map[string]int)
+	for i, r := range oldRules {
+		seen[r]++
+		if seen[r] > newCount[r] {
+			changes = append(changes, RuleChange{Type: ChangeRemoved, Table: table, Rule: r, OldIndex: i + 1})
+			continue
+		}
+		oldCommon = append(oldCommon, i)
+	}
+
+	// 추가된 규칙
+	var newCommon []int
+	seen = make(map[string]int)
+	var added []RuleChange
+	for i, r := range newRules {
+		seen[r]++
+		if seen[r] > oldCount[r] {
+			added = append(added, RuleChange{Type: ChangeAdded, Table: table, Rule: r, NewIndex: i + 1})
+			continue
+		}
+		newCommon = append(newCommon, i)
+	}
+
+	// 공통 규칙의 최장 공통 부분 수열 계산
+	n, m := len(oldCommon), len(newCommon)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if oldRules[oldCommon[i]] == newRules[newCommon[j]] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	inOrderOld := make(map[int]bool)
+	inOrderNew := make(map[int]bool)
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case oldRules[oldCommon[i]] == newRules[newCommon[j]]:
+			inOrderOld[i] = true
+			inOrderNew[j] = true
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	// 순서가 바뀐 규칙의 이전 위치 매칭
+	movedFrom := make(map[string][]int)
+	for i, idx := range oldCommon {
+		if !inOrderOld[i] {
+			r := oldRules[idx]
+			movedFrom[r] = append(movedFrom[r], idx)
+		}
+	}
+
+	var moved []RuleChange
+	for j, idx := range newCommon {
+		if inOrderNew[j] {
+			continue
+		}
+		r := newRules[idx]
+		from := movedFrom[r][0]
+		movedFrom[r] = movedFrom[r][1:]
+		moved = append(moved, RuleChange{Type: ChangeMoved, Table: table, Rule: r, OldIndex: from + 1, NewIndex: idx + 1})
+	}
+
+	// 추가/순서 변경은 배포 버전 순서대로 정렬
+	for len(added) > 0 || len(moved) > 0 {
+		if len(moved) == 0 || (len(added) > 0 && added[0].NewIndex < moved[0].NewIndex) {
+			changes = append(changes, added[0])
+			added = added[1:]
+		} else {
+			changes = append(changes, moved[0])
+			moved = moved[1:]
+		}
+	}
+
+	return changes
+}
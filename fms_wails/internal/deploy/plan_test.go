@@ -0,0 +1,144 @@
+package deploy
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"fms_wails/internal/model"
+)
+
+// TestDiffRules 규칙 목록 비교 테스트
+func TestDiffRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldRules []string
+		newRules []string
+		expected []RuleChange
+	}{
+		{
+			name:     "동일",
+			oldRules: []string{"a", "b", "c"},
+			newRules: []string{"a", "b", "c"},
+			expected: nil,
+		},
+		{
+			name:     "추가 및 삭제",
+			oldRules: []string{"a", "b", "c"},
+			newRules: []string{"a", "c", "d"},
+			expected: []RuleChange{
+				{Type: ChangeRemoved, Rule: "b", OldIndex: 2},
+				{Type: ChangeAdded, Rule: "d", NewIndex: 3},
+			},
+		},
+		{
+			name:     "순서 변경",
+			oldRules: []string{"a", "b", "c"},
+			newRules: []string{"c", "a", "b"},
+			expected: []RuleChange{
+				{Type: ChangeMoved, Rule: "c", OldIndex: 3, NewIndex: 1},
+			},
+		},
+		{
+			name:     "중복 규칙",
+			oldRules: []string{"a", "a"},
+			newRules: []string{"a"},
+			expected: []RuleChange{
+				{Type: ChangeRemoved, Rule: "a", OldIndex: 2},
+			},
+		},
+		{
+			name:     "빈 현재 버전",
+			oldRules: nil,
+			newRules: []string{"a", "b"},
+			expected: []RuleChange{
+				{Type: ChangeAdded, Rule: "a", NewIndex: 1},
+				{Type: ChangeAdded, Rule: "b", NewIndex: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := diffRules(TableFilter, tt.oldRules, tt.newRules)
+			if len(changes) != len(tt.expected) {
+				t.Fatalf("diffRules() = %+v, want %+v", changes, tt.expected)
+			}
+			for i, want := range tt.expected {
+				want.Table = TableFilter
+				if changes[i] != want {
+					t.Errorf("changes[%d] = %+v, want %+v", i, changes[i], want)
+				}
+			}
+		})
+	}
+}
+
+// TestBuildPlan 장비별 배포 계획 생성 테스트
+func TestBuildPlan(t *testing.T) {
+	v1 := model.NewTemplate("v1", strings.Join([]string{
+		"# 기본 정책",
+		"agent -m=insert -c=INPUT -p=tcp --dport=22 -a=ACCEPT",
+		"agent -m=insert -c=INPUT -p=tcp --dport=80 -a=ACCEPT",
+		"agent -t=nat -m=insert -c=PREROUTING -p=tcp --dport=8080 -j=DNAT --to-destination=10.0.0.1:80",
+	}, "\n"))
+	v2 := model.NewTemplate("v2", strings.Join([]string{
+		"agent -m=insert -c=INPUT -p=tcp --dport=80 -a=ACCEPT",
+		"agent -m=insert -c=INPUT -p=tcp --dport=22 -a=ACCEPT",
+		"agent -m=insert -c=INPUT -p=tcp --dport=443 -a=ACCEPT",
+	}, "\n"))
+	lookup := func(version string) (*model.Template, error) {
+		switch version {
+		case "v1":
+			return v1, nil
+		case "v2":
+			return v2, nil
+		}
+		return nil, fmt.Errorf("템플릿을 찾을 수 없습니다: %s", version)
+	}
+
+	current := model.NewFirewall("10.0.0.1")
+	current.Version = "v1"
+	same := model.NewFirewall("10.0.0.2")
+	same.Version = "v2"
+	unknown := model.NewFirewall("10.0.0.3")
+
+	plan, err := BuildPlan([]*model.Firewall{current, same, unknown}, v2, lookup, nil)
+	if err != nil {
+		t.Fatalf("BuildPlan() error: %v", err)
+	}
+	if len(plan.Devices) != 3 {
+		t.Fatalf("len(Devices) = %d, want 3", len(plan.Devices))
+	}
+
+	added, removed, moved := plan.Devices[0].CountChanges()
+	if added != 1 || removed != 1 || moved != 1 {
+		t.Errorf("v1 → v2 = (added %d, removed %d, moved %d), want (1, 1, 1)", added, removed, moved)
+	}
+	if plan.Devices[1].HasChanges() {
+		t.Errorf("v2 → v2 변경 사항 = %+v, want 없음", plan.Devices[1].Changes)
+	}
+	added, _, _ = plan.Devices[2].CountChanges()
+	if added != 3 || plan.Devices[2].Warning == "" {
+		t.Errorf("버전 없음 = (added %d, warning %q), want (3, 경고)", added, plan.Devices[2].Warning)
+	}
+	if plan.IsEmpty() {
+		t.Error("IsEmpty() = true, want false")
+	}
+
+	plan, err = BuildPlan([]*model.Firewall{same}, v2, lookup, nil)
+	if err != nil {
+		t.Fatalf("BuildPlan() error: %v", err)
+	}
+	if !plan.IsEmpty() {
+		t.Error("IsEmpty() = false, want true")
+	}
+}
+
+// TestBuildPlanInvalidTemplate 파싱할 수 없는 템플릿 계획 거부 테스트
+func TestBuildPlanInvalidTemplate(t *testing.T) {
+	template := model.NewTemplate("bad", "iptables -A INPUT -p tcp --dport 22 -j ACCEPT")
+	if _, err := BuildPlan(nil, template, nil, nil); err == nil {
+		t.Error("BuildPlan()이 잘못된 템플릿에 대해 에러를 반환하지 않았습니다")
+	}
+}
@@ -0,0 +1,161 @@
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"fms_wails/internal/model"
+)
+
+// newRolloutTestServer 배포 및 상태 확인 요청을 처리하는 테스트 장비 서버 생성
+// fail이 true이면 배포 요청에 오류 응답
+func newRolloutTestServer(fail bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/respCheck" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []model.DeployResult{
+				{IP: r.Host, Status: model.DeployStatusSuccess},
+			},
+		})
+	}))
+}
+
+// TestWaveSizes 웨이브 분할 테스트
+func TestWaveSizes(t *testing.T) {
+	opts := &model.RolloutOptions{CanarySize: 2, WaveSize: 3}
+	got := opts.WaveSizes(7)
+	want := []int{2, 3, 2}
+	if len(got) != len(want) {
+		t.Fatalf("WaveSizes(7) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("WaveSizes(7)[%d] = %d, want %d", i, got[i], want[i])
+		}
+	}
+	if got := opts.WaveSizes(0); len(got) != 0 {
+		t.Errorf("WaveSizes(0) = %v, want []", got)
+	}
+}
+
+// TestDeployRolloutSuccess 모든 웨이브 성공 시 전체 배포 테스트
+func TestDeployRolloutSuccess(t *testing.T) {
+	server := newRolloutTestServer(false)
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	firewalls := make([]*model.Firewall, 5)
+	for i := range firewalls {
+		firewalls[i] = model.NewFirewall(host)
+	}
+	template := model.NewTemplate("v1", "agent -m=insert -c=INPUT -p=tcp --dport=22 -a=DROP")
+	opts := &model.RolloutOptions{CanarySize: 1, WaveSize: 2}
+
+	var phases []string
+	deployer := NewDeployer(model.DefaultConfig())
+	rollout, err := deployer.DeployRollout(context.Background(), firewalls, template, opts, func(p RolloutProgress) {
+		phases = append(phases, p.Phase)
+	})
+	if err != nil {
+		t.Fatalf("DeployRollout() error: %v", err)
+	}
+
+	if rollout.Halted {
+		t.Errorf("Halted = true, reason: %s", rollout.HaltReason)
+	}
+	if len(rollout.Waves) != 3 {
+		t.Fatalf("len(Waves) = %d, want 3", len(rollout.Waves))
+	}
+	wantWaves := []int{1, 2, 2, 3, 3}
+	for i, result := range rollout.Results {
+		if !result.Success {
+			t.Errorf("Results[%d].Success = false, error: %s", i, result.ErrorMsg)
+		}
+		if result.History.Wave != wantWaves[i] {
+			t.Errorf("Results[%d].History.Wave = %d, want %d", i, result.History.Wave, wantWaves[i])
+		}
+		if result.History.RolloutID != rollout.RolloutID {
+			t.Errorf("Results[%d].History.RolloutID = %q, want %q", i, result.History.RolloutID, rollout.RolloutID)
+		}
+	}
+	if len(phases) == 0 || phases[0] != RolloutPhaseDeploy {
+		t.Errorf("phases = %v, 첫 단계는 %s이어야 합니다", phases, RolloutPhaseDeploy)
+	}
+}
+
+// TestDeployRolloutHalt 카나리 실패 시 롤아웃 중단 테스트
+func TestDeployRolloutHalt(t *testing.T) {
+	failServer := newRolloutTestServer(true)
+	defer failServer.Close()
+	okServer := newRolloutTestServer(false)
+	defer okServer.Close()
+
+	firewalls := []*model.Firewall{
+		model.NewFirewall(strings.TrimPrefix(failServer.URL, "http://")),
+		model.NewFirewall(strings.TrimPrefix(okServer.URL, "http://")),
+		model.NewFirewall(strings.TrimPrefix(okServer.URL, "http://")),
+	}
+	for _, fw := range firewalls {
+		fw.Version = "v0"
+	}
+	template := model.NewTemplate("v1", "agent -m=insert -c=INPUT -p=tcp --dport=22 -a=DROP")
+	template.Revision = model.TemplateHash(template.Contents)
+	opts := &model.RolloutOptions{CanarySize: 1, WaveSize: 2, MaxFailurePercent: 0}
+
+	deployer := NewDeployer(model.DefaultConfig())
+	rollout, err := deployer.DeployRollout(context.Background(), firewalls, template, opts, nil)
+	if err != nil {
+		t.Fatalf("DeployRollout() error: %v", err)
+	}
+
+	if !rollout.Halted {
+		t.Fatal("Halted = false, want true")
+	}
+	if rollout.HaltReason == "" {
+		t.Error("HaltReason가 비어 있습니다")
+	}
+	if len(rollout.Waves) != 1 {
+		t.Errorf("len(Waves) = %d, want 1", len(rollout.Waves))
+	}
+	if len(rollout.Results) != len(firewalls) {
+		t.Fatalf("len(Results) = %d, want %d", len(rollout.Results), len(firewalls))
+	}
+
+	if rollout.Results[0].History.Status != model.DeployStatusFail {
+		t.Errorf("카나리 Status = %s, want %s", rollout.Results[0].History.Status, model.DeployStatusFail)
+	}
+	for i, result := range rollout.Results[1:] {
+		if result.History.Status != model.DeployStatusHalted {
+			t.Errorf("Results[%d].History.Status = %s, want %s", i+1, result.History.Status, model.DeployStatusHalted)
+		}
+		if result.History.Wave != 2 {
+			t.Errorf("Results[%d].History.Wave = %d, want 2", i+1, result.History.Wave)
+		}
+		if result.History.Revision != template.Revision {
+			t.Errorf("Results[%d].History.Revision = %q, want %q", i+1, result.History.Revision, template.Revision)
+		}
+		if result.Firewall.Version != "v0" {
+			t.Errorf("Results[%d].Firewall.Version = %s, want v0", i+1, result.Firewall.Version)
+		}
+	}
+}
+
+// TestDeployRolloutInvalidOptions 잘못된 설정 거부 테스트
+func TestDeployRolloutInvalidOptions(t *testing.T) {
+	deployer := NewDeployer(model.DefaultConfig())
+	opts := &model.RolloutOptions{CanarySize: 0, WaveSize: 1}
+	if _, err := deployer.DeployRollout(context.Background(), nil, model.NewTemplate("v1", "agent"), opts, nil); err == nil {
+		t.Error("CanarySize 0에 대해 에러가 반환되지 않았습니다")
+	}
+}
@@ -0,0 +1,34 @@
+package deploy
+
+import (
+	"context"
+
+	"fms_wails/internal/http"
+	"fms_wails/internal/model"
+	"fms_wails/internal/ssh"
+)
+
+// 장비에 접속하는 전송 방식입니다. (Agent/Direct HTTP, SSH)
+type Transport interface {
+	// 장비 상태(running/stop)를 확인합니다.
+	CheckHealth(ctx context.Context, fw *model.Firewall) (string, error)
+	// 장비에 템플릿을 배포합니다.
+	DeployTemplate(ctx context.Context, fw *model.Firewall, template string) (*model.DeployResult, error)
+	// 장비에 적용된 규칙을 조회합니다. (지원하지 않는 전송 방식은 에러 반환)
+	FetchRules(ctx context.Context, fw *model.Firewall) (string, error)
+}
+
+// Agent 서버를 통해 여러 장비를 한번의 요청으로 처리할 수 있는 전송 방식입니다.
+type batchTransport interface {
+	Transport
+	CheckHealthViaAgent(ctx context.Context, ipAddrs []string) (map[string]bool, error)
+	DeployBatchViaAgent(ctx context.Context, deviceIPs []string, template string) (map[string]*model.DeployResult, error)
+}
+
+// 설정의 연결 모드에 맞는 전송 방식을 생성합니다.
+func NewTransport(config *model.Config) Transport {
+	if config.IsSSHMode() {
+		return ssh.NewClient(config)
+	}
+	return http.NewClient(config)
+}
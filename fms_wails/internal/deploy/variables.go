@@ -0,0 +1,90 @@
+package deploy
+
+import (
+	"fmt"
+	"strings"
+
+	"fms_wails/internal/model"
+	"fms_wails/internal/parser"
+)
+
+// 장비에 적용할 템플릿 변수 값을 조회하는 함수입니다.
+type VariableLookup func(fw *model.Firewall) (map[string]string, error)
+
+// 이름으로 장비 그룹을 조회하는 함수입니다.
+type GroupLookup func(name string) (*model.DeviceGroup, error)
+
+// 장비 그룹의 변수 위에 장비의 변수를 덮어쓰는 VariableLookup을 반환합니다.
+// 그룹이 지정되지 않은 장비는 장비의 변수만 사용합니다.
+func GroupVariables(groups GroupLookup) VariableLookup {
+	return func(fw *model.Firewall) (map[string]string, error) {
+		if fw.Group == "" || groups == nil {
+			return model.MergeVariables(nil, fw), nil
+		}
+		group, err := groups(fw.Group)
+		if err != nil {
+			return nil, err
+		}
+		return model.MergeVariables(group, fw), nil
+	}
+}
+
+// 장비의 변수 값으로 템플릿 변수(${이름})를 바꾼 배포용 복사본을 반환합니다.
+// 변수를 참조하지 않는 템플릿은 그대로 반환합니다.
+func RenderTemplate(template *model.Template, fw *model.Firewall, variables VariableLookup) (*model.Template, error) {
+	if !model.HasTemplateVariables(template.Contents) {
+		return template, nil
+	}
+	values := map[string]string{}
+	if variables != nil {
+		var err error
+		if values, err = variables(fw); err != nil {
+			return nil, err
+		}
+	}
+	contents, err := model.RenderVariables(template.Contents, values)
+	if err != nil {
+		return nil, err
+	}
+	rendered := template.Clone()
+	rendered.Contents = contents
+	return rendered, nil
+}
+
+// 배포 전에 모든 대상 장비에 대해 템플릿 변수를 렌더링하여 검사합니다.
+// 정의되지 않은 변수, 렌더링 후 규칙 오류, 실패한 기대 결과(# expect:)를 장비별로 모아 반환합니다.
+// 변수를 참조하지 않는 템플릿은 항상 통과합니다.
+func CheckVariables(firewalls []*model.Firewall, template *model.Template, variables VariableLookup) error {
+	if !model.HasTemplateVariables(template.Contents) {
+		return nil
+	}
+
+	var problems []string
+	for _, fw := range firewalls {
+		rendered, err := RenderTemplate(template, fw, variables)
+		if err == nil {
+			err = checkRendered(rendered)
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("장비 %s: %v", fw.DeviceName, err))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("템플릿 %s의 변수를 적용할 수 없는 장비가 %d대 있어 배포하지 않습니다:\n%s",
+			template.Version, len(problems), strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// 변수를 적용한 템플릿의 규칙 오류와 기대 결과를 검사합니다.
+func checkRendered(rendered *model.Template) error {
+	for _, d := range model.ValidateTemplate(rendered.Contents) {
+		if d.IsError() {
+			return fmt.Errorf("변수 적용 후 규칙 오류: %s", d)
+		}
+	}
+	if failures := parser.FailedExpectations(rendered.Contents); len(failures) > 0 {
+		return fmt.Errorf("변수 적용 후 기대 결과 실패: %s", failures[0])
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"fms_wails/internal/model"
+)
+
+// newVariableTestFirewalls 그룹 seoul에 속한 장비 2대와 그룹이 없는 장비 1대 생성
+func newVariableTestFirewalls() []*model.Firewall {
+	fw1 := model.NewFirewall("10.1.0.1")
+	fw1.Group = "seoul"
+	fw2 := model.NewFirewall("10.1.0.2")
+	fw2.Group = "seoul"
+	fw2.Variables = map[string]string{"MGMT_NET": "10.2.0.0/24"}
+	fw3 := model.NewFirewall("10.3.0.1")
+	return []*model.Firewall{fw1, fw2, fw3}
+}
+
+// testGroupLookup seoul 그룹만 있는 그룹 조회 함수
+func testGroupLookup(name string) (*model.DeviceGroup, error) {
+	if name != "seoul" {
+		return nil, fmt.Errorf("장비 그룹을 찾을 수 없습니다: %s", name)
+	}
+	group := model.NewDeviceGroup("seoul")
+	group.Variables["MGMT_NET"] = "10.1.0.0/24"
+	return group, nil
+}
+
+// TestGroupVariables 그룹 변수와 장비 변수 병합 테스트
+func TestGroupVariables(t *testing.T) {
+	lookup := GroupVariables(testGroupLookup)
+	firewalls := newVariableTestFirewalls()
+
+	want := []string{"10.1.0.0/24", "10.2.0.0/24", ""}
+	for i, fw := range firewalls {
+		values, err := lookup(fw)
+		if err != nil {
+			t.Fatalf("lookup(%s) error: %v", fw.DeviceName, err)
+		}
+		if values["MGMT_NET"] != want[i] {
+			t.Errorf("lookup(%s)[MGMT_NET] = %q, want %q", fw.DeviceName, values["MGMT_NET"], want[i])
+		}
+	}
+
+	missing := model.NewFirewall("10.9.0.1")
+	missing.Group = "busan"
+	if _, err := lookup(missing); err == nil {
+		t.Error("없는 그룹에 대해 에러가 반환되지 않았습니다")
+	}
+}
+
+// TestCheckVariables 장비별 변수 누락 및 렌더링 후 규칙 오류 검사 테스트
+func TestCheckVariables(t *testing.T) {
+	lookup := GroupVariables(testGroupLookup)
+	firewalls := newVariableTestFirewalls()
+	template := model.NewTemplate("v1", "agent -m=insert -c=INPUT -p=tcp --dport=22 --sip=${MGMT_NET} -a=ACCEPT")
+
+	err := CheckVariables(firewalls, template, lookup)
+	if err == nil {
+		t.Fatal("변수가 없는 장비에 대해 에러가 반환되지 않았습니다")
+	}
+	if !strings.Contains(err.Error(), "장비 10.3.0.1: 정의되지 않은 변수: ${MGMT_NET}") {
+		t.Errorf("CheckVariables() error = %v", err)
+	}
+	if strings.Contains(err.Error(), "10.1.0.1") {
+		t.Errorf("변수가 있는 장비가 에러에 포함되었습니다: %v", err)
+	}
+
+	if err := CheckVariables(firewalls[:2], template, lookup); err != nil {
+		t.Errorf("CheckVariables() error: %v", err)
+	}
+
+	// 렌더링 결과가 잘못된 IP이면 거부
+	firewalls[0].Variables = map[string]string{"MGMT_NET": "10.1.0.0/99"}
+	if err := CheckVariables(firewalls[:1], template, lookup); err == nil || !strings.Contains(err.Error(), "변수 적용 후 규칙 오류") {
+		t.Errorf("CheckVariables() error = %v, want 규칙 오류", err)
+	}
+
+	// 변수를 참조하지 않는 템플릿은 항상 통과
+	if err := CheckVariables(firewalls, model.NewTemplate("v2", "agent -m=insert -c=INPUT -a=DROP"), nil); err != nil {
+		t.Errorf("CheckVariables() error: %v", err)
+	}
+}
+
+// TestDeployRendersVariables 장비별로 변수를 적용하여 배포하고 누락 장비는 요청하지 않는지 테스트
+func TestDeployRendersVariables(t *testing.T) {
+	config := model.DefaultConfig()
+	config.ConnectionMode = model.ConnectionModeAgent
+	transport := &fakeTransport{}
+	deployer := NewDeployerWithTransport(config, transport)
+	deployer.SetVariableLookup(GroupVariables(testGroupLookup))
+
+	firewalls := newVariableTestFirewalls()
+	template := model.NewTemplate("v1", "agent -m=insert -c=INPUT -p=tcp --dport=22 --sip=${MGMT_NET} -a=ACCEPT")
+	results := deployer.DeployToMultiple(context.Background(), firewalls, template, nil)
+
+	wantSIP := map[string]string{"10.1.0.1": "--sip=10.1.0.0/24", "10.1.0.2": "--sip=10.2.0.0/24"}
+	for device, sip := range wantSIP {
+		if !strings.Contains(transport.templates[device], sip) {
+			t.Errorf("%s에 전송한 템플릿 = %q, want %s 포함", device, transport.templates[device], sip)
+		}
+	}
+	if _, ok := transport.templates["10.3.0.1"]; ok {
+		t.Error("변수가 없는 장비에 배포 요청이 전송되었습니다")
+	}
+
+	if !results[0].Success || !results[1].Success {
+		t.Errorf("변수가 있는 장비의 배포 실패: %s / %s", results[0].ErrorMsg, results[1].ErrorMsg)
+	}
+	if results[2].Success || results[2].Firewall.DeployStatus != model.DeployStatusFail {
+		t.Errorf("results[2] = Success %v, DeployStatus %s, want 실패", results[2].Success, results[2].Firewall.DeployStatus)
+	}
+	if results[2].Firewall.Version == "v1" {
+		t.Error("배포하지 않은 장비의 버전이 변경되었습니다")
+	}
+	// 이력에는 변수가 포함된 원본 템플릿 버전을 기록
+	if results[0].History.TemplateVer != "v1" {
+		t.Errorf("History.TemplateVer = %s, want v1", results[0].History.TemplateVer)
+	}
+}
+
+// TestDeployRolloutMissingVariable 변수가 없는 장비가 있으면 롤아웃을 시작하지 않는지 테스트
+func TestDeployRolloutMissingVariable(t *testing.T) {
+	transport := &fakeTransport{}
+	deployer := NewDeployerWithTransport(model.DefaultConfig(), transport)
+	deployer.SetVariableLookup(GroupVariables(testGroupLookup))
+
+	template := model.NewTemplate("v1", "agent -m=insert -c=INPUT -p=tcp --sip=${MGMT_NET} -a=ACCEPT")
+	opts := &model.RolloutOptions{CanarySize: 1, WaveSize: 2}
+	result, err := deployer.DeployRollout(context.Background(), newVariableTestFirewalls(), template, opts, nil)
+	if err == nil || result != nil {
+		t.Fatalf("DeployRollout() = %v, %v, want 에러", result, err)
+	}
+	if len(transport.deployed) != 0 {
+		t.Errorf("배포 요청이 전송되었습니다: %v", transport.deployed)
+	}
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"fms_wails/internal/model"
+)
+
+// HMAC 서명 요청 헤더
+const (
+	HeaderKeyID     = "X-FMS-Key-Id"    // HMAC 키 ID (설정의 사용자명)
+	HeaderTimestamp = "X-FMS-Timestamp" // 서명 시각 (Unix 초)
+	HeaderSignature = "X-FMS-Signature" // 서명 (hex)
+)
+
+// 서버가 인증을 거부했을 때(401/403) 반환되는 StatusError와 일치하는 에러입니다.
+var ErrUnauthorized = errors.New("인증 실패")
+
+// 요청 서명을 계산합니다.
+// 서명 대상은 "메서드\n경로\n시각\n본문 SHA-256(hex)"이며, 키로 HMAC-SHA256을 계산하여 hex로 반환합니다.
+func SignRequest(secret, method, path, timestamp string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s", method, path, timestamp, hex.EncodeToString(bodyHash[:]))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// 요청에 인증 정보를 추가합니다.
+func applyAuth(req *http.Request, body []byte, auth *model.AuthConfig) error {
+	if !auth.IsEnabled() {
+		return nil
+	}
+	if err := auth.Validate(); err != nil {
+		return fmt.Errorf("인증 설정 오류: %v", err)
+	}
+
+	switch auth.Type {
+	case model.AuthTypeBearer:
+		req.Header.Set("Authorization", "Bearer "+auth.Secret)
+	case model.AuthTypeBasic:
+		req.SetBasicAuth(auth.Username, auth.Secret)
+	case model.AuthTypeHMAC:
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		if auth.Username != "" {
+			req.Header.Set(HeaderKeyID, auth.Username)
+		}
+		req.Header.Set(HeaderTimestamp, timestamp)
+		req.Header.Set(HeaderSignature, SignRequest(auth.Secret, req.Method, req.URL.RequestURI(), timestamp, body))
+	}
+	return nil
+}
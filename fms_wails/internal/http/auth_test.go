@@ -0,0 +1,109 @@
+package http
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"fms_wails/internal/model"
+)
+
+// newAuthTestServer 요청 헤더와 본문을 기록하고 status로 응답하는 테스트 장비 서버 생성
+func newAuthTestServer(t *testing.T, status int, got *http.Request, body *[]byte) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*got = *r.Clone(context.Background())
+		*body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+		io.WriteString(w, `{"data":[{"ip":"`+r.Host+`","status":"success"}]}`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestDirectRequestAuth 인증 방식별 요청 헤더 테스트
+func TestDirectRequestAuth(t *testing.T) {
+	var got http.Request
+	var body []byte
+	server := newAuthTestServer(t, http.StatusOK, &got, &body)
+	fw := model.NewFirewall(server.Listener.Addr().String())
+
+	t.Run("bearer", func(t *testing.T) {
+		config := model.DefaultConfig()
+		config.Auth = model.AuthConfig{Type: model.AuthTypeBearer, Secret: "token-1"}
+		if _, err := NewClient(config).CheckHealthDirect(context.Background(), fw); err != nil {
+			t.Fatalf("CheckHealthDirect() error: %v", err)
+		}
+		if auth := got.Header.Get("Authorization"); auth != "Bearer token-1" {
+			t.Errorf("Authorization = %q, want %q", auth, "Bearer token-1")
+		}
+	})
+
+	t.Run("basic", func(t *testing.T) {
+		config := model.DefaultConfig()
+		config.Auth = model.AuthConfig{Type: model.AuthTypeBasic, Username: "admin", Secret: "pass"}
+		if _, err := NewClient(config).CheckHealthDirect(context.Background(), fw); err != nil {
+			t.Fatalf("CheckHealthDirect() error: %v", err)
+		}
+		want := "Basic " + base64.StdEncoding.EncodeToString([]byte("admin:pass"))
+		if auth := got.Header.Get("Authorization"); auth != want {
+			t.Errorf("Authorization = %q, want %q", auth, want)
+		}
+	})
+
+	t.Run("hmac", func(t *testing.T) {
+		config := model.DefaultConfig()
+		config.Auth = model.AuthConfig{Type: model.AuthTypeHMAC, Username: "fms", Secret: "key"}
+		if _, err := NewClient(config).DeployDirect(context.Background(), fw, "agent -m=insert -c=INPUT -a=ACCEPT"); err != nil {
+			t.Fatalf("DeployDirect() error: %v", err)
+		}
+		want := SignRequest("key", http.MethodPost, "/agent/req-deploy", got.Header.Get(HeaderTimestamp), body)
+		if sig := got.Header.Get(HeaderSignature); sig != want {
+			t.Errorf("%s = %q, want %q", HeaderSignature, sig, want)
+		}
+		if keyID := got.Header.Get(HeaderKeyID); keyID != "fms" {
+			t.Errorf("%s = %q, want %q", HeaderKeyID, keyID, "fms")
+		}
+	})
+
+	t.Run("장비별 설정 우선", func(t *testing.T) {
+		config := model.DefaultConfig()
+		config.Auth = model.AuthConfig{Type: model.AuthTypeBearer, Secret: "global"}
+		override := fw.Clone()
+		override.Auth = &model.AuthConfig{Type: model.AuthTypeBearer, Secret: "device"}
+		if _, err := NewClient(config).CheckHealthDirect(context.Background(), override); err != nil {
+			t.Fatalf("CheckHealthDirect() error: %v", err)
+		}
+		if auth := got.Header.Get("Authorization"); auth != "Bearer device" {
+			t.Errorf("Authorization = %q, want %q", auth, "Bearer device")
+		}
+
+		override.Auth = &model.AuthConfig{Type: model.AuthTypeNone}
+		if _, err := NewClient(config).CheckHealthDirect(context.Background(), override); err != nil {
+			t.Fatalf("CheckHealthDirect() error: %v", err)
+		}
+		if auth := got.Header.Get("Authorization"); auth != "" {
+			t.Errorf("인증 없음 설정 시 Authorization = %q, want 없음", auth)
+		}
+	})
+}
+
+// TestDirectRequestUnauthorized 인증 거부 응답 테스트
+func TestDirectRequestUnauthorized(t *testing.T) {
+	var got http.Request
+	var body []byte
+	server := newAuthTestServer(t, http.StatusUnauthorized, &got, &body)
+	fw := model.NewFirewall(server.Listener.Addr().String())
+
+	_, err := NewClient(model.DefaultConfig()).DeployDirect(context.Background(), fw, "")
+	if err == nil {
+		t.Fatal("DeployDirect() error = nil, want 인증 실패")
+	}
+	if reason := AnalyzeConnectionError(err); reason != "인증 실패" {
+		t.Errorf("AnalyzeConnectionError() = %q, want %q", reason, "인증 실패")
+	}
+}
@@ -0,0 +1,211 @@
+package http
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"fms_wails/internal/model"
+)
+
+// newTLSTestServer 상태 확인 요청에 응답하는 TLS 테스트 장비 서버 생성
+func newTLSTestServer(t *testing.T, clientCAs *x509.CertPool) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	if clientCAs != nil {
+		server.TLS = &tls.Config{
+			ClientCAs:  clientCAs,
+			ClientAuth: tls.RequireAndVerifyClientCert,
+		}
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+// writePEM PEM 블록을 임시 파일로 저장하고 경로 반환
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("PEM 파일 저장 실패: %v", err)
+	}
+	return path
+}
+
+// newClientCert 자체 서명 클라이언트 인증서를 생성하여 인증서/키 파일 경로 반환
+func newClientCert(t *testing.T) (*x509.Certificate, string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("키 생성 실패: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "fms-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("인증서 생성 실패: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("인증서 파싱 실패: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("키 변환 실패: %v", err)
+	}
+
+	certFile := writePEM(t, "client.crt", "CERTIFICATE", der)
+	keyFile := writePEM(t, "client.key", "EC PRIVATE KEY", keyDER)
+	return cert, certFile, keyFile
+}
+
+// checkHealth HTTPS 직접 연결로 상태 확인
+func checkHealth(server *httptest.Server, tlsConfig model.TLSConfig) (bool, error) {
+	config := model.DefaultConfig()
+	config.DeviceScheme = model.SchemeHTTPS
+	config.DeviceTLS = tlsConfig
+	client := NewClient(config)
+	return client.CheckHealthDirect(context.Background(), model.NewFirewall(server.Listener.Addr().String()))
+}
+
+// TestCheckHealthDirectTLS HTTPS 직접 연결 인증서 검증 테스트
+func TestCheckHealthDirectTLS(t *testing.T) {
+	server := newTLSTestServer(t, nil)
+	serverCert := server.Certificate()
+	caFile := writePEM(t, "ca.crt", "CERTIFICATE", serverCert.Raw)
+
+	tests := []struct {
+		name       string
+		tlsConfig  model.TLSConfig
+		wantReason string // 빈 문자열이면 성공
+	}{
+		{
+			name:       "CA 미설정",
+			tlsConfig:  model.TLSConfig{},
+			wantReason: "인증서 신뢰 실패 (알 수 없는 CA)",
+		},
+		{
+			name:      "사용자 지정 CA",
+			tlsConfig: model.TLSConfig{CAFile: caFile},
+		},
+		{
+			name:      "서버 이름 지정",
+			tlsConfig: model.TLSConfig{CAFile: caFile, ServerName: "example.com"},
+		},
+		{
+			name:       "서버 이름 불일치",
+			tlsConfig:  model.TLSConfig{CAFile: caFile, ServerName: "fms.invalid"},
+			wantReason: "인증서 이름 불일치",
+		},
+		{
+			name:      "인증서 고정 일치",
+			tlsConfig: model.TLSConfig{CAFile: caFile, PinnedSHA256: []string{"sha256/" + PublicKeyPin(serverCert)}},
+		},
+		{
+			name:       "인증서 고정 불일치",
+			tlsConfig:  model.TLSConfig{CAFile: caFile, PinnedSHA256: []string{"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="}},
+			wantReason: "인증서 고정 불일치",
+		},
+		{
+			name:       "CA 파일 없음",
+			tlsConfig:  model.TLSConfig{CAFile: filepath.Join(t.TempDir(), "missing.crt")},
+			wantReason: "TLS 설정 오류",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := checkHealth(server, tt.tlsConfig)
+			if tt.wantReason == "" {
+				if err != nil || !ok {
+					t.Fatalf("CheckHealthDirect() = (%v, %v), want (true, nil)", ok, err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("CheckHealthDirect() error = nil, want %q", tt.wantReason)
+			}
+			if reason := AnalyzeConnectionError(err); reason != tt.wantReason {
+				t.Errorf("AnalyzeConnectionError() = %q, want %q (err: %v)", reason, tt.wantReason, err)
+			}
+		})
+	}
+}
+
+// TestCheckHealthDirectMutualTLS 클라이언트 인증서(mTLS) 테스트
+func TestCheckHealthDirectMutualTLS(t *testing.T) {
+	clientCert, certFile, keyFile := newClientCert(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+
+	server := newTLSTestServer(t, clientCAs)
+	caFile := writePEM(t, "ca.crt", "CERTIFICATE", server.Certificate().Raw)
+
+	ok, err := checkHealth(server, model.TLSConfig{CAFile: caFile, CertFile: certFile, KeyFile: keyFile})
+	if err != nil || !ok {
+		t.Fatalf("클라이언트 인증서 사용 시 CheckHealthDirect() = (%v, %v), want (true, nil)", ok, err)
+	}
+
+	_, err = checkHealth(server, model.TLSConfig{CAFile: caFile})
+	if err == nil {
+		t.Fatal("클라이언트 인증서 없이 연결에 성공했습니다")
+	}
+	if reason := AnalyzeConnectionError(err); reason != "클라이언트 인증서 거부" {
+		t.Errorf("AnalyzeConnectionError() = %q, want %q (err: %v)", reason, "클라이언트 인증서 거부", err)
+	}
+
+	_, err = checkHealth(server, model.TLSConfig{CAFile: caFile, CertFile: certFile})
+	if reason := AnalyzeConnectionError(err); reason != "TLS 설정 오류" {
+		t.Errorf("개인키 누락 시 AnalyzeConnectionError() = %q, want %q", reason, "TLS 설정 오류")
+	}
+}
+
+// TestFetchRulesUnsupported HTTP 연결은 규칙 조회 요청 없이 미지원 에러를 반환하는지 테스트
+func TestFetchRulesUnsupported(t *testing.T) {
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+	}))
+	defer server.Close()
+
+	for _, mode := range []string{model.ConnectionModeAgent, model.ConnectionModeDirect} {
+		config := model.DefaultConfig()
+		config.ConnectionMode = mode
+		config.AgentServerURL = server.URL
+		client := NewClient(config)
+
+		_, err := client.FetchRules(context.Background(), model.NewFirewall(server.Listener.Addr().String()))
+		if !errors.Is(err, ErrFetchRulesUnsupported) {
+			t.Errorf("%s FetchRules() error = %v, want ErrFetchRulesUnsupported", mode, err)
+		}
+	}
+	if requested {
+		t.Error("지원하지 않는 규칙 조회 요청이 전송되었습니다")
+	}
+}
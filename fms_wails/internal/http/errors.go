@@ -0,0 +1,221 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+	"unicode/utf8"
+
+	"fms_wails/internal/model"
+)
+
+// 응답 본문 발췌 최대 길이 (바이트)
+const bodyExcerptLimit = 200
+
+// HTTP 연결(Agent, Direct)에서 장비 규칙 조회를 요청하면 반환하는 에러입니다.
+// 규칙 조회는 장비에서 agent -m=list를 실행할 수 있는 SSH 연결에서만 지원합니다.
+var ErrFetchRulesUnsupported = errors.New("HTTP 연결(Agent, Direct)에서는 장비 규칙 조회를 지원하지 않습니다 (SSH 연결에서만 지원)")
+
+// 연결 실패 원인 분류를 제공하는 에러입니다.
+// Client의 모든 메서드는 연결 실패 시 이 인터페이스를 구현하는 에러를 (래핑하여) 반환합니다.
+type Error interface {
+	error
+	Category() string // model.ErrorCategory* 상수
+}
+
+// 응답 시간 초과 에러입니다.
+type TimeoutError struct {
+	Err error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("응답 시간 초과: %v", e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+func (e *TimeoutError) Category() string {
+	return model.ErrorCategoryTimeout
+}
+
+// 연결 거부 에러입니다.
+type RefusedError struct {
+	Err error
+}
+
+func (e *RefusedError) Error() string {
+	return fmt.Sprintf("연결 거부: %v", e.Err)
+}
+
+func (e *RefusedError) Unwrap() error {
+	return e.Err
+}
+
+func (e *RefusedError) Category() string {
+	return model.ErrorCategoryRefused
+}
+
+// 호스트 이름 확인(DNS) 실패 에러입니다.
+type DNSError struct {
+	Host string
+	Err  error
+}
+
+func (e *DNSError) Error() string {
+	return fmt.Sprintf("호스트 %s 이름 확인 실패: %v", e.Host, e.Err)
+}
+
+func (e *DNSError) Unwrap() error {
+	return e.Err
+}
+
+func (e *DNSError) Category() string {
+	return model.ErrorCategoryDNS
+}
+
+// TLS 핸드셰이크 또는 인증서 검증 실패 에러입니다.
+type TLSError struct {
+	Reason string // 사용자 표시용 사유 (예: 인증서 만료)
+	Err    error
+}
+
+func (e *TLSError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Reason, e.Err)
+}
+
+func (e *TLSError) Unwrap() error {
+	return e.Err
+}
+
+func (e *TLSError) Category() string {
+	return model.ErrorCategoryTLS
+}
+
+// 서버가 오류 상태 코드로 응답한 에러입니다.
+type StatusError struct {
+	Target     string // 요청 대상 (Agent 서버/장비)
+	StatusCode int
+	Body       string // 응답 본문 발췌
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("%s 응답 오류: %d", e.Target, e.StatusCode)
+	}
+	return fmt.Sprintf("%s 응답 오류: %d (%s)", e.Target, e.StatusCode, e.Body)
+}
+
+func (e *StatusError) Category() string {
+	if e.StatusCode == 401 || e.StatusCode == 403 {
+		return model.ErrorCategoryAuth
+	}
+	return model.ErrorCategoryHTTP
+}
+
+// 인증 거부(401/403) 응답이면 ErrUnauthorized와 일치합니다.
+func (e *StatusError) Is(target error) bool {
+	return target == ErrUnauthorized && e.Category() == model.ErrorCategoryAuth
+}
+
+// 응답을 읽거나 해석할 수 없는 에러입니다.
+type ProtocolError struct {
+	Err error
+}
+
+func (e *ProtocolError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ProtocolError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ProtocolError) Category() string {
+	return model.ErrorCategoryProtocol
+}
+
+// 응답에 요청한 장비의 결과가 없는 에러입니다.
+type MissingDeviceError struct {
+	DeviceIP string
+}
+
+func (e *MissingDeviceError) Error() string {
+	return fmt.Sprintf("장비 %s의 배포 결과를 찾을 수 없습니다", e.DeviceIP)
+}
+
+func (e *MissingDeviceError) Category() string {
+	return model.ErrorCategoryMissing
+}
+
+// 그 외 네트워크 에러입니다.
+type NetworkError struct {
+	Err error
+}
+
+func (e *NetworkError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+func (e *NetworkError) Category() string {
+	return model.ErrorCategoryNetwork
+}
+
+// 에러의 연결 실패 원인 분류를 반환합니다. 분류할 수 없으면 빈 문자열을 반환합니다.
+func CategoryOf(err error) string {
+	var typed Error
+	if errors.As(err, &typed) {
+		return typed.Category()
+	}
+	return ""
+}
+
+// 요청 전송 에러를 원인별 에러 타입으로 변환합니다.
+// 취소(context.Canceled)는 그대로 반환합니다. SSH 등 다른 전송 방식의 연결 에러에도 사용합니다.
+func ClassifyError(err error) error {
+	var typed Error
+	if err == nil || errors.Is(err, context.Canceled) || errors.As(err, &typed) {
+		return err
+	}
+
+	if reason := analyzeTLSError(err); reason != "" {
+		return &TLSError{Reason: reason, Err: err}
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return &DNSError{Host: dnsErr.Name, Err: err}
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return &TimeoutError{Err: err}
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) || strings.Contains(err.Error(), "connection refused") {
+		return &RefusedError{Err: err}
+	}
+
+	return &NetworkError{Err: err}
+}
+
+// 응답 본문의 앞부분을 한 줄로 발췌합니다.
+func excerptBody(body []byte) string {
+	text := strings.Join(strings.Fields(string(body)), " ")
+	if len(text) <= bodyExcerptLimit {
+		return text
+	}
+	cut := bodyExcerptLimit
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut] + "..."
+}
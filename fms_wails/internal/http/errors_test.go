@@ -0,0 +1,132 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"fms_wails/internal/model"
+)
+
+// TestClientErrorCategory 요청 실패 원인별 에러 분류 테스트
+func TestClientErrorCategory(t *testing.T) {
+	// 닫힌 포트 (연결 거부)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("포트 할당 실패: %v", err)
+	}
+	closedAddr := listener.Addr().String()
+	listener.Close()
+
+	newServer := func(status int, body string, delay time.Duration) string {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+			w.WriteHeader(status)
+			w.Write([]byte(body))
+		}))
+		t.Cleanup(server.Close)
+		return server.Listener.Addr().String()
+	}
+
+	tests := []struct {
+		name       string
+		addr       string
+		timeout    time.Duration
+		wantCat    string
+		wantReason string
+	}{
+		{
+			name:       "연결 거부",
+			addr:       closedAddr,
+			wantCat:    model.ErrorCategoryRefused,
+			wantReason: "연결 거부",
+		},
+		{
+			name:       "시간 초과",
+			addr:       newServer(http.StatusOK, "", time.Second),
+			timeout:    50 * time.Millisecond,
+			wantCat:    model.ErrorCategoryTimeout,
+			wantReason: "응답 없음 (시간 초과)",
+		},
+		{
+			name:       "DNS 실패",
+			addr:       "fms-device.invalid",
+			wantCat:    model.ErrorCategoryDNS,
+			wantReason: "호스트 이름 확인 실패",
+		},
+		{
+			name:       "HTTP 오류",
+			addr:       newServer(http.StatusInternalServerError, "iptables\n  failed", 0),
+			wantCat:    model.ErrorCategoryHTTP,
+			wantReason: "HTTP 500 오류: iptables failed",
+		},
+		{
+			name:       "인증 거부",
+			addr:       newServer(http.StatusForbidden, "", 0),
+			wantCat:    model.ErrorCategoryAuth,
+			wantReason: "인증 실패",
+		},
+		{
+			name:       "응답 형식 오류",
+			addr:       newServer(http.StatusOK, "<html>", 0),
+			wantCat:    model.ErrorCategoryProtocol,
+			wantReason: "응답 형식 오류",
+		},
+		{
+			name:       "장비 결과 없음",
+			addr:       newServer(http.StatusOK, `{"data":[]}`, 0),
+			wantCat:    model.ErrorCategoryMissing,
+			wantReason: "응답에 장비 결과 없음",
+		},
+	}
+
+	client := NewClient(model.DefaultConfig())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			_, err := client.DeployDirect(ctx, model.NewFirewall(tt.addr), "")
+			if err == nil {
+				t.Fatal("DeployDirect() error = nil")
+			}
+			if cat := CategoryOf(err); cat != tt.wantCat {
+				t.Errorf("CategoryOf() = %q, want %q (err: %v)", cat, tt.wantCat, err)
+			}
+			if reason := AnalyzeConnectionError(err); reason != tt.wantReason {
+				t.Errorf("AnalyzeConnectionError() = %q, want %q", reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+// TestStatusErrorExcerpt 응답 본문 발췌 테스트
+func TestStatusErrorExcerpt(t *testing.T) {
+	body := strings.Repeat("가", bodyExcerptLimit)
+	excerpt := excerptBody([]byte(body))
+	if !strings.HasSuffix(excerpt, "...") || len(excerpt) > bodyExcerptLimit+3 {
+		t.Errorf("excerptBody() 길이 = %d, want <= %d", len(excerpt), bodyExcerptLimit+3)
+	}
+
+	err := error(&StatusError{Target: "장비", StatusCode: http.StatusUnauthorized})
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Error("401 StatusError가 ErrUnauthorized와 일치하지 않습니다")
+	}
+	err = &StatusError{Target: "장비", StatusCode: http.StatusBadGateway}
+	if errors.Is(err, ErrUnauthorized) {
+		t.Error("502 StatusError가 ErrUnauthorized와 일치합니다")
+	}
+}
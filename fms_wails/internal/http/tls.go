@@ -0,0 +1,139 @@
+package http
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"fms_wails/internal/model"
+)
+
+// TLS 설정(인증서 파일 등)을 불러올 수 없을 때 반환되는 에러입니다.
+var ErrTLSConfig = errors.New("TLS 설정 오류")
+
+// 서버 인증서가 고정된 공개키와 일치하지 않을 때 반환되는 에러입니다.
+var ErrPinMismatch = errors.New("서버 인증서가 고정된 공개키와 일치하지 않습니다")
+
+// 인증서 공개키(SPKI)의 SHA-256 해시를 base64로 반환합니다. (인증서 고정 설정용)
+func PublicKeyPin(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// TLS 설정으로 HTTP 클라이언트를 생성합니다.
+// 설정을 불러올 수 없으면 기본 클라이언트와 함께 에러를 반환합니다.
+func newHTTPClient(timeout time.Duration, cfg model.TLSConfig) (*http.Client, error) {
+	client := &http.Client{Timeout: timeout}
+	if cfg.IsEmpty() {
+		return client, nil
+	}
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return client, fmt.Errorf("%w: %v", ErrTLSConfig, err)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	client.Transport = transport
+	return client, nil
+}
+
+// TLS 설정을 crypto/tls 설정으로 변환합니다.
+func newTLSConfig(cfg model.TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.ServerName,
+	}
+
+	// 사용자 지정 CA
+	if cfg.CAFile != "" {
+		data, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("CA 파일 읽기 실패: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("CA 파일에 유효한 인증서가 없습니다: %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	// 클라이언트 인증서 (mTLS)
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		if cfg.CertFile == "" || cfg.KeyFile == "" {
+			return nil, fmt.Errorf("클라이언트 인증서와 개인키를 모두 지정해야 합니다")
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("클라이언트 인증서 로드 실패: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	// 인증서 고정 (인증서 체인 중 하나의 공개키가 일치해야 함)
+	if len(cfg.PinnedSHA256) > 0 {
+		pins := make(map[string]bool)
+		for _, pin := range cfg.PinnedSHA256 {
+			pin = strings.TrimPrefix(strings.TrimSpace(pin), "sha256/")
+			if pin != "" {
+				pins[pin] = true
+			}
+		}
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			for _, cert := range state.PeerCertificates {
+				if pins[PublicKeyPin(cert)] {
+					return nil
+				}
+			}
+			return ErrPinMismatch
+		}
+	}
+
+	return tlsConfig, nil
+}
+
+// TLS 관련 에러를 분석하여 사용자 친화적인 메시지를 반환합니다.
+// TLS 에러가 아니면 빈 문자열을 반환합니다.
+func analyzeTLSError(err error) string {
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+	var recordHeader tls.RecordHeaderError
+
+	switch {
+	case errors.Is(err, ErrTLSConfig):
+		return "TLS 설정 오류"
+	case errors.Is(err, ErrPinMismatch):
+		return "인증서 고정 불일치"
+	case errors.As(err, &unknownAuthority):
+		return "인증서 신뢰 실패 (알 수 없는 CA)"
+	case errors.As(err, &hostname):
+		return "인증서 이름 불일치"
+	case errors.As(err, &invalid):
+		if invalid.Reason == x509.Expired {
+			return "인증서 만료"
+		}
+		return "인증서 무효"
+	case errors.As(err, &recordHeader):
+		return "TLS 미지원 서버"
+	}
+
+	// 서버가 클라이언트 인증서를 거부한 경우 (alert 메시지로만 전달됨)
+	errStr := err.Error()
+	if strings.Contains(errStr, "tls: certificate required") || strings.Contains(errStr, "tls: bad certificate") ||
+		strings.Contains(errStr, "tls: unknown certificate authority") {
+		return "클라이언트 인증서 거부"
+	}
+	if strings.Contains(errStr, "tls: ") {
+		return "TLS 핸드셰이크 실패"
+	}
+	return ""
+}
@@ -0,0 +1,366 @@
+package model
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// 규칙 분석 결과 종류
+const (
+	FindingShadowed  = "shadowed"  // 앞선 다른 동작 규칙이 먼저 매칭되어 적용되지 않음
+	FindingRedundant = "redundant" // 앞선 같은 동작 규칙이 이미 처리하여 불필요
+	FindingConflict  = "conflict"  // 앞선 규칙과 조건이 같고 동작이 다름
+)
+
+// 규칙 목록 분석에서 발견된 문제입니다.
+type RuleFinding struct {
+	Kind      string `json:"kind"`      // shadowed, redundant, conflict
+	Line      int    `json:"line"`      // 문제가 되는 규칙의 줄 번호
+	CoverLine int    `json:"coverLine"` // 먼저 매칭되는 규칙의 줄 번호
+	Message   string `json:"message"`   // 사용자 표시용 메시지
+}
+
+// 템플릿 편집기에 표시할 경고 진단으로 변환합니다.
+func (f RuleFinding) Diagnostic() Diagnostic {
+	code := DiagShadowedRule
+	switch f.Kind {
+	case FindingRedundant:
+		code = DiagRedundantRule
+	case FindingConflict:
+		code = DiagConflictingRule
+	}
+	return Diagnostic{
+		Line:     f.Line,
+		Column:   1,
+		Severity: SeverityWarning,
+		Code:     code,
+		Message:  f.Message,
+	}
+}
+
+// 분석 결과를 진단 목록으로 변환합니다.
+func FindingDiagnostics(findings []RuleFinding) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, f := range findings {
+		diagnostics = append(diagnostics, f.Diagnostic())
+	}
+	return diagnostics
+}
+
+// 규칙 목록에서 가려진 규칙, 불필요한 규칙, 충돌하는 규칙을 찾습니다.
+// lines[i]는 rules[i]의 줄 번호이며, nil이면 규칙 순번(1부터)을 사용합니다.
+// 규칙마다 자신을 완전히 포함하는 첫 번째 앞선 규칙 하나만 보고합니다.
+// 블랙리스트/화이트리스트 규칙은 같은 종류의 규칙끼리만 비교합니다.
+func AnalyzeRules(rules []*FirewallRule, lines []int) []RuleFinding {
+	lineOf := func(i int) int {
+		if i < len(lines) {
+			return lines[i]
+		}
+		return i + 1
+	}
+
+	matches := make([]*ruleMatch, len(rules))
+	for i, rule := range rules {
+		if rule != nil {
+			matches[i] = newRuleMatch(rule)
+		}
+	}
+
+	var findings []RuleFinding
+	for j, later := range matches {
+		if later == nil {
+			continue
+		}
+		for i, earlier := range matches[:j] {
+			if earlier == nil || !earlier.covers(later) {
+				continue
+			}
+
+			finding := RuleFinding{Line: lineOf(j), CoverLine: lineOf(i)}
+			earlierAction, laterAction := ruleAction(rules[i]), ruleAction(rules[j])
+			switch {
+			case earlierAction == laterAction:
+				finding.Kind = FindingRedundant
+				finding.Message = fmt.Sprintf("라인 %d의 %s 규칙에 포함되어 불필요합니다", finding.CoverLine, ActionToString(earlierAction))
+			case later.covers(earlier):
+				finding.Kind = FindingConflict
+				finding.Message = fmt.Sprintf("라인 %d의 규칙과 조건이 같지만 동작이 다릅니다 (%s, %s 적용)",
+					finding.CoverLine, ActionToString(laterAction), ActionToString(earlierAction))
+			default:
+				finding.Kind = FindingShadowed
+				finding.Message = fmt.Sprintf("라인 %d의 %s 규칙에 가려져 적용되지 않습니다", finding.CoverLine, ActionToString(earlierAction))
+			}
+			findings = append(findings, finding)
+			break
+		}
+	}
+	return findings
+}
+
+// 규칙이 적용하는 동작을 반환합니다.
+// 블랙리스트는 DROP, 화이트리스트는 ACCEPT로, 저장된 -a 값과 관계없이 종류가 동작을 정합니다.
+func ruleAction(rule *FirewallRule) Action {
+	switch {
+	case rule.White:
+		return ActionACCEPT
+	case rule.Black:
+		return ActionDROP
+	default:
+		return rule.Action
+	}
+}
+
+// 포트 범위 (양 끝 포함)
+type portRange struct {
+	start, end int
+}
+
+// 규칙의 매칭 조건을 비교하기 쉬운 형태로 변환한 값입니다.
+type ruleMatch struct {
+	chain     Chain
+	family    Family
+	kind      int // 0: 일반, 1: 블랙리스트, 2: 화이트리스트
+	protocol  Protocol
+	flagMask  int // TCP flags 검사할 플래그 (0이면 조건 없음)
+	flagSet   int // TCP flags 설정된 플래그
+	icmpType  int // -1이면 조건 없음
+	icmpCode  int // -1이면 조건 없음
+	ports     []portRange
+	sports    []portRange
+	sources   []*net.IPNet
+	dests     []*net.IPNet
+	anyPort   bool
+	anySPort  bool
+	anySource bool
+	anyDest   bool
+	inIface   string          // 빈 값이면 조건 없음
+	outIface  string          // 빈 값이면 조건 없음
+	states    map[string]bool // nil이면 조건 없음
+	deferred  bool            // 객체 참조(@이름)나 변수(${이름})가 있어 배포 시점에 조건이 정해짐
+}
+
+func newRuleMatch(rule *FirewallRule) *ruleMatch {
+	m := &ruleMatch{
+		chain:    rule.Chain,
+		family:   rule.Family,
+		protocol: rule.Protocol,
+		icmpType: -1,
+		icmpCode: -1,
+	}
+	if rule.Black {
+		m.kind = 1
+	} else if rule.White {
+		m.kind = 2
+	}
+
+	if rule.Options != nil {
+		if rule.Protocol == ProtocolTCP && rule.Options.TCPFlags != "" {
+			m.flagMask, m.flagSet = parseTCPFlagBits(rule.Options.TCPFlags)
+		}
+		if rule.Protocol == ProtocolICMP && rule.Options.ICMPType != "" {
+			if num, err := ICMPTypeNumber(rule.Family, rule.Options.ICMPType); err == nil {
+				m.icmpType = num
+			}
+			if rule.Options.ICMPCode != "" {
+				if num, err := ICMPCodeNumber(rule.Family, rule.Options.ICMPCode); err == nil {
+					m.icmpCode = num
+				}
+			}
+		}
+	}
+
+	for _, value := range []string{rule.DPort, rule.SPort, rule.SIP, rule.DIP, rule.InInterface, rule.OutInterface} {
+		if strings.Contains(value, ObjectRefPrefix) || strings.Contains(value, VariablePrefix) {
+			m.deferred = true
+		}
+	}
+	m.ports, m.anyPort = parsePortRanges(rule.DPort)
+	m.sports, m.anySPort = parsePortRanges(rule.SPort)
+	m.sources, m.anySource = parseIPNets(rule.SIP)
+	m.dests, m.anyDest = parseIPNets(rule.DIP)
+	m.inIface, m.outIface = rule.InInterface, rule.OutInterface
+	if rule.State != "" {
+		m.states = make(map[string]bool)
+		for _, state := range strings.Split(rule.State, ",") {
+			m.states[strings.ToUpper(strings.TrimSpace(state))] = true
+		}
+	}
+	return m
+}
+
+// other와 매칭되는 모든 패킷이 m과도 매칭되는지 확인합니다.
+func (m *ruleMatch) covers(other *ruleMatch) bool {
+	if m.deferred || other.deferred {
+		return false
+	}
+	if m.chain != other.chain || m.family != other.family || m.kind != other.kind {
+		return false
+	}
+	if m.protocol != ProtocolANY && m.protocol != other.protocol {
+		return false
+	}
+
+	// TCP flags: m이 검사하는 플래그를 other가 모두 같은 값으로 고정해야 함
+	if m.flagMask != 0 {
+		if m.flagMask&other.flagMask != m.flagMask || other.flagSet&m.flagMask != m.flagSet {
+			return false
+		}
+	}
+	if m.icmpType != -1 && m.icmpType != other.icmpType {
+		return false
+	}
+	if m.icmpCode != -1 && m.icmpCode != other.icmpCode {
+		return false
+	}
+
+	if !m.anyPort && (other.anyPort || !portsCover(m.ports, other.ports)) {
+		return false
+	}
+	if !m.anySPort && (other.anySPort || !portsCover(m.sports, other.sports)) {
+		return false
+	}
+	if (m.inIface != "" && m.inIface != other.inIface) || (m.outIface != "" && m.outIface != other.outIface) {
+		return false
+	}
+	if m.states != nil {
+		if other.states == nil {
+			return false
+		}
+		for state := range other.states {
+			if !m.states[state] {
+				return false
+			}
+		}
+	}
+	if !m.anySource && (other.anySource || !netsCover(m.sources, other.sources)) {
+		return false
+	}
+	if !m.anyDest && (other.anyDest || !netsCover(m.dests, other.dests)) {
+		return false
+	}
+	return true
+}
+
+// TCP flags 문자열(검사할 플래그/설정된 플래그)을 비트로 변환합니다.
+func parseTCPFlagBits(s string) (int, int) {
+	mask, set, _ := strings.Cut(s, "/")
+	return tcpFlagBits(mask), tcpFlagBits(set)
+}
+
+func tcpFlagBits(s string) int {
+	flags := GetTCPFlagsList()
+	bits := 0
+	for _, name := range strings.Split(strings.ToLower(s), ",") {
+		switch name {
+		case "all":
+			return 1<<len(flags) - 1
+		case "none":
+			continue
+		}
+		for i, flag := range flags {
+			if flag == name {
+				bits |= 1 << i
+			}
+		}
+	}
+	return bits
+}
+
+// 포트 목록(80, 8000:8080, 80,443)을 범위 목록으로 변환합니다.
+// 값이 없으면 모든 포트로 처리합니다. 잘못된 항목은 어떤 포트와도 매칭되지 않습니다.
+func parsePortRanges(s string) ([]portRange, bool) {
+	if strings.TrimSpace(s) == "" {
+		return nil, true
+	}
+	var ranges []portRange
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if validatePortRange(item) != nil {
+			continue
+		}
+		r := portRange{}
+		if sep := strings.IndexAny(item, ":-"); sep != -1 {
+			r.start, _ = parsePort(item[:sep])
+			r.end, _ = parsePort(item[sep+1:])
+		} else {
+			r.start, _ = parsePort(item)
+			r.end = r.start
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, false
+}
+
+// outer 범위들의 합집합이 inner 범위를 모두 포함하는지 확인합니다.
+func portsCover(outer, inner []portRange) bool {
+	if len(inner) == 0 {
+		return false
+	}
+	for _, r := range inner {
+		next := r.start
+		for next <= r.end {
+			advanced := false
+			for _, o := range outer {
+				if o.start <= next && next <= o.end {
+					next = o.end + 1
+					advanced = true
+				}
+			}
+			if !advanced {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// IP 목록(10.0.0.1, 192.168.1.0/24, 쉼표 구분)을 네트워크 목록으로 변환합니다.
+// 값이 없으면 모든 주소로 처리합니다. 잘못된 항목은 어떤 주소와도 매칭되지 않습니다.
+func parseIPNets(s string) ([]*net.IPNet, bool) {
+	if strings.TrimSpace(s) == "" {
+		return nil, true
+	}
+	var nets []*net.IPNet
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if !strings.Contains(item, "/") {
+			ip := net.ParseIP(item)
+			if ip == nil {
+				continue
+			}
+			bits := 128
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 32
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		if _, ipNet, err := net.ParseCIDR(item); err == nil {
+			nets = append(nets, ipNet)
+		}
+	}
+	return nets, false
+}
+
+// inner의 각 네트워크가 outer 중 하나의 네트워크에 포함되는지 확인합니다.
+func netsCover(outer, inner []*net.IPNet) bool {
+	if len(inner) == 0 {
+		return false
+	}
+	for _, in := range inner {
+		inOnes, inBits := in.Mask.Size()
+		covered := false
+		for _, out := range outer {
+			outOnes, outBits := out.Mask.Size()
+			if outBits == inBits && outOnes <= inOnes && out.Contains(in.IP) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
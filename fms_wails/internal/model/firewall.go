@@ -1,13 +1,26 @@
 package model
 
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
 // 방화벽 장비 정보를 나타냅니다.
 type Firewall struct {
-	Index        int           `json:"index"`                  // 고유 ID (Auto Increment)
-	DeviceName   string        `json:"deviceName"`             // 장비 IP 주소
-	ServerStatus string        `json:"serverStatus"`           // 서버 상태 (running/stop/-)
-	DeployStatus string        `json:"deployStatus"`           // 배포 상태 (success/fail/error/-)
-	Version      string        `json:"version"`                // 배포된 템플릿 버전
-	DeployResult *DeployResult `json:"deployResult,omitempty"` // 마지막 배포 결과
+	Index            int               `json:"index"`                      // 고유 ID (Auto Increment)
+	DeviceName       string            `json:"deviceName"`                 // 장비 IP 주소 (IPv4, IPv6, 포트 지정 시 IP:PORT 또는 [IPv6]:PORT)
+	ServerStatus     string            `json:"serverStatus"`               // 서버 상태 (running/stop/-)
+	DeployStatus     string            `json:"deployStatus"`               // 배포 상태 (success/fail/error/cancelled/halted/rollback/-)
+	Version          string            `json:"version"`                    // 배포된 템플릿 버전
+	LastGoodVersion  string            `json:"lastGoodVersion,omitempty"`  // 마지막으로 정상 배포된 템플릿 버전 (자동 롤백용)
+	LastGoodRevision string            `json:"lastGoodRevision,omitempty"` // 마지막으로 정상 배포된 템플릿 리비전 해시
+	LastGoodContents string            `json:"lastGoodContents,omitempty"` // 마지막으로 정상 배포된 내용 (포함 템플릿, 객체, 변수를 펼친 내용, 자동 롤백 시 그대로 재배포)
+	DeployResult     *DeployResult     `json:"deployResult,omitempty"`     // 마지막 배포 결과
+	Auth             *AuthConfig       `json:"auth,omitempty"`             // 장비별 인증 설정 (nil이면 전역 설정 사용)
+	Group            string            `json:"group,omitempty"`            // 장비 그룹 이름 (그룹의 템플릿 변수를 기본값으로 사용)
+	Variables        map[string]string `json:"variables,omitempty"`        // 장비별 템플릿 변수 값 (그룹 값보다 우선)
 }
 
 // 배포 결과를 나타냅니다.
@@ -35,10 +48,13 @@ const (
 
 // 배포 상태 상수
 const (
-	DeployStatusSuccess = "success"
-	DeployStatusFail    = "fail"
-	DeployStatusError   = "error"
-	DeployStatusUnknown = "-"
+	DeployStatusSuccess    = "success"
+	DeployStatusFail       = "fail"
+	DeployStatusError      = "error"
+	DeployStatusCancelled  = "cancelled"
+	DeployStatusHalted     = "halted"
+	DeployStatusRolledBack = "rollback"
+	DeployStatusUnknown    = "-"
 )
 
 // 새로운 장비를 생성합니다.
@@ -52,6 +68,55 @@ func NewFirewall(deviceName string) *Firewall {
 	}
 }
 
+// 장비 주소(DeviceName)를 IP와 포트로 나눕니다.
+// 192.168.1.1, 192.168.1.1:8080, 2001:db8::1, [2001:db8::1], [2001:db8::1]:8080 형식을 지원하며,
+// 포트가 없으면 빈 문자열을 반환합니다.
+func SplitDeviceAddress(address string) (string, string, error) {
+	if host, port, err := net.SplitHostPort(address); err == nil {
+		if net.ParseIP(host) == nil {
+			return "", "", fmt.Errorf("올바른 IP 주소가 아닙니다: %s", host)
+		}
+		if num, err := strconv.Atoi(port); err != nil || num < 1 || num > 65535 {
+			return "", "", fmt.Errorf("잘못된 포트: %s", port)
+		}
+		return host, port, nil
+	}
+
+	host := address
+	if inner, ok := strings.CutPrefix(address, "["); ok {
+		host = strings.TrimSuffix(inner, "]")
+		if !strings.Contains(host, ":") {
+			return "", "", fmt.Errorf("대괄호는 IPv6 주소에만 사용할 수 있습니다: %s", address)
+		}
+	}
+	if net.ParseIP(host) == nil {
+		return "", "", fmt.Errorf("올바른 IP 주소가 아닙니다: %s", address)
+	}
+	return host, "", nil
+}
+
+// 장비 주소 형식이 올바른지 검사합니다.
+func ValidateDeviceAddress(address string) error {
+	_, _, err := SplitDeviceAddress(address)
+	return err
+}
+
+// URL에 사용할 장비 주소를 반환합니다. (IPv6 주소는 대괄호로 감쌈)
+// 형식이 올바르지 않은 주소는 그대로 반환합니다.
+func DeviceURLHost(address string) string {
+	host, port, err := SplitDeviceAddress(address)
+	if err != nil {
+		return address
+	}
+	if port != "" {
+		return net.JoinHostPort(host, port)
+	}
+	if strings.Contains(host, ":") {
+		return "[" + host + "]"
+	}
+	return host
+}
+
 // 장비 정보가 유효한지 검사합니다.
 func (f *Firewall) IsValid() bool {
 	return f.DeviceName != ""
@@ -60,11 +125,17 @@ func (f *Firewall) IsValid() bool {
 // 장비의 복사본을 반환합니다.
 func (f *Firewall) Clone() *Firewall {
 	clone := &Firewall{
-		Index:        f.Index,
-		DeviceName:   f.DeviceName,
-		ServerStatus: f.ServerStatus,
-		DeployStatus: f.DeployStatus,
-		Version:      f.Version,
+		Index:            f.Index,
+		DeviceName:       f.DeviceName,
+		ServerStatus:     f.ServerStatus,
+		DeployStatus:     f.DeployStatus,
+		Version:          f.Version,
+		LastGoodVersion:  f.LastGoodVersion,
+		LastGoodRevision: f.LastGoodRevision,
+		LastGoodContents: f.LastGoodContents,
+		Auth:             f.Auth.Clone(),
+		Group:            f.Group,
+		Variables:        cloneVariables(f.Variables),
 	}
 
 	// DeployResult 복사
@@ -103,6 +174,12 @@ func GetDeployStatusText(status string) string {
 		return "실패"
 	case DeployStatusError:
 		return "확인요망"
+	case DeployStatusCancelled:
+		return "취소"
+	case DeployStatusHalted:
+		return "중단"
+	case DeployStatusRolledBack:
+		return "롤백"
 	default:
 		return "-"
 	}
@@ -0,0 +1,55 @@
+package model
+
+import "testing"
+
+// TestSplitDeviceAddress 장비 주소 분리 테스트
+func TestSplitDeviceAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		host    string
+		port    string
+		wantErr bool
+	}{
+		{"192.168.1.1", "192.168.1.1", "", false},
+		{"192.168.1.1:8080", "192.168.1.1", "8080", false},
+		{"2001:db8::1", "2001:db8::1", "", false},
+		{"[2001:db8::1]", "2001:db8::1", "", false},
+		{"[2001:db8::1]:8080", "2001:db8::1", "8080", false},
+		{"[192.168.1.1]", "", "", true},
+		{"192.168.1.1:0", "", "", true},
+		{"192.168.1.1:http", "", "", true},
+		{"firewall-01", "", "", true},
+		{"", "", "", true},
+	}
+
+	for _, tt := range tests {
+		host, port, err := SplitDeviceAddress(tt.address)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("SplitDeviceAddress(%q) error = %v, wantErr %v", tt.address, err, tt.wantErr)
+			continue
+		}
+		if host != tt.host || port != tt.port {
+			t.Errorf("SplitDeviceAddress(%q) = %q, %q, want %q, %q", tt.address, host, port, tt.host, tt.port)
+		}
+	}
+}
+
+// TestDeviceURLHost URL용 장비 주소 테스트
+func TestDeviceURLHost(t *testing.T) {
+	tests := []struct {
+		address  string
+		expected string
+	}{
+		{"192.168.1.1", "192.168.1.1"},
+		{"192.168.1.1:8080", "192.168.1.1:8080"},
+		{"2001:db8::1", "[2001:db8::1]"},
+		{"[2001:db8::1]", "[2001:db8::1]"},
+		{"[2001:db8::1]:8080", "[2001:db8::1]:8080"},
+	}
+
+	for _, tt := range tests {
+		if result := DeviceURLHost(tt.address); result != tt.expected {
+			t.Errorf("DeviceURLHost(%q) = %q, want %q", tt.address, result, tt.expected)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strconv"
 	"strings"
 
 	"fms_wails/internal/utils"
@@ -13,26 +14,47 @@ const (
 	RuleStatusUnfind     = "unfind"
 	RuleStatusValidation = "validation"
 	RuleStatusWrite      = "write"
+	RuleStatusCancelled  = "cancelled"
+	RuleStatusHalted     = "halted"
 )
 
 // 배포 이력을 나타냅니다.
 type DeployHistory struct {
-	ID          int            `json:"id"`              // 고유 ID (Auto Increment)
-	Timestamp   utils.JSONTime `json:"timestamp"`       // 배포 시간
-	DeviceIP    string         `json:"deviceIp"`        // 장비 IP
-	TemplateVer string         `json:"templateVersion"` // 배포한 템플릿 버전
-	Status      string         `json:"status"`          // 배포 상태 (success/fail/error)
-	Results     []RuleResult   `json:"results"`         // 규칙별 결과
+	ID          int            `json:"id"`                   // 고유 ID (Auto Increment)
+	Timestamp   utils.JSONTime `json:"timestamp"`            // 배포 시간
+	DeviceIP    string         `json:"deviceIp"`             // 장비 IP
+	TemplateVer string         `json:"templateVersion"`      // 배포한 템플릿 버전
+	Revision    string         `json:"revision,omitempty"`   // 배포한 템플릿 리비전 해시
+	Includes    []string       `json:"includes,omitempty"`   // 배포한 템플릿이 포함한 템플릿 버전과 리비전 (버전@해시, 펼친 순서)
+	Status      string         `json:"status"`               // 배포 상태 (success/fail/error/cancelled/halted)
+	Results     []RuleResult   `json:"results"`              // 규칙별 결과
+	RolloutID   string         `json:"rolloutId,omitempty"`  // 단계별 배포 ID (단계별 배포 시에만)
+	Wave        int            `json:"wave,omitempty"`       // 단계별 배포 웨이브 번호 (1 = 카나리)
+	RollbackOf  int            `json:"rollbackOf,omitempty"` // 자동 롤백 이력인 경우 원본(실패) 이력 ID
 }
 
 // 개별 규칙의 배포 결과를 나타냅니다.
 type RuleResult struct {
-	Rule   string `json:"rule"`   // 규칙 내용
-	Text   string `json:"text"`   // 규칙 설명 (optional)
-	Status string `json:"status"` // 결과 (ok/error/unfind/validation)
-	Reason string `json:"reason"` // 실패 사유
+	Rule     string `json:"rule"`               // 규칙 내용
+	Text     string `json:"text"`               // 규칙 설명 (optional)
+	Status   string `json:"status"`             // 결과 (ok/error/unfind/validation)
+	Reason   string `json:"reason"`             // 실패 사유
+	Category string `json:"category,omitempty"` // 연결 실패 원인 분류 (연결 실패 시에만)
 }
 
+// 연결 실패 원인 분류 상수
+const (
+	ErrorCategoryTimeout  = "timeout"  // 응답 시간 초과
+	ErrorCategoryRefused  = "refused"  // 연결 거부
+	ErrorCategoryDNS      = "dns"      // 호스트 이름 확인 실패
+	ErrorCategoryTLS      = "tls"      // TLS 핸드셰이크/인증서 오류
+	ErrorCategoryAuth     = "auth"     // 인증 거부 (HTTP 401/403)
+	ErrorCategoryHTTP     = "http"     // 그 외 HTTP 오류 상태 코드
+	ErrorCategoryProtocol = "protocol" // 응답 형식 오류 (읽기/파싱 실패)
+	ErrorCategoryMissing  = "missing"  // 응답에 장비 결과 없음
+	ErrorCategoryNetwork  = "network"  // 그 외 네트워크 오류
+)
+
 // 새로운 배포 이력을 생성합니다.
 func NewDeployHistory(deviceIP, templateVer string) *DeployHistory {
 	return &DeployHistory{
@@ -75,11 +97,33 @@ func (h *DeployHistory) CalculateStatus() {
 	}
 }
 
+// 연결 실패 원인 분류를 반환합니다. 연결 실패 이력이 아니면 빈 문자열을 반환합니다.
+func (h *DeployHistory) GetErrorCategory() string {
+	for _, r := range h.Results {
+		if r.Category != "" {
+			return r.Category
+		}
+	}
+	return ""
+}
+
 // 포맷된 시간 문자열을 반환합니다.
 func (h *DeployHistory) GetTimestampString() string {
 	return h.Timestamp.Time().Format("2006-01-02 15:04:05")
 }
 
+// 단계별 배포 웨이브 텍스트를 반환합니다.
+func (h *DeployHistory) GetWaveText() string {
+	switch h.Wave {
+	case 0:
+		return "-"
+	case 1:
+		return "1 (카나리)"
+	default:
+		return strconv.Itoa(h.Wave)
+	}
+}
+
 // 규칙 상태 코드를 표시 텍스트로 변환합니다.
 func GetRuleStatusText(status string) string {
 	// 대소문자 구분 없이 비교
@@ -90,11 +134,49 @@ func GetRuleStatusText(status string) string {
 		return "실패"
 	case RuleStatusWrite:
 		return "진행중"
+	case RuleStatusCancelled:
+		return "취소"
+	case RuleStatusHalted:
+		return "중단"
 	default:
 		return status // 원본 상태 표시
 	}
 }
 
+// 연결 실패 원인 분류 목록을 반환합니다.
+func GetErrorCategoryOptions() []string {
+	return []string{
+		ErrorCategoryTimeout, ErrorCategoryRefused, ErrorCategoryDNS, ErrorCategoryTLS, ErrorCategoryAuth,
+		ErrorCategoryHTTP, ErrorCategoryProtocol, ErrorCategoryMissing, ErrorCategoryNetwork,
+	}
+}
+
+// 연결 실패 원인 분류를 표시 텍스트로 변환합니다.
+func GetErrorCategoryText(category string) string {
+	switch category {
+	case ErrorCategoryTimeout:
+		return "시간 초과"
+	case ErrorCategoryRefused:
+		return "연결 거부"
+	case ErrorCategoryDNS:
+		return "DNS 실패"
+	case ErrorCategoryTLS:
+		return "TLS 오류"
+	case ErrorCategoryAuth:
+		return "인증 실패"
+	case ErrorCategoryHTTP:
+		return "HTTP 오류"
+	case ErrorCategoryProtocol:
+		return "응답 형식 오류"
+	case ErrorCategoryMissing:
+		return "결과 없음"
+	case ErrorCategoryNetwork:
+		return "네트워크 오류"
+	default:
+		return "-"
+	}
+}
+
 // 사유를 표시용 텍스트로 변환합니다.
 func GetReasonText(reason string) string {
 	// OK 또는 빈 문자열이면 "-" 표시
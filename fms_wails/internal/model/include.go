@@ -0,0 +1,156 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// 다른 템플릿을 포함하는 지시문 (예: #include baseline-v3)
+const IncludeDirective = "#include"
+
+// 포함 기록에서 템플릿 버전과 리비전 해시를 구분하는 문자 (예: baseline-v3@1a2b3c...)
+const IncludeRevisionSeparator = "@"
+
+// 줄이 포함 지시문이면 포함할 템플릿 버전을 반환합니다.
+// 버전이 비어 있어도 지시문이면 ok는 true입니다.
+func ParseIncludeLine(line string) (version string, ok bool) {
+	line = strings.TrimSpace(line)
+	rest, found := strings.CutPrefix(line, IncludeDirective)
+	if !found || (rest != "" && !unicode.IsSpace(rune(rest[0]))) {
+		return "", false
+	}
+	return strings.TrimSpace(rest), true
+}
+
+// 템플릿 내용에 포함 지시문이 있는지 확인합니다.
+func HasIncludes(contents string) bool {
+	for _, line := range strings.Split(contents, "\n") {
+		if _, ok := ParseIncludeLine(line); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// 포함한 템플릿 버전과 리비전 해시를 "버전@해시" 형식의 포함 기록으로 반환합니다.
+func IncludeRef(version, hash string) string {
+	return version + IncludeRevisionSeparator + hash
+}
+
+// 포함 기록을 템플릿 버전과 리비전 해시로 나눕니다.
+// 리비전 도입 전의 기록처럼 해시가 없으면 hash는 비어 있습니다.
+func SplitIncludeRef(ref string) (version, hash string) {
+	i := strings.LastIndex(ref, IncludeRevisionSeparator)
+	if i == -1 {
+		return ref, ""
+	}
+	return ref[:i], ref[i+len(IncludeRevisionSeparator):]
+}
+
+// 포함 기록을 화면 표시용 "버전@짧은해시" 형식으로 반환합니다.
+func IncludeRefText(ref string) string {
+	version, hash := SplitIncludeRef(ref)
+	if hash == "" {
+		return version
+	}
+	return IncludeRef(version, ShortHash(hash))
+}
+
+// 템플릿 내용이 직접 포함하는 템플릿 버전을 나온 순서대로 중복 없이 반환합니다.
+func TemplateIncludes(contents string) []string {
+	var versions []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(contents, "\n") {
+		if version, ok := ParseIncludeLine(line); ok && version != "" && !seen[version] {
+			seen[version] = true
+			versions = append(versions, version)
+		}
+	}
+	return versions
+}
+
+// 포함 지시문을 포함한 템플릿의 내용으로 펼친 유효 템플릿을 반환합니다.
+// 포함한 템플릿도 재귀적으로 펼치며, 같은 템플릿은 처음 나온 위치에 한 번만 넣습니다.
+// 포함이 순환하거나 포함할 템플릿을 조회할 수 없으면 에러를 반환합니다.
+// 반환한 템플릿의 Includes에는 실제로 펼친 템플릿의 버전과 리비전이 펼친 순서대로
+// "버전@해시" 형식으로 기록됩니다.
+// 포함 지시문이 없는 템플릿은 그대로 반환합니다.
+func ResolveIncludes(template *Template, lookup func(version string) (*Template, error)) (*Template, error) {
+	if !HasIncludes(template.Contents) {
+		return template, nil
+	}
+
+	r := &includeResolver{lookup: lookup, included: make(map[string]bool)}
+	if template.Version != "" {
+		r.included[template.Version] = true
+	}
+	contents, err := r.resolve(template.Contents, []string{template.Version})
+	if err != nil {
+		return nil, fmt.Errorf("템플릿 %s: %v", template.Version, err)
+	}
+
+	resolved := template.Clone()
+	resolved.Contents = contents
+	resolved.Includes = r.versions
+	return resolved, nil
+}
+
+// 포함 지시문이 순환하는지 검사합니다.
+// 아직 없는 템플릿은 빈 템플릿으로 보고 건너뜁니다. (조회 실패는 배포 시 검사)
+func CheckIncludeCycle(template *Template, lookup func(version string) (*Template, error)) error {
+	_, err := ResolveIncludes(template, func(version string) (*Template, error) {
+		if t, err := lookup(version); err == nil {
+			return t, nil
+		}
+		return NewTemplate(version, ""), nil
+	})
+	return err
+}
+
+// 포함 지시문을 재귀적으로 펼치는 상태입니다.
+type includeResolver struct {
+	lookup   func(version string) (*Template, error)
+	included map[string]bool // 이미 펼친 템플릿 버전 (루트 포함)
+	versions []string        // 펼친 순서대로의 포함 기록 (버전@해시)
+}
+
+// 내용의 포함 지시문을 펼칩니다. stack은 현재 포함 경로입니다. (순환 검사용)
+func (r *includeResolver) resolve(contents string, stack []string) (string, error) {
+	lines := strings.Split(contents, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		version, ok := ParseIncludeLine(line)
+		if !ok {
+			out = append(out, line)
+			continue
+		}
+		if version == "" {
+			return "", fmt.Errorf("%s 지시문에 템플릿 버전이 없습니다", IncludeDirective)
+		}
+		for i, v := range stack {
+			if v == version {
+				return "", fmt.Errorf("템플릿 포함이 순환합니다: %s", strings.Join(append(stack[i:], version), " → "))
+			}
+		}
+		if r.included[version] {
+			out = append(out, fmt.Sprintf("# include %s (이미 포함됨)", version))
+			continue
+		}
+
+		included, err := r.lookup(version)
+		if err != nil {
+			return "", fmt.Errorf("포함할 템플릿 %s를 불러올 수 없습니다: %v", version, err)
+		}
+		// 리비전 해시는 내용 해시이므로 조회한 내용으로 계산 (배포한 내용과 항상 일치)
+		r.included[version] = true
+		r.versions = append(r.versions, IncludeRef(version, TemplateHash(included.Contents)))
+
+		body, err := r.resolve(strings.TrimRight(included.Contents, "\n"), append(stack, version))
+		if err != nil {
+			return "", err
+		}
+		out = append(out, fmt.Sprintf("# ----- begin include %s -----", version), body, fmt.Sprintf("# ----- end include %s -----", version))
+	}
+	return strings.Join(out, "\n"), nil
+}
@@ -0,0 +1,134 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// newIncludeLookup 메모리의 템플릿 목록으로 조회 함수 생성
+func newIncludeLookup(templates ...*Template) func(string) (*Template, error) {
+	stored := make(map[string]*Template)
+	for _, t := range templates {
+		stored[t.Version] = t
+	}
+	return func(version string) (*Template, error) {
+		if t, ok := stored[version]; ok {
+			return t.Clone(), nil
+		}
+		return nil, fmt.Errorf("템플릿을 찾을 수 없습니다: %s", version)
+	}
+}
+
+// TestParseIncludeLine 포함 지시문 인식 테스트
+func TestParseIncludeLine(t *testing.T) {
+	tests := []struct {
+		line    string
+		version string
+		ok      bool
+	}{
+		{"#include baseline-v3", "baseline-v3", true},
+		{"  #include\tbaseline v3  ", "baseline v3", true},
+		{"#include", "", true},
+		{"#includes baseline", "", false},
+		{"# include baseline", "", false},
+		{"agent -m=insert -c=INPUT -a=DROP", "", false},
+	}
+
+	for _, tt := range tests {
+		version, ok := ParseIncludeLine(tt.line)
+		if version != tt.version || ok != tt.ok {
+			t.Errorf("ParseIncludeLine(%q) = %q, %v, want %q, %v", tt.line, version, ok, tt.version, tt.ok)
+		}
+	}
+}
+
+// TestResolveIncludes 중첩 포함과 중복 포함 펼침 테스트
+func TestResolveIncludes(t *testing.T) {
+	lookup := newIncludeLookup(
+		NewTemplate("icmp", "agent -m=insert -c=INPUT -p=icmp -a=DROP"),
+		NewTemplate("baseline", "#include icmp\nagent -m=insert -c=INPUT -p=tcp?flags=SYN,FIN/SYN,FIN -a=DROP\n"),
+	)
+	site := NewTemplate("site-a", "#include baseline\n#include icmp\nagent -m=insert -c=INPUT -p=tcp --dport=22 -a=ACCEPT")
+
+	resolved, err := ResolveIncludes(site, lookup)
+	if err != nil {
+		t.Fatalf("ResolveIncludes() error: %v", err)
+	}
+	want := strings.Join([]string{
+		"# ----- begin include baseline -----",
+		"# ----- begin include icmp -----",
+		"agent -m=insert -c=INPUT -p=icmp -a=DROP",
+		"# ----- end include icmp -----",
+		"agent -m=insert -c=INPUT -p=tcp?flags=SYN,FIN/SYN,FIN -a=DROP",
+		"# ----- end include baseline -----",
+		"# include icmp (이미 포함됨)",
+		"agent -m=insert -c=INPUT -p=tcp --dport=22 -a=ACCEPT",
+	}, "\n")
+	if resolved.Contents != want {
+		t.Errorf("ResolveIncludes() =\n%s\nwant\n%s", resolved.Contents, want)
+	}
+	baseline, _ := lookup("baseline")
+	icmp, _ := lookup("icmp")
+	wantIncludes := []string{
+		"baseline@" + TemplateHash(baseline.Contents),
+		"icmp@" + TemplateHash(icmp.Contents),
+	}
+	if strings.Join(resolved.Includes, ",") != strings.Join(wantIncludes, ",") {
+		t.Errorf("Includes = %v, want %v", resolved.Includes, wantIncludes)
+	}
+	if site.Includes != nil || strings.Contains(site.Contents, "begin include") {
+		t.Error("원본 템플릿이 변경되었습니다")
+	}
+
+	// 포함 지시문이 없으면 그대로 반환
+	plain := NewTemplate("plain", "agent -m=insert -c=INPUT -a=DROP")
+	if got, err := ResolveIncludes(plain, lookup); err != nil || got != plain {
+		t.Errorf("ResolveIncludes(plain) = %v, %v, want 원본", got, err)
+	}
+}
+
+// TestIncludeRef 포함 기록(버전@해시) 변환 테스트
+func TestIncludeRef(t *testing.T) {
+	hash := TemplateHash("agent -m=insert -c=INPUT -a=DROP")
+	ref := IncludeRef("baseline-v3", hash)
+	if version, got := SplitIncludeRef(ref); version != "baseline-v3" || got != hash {
+		t.Errorf("SplitIncludeRef(%q) = %q, %q", ref, version, got)
+	}
+	if got := IncludeRefText(ref); got != "baseline-v3@"+ShortHash(hash) {
+		t.Errorf("IncludeRefText(%q) = %q", ref, got)
+	}
+	// 리비전 도입 전의 기록은 버전만 있음
+	if version, got := SplitIncludeRef("baseline-v3"); version != "baseline-v3" || got != "" {
+		t.Errorf("SplitIncludeRef(baseline-v3) = %q, %q", version, got)
+	}
+	if got := IncludeRefText("baseline-v3"); got != "baseline-v3" {
+		t.Errorf("IncludeRefText(baseline-v3) = %q", got)
+	}
+}
+
+// TestResolveIncludesErrors 순환 포함과 없는 템플릿 테스트
+func TestResolveIncludesErrors(t *testing.T) {
+	lookup := newIncludeLookup(
+		NewTemplate("a", "#include b"),
+		NewTemplate("b", "#include c"),
+		NewTemplate("c", "#include a"),
+	)
+
+	_, err := ResolveIncludes(NewTemplate("a", "#include b"), lookup)
+	if err == nil || !strings.Contains(err.Error(), "a → b → c → a") {
+		t.Errorf("ResolveIncludes() error = %v, want 순환 경로", err)
+	}
+	if err := CheckIncludeCycle(NewTemplate("c", "#include a"), lookup); err == nil {
+		t.Error("CheckIncludeCycle() 순환에 대해 에러가 반환되지 않았습니다")
+	}
+
+	_, err = ResolveIncludes(NewTemplate("site", "#include missing"), lookup)
+	if err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("ResolveIncludes() error = %v, want 없는 템플릿", err)
+	}
+	// 없는 템플릿은 순환 검사에서 건너뜀
+	if err := CheckIncludeCycle(NewTemplate("site", "#include missing"), lookup); err != nil {
+		t.Errorf("CheckIncludeCycle() error = %v, want nil", err)
+	}
+}
@@ -1,6 +1,10 @@
 package model
 
-import "strings"
+import (
+	"fmt"
+	"net"
+	"strings"
+)
 
 // NATType NAT 규칙 타입
 type NATType int
@@ -31,6 +35,7 @@ type NATRule struct {
 
 	// 추가 옵션
 	Description string `json:"description,omitempty"` // 규칙 설명 (선택)
+	Family      Family `json:"family,omitempty"`      // 주소 체계 (기본값 IPv4)
 }
 
 // NewNATRule 기본값으로 새 NAT 규칙 생성
@@ -42,6 +47,12 @@ func NewNATRule() *NATRule {
 	}
 }
 
+// Clone NAT 규칙 복사본 반환
+func (r *NATRule) Clone() *NATRule {
+	clone := *r
+	return &clone
+}
+
 // NewDNATRule DNAT 규칙 생성
 func NewDNATRule() *NATRule {
 	return &NATRule{
@@ -81,20 +92,26 @@ func NATTypeToString(t NATType) string {
 	}
 }
 
-// StringToNATType 문자열을 NATType으로 변환
-func StringToNATType(s string) NATType {
+// ParseNATType 문자열을 NATType으로 변환 (알 수 없는 값은 에러)
+func ParseNATType(s string) (NATType, error) {
 	switch strings.ToUpper(s) {
 	case "DNAT":
-		return NATTypeDNAT
+		return NATTypeDNAT, nil
 	case "SNAT":
-		return NATTypeSNAT
+		return NATTypeSNAT, nil
 	case "MASQUERADE", "MASQ":
-		return NATTypeMASQUERADE
+		return NATTypeMASQUERADE, nil
 	default:
-		return NATTypeDNAT
+		return NATTypeDNAT, fmt.Errorf("알 수 없는 NAT 타입: %s", s)
 	}
 }
 
+// StringToNATType 문자열을 NATType으로 변환 (알 수 없는 값은 DNAT)
+func StringToNATType(s string) NATType {
+	v, _ := ParseNATType(s)
+	return v
+}
+
 // GetNATTypeOptions UI Select용 NAT 타입 옵션 목록
 func GetNATTypeOptions() []string {
 	return []string{"DNAT", "SNAT", "MASQUERADE"}
@@ -118,3 +135,33 @@ func NATTypeToDisplayString(t NATType) string {
 		return "DNAT (포트 포워딩)"
 	}
 }
+
+// SplitDestination "IP:포트" 형식의 변환 대상을 IP와 포트로 분리
+// IPv6 주소는 "[2001:db8::1]:8080" 처럼 대괄호로 감싸야 포트를 지정할 수 있으며,
+// 대괄호 없이 IPv6 주소 전체가 주소로 해석되면 포트 없는 주소로 처리합니다.
+func SplitDestination(dest string) (string, string) {
+	if rest, ok := strings.CutPrefix(dest, "["); ok {
+		ip, after, found := strings.Cut(rest, "]")
+		if !found {
+			return dest, ""
+		}
+		port, _ := strings.CutPrefix(after, ":")
+		return ip, port
+	}
+	if net.ParseIP(dest) != nil {
+		return dest, ""
+	}
+	ip, port, _ := strings.Cut(dest, ":")
+	return ip, port
+}
+
+// JoinDestination IP와 포트를 변환 대상 문자열로 결합 (포트가 있는 IPv6 주소는 대괄호 사용)
+func JoinDestination(ip, port string) string {
+	if port == "" {
+		return ip
+	}
+	if strings.Contains(ip, ":") {
+		return "[" + ip + "]:" + port
+	}
+	return ip + ":" + port
+}
@@ -132,3 +132,46 @@ func TestNATTypeToDisplayString(t *testing.T) {
 		}
 	}
 }
+
+// TestSplitDestination 변환 대상 IP:포트 분리 테스트
+func TestSplitDestination(t *testing.T) {
+	tests := []struct {
+		dest string
+		ip   string
+		port string
+	}{
+		{"192.168.1.10", "192.168.1.10", ""},
+		{"192.168.1.10:8080", "192.168.1.10", "8080"},
+		{"192.168.1.10:8000:8080", "192.168.1.10", "8000:8080"},
+		{"2001:db8::10", "2001:db8::10", ""},
+		{"[2001:db8::10]", "2001:db8::10", ""},
+		{"[2001:db8::10]:8080", "2001:db8::10", "8080"},
+	}
+
+	for _, tt := range tests {
+		ip, port := SplitDestination(tt.dest)
+		if ip != tt.ip || port != tt.port {
+			t.Errorf("SplitDestination(%s) = %s, %s, want %s, %s", tt.dest, ip, port, tt.ip, tt.port)
+		}
+	}
+}
+
+// TestJoinDestination 변환 대상 IP:포트 결합 테스트
+func TestJoinDestination(t *testing.T) {
+	tests := []struct {
+		ip       string
+		port     string
+		expected string
+	}{
+		{"192.168.1.10", "", "192.168.1.10"},
+		{"192.168.1.10", "8080", "192.168.1.10:8080"},
+		{"2001:db8::10", "", "2001:db8::10"},
+		{"2001:db8::10", "8080", "[2001:db8::10]:8080"},
+	}
+
+	for _, tt := range tests {
+		if result := JoinDestination(tt.ip, tt.port); result != tt.expected {
+			t.Errorf("JoinDestination(%s, %s) = %s, want %s", tt.ip, tt.port, result, tt.expected)
+		}
+	}
+}
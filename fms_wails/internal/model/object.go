@@ -0,0 +1,220 @@
+package model
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// 객체 종류
+type ObjectKind string
+
+const (
+	ObjectKindAddress ObjectKind = "address" // 주소 그룹 (IP, CIDR, 범위)
+	ObjectKindService ObjectKind = "service" // 서비스 그룹 (프로토콜/포트)
+)
+
+// 템플릿에서 객체를 참조할 때 사용하는 접두사 (예: --sip=@monitoring)
+const ObjectRefPrefix = "@"
+
+// 객체 이름 형식 (영문자로 시작, 영문자/숫자/-/_/.)
+var objectNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]*$`)
+
+// 여러 템플릿에서 재사용하는 이름 있는 주소/서비스 객체입니다.
+type NamedObject struct {
+	Name        string     `json:"name"`                  // 객체 이름 (Primary Key, 템플릿에서 @이름으로 참조)
+	Kind        ObjectKind `json:"kind"`                  // 객체 종류 (address, service)
+	Members     []string   `json:"members"`               // 주소: 10.0.0.1, 10.0.0.0/24, 10.0.0.1-10.0.0.9 / 서비스: tcp/443, udp/53, tcp/8000:8100
+	Description string     `json:"description,omitempty"` // 설명 (선택)
+}
+
+// 새로운 객체를 생성합니다.
+func NewNamedObject(name string, kind ObjectKind, members ...string) *NamedObject {
+	return &NamedObject{
+		Name:    name,
+		Kind:    kind,
+		Members: members,
+	}
+}
+
+// 객체의 복사본을 반환합니다.
+func (o *NamedObject) Clone() *NamedObject {
+	clone := *o
+	clone.Members = append([]string(nil), o.Members...)
+	return &clone
+}
+
+// 템플릿에서 사용하는 참조 문자열(@이름)을 반환합니다.
+func (o *NamedObject) Ref() string {
+	return ObjectRefPrefix + o.Name
+}
+
+// 객체가 유효한지 검사합니다.
+// 이름 형식, 종류, 멤버가 하나 이상 있는지와 각 멤버의 형식을 확인합니다.
+func (o *NamedObject) Validate() error {
+	if err := ValidateObjectName(o.Name); err != nil {
+		return err
+	}
+	if len(o.Members) == 0 {
+		return fmt.Errorf("객체 %s에 멤버가 없습니다", o.Name)
+	}
+	for _, member := range o.Members {
+		var err error
+		switch o.Kind {
+		case ObjectKindAddress:
+			_, err = addressMemberCIDRs(member)
+		case ObjectKindService:
+			_, _, err = parseServiceMember(member)
+		default:
+			return fmt.Errorf("알 수 없는 객체 종류: %s (address, service)", o.Kind)
+		}
+		if err != nil {
+			return fmt.Errorf("객체 %s의 멤버 %q: %v", o.Name, member, err)
+		}
+	}
+	return nil
+}
+
+// 객체 이름 형식을 검사합니다.
+func ValidateObjectName(name string) error {
+	if !objectNamePattern.MatchString(name) {
+		return fmt.Errorf("잘못된 객체 이름: %q (영문자로 시작, 영문자/숫자/-/_/. 사용)", name)
+	}
+	return nil
+}
+
+// 값이 객체 참조(@이름)이면 객체 이름을 반환합니다.
+func ObjectRefName(value string) (string, bool) {
+	return strings.CutPrefix(value, ObjectRefPrefix)
+}
+
+// 주소 객체의 멤버를 IP/CIDR 목록으로 반환합니다. (범위는 CIDR 목록으로 변환)
+func (o *NamedObject) Addresses() ([]string, error) {
+	if o.Kind != ObjectKindAddress {
+		return nil, fmt.Errorf("%s는 주소 객체가 아닙니다", o.Ref())
+	}
+	var addresses []string
+	for _, member := range o.Members {
+		cidrs, err := addressMemberCIDRs(member)
+		if err != nil {
+			return nil, fmt.Errorf("객체 %s의 멤버 %q: %v", o.Name, member, err)
+		}
+		addresses = append(addresses, cidrs...)
+	}
+	return addresses, nil
+}
+
+// 서비스 객체에 포함된 프로토콜을 처음 나온 순서대로 반환합니다.
+func (o *NamedObject) Protocols() []Protocol {
+	var protocols []Protocol
+	seen := make(map[Protocol]bool)
+	for _, member := range o.Members {
+		protocol, _, err := parseServiceMember(member)
+		if err != nil || seen[protocol] {
+			continue
+		}
+		seen[protocol] = true
+		protocols = append(protocols, protocol)
+	}
+	return protocols
+}
+
+// 서비스 객체에서 지정한 프로토콜의 포트 목록을 반환합니다.
+func (o *NamedObject) Ports(protocol Protocol) []string {
+	var ports []string
+	for _, member := range o.Members {
+		p, port, err := parseServiceMember(member)
+		if err == nil && p == protocol {
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
+// 서비스 멤버(tcp/443, udp/53, tcp/8000:8100)를 프로토콜과 포트로 나눕니다.
+func parseServiceMember(member string) (Protocol, string, error) {
+	name, port, ok := strings.Cut(member, "/")
+	if !ok {
+		return 0, "", fmt.Errorf("프로토콜/포트 형식이어야 합니다 (예: tcp/443)")
+	}
+	var protocol Protocol
+	switch strings.ToLower(name) {
+	case "tcp":
+		protocol = ProtocolTCP
+	case "udp":
+		protocol = ProtocolUDP
+	default:
+		return 0, "", fmt.Errorf("알 수 없는 프로토콜: %s (tcp, udp)", name)
+	}
+	if err := validatePortRange(port); err != nil {
+		return 0, "", err
+	}
+	return protocol, port, nil
+}
+
+// 주소 멤버(IP, CIDR, 시작-끝 범위)를 IP/CIDR 목록으로 변환합니다.
+func addressMemberCIDRs(member string) ([]string, error) {
+	start, end, isRange := strings.Cut(member, "-")
+	if !isRange {
+		if !isValidIPOrCIDR(member) {
+			return nil, fmt.Errorf("올바른 IP 주소 또는 CIDR이 아닙니다")
+		}
+		return []string{member}, nil
+	}
+	return AddressRangeToCIDRs(strings.TrimSpace(start), strings.TrimSpace(end))
+}
+
+// IP 범위(시작~끝, 양 끝 포함)를 이를 정확히 덮는 최소 CIDR 목록으로 변환합니다.
+// 한 주소만 덮는 블록은 IP 주소로 반환합니다. (예: 10.0.0.1-10.0.0.6 → 10.0.0.1, 10.0.0.2/31, 10.0.0.4/31, 10.0.0.6)
+func AddressRangeToCIDRs(start, end string) ([]string, error) {
+	startIP, endIP := net.ParseIP(start), net.ParseIP(end)
+	if startIP == nil || endIP == nil {
+		return nil, fmt.Errorf("잘못된 IP 범위: %s-%s", start, end)
+	}
+	family, _ := AddressFamily(start)
+	if endFamily, _ := AddressFamily(end); endFamily != family {
+		return nil, fmt.Errorf("범위의 시작과 끝의 주소 체계가 다릅니다: %s-%s", start, end)
+	}
+
+	bits := 128
+	if family == FamilyIPv4 {
+		bits = 32
+		startIP, endIP = startIP.To4(), endIP.To4()
+	}
+	lo := new(big.Int).SetBytes(startIP)
+	hi := new(big.Int).SetBytes(endIP)
+	if lo.Cmp(hi) > 0 {
+		return nil, fmt.Errorf("범위의 시작이 끝보다 큽니다: %s-%s", start, end)
+	}
+
+	one := big.NewInt(1)
+	var cidrs []string
+	for lo.Cmp(hi) <= 0 {
+		// 시작 주소에 정렬되고 끝을 넘지 않는 가장 큰 블록
+		size := 0
+		for size < bits && lo.Bit(size) == 0 {
+			last := new(big.Int).Lsh(one, uint(size+1))
+			last.Add(last, lo).Sub(last, one)
+			if last.Cmp(hi) > 0 {
+				break
+			}
+			size++
+		}
+
+		ip := net.IP(lo.FillBytes(make([]byte, bits/8)))
+		if size == 0 {
+			cidrs = append(cidrs, ip.String())
+		} else {
+			cidrs = append(cidrs, fmt.Sprintf("%s/%d", ip, bits-size))
+		}
+		lo.Add(lo, new(big.Int).Lsh(one, uint(size)))
+	}
+	return cidrs, nil
+}
+
+// UI Select용 객체 종류 목록
+func GetObjectKindOptions() []string {
+	return []string{string(ObjectKindAddress), string(ObjectKindService)}
+}
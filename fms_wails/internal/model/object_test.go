@@ -0,0 +1,78 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestAddressRangeToCIDRs IP 범위 → CIDR 변환 테스트
+func TestAddressRangeToCIDRs(t *testing.T) {
+	tests := []struct {
+		start, end string
+		want       string
+	}{
+		{"10.0.0.1", "10.0.0.1", "10.0.0.1"},
+		{"10.0.0.0", "10.0.0.255", "10.0.0.0/24"},
+		{"10.0.0.1", "10.0.0.6", "10.0.0.1,10.0.0.2/31,10.0.0.4/31,10.0.0.6"},
+		{"192.168.0.250", "192.168.1.3", "192.168.0.250/31,192.168.0.252/30,192.168.1.0/30"},
+		{"0.0.0.0", "255.255.255.255", "0.0.0.0/0"},
+		{"2001:db8::", "2001:db8::ff", "2001:db8::/120"},
+		{"2001:db8::1", "2001:db8::2", "2001:db8::1,2001:db8::2"},
+	}
+
+	for _, tt := range tests {
+		got, err := AddressRangeToCIDRs(tt.start, tt.end)
+		if err != nil || strings.Join(got, ",") != tt.want {
+			t.Errorf("AddressRangeToCIDRs(%s, %s) = %v, %v, want %s", tt.start, tt.end, got, err, tt.want)
+		}
+	}
+
+	for _, r := range [][2]string{{"10.0.0.9", "10.0.0.1"}, {"10.0.0.1", "2001:db8::1"}, {"10.0.0.1", "host"}} {
+		if _, err := AddressRangeToCIDRs(r[0], r[1]); err == nil {
+			t.Errorf("AddressRangeToCIDRs(%s, %s) should return error", r[0], r[1])
+		}
+	}
+}
+
+// TestNamedObjectValidate 객체 검증 테스트
+func TestNamedObjectValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		object  *NamedObject
+		wantErr bool
+	}{
+		{"주소 객체", NewNamedObject("monitoring", ObjectKindAddress, "10.0.0.1", "10.1.0.0/16", "10.2.0.1-10.2.0.9", "2001:db8::1"), false},
+		{"서비스 객체", NewNamedObject("web", ObjectKindService, "tcp/80", "tcp/8000:8100", "udp/53"), false},
+		{"잘못된 이름", NewNamedObject("web servers", ObjectKindService, "tcp/80"), true},
+		{"숫자로 시작하는 이름", NewNamedObject("1web", ObjectKindService, "tcp/80"), true},
+		{"멤버 없음", NewNamedObject("empty", ObjectKindAddress), true},
+		{"잘못된 주소", NewNamedObject("bad", ObjectKindAddress, "10.0.0.256"), true},
+		{"잘못된 범위", NewNamedObject("bad", ObjectKindAddress, "10.0.0.9-10.0.0.1"), true},
+		{"프로토콜 없는 포트", NewNamedObject("bad", ObjectKindService, "80"), true},
+		{"잘못된 프로토콜", NewNamedObject("bad", ObjectKindService, "icmp/8"), true},
+		{"잘못된 포트", NewNamedObject("bad", ObjectKindService, "tcp/70000"), true},
+		{"알 수 없는 종류", NewNamedObject("bad", ObjectKind("host"), "10.0.0.1"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.object.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestNamedObjectPorts 서비스 객체의 프로토콜별 포트 테스트
+func TestNamedObjectPorts(t *testing.T) {
+	obj := NewNamedObject("dns", ObjectKindService, "udp/53", "tcp/53", "udp/5353")
+	if got := obj.Protocols(); len(got) != 2 || got[0] != ProtocolUDP || got[1] != ProtocolTCP {
+		t.Errorf("Protocols() = %v, want [udp tcp]", got)
+	}
+	if got := strings.Join(obj.Ports(ProtocolUDP), ","); got != "53,5353" {
+		t.Errorf("Ports(udp) = %s, want 53,5353", got)
+	}
+	if got := obj.Ports(ProtocolICMP); len(got) != 0 {
+		t.Errorf("Ports(icmp) = %v, want 없음", got)
+	}
+}
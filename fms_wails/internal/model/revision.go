@@ -0,0 +1,172 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"fms_wails/internal/utils"
+)
+
+// 화면에 표시하는 리비전 해시 길이
+const shortHashLength = 12
+
+// 템플릿 내용의 변경 불가능한 리비전입니다.
+// 템플릿을 저장할 때마다 내용이 바뀌었으면 새 리비전이 추가되며, 기존 리비전은 수정하지 않습니다.
+type TemplateRevision struct {
+	Hash      string         `json:"hash"`             // 내용 해시 (SHA-256, 16진수)
+	Version   string         `json:"version"`          // 템플릿 버전명
+	Number    int            `json:"number"`           // 버전 안에서의 리비전 번호 (1부터)
+	Contents  string         `json:"contents"`         // 템플릿 내용
+	CreatedAt utils.JSONTime `json:"createdAt"`        // 생성 시간
+	Author    string         `json:"author,omitempty"` // 작성자
+	Note      string         `json:"note,omitempty"`   // 변경 메모
+	Parent    string         `json:"parent,omitempty"` // 이전 리비전 해시 (첫 리비전은 비어 있음)
+}
+
+// 템플릿 내용의 해시를 반환합니다.
+func TemplateHash(contents string) string {
+	sum := sha256.Sum256([]byte(contents))
+	return hex.EncodeToString(sum[:])
+}
+
+// 화면 표시용으로 줄인 리비전 해시를 반환합니다.
+func ShortHash(hash string) string {
+	if len(hash) > shortHashLength {
+		return hash[:shortHashLength]
+	}
+	return hash
+}
+
+// 템플릿의 새 리비전을 생성합니다. parent가 nil이면 첫 리비전입니다.
+func NewTemplateRevision(template *Template, parent *TemplateRevision, author, note string) *TemplateRevision {
+	revision := &TemplateRevision{
+		Hash:      TemplateHash(template.Contents),
+		Version:   template.Version,
+		Number:    1,
+		Contents:  template.Contents,
+		CreatedAt: utils.Now(),
+		Author:    author,
+		Note:      note,
+	}
+	if parent != nil {
+		revision.Number = parent.Number + 1
+		revision.Parent = parent.Hash
+	}
+	return revision
+}
+
+// 리비전의 복사본을 반환합니다.
+func (r *TemplateRevision) Clone() *TemplateRevision {
+	clone := *r
+	return &clone
+}
+
+// 리비전 내용으로 템플릿을 생성합니다.
+func (r *TemplateRevision) Template() *Template {
+	return &Template{
+		Version:  r.Version,
+		Contents: r.Contents,
+		Revision: r.Hash,
+	}
+}
+
+// 리비전을 "r3 (1a2b3c4d5e6f)" 형식으로 반환합니다.
+func (r *TemplateRevision) String() string {
+	return fmt.Sprintf("r%d (%s)", r.Number, ShortHash(r.Hash))
+}
+
+// 내용 비교 줄 유형 상수
+const (
+	DiffSame    = "same"    // 양쪽에 같은 줄
+	DiffAdded   = "added"   // 새 내용에만 있는 줄
+	DiffRemoved = "removed" // 이전 내용에만 있는 줄
+)
+
+// 두 템플릿 내용을 줄 단위로 비교한 결과의 한 줄입니다.
+type DiffLine struct {
+	Type    string `json:"type"`    // 줄 유형 (same/added/removed)
+	Text    string `json:"text"`    // 줄 내용
+	OldLine int    `json:"oldLine"` // 이전 내용에서의 줄 번호 (1부터, 추가된 줄은 0)
+	NewLine int    `json:"newLine"` // 새 내용에서의 줄 번호 (1부터, 삭제된 줄은 0)
+}
+
+// 비교 줄을 "+ 내용", "- 내용", "  내용" 형식으로 반환합니다.
+func (d DiffLine) String() string {
+	switch d.Type {
+	case DiffAdded:
+		return "+ " + d.Text
+	case DiffRemoved:
+		return "- " + d.Text
+	default:
+		return "  " + d.Text
+	}
+}
+
+// 두 템플릿 내용을 줄 단위로 비교합니다.
+// 최장 공통 부분 수열에 속하는 줄은 같은 줄로, 나머지는 삭제/추가된 줄로 표시합니다.
+func DiffContents(oldContents, newContents string) []DiffLine {
+	oldLines := splitContentLines(oldContents)
+	newLines := splitContentLines(newContents)
+
+	n, m := len(oldLines), len(newLines)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	lines := make([]DiffLine, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case oldLines[i] == newLines[j]:
+			lines = append(lines, DiffLine{Type: DiffSame, Text: oldLines[i], OldLine: i + 1, NewLine: j + 1})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, DiffLine{Type: DiffRemoved, Text: oldLines[i], OldLine: i + 1})
+			i++
+		default:
+			lines = append(lines, DiffLine{Type: DiffAdded, Text: newLines[j], NewLine: j + 1})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		lines = append(lines, DiffLine{Type: DiffRemoved, Text: oldLines[i], OldLine: i + 1})
+	}
+	for ; j < m; j++ {
+		lines = append(lines, DiffLine{Type: DiffAdded, Text: newLines[j], NewLine: j + 1})
+	}
+	return lines
+}
+
+// 비교 결과에 바뀐 줄이 있는지 확인합니다.
+func HasDiff(lines []DiffLine) bool {
+	for _, l := range lines {
+		if l.Type != DiffSame {
+			return true
+		}
+	}
+	return false
+}
+
+// 템플릿 내용을 줄 목록으로 나눕니다. (CRLF와 마지막 줄바꿈은 무시)
+func splitContentLines(contents string) []string {
+	contents = strings.TrimRight(strings.ReplaceAll(contents, "\r\n", "\n"), "\n")
+	if contents == "" {
+		return nil
+	}
+	return strings.Split(contents, "\n")
+}
@@ -444,3 +444,152 @@ func TestGetICMPCodeOptions(t *testing.T) {
 		t.Errorf("GetICMPCodeOptions()[0] = %s, want None", options[0])
 	}
 }
+
+// TestICMPv6TypeConversion ICMPv6 Type 변환 테스트
+func TestICMPv6TypeConversion(t *testing.T) {
+	tests := []struct {
+		name   string
+		number int
+	}{
+		{"destination-unreachable", 1},
+		{"packet-too-big", 2},
+		{"echo-request", 128},
+		{"echo-reply", 129},
+		{"neighbour-solicitation", 135},
+		{"neighbour-advertisement", 136},
+	}
+
+	for _, tt := range tests {
+		num, err := ICMPv6TypeNameToNumber(tt.name)
+		if err != nil || num != tt.number {
+			t.Errorf("ICMPv6TypeNameToNumber(%s) = %d, %v, want %d", tt.name, num, err, tt.number)
+		}
+		if name := ICMPv6TypeNumberToName(tt.number); name != tt.name {
+			t.Errorf("ICMPv6TypeNumberToName(%d) = %s, want %s", tt.number, name, tt.name)
+		}
+	}
+
+	// 미국식 표기도 허용
+	if num, err := ICMPv6TypeNameToNumber("neighbor-solicitation"); err != nil || num != 135 {
+		t.Errorf("ICMPv6TypeNameToNumber('neighbor-solicitation') = %d, %v, want 135", num, err)
+	}
+	// IPv4 전용 이름은 에러
+	if _, err := ICMPv6TypeNameToNumber("source-quench"); err == nil {
+		t.Error("ICMPv6TypeNameToNumber('source-quench') should return error")
+	}
+}
+
+// TestICMPv6CodeConversion ICMPv6 Code 변환 테스트
+func TestICMPv6CodeConversion(t *testing.T) {
+	tests := []struct {
+		name   string
+		number int
+	}{
+		{"no-route", 0},
+		{"address-unreachable", 3},
+		{"port-unreachable", 4},
+	}
+
+	for _, tt := range tests {
+		num, err := ICMPv6CodeNameToNumber(tt.name)
+		if err != nil || num != tt.number {
+			t.Errorf("ICMPv6CodeNameToNumber(%s) = %d, %v, want %d", tt.name, num, err, tt.number)
+		}
+		if name := ICMPv6CodeNumberToName(tt.number); name != tt.name {
+			t.Errorf("ICMPv6CodeNumberToName(%d) = %s, want %s", tt.number, name, tt.name)
+		}
+	}
+}
+
+// TestICMPTypeNumberByFamily 주소 체계별 ICMP type 변환 테스트
+func TestICMPTypeNumberByFamily(t *testing.T) {
+	if num, _ := ICMPTypeNumber(FamilyIPv4, "echo-request"); num != 8 {
+		t.Errorf("ICMPTypeNumber(IPv4, echo-request) = %d, want 8", num)
+	}
+	if num, _ := ICMPTypeNumber(FamilyIPv6, "echo-request"); num != 128 {
+		t.Errorf("ICMPTypeNumber(IPv6, echo-request) = %d, want 128", num)
+	}
+	if name := ICMPCodeName(FamilyIPv6, 4); name != "port-unreachable" {
+		t.Errorf("ICMPCodeName(IPv6, 4) = %s, want port-unreachable", name)
+	}
+}
+
+// TestParseFamily 주소 체계 문자열 변환 테스트
+func TestParseFamily(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Family
+		wantErr  bool
+	}{
+		{"ipv4", FamilyIPv4, false},
+		{"inet", FamilyIPv4, false},
+		{"ipv6", FamilyIPv6, false},
+		{"IPv6", FamilyIPv6, false},
+		{"ip6", FamilyIPv6, false},
+		{"ipx", FamilyIPv4, true},
+	}
+
+	for _, tt := range tests {
+		result, err := ParseFamily(tt.input)
+		if (err != nil) != tt.wantErr || result != tt.expected {
+			t.Errorf("ParseFamily(%s) = %d, %v, want %d (err=%v)", tt.input, result, err, tt.expected, tt.wantErr)
+		}
+		if back, _ := ParseFamily(FamilyToString(result)); err == nil && back != result {
+			t.Errorf("ParseFamily(FamilyToString(%d)) = %d", result, back)
+		}
+	}
+}
+
+// TestInferFamily 주소로 주소 체계 추론 테스트
+func TestInferFamily(t *testing.T) {
+	tests := []struct {
+		lists    []string
+		expected Family
+	}{
+		{[]string{"", ""}, FamilyIPv4},
+		{[]string{"10.0.0.1", "192.168.0.0/24"}, FamilyIPv4},
+		{[]string{"2001:db8::1", ""}, FamilyIPv6},
+		{[]string{"10.0.0.1", "2001:db8::/32"}, FamilyIPv6},
+		{[]string{"ANY"}, FamilyIPv4},
+		{[]string{"::ffff:10.0.0.1"}, FamilyIPv4}, // IPv4-mapped
+	}
+
+	for _, tt := range tests {
+		if result := InferFamily(tt.lists...); result != tt.expected {
+			t.Errorf("InferFamily(%v) = %d, want %d", tt.lists, result, tt.expected)
+		}
+	}
+}
+
+// TestValidateInterfaceName 인터페이스 이름 검사 테스트
+func TestValidateInterfaceName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"eth0", false},
+		{"eth0.100", false},
+		{"br-lan_1", false},
+		{"ppp+", false},
+		{"+", false},
+		{"abcdefghijklmno", false}, // 15자
+		{"", true},
+		{"abcdefghijklmnop", true}, // 16자
+		{"ppp+0", true},
+		{"eth0 ", true},
+		{"$(reboot)", true},
+		{"`reboot`", true},
+		{"eth0;reboot", true},
+		{"eth0|nc", true},
+		{"eth0>/tmp/x", true},
+		{"eth0&", true},
+		{"eth/0", true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateInterfaceName(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateInterfaceName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
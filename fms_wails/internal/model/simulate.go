@@ -0,0 +1,293 @@
+package model
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// 패킷 시뮬레이션 단계
+const (
+	StageDNAT   = "dnat"   // PREROUTING DNAT 규칙
+	StageWhite  = "white"  // 화이트리스트 규칙
+	StageBlack  = "black"  // 블랙리스트 규칙
+	StageFilter = "filter" // 일반 필터 규칙
+)
+
+// 일치하는 규칙이 없을 때 적용되는 기본 정책 (내보내기 룰셋의 체인 정책과 동일)
+const DefaultPolicy = ActionACCEPT
+
+// 시뮬레이션할 패킷입니다.
+type Packet struct {
+	Chain        Chain    `json:"chain"`        // INPUT, OUTPUT, FORWARD
+	Protocol     Protocol `json:"protocol"`     // tcp, udp, icmp
+	SIP          string   `json:"sip"`          // 출발지 IP
+	DIP          string   `json:"dip"`          // 목적지 IP
+	DPort        int      `json:"dport"`        // 목적지 포트 (tcp/udp)
+	SPort        int      `json:"sport"`        // 출발지 포트 (tcp/udp, 0이면 지정 안 함)
+	TCPFlags     string   `json:"tcpFlags"`     // 설정된 TCP 플래그 (예: "syn", "syn,ack", 비어 있으면 syn, 플래그 없음은 "none")
+	ICMPType     string   `json:"icmpType"`     // ICMP type 이름 또는 숫자
+	ICMPCode     string   `json:"icmpCode"`     // ICMP code (비어 있으면 0)
+	InInterface  string   `json:"inInterface"`  // 입력 인터페이스 (-i 조건 비교)
+	OutInterface string   `json:"outInterface"` // 출력 인터페이스 (-o 조건 비교)
+	State        string   `json:"state"`        // 연결 상태 (비어 있으면 NEW)
+}
+
+// 패킷 값이 올바른지 검사합니다.
+func (p *Packet) Validate() error {
+	switch p.Chain {
+	case ChainINPUT, ChainOUTPUT, ChainFORWARD:
+	default:
+		return fmt.Errorf("패킷 체인은 INPUT, OUTPUT, FORWARD 중 하나여야 합니다")
+	}
+	if p.Protocol == ProtocolANY {
+		return fmt.Errorf("패킷 프로토콜은 tcp, udp, icmp 중 하나여야 합니다")
+	}
+	if net.ParseIP(p.SIP) == nil {
+		return fmt.Errorf("잘못된 출발지 IP: %q", p.SIP)
+	}
+	if net.ParseIP(p.DIP) == nil {
+		return fmt.Errorf("잘못된 목적지 IP: %q", p.DIP)
+	}
+	if dipFamily, _ := AddressFamily(p.DIP); dipFamily != p.family() {
+		return fmt.Errorf("출발지와 목적지 IP의 주소 체계가 다릅니다")
+	}
+	switch p.Protocol {
+	case ProtocolTCP, ProtocolUDP:
+		if p.DPort < 1 || p.DPort > 65535 {
+			return fmt.Errorf("목적지 포트는 1-65535 범위여야 합니다")
+		}
+		if p.SPort < 0 || p.SPort > 65535 {
+			return fmt.Errorf("출발지 포트는 1-65535 범위여야 합니다")
+		}
+		if p.Protocol == ProtocolTCP && p.TCPFlags != "" {
+			for _, flag := range strings.Split(p.TCPFlags, ",") {
+				if !isValidTCPFlag(flag) {
+					return fmt.Errorf("알 수 없는 TCP 플래그: %q", flag)
+				}
+			}
+		}
+	case ProtocolICMP:
+		if _, err := p.icmpType(); err != nil {
+			return err
+		}
+		if _, err := p.icmpCode(); err != nil {
+			return err
+		}
+	}
+	if p.State != "" && !isValidConnState(strings.ToUpper(p.State)) {
+		return fmt.Errorf("패킷 연결 상태는 NEW, ESTABLISHED, RELATED, INVALID 중 하나여야 합니다")
+	}
+	return nil
+}
+
+// 패킷의 연결 상태를 반환합니다. (기본값 NEW)
+func (p *Packet) state() string {
+	if p.State == "" {
+		return "NEW"
+	}
+	return strings.ToUpper(p.State)
+}
+
+// 패킷의 TCP 플래그를 반환합니다. (기본값 syn, 새 연결 요청)
+// 플래그가 없는 패킷(NULL 스캔)은 "none"으로 지정합니다.
+func (p *Packet) tcpFlags() string {
+	if p.TCPFlags == "" {
+		return "syn"
+	}
+	return p.TCPFlags
+}
+
+// 패킷의 주소 체계를 반환합니다. (출발지 IP 기준)
+func (p *Packet) family() Family {
+	family, _ := AddressFamily(p.SIP)
+	return family
+}
+
+// IPv6 패킷의 type/code는 ICMPv6 값으로 해석합니다.
+func (p *Packet) icmpType() (int, error) {
+	if p.ICMPType == "" {
+		return 0, fmt.Errorf("ICMP 패킷에는 type이 필요합니다")
+	}
+	return ICMPTypeNumber(p.family(), p.ICMPType)
+}
+
+func (p *Packet) icmpCode() (int, error) {
+	if p.ICMPCode == "" {
+		return 0, nil
+	}
+	return ICMPCodeNumber(p.family(), p.ICMPCode)
+}
+
+// 패킷을 "tcp 10.1.2.3 → 192.168.0.5:443" 형식으로 반환합니다.
+func (p Packet) String() string {
+	dest := p.DIP
+	if p.Protocol != ProtocolICMP {
+		dest = net.JoinHostPort(p.DIP, strconv.Itoa(p.DPort))
+	}
+	return fmt.Sprintf("%s %s → %s", ProtocolToString(p.Protocol), p.SIP, dest)
+}
+
+// 필터 규칙과 패킷을 비교합니다.
+// 일치하지 않으면 첫 번째로 다른 조건을 사유로 반환합니다. (체인은 호출한 쪽에서 비교)
+func (p *Packet) MatchRule(rule *FirewallRule) (bool, string) {
+	if rule.Family != p.family() {
+		return false, fmt.Sprintf("주소 체계 불일치 (%s)", FamilyToString(rule.Family))
+	}
+	if rule.Protocol != ProtocolANY && rule.Protocol != p.Protocol {
+		return false, fmt.Sprintf("프로토콜 불일치 (%s)", ProtocolToString(rule.Protocol))
+	}
+	if !ipInList(p.SIP, rule.SIP) {
+		return false, fmt.Sprintf("출발지 IP 불일치 (%s)", rule.SIP)
+	}
+	if !ipInList(p.DIP, rule.DIP) {
+		return false, fmt.Sprintf("목적지 IP 불일치 (%s)", rule.DIP)
+	}
+	if rule.DPort != "" && (p.Protocol == ProtocolICMP || !portInList(p.DPort, rule.DPort)) {
+		return false, fmt.Sprintf("목적지 포트 불일치 (%s)", rule.DPort)
+	}
+	if rule.SPort != "" && (p.Protocol == ProtocolICMP || !portInList(p.SPort, rule.SPort)) {
+		return false, fmt.Sprintf("출발지 포트 불일치 (%s)", rule.SPort)
+	}
+	if rule.InInterface != "" && rule.InInterface != p.InInterface {
+		return false, fmt.Sprintf("입력 인터페이스 불일치 (%s)", rule.InInterface)
+	}
+	if rule.OutInterface != "" && rule.OutInterface != p.OutInterface {
+		return false, fmt.Sprintf("출력 인터페이스 불일치 (%s)", rule.OutInterface)
+	}
+	if rule.State != "" && !stateInList(p.state(), rule.State) {
+		return false, fmt.Sprintf("연결 상태 불일치 (%s)", rule.State)
+	}
+
+	if opts := rule.Options; opts != nil {
+		if rule.Protocol == ProtocolTCP && opts.TCPFlags != "" {
+			mask, set := parseTCPFlagBits(opts.TCPFlags)
+			if tcpFlagBits(p.tcpFlags())&mask != set {
+				return false, fmt.Sprintf("TCP flags 불일치 (%s)", opts.TCPFlags)
+			}
+		}
+		if rule.Protocol == ProtocolICMP && opts.ICMPType != "" {
+			ruleType, _ := ICMPTypeNumber(rule.Family, opts.ICMPType)
+			packetType, _ := p.icmpType()
+			if ruleType != packetType {
+				return false, fmt.Sprintf("ICMP type 불일치 (%s)", opts.ICMPType)
+			}
+			if opts.ICMPCode != "" {
+				ruleCode, _ := ICMPCodeNumber(rule.Family, opts.ICMPCode)
+				packetCode, _ := p.icmpCode()
+				if ruleCode != packetCode {
+					return false, fmt.Sprintf("ICMP code 불일치 (%s)", opts.ICMPCode)
+				}
+			}
+		}
+	}
+
+	return true, "일치"
+}
+
+// DNAT 규칙과 패킷을 비교합니다.
+// DNAT가 아닌 규칙은 일치하지 않습니다.
+func (p *Packet) MatchNAT(rule *NATRule) (bool, string) {
+	if rule.NATType != NATTypeDNAT {
+		return false, "DNAT 규칙이 아님"
+	}
+	if rule.Family != p.family() {
+		return false, fmt.Sprintf("주소 체계 불일치 (%s)", FamilyToString(rule.Family))
+	}
+	if rule.Protocol != ProtocolANY && rule.Protocol != p.Protocol {
+		return false, fmt.Sprintf("프로토콜 불일치 (%s)", ProtocolToString(rule.Protocol))
+	}
+	if matchIP := rule.MatchIP; !strings.EqualFold(matchIP, "ANY") && !ipInList(p.SIP, matchIP) {
+		return false, fmt.Sprintf("출발지 IP 불일치 (%s)", matchIP)
+	}
+	if rule.MatchPort != "" && (p.Protocol == ProtocolICMP || !portInList(p.DPort, rule.MatchPort)) {
+		return false, fmt.Sprintf("목적지 포트 불일치 (%s)", rule.MatchPort)
+	}
+	if rule.InInterface != "" && rule.InInterface != p.InInterface {
+		return false, fmt.Sprintf("입력 인터페이스 불일치 (%s)", rule.InInterface)
+	}
+	return true, "일치"
+}
+
+// DNAT 규칙의 변환 대상을 적용한 패킷을 반환합니다.
+// 변환 포트가 범위이면 시작 포트를 사용합니다.
+func (p Packet) Translate(rule *NATRule) Packet {
+	p.DIP = rule.TranslateIP
+	if rule.TranslatePort != "" && p.Protocol != ProtocolICMP {
+		start := rule.TranslatePort
+		if sep := strings.IndexAny(start, ":-"); sep != -1 {
+			start = start[:sep]
+		}
+		if port, err := parsePort(start); err == nil {
+			p.DPort = port
+		}
+	}
+	return p
+}
+
+// 시뮬레이션 중 규칙 하나를 평가한 기록입니다.
+type TraceStep struct {
+	Stage   string `json:"stage"`   // dnat, white, black, filter
+	Line    int    `json:"line"`    // 규칙의 줄 번호
+	Rule    string `json:"rule"`    // 규칙 원문
+	Matched bool   `json:"matched"` // 일치 여부
+	Reason  string `json:"reason"`  // 일치 또는 불일치 사유
+}
+
+// 패킷 시뮬레이션 결과입니다.
+type SimulationResult struct {
+	Verdict     string      `json:"verdict"`     // 최종 동작 (ACCEPT, DROP, REJECT)
+	MatchedLine int         `json:"matchedLine"` // 동작을 결정한 규칙의 줄 번호 (기본 정책이면 0)
+	MatchedRule string      `json:"matchedRule"` // 동작을 결정한 규칙 원문
+	Translated  bool        `json:"translated"`  // DNAT 적용 여부
+	Packet      Packet      `json:"packet"`      // 필터 규칙에 전달된 패킷 (DNAT 적용 후)
+	Trace       []TraceStep `json:"trace"`       // 평가한 규칙 순서
+}
+
+// 결과를 한 줄 요약으로 반환합니다.
+func (r *SimulationResult) Summary() string {
+	if r.MatchedLine == 0 {
+		return fmt.Sprintf("%s (일치하는 규칙이 없어 기본 정책 적용)", r.Verdict)
+	}
+	return fmt.Sprintf("%s (라인 %d: %s)", r.Verdict, r.MatchedLine, r.MatchedRule)
+}
+
+// IP가 IP 목록(쉼표 구분, CIDR 포함)에 포함되는지 확인합니다. 빈 목록은 모든 IP와 일치합니다.
+func ipInList(ip, list string) bool {
+	nets, all := parseIPNets(list)
+	if all {
+		return true
+	}
+	addr := net.ParseIP(ip)
+	for _, n := range nets {
+		if n.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// 연결 상태가 상태 목록(ESTABLISHED,RELATED)에 포함되는지 확인합니다.
+func stateInList(state, list string) bool {
+	for _, s := range strings.Split(list, ",") {
+		if strings.EqualFold(strings.TrimSpace(s), state) {
+			return true
+		}
+	}
+	return false
+}
+
+// 포트가 포트 목록(80, 8000:8080, 80,443)에 포함되는지 확인합니다.
+func portInList(port int, list string) bool {
+	ranges, all := parsePortRanges(list)
+	if all {
+		return true
+	}
+	for _, r := range ranges {
+		if r.start <= port && port <= r.end {
+			return true
+		}
+	}
+	return false
+}
@@ -5,8 +5,10 @@ import "strings"
 
 // 방화벽 규칙 템플릿을 나타냅니다.
 type Template struct {
-	Version  string `json:"version"`  // 템플릿 버전명 (Primary Key)
-	Contents string `json:"contents"` // 방화벽 규칙 내용 (줄 단위)
+	Version  string   `json:"version"`            // 템플릿 버전명 (Primary Key)
+	Contents string   `json:"contents"`           // 방화벽 규칙 내용 (줄 단위)
+	Revision string   `json:"revision,omitempty"` // 현재 내용의 리비전 해시 (저장 시 설정)
+	Includes []string `json:"includes,omitempty"` // 펼친 포함 템플릿 버전과 리비전 (버전@해시, 포함 지시문을 펼친 유효 템플릿에만 설정)
 }
 
 // 새로운 템플릿을 생성합니다.
@@ -18,8 +20,14 @@ func NewTemplate(version, contents string) *Template {
 }
 
 // 템플릿이 유효한지 검사합니다.
+// 버전과 내용이 있고 규칙에 오류 진단이 없어야 합니다.
 func (t *Template) IsValid() bool {
-	return t.Version != "" && strings.TrimSpace(t.Contents) != ""
+	return t.Version != "" && strings.TrimSpace(t.Contents) != "" && !HasErrors(t.Validate())
+}
+
+// 템플릿 내용의 진단 목록을 반환합니다.
+func (t *Template) Validate() []Diagnostic {
+	return ValidateTemplate(t.Contents)
 }
 
 // 템플릿의 복사본을 반환합니다.
@@ -27,5 +35,7 @@ func (t *Template) Clone() *Template {
 	return &Template{
 		Version:  t.Version,
 		Contents: t.Contents,
+		Revision: t.Revision,
+		Includes: append([]string(nil), t.Includes...),
 	}
 }
@@ -0,0 +1,756 @@
+package model
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 진단 심각도
+const (
+	SeverityError   = "error"   // 저장 및 배포 불가
+	SeverityWarning = "warning" // 기본값 적용 또는 무시되는 항목
+)
+
+// 진단 코드
+const (
+	DiagUnknownFormat          = "unknown-format"           // agent 명령 형식이 아님
+	DiagUnknownOption          = "unknown-option"           // 알 수 없는 옵션
+	DiagUnexpectedToken        = "unexpected-token"         // 옵션 형식이 아닌 토큰
+	DiagDuplicateOption        = "duplicate-option"         // 같은 옵션 중복
+	DiagEmptyValue             = "empty-value"              // 값이 비어 있는 옵션
+	DiagInvalidMode            = "invalid-mode"             // -m 값 오류
+	DiagInvalidTable           = "invalid-table"            // -t 값 오류
+	DiagInvalidChain           = "invalid-chain"            // -c 값 오류
+	DiagInvalidProtocol        = "invalid-protocol"         // -p 값 오류
+	DiagInvalidAction          = "invalid-action"           // -a 값 오류
+	DiagInvalidNATType         = "invalid-nat-type"         // --nat-type 값 오류
+	DiagInvalidPort            = "invalid-port"             // 포트 범위(1-65535) 오류
+	DiagInvalidIP              = "invalid-ip"               // IP/CIDR 형식 오류
+	DiagInvalidTCPFlags        = "invalid-tcp-flags"        // flags 값 오류
+	DiagInvalidICMPType        = "invalid-icmp-type"        // ICMP type 값 오류
+	DiagInvalidICMPCode        = "invalid-icmp-code"        // ICMP code 값 오류
+	DiagUnknownProtocolOption  = "unknown-protocol-option"  // -p 쿼리의 알 수 없는 키
+	DiagOptionProtocolMismatch = "option-protocol-mismatch" // 프로토콜에 맞지 않는 옵션
+	DiagConflictingFlags       = "conflicting-flags"        // --black과 --white 동시 지정
+	DiagMissingChain           = "missing-chain"            // -c 생략 (INPUT 적용)
+	DiagMissingAction          = "missing-action"           // -a 생략 (DROP 적용)
+	DiagMissingNATType         = "missing-nat-type"         // --nat-type 생략 (DNAT 적용)
+	DiagMissingTarget          = "missing-target"           // DNAT/SNAT 변환 대상 누락
+	DiagInvalidState           = "invalid-state"            // --state 값 오류
+	DiagOptionChainMismatch    = "option-chain-mismatch"    // 체인에 맞지 않는 인터페이스 옵션
+	DiagInvalidInterface       = "invalid-interface"        // -i/-o 인터페이스 이름 오류
+	DiagInvalidFamily          = "invalid-family"           // --family 값 오류
+	DiagFamilyMismatch         = "family-mismatch"          // 규칙 주소 체계와 다른 주소
+	DiagInvalidObjectRef       = "invalid-object-ref"       // 객체 참조(@이름) 오류
+	DiagInvalidVariable        = "invalid-variable"         // 템플릿 변수(${이름}) 오류
+	DiagInvalidInclude         = "invalid-include"          // 포함 지시문(#include) 오류
+	DiagShadowedRule           = "shadowed-rule"            // 앞선 다른 동작 규칙에 가려져 매칭되지 않음
+	DiagRedundantRule          = "redundant-rule"           // 앞선 같은 동작 규칙에 포함되어 불필요
+	DiagConflictingRule        = "conflicting-rule"         // 앞선 규칙과 같은 조건, 다른 동작
+)
+
+// NAT 규칙 설명 옵션 (값은 공백을 포함하여 줄 끝까지)
+const natDescOption = "--desc"
+
+// 템플릿 한 줄에 대한 검증 결과입니다.
+type Diagnostic struct {
+	Line     int    `json:"line"`     // 줄 번호 (1부터)
+	Column   int    `json:"column"`   // 열 번호 (1부터, 문자 단위)
+	Severity string `json:"severity"` // error, warning
+	Code     string `json:"code"`     // 진단 코드
+	Message  string `json:"message"`  // 사용자 표시용 메시지
+}
+
+// "라인 3:12: [error] 메시지" 형식으로 반환합니다.
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("라인 %d:%d: [%s] %s", d.Line, d.Column, d.Severity, d.Message)
+}
+
+// 오류 심각도인지 확인합니다.
+func (d Diagnostic) IsError() bool {
+	return d.Severity == SeverityError
+}
+
+// 진단 목록에 오류가 있는지 확인합니다.
+func HasErrors(diagnostics []Diagnostic) bool {
+	for _, d := range diagnostics {
+		if d.IsError() {
+			return true
+		}
+	}
+	return false
+}
+
+// 템플릿 텍스트의 문법 및 의미 오류를 검사합니다.
+// 빈 줄과 주석은 건너뛰며, -t=nat 줄은 NAT 규칙으로 검사합니다.
+func ValidateTemplate(contents string) []Diagnostic {
+	var diagnostics []Diagnostic
+	for i, line := range strings.Split(contents, "\n") {
+		v := &lineValidator{line: i + 1}
+		v.validate(strings.TrimRight(line, "\r"))
+		diagnostics = append(diagnostics, v.diagnostics...)
+	}
+	return diagnostics
+}
+
+// 템플릿 한 줄의 문법 및 의미 오류를 검사합니다. 진단의 줄 번호는 1입니다.
+func ValidateLine(line string) []Diagnostic {
+	v := &lineValidator{line: 1}
+	v.validate(strings.TrimRight(line, "\r"))
+	return v.diagnostics
+}
+
+// -p 값(프로토콜과 쿼리 옵션) 하나를 템플릿과 같은 문법으로 검사합니다.
+// 템플릿 밖에서 읽은 규칙(SmartFW 요청 등)에 사용하며, 진단의 열 번호는 값 안의 위치입니다.
+func ValidateProtocol(value string, family Family) []Diagnostic {
+	v := &lineValidator{line: 1}
+	protocol, query := v.checkProtocol(value, 1)
+	v.checkProtocolOptions(protocol, family, query)
+	return v.diagnostics
+}
+
+// 줄 안의 토큰과 시작 열 번호입니다.
+// 템플릿 검증과 규칙 파싱(parser)이 같은 토큰으로 줄을 읽습니다.
+type Token struct {
+	Text   string // 토큰 내용
+	Column int    // 시작 열 번호 (1부터, 문자 단위)
+}
+
+// 옵션 토큰의 이름(= 앞)과 값을 반환합니다.
+func (t Token) Option() (string, string) {
+	name, value, _ := strings.Cut(t.Text, "=")
+	return name, value
+}
+
+// 옵션 값의 시작 열 번호를 반환합니다.
+func (t Token) ValueColumn() int {
+	name, _ := t.Option()
+	return t.Column + utf8.RuneCountInString(name) + 1
+}
+
+// 공백 기준으로 토큰을 나누고 각 토큰의 열 번호를 함께 반환합니다.
+func TokenizeLine(line string) []Token {
+	var tokens []Token
+	column, start := 0, -1
+	for _, r := range line {
+		column++
+		if unicode.IsSpace(r) {
+			start = -1
+			continue
+		}
+		if start == -1 {
+			start = column
+			tokens = append(tokens, Token{Column: start})
+		}
+		tokens[len(tokens)-1].Text += string(r)
+	}
+	return tokens
+}
+
+// 한 줄의 진단을 수집합니다.
+type lineValidator struct {
+	line        int
+	diagnostics []Diagnostic
+	addresses   []Token // 주소 체계 검사용 주소 (IP 또는 CIDR 하나씩)
+	objectRefs  bool    // 객체 참조(@이름) 허용 여부 (필터 규칙의 IP/포트)
+}
+
+func (v *lineValidator) add(column int, severity, code, format string, args ...interface{}) {
+	v.diagnostics = append(v.diagnostics, Diagnostic{
+		Line:     v.line,
+		Column:   column,
+		Severity: severity,
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *lineValidator) errorf(column int, code, format string, args ...interface{}) {
+	v.add(column, SeverityError, code, format, args...)
+}
+
+func (v *lineValidator) warnf(column int, code, format string, args ...interface{}) {
+	v.add(column, SeverityWarning, code, format, args...)
+}
+
+func (v *lineValidator) validate(line string) {
+	tokens := TokenizeLine(line)
+	if version, ok := ParseIncludeLine(line); ok {
+		if version == "" {
+			v.errorf(tokens[0].Column, DiagInvalidInclude, "%s 지시문에 템플릿 버전이 없습니다", IncludeDirective)
+		}
+		return
+	}
+	if len(tokens) == 0 || strings.HasPrefix(tokens[0].Text, "#") {
+		return
+	}
+	if tokens[0].Text != "agent" || len(tokens) == 1 {
+		v.errorf(tokens[0].Column, DiagUnknownFormat, "agent 명령 형식이 아닙니다: %s", strings.TrimSpace(line))
+		return
+	}
+
+	if IsNATOptions(tokens[1:]) {
+		v.validateNAT(tokens[1:])
+		return
+	}
+	v.validateRule(tokens[1:])
+}
+
+// 옵션 토큰(agent 다음부터)에 -t=nat이 있으면 NAT 규칙으로 봅니다. (--desc 이후의 설명은 제외)
+func IsNATOptions(options []Token) bool {
+	for _, tok := range options {
+		if name, _ := tok.Option(); name == natDescOption {
+			return false
+		}
+		if tok.Text == "-t=nat" {
+			return true
+		}
+	}
+	return false
+}
+
+// 템플릿 줄이 NAT 규칙(-t=nat 옵션이 있는 agent 명령)인지 확인합니다.
+func IsNATLine(line string) bool {
+	tokens := TokenizeLine(line)
+	return len(tokens) > 1 && tokens[0].Text == "agent" && IsNATOptions(tokens[1:])
+}
+
+// 같은 옵션(별칭 포함, name으로 전달)이 이미 나왔으면 오류를 추가합니다.
+func (v *lineValidator) checkDuplicate(seen map[string]bool, name string, tok Token) {
+	if seen[name] {
+		v.errorf(tok.Column, DiagDuplicateOption, "%s 옵션이 중복되었습니다", name)
+	}
+	seen[name] = true
+}
+
+// 옵션이 아닌 토큰은 불필요한 토큰, 나머지는 알 수 없는 옵션 오류를 추가합니다.
+func (v *lineValidator) unknownToken(tok Token) {
+	if strings.HasPrefix(tok.Text, "-") {
+		v.errorf(tok.Column, DiagUnknownOption, "알 수 없는 옵션: %s", tok.Text)
+	} else {
+		v.errorf(tok.Column, DiagUnexpectedToken, "불필요한 토큰: %s", tok.Text)
+	}
+}
+
+// 일반 방화벽 규칙 옵션을 검사합니다.
+func (v *lineValidator) validateRule(options []Token) {
+	v.objectRefs = true
+	seen := make(map[string]bool)
+	protocol := "tcp" // -p 생략 시 기본값
+	chain := "INPUT"  // -c 생략 시 기본값
+	var blackTok, whiteTok *Token
+	var portToks []Token // --dport, --sport
+	var ifaceToks []Token
+	var query []Token // -p 쿼리 옵션 (flags, type, code)
+	var familyTok *Token
+
+	for i := range options {
+		tok := options[i]
+		name, value := tok.Option()
+
+		switch name {
+		case "-m":
+			v.checkDuplicate(seen, name, tok)
+			v.checkMode(tok)
+		case "-t":
+			v.checkDuplicate(seen, name, tok)
+			if !strings.EqualFold(value, "filter") {
+				v.errorf(tok.ValueColumn(), DiagInvalidTable, "알 수 없는 테이블: %q (filter, nat)", value)
+			}
+		case "-c":
+			v.checkDuplicate(seen, name, tok)
+			if _, err := ParseChain(value); err != nil {
+				v.errorf(tok.ValueColumn(), DiagInvalidChain, "알 수 없는 체인: %q (INPUT, OUTPUT, FORWARD, PREROUTING, POSTROUTING)", value)
+			}
+			chain = strings.ToUpper(value)
+		case "-p":
+			v.checkDuplicate(seen, name, tok)
+			protocol, query = v.checkProtocol(value, tok.ValueColumn())
+		case "-a":
+			v.checkDuplicate(seen, name, tok)
+			if _, err := ParseAction(value); err != nil {
+				v.errorf(tok.ValueColumn(), DiagInvalidAction, "알 수 없는 동작: %q (DROP, ACCEPT, REJECT)", value)
+			}
+		case "--family":
+			v.checkDuplicate(seen, name, tok)
+			familyTok = v.checkFamilyOption(&options[i])
+		case "--dport", "--sport":
+			v.checkDuplicate(seen, name, tok)
+			if value == "" {
+				v.warnf(tok.ValueColumn(), DiagEmptyValue, "%s 값이 비어 있어 무시됩니다", name)
+				continue
+			}
+			portToks = append(portToks, tok)
+			v.checkPorts(tok)
+		case "-i", "-o":
+			v.checkDuplicate(seen, name, tok)
+			if value == "" {
+				v.errorf(tok.ValueColumn(), DiagEmptyValue, "%s 인터페이스 이름이 비어 있습니다", name)
+				continue
+			}
+			v.checkInterface(tok)
+			ifaceToks = append(ifaceToks, tok)
+		case "--state":
+			v.checkDuplicate(seen, name, tok)
+			if _, err := ParseConnState(value); err != nil {
+				v.errorf(tok.ValueColumn(), DiagInvalidState, "잘못된 연결 상태 %q (NEW, ESTABLISHED, RELATED, INVALID)", value)
+			}
+		case "--sip", "--dip":
+			v.checkDuplicate(seen, name, tok)
+			if value == "" {
+				v.warnf(tok.ValueColumn(), DiagEmptyValue, "%s 값이 비어 있어 무시됩니다", name)
+				continue
+			}
+			v.checkIPs(tok)
+		case "--black":
+			if tok.Text != name {
+				v.errorf(tok.Column, DiagUnknownOption, "알 수 없는 옵션: %s", tok.Text)
+				continue
+			}
+			v.checkDuplicate(seen, name, tok)
+			blackTok = &options[i]
+		case "--white":
+			if tok.Text != name {
+				v.errorf(tok.Column, DiagUnknownOption, "알 수 없는 옵션: %s", tok.Text)
+				continue
+			}
+			v.checkDuplicate(seen, name, tok)
+			whiteTok = &options[i]
+		default:
+			v.unknownToken(tok)
+		}
+	}
+
+	family := v.checkFamily(familyTok)
+	v.checkProtocolOptions(protocol, family, query)
+	if protocol == "icmp" {
+		for _, tok := range portToks {
+			name, _ := tok.Option()
+			v.errorf(tok.Column, DiagOptionProtocolMismatch, "%s는 icmp 프로토콜에서 사용할 수 없습니다", name)
+		}
+	}
+	for _, tok := range ifaceToks {
+		name, _ := tok.Option()
+		if (name == "-i" && chain == "OUTPUT") || (name == "-o" && chain == "INPUT") {
+			v.errorf(tok.Column, DiagOptionChainMismatch, "%s 옵션은 %s 체인에서 사용할 수 없습니다", name, chain)
+		}
+	}
+	if blackTok != nil && whiteTok != nil {
+		v.errorf(whiteTok.Column, DiagConflictingFlags, "--black과 --white는 함께 사용할 수 없습니다")
+	}
+	if !seen["-c"] {
+		v.warnf(options[0].Column, DiagMissingChain, "-c 옵션이 없어 INPUT 체인이 적용됩니다")
+	}
+	if !seen["-a"] {
+		v.warnf(options[0].Column, DiagMissingAction, "-a 옵션이 없어 DROP 동작이 적용됩니다")
+	}
+}
+
+// -m 값(처리 방식)을 검사합니다. 템플릿 규칙은 insert만 사용할 수 있습니다.
+func (v *lineValidator) checkMode(tok Token) {
+	_, value := tok.Option()
+	if value == "" {
+		v.errorf(tok.ValueColumn(), DiagEmptyValue, "-m 값이 비어 있습니다")
+	} else if !strings.EqualFold(value, "insert") {
+		v.errorf(tok.ValueColumn(), DiagInvalidMode, "알 수 없는 처리 방식: %q (insert)", value)
+	}
+}
+
+// -p 값을 프로토콜 이름과 쿼리 옵션 토큰으로 나누고, 프로토콜 이름을 검사합니다.
+func (v *lineValidator) checkProtocol(value string, column int) (string, []Token) {
+	base, rest, hasQuery := strings.Cut(value, "?")
+	protocol := strings.ToLower(base)
+	if _, err := ParseProtocol(protocol); err != nil {
+		v.errorf(column, DiagInvalidProtocol, "알 수 없는 프로토콜: %q (tcp, udp, icmp, any)", base)
+	}
+	if !hasQuery {
+		return protocol, nil
+	}
+	return protocol, queryTokens(rest, column+utf8.RuneCountInString(base)+1)
+}
+
+// -p 쿼리 문자열(flags=..&type=..)을 열 번호가 있는 토큰으로 나눕니다.
+func queryTokens(query string, column int) []Token {
+	var tokens []Token
+	for _, param := range strings.Split(query, "&") {
+		tokens = append(tokens, Token{Text: param, Column: column})
+		column += utf8.RuneCountInString(param) + 1
+	}
+	return tokens
+}
+
+// --family 값을 검사하고, 올바르면 토큰을 반환합니다.
+func (v *lineValidator) checkFamilyOption(tok *Token) *Token {
+	_, value := tok.Option()
+	if _, err := ParseFamily(value); err != nil {
+		v.errorf(tok.ValueColumn(), DiagInvalidFamily, "알 수 없는 주소 체계: %q (ipv4, ipv6)", value)
+		return nil
+	}
+	return tok
+}
+
+// 규칙의 주소 체계를 정하고, 다른 주소 체계의 주소가 섞여 있는지 검사합니다.
+// --family가 없으면 주소에 IPv6가 하나라도 있을 때 IPv6 규칙으로 봅니다.
+func (v *lineValidator) checkFamily(familyTok *Token) Family {
+	var family Family
+	if familyTok != nil {
+		_, value := familyTok.Option()
+		family, _ = ParseFamily(value)
+	} else {
+		for _, addr := range v.addresses {
+			if f, _ := AddressFamily(addr.Text); f == FamilyIPv6 {
+				family = FamilyIPv6
+			}
+		}
+	}
+	for _, addr := range v.addresses {
+		if f, _ := AddressFamily(addr.Text); f != family {
+			v.errorf(addr.Column, DiagFamilyMismatch, "%s 규칙에 %s 주소를 사용할 수 없습니다: %q", familyName(family), familyName(f), addr.Text)
+		}
+	}
+	return family
+}
+
+// 진단 메시지용 주소 체계 이름을 반환합니다.
+func familyName(f Family) string {
+	if f == FamilyIPv6 {
+		return "IPv6"
+	}
+	return "IPv4"
+}
+
+// 프로토콜 쿼리 옵션이 프로토콜과 맞는지, 값이 올바른지 검사합니다.
+// IPv6 규칙의 type/code는 ICMPv6 값으로 검사합니다.
+func (v *lineValidator) checkProtocolOptions(protocol string, family Family, query []Token) {
+	icmpName := "ICMP"
+	if family == FamilyIPv6 {
+		icmpName = "ICMPv6"
+	}
+	var typeTok, codeTok *Token
+	seen := make(map[string]bool)
+	for i := range query {
+		tok := query[i]
+		key, value := tok.Option()
+		v.checkDuplicate(seen, key, tok)
+		switch key {
+		case "flags":
+			if protocol != "tcp" {
+				v.errorf(tok.Column, DiagOptionProtocolMismatch, "TCP flags는 tcp 프로토콜에서만 사용할 수 있습니다 (현재 %s)", protocol)
+				continue
+			}
+			if err := validateTCPFlags(value); err != nil {
+				v.errorf(tok.ValueColumn(), DiagInvalidTCPFlags, "잘못된 TCP flags %q: %v", value, err)
+			}
+		case "type":
+			typeTok = &query[i]
+			if protocol != "icmp" {
+				v.errorf(tok.Column, DiagOptionProtocolMismatch, "ICMP type은 icmp 프로토콜에서만 사용할 수 있습니다 (현재 %s)", protocol)
+				continue
+			}
+			if num, err := ICMPTypeNumber(family, value); err != nil || num < 0 || num > 255 {
+				v.errorf(tok.ValueColumn(), DiagInvalidICMPType, "알 수 없는 %s type: %q", icmpName, value)
+			}
+		case "code":
+			codeTok = &query[i]
+			if protocol != "icmp" {
+				v.errorf(tok.Column, DiagOptionProtocolMismatch, "ICMP code는 icmp 프로토콜에서만 사용할 수 있습니다 (현재 %s)", protocol)
+				continue
+			}
+			if num, err := ICMPCodeNumber(family, value); err != nil || num < 0 || num > 255 {
+				v.errorf(tok.ValueColumn(), DiagInvalidICMPCode, "알 수 없는 %s code: %q", icmpName, value)
+			}
+		default:
+			v.errorf(tok.Column, DiagUnknownProtocolOption, "알 수 없는 프로토콜 옵션: %q (flags, type, code)", tok.Text)
+		}
+	}
+	if protocol == "icmp" && codeTok != nil && typeTok == nil {
+		v.errorf(codeTok.Column, DiagInvalidICMPCode, "ICMP code는 type과 함께 지정해야 합니다")
+	}
+}
+
+// NAT 규칙 옵션을 검사합니다.
+func (v *lineValidator) validateNAT(options []Token) {
+	seen := make(map[string]bool)
+	natType := NATTypeDNAT // --nat-type 생략 시 기본값
+	var familyTok *Token
+
+loop:
+	for i := range options {
+		tok := options[i]
+		name, value := tok.Option()
+
+		switch name {
+		case "-m":
+			v.checkDuplicate(seen, name, tok)
+			v.checkMode(tok)
+		case "-t":
+			v.checkDuplicate(seen, name, tok)
+			if value != "nat" {
+				v.errorf(tok.ValueColumn(), DiagInvalidTable, "NAT 규칙에는 다른 테이블을 지정할 수 없습니다: %q", value)
+			}
+		case "--nat-type":
+			v.checkDuplicate(seen, name, tok)
+			var err error
+			if natType, err = ParseNATType(value); err != nil {
+				v.errorf(tok.ValueColumn(), DiagInvalidNATType, "알 수 없는 NAT 타입: %q (dnat, snat, masquerade)", value)
+			}
+		case "-p":
+			v.checkDuplicate(seen, name, tok)
+			switch strings.ToLower(value) {
+			case "tcp", "udp", "any":
+			default:
+				v.errorf(tok.ValueColumn(), DiagInvalidProtocol, "NAT 규칙에 사용할 수 없는 프로토콜: %q (tcp, udp, any)", value)
+			}
+		case "--family":
+			v.checkDuplicate(seen, name, tok)
+			familyTok = v.checkFamilyOption(&options[i])
+		case "--match-port":
+			v.checkDuplicate(seen, name, tok)
+			v.checkPorts(tok)
+		case "--match-ip", "-s":
+			// --match-ip와 -s는 같은 필드의 별칭
+			v.checkDuplicate(seen, "-s", tok)
+			if !strings.EqualFold(value, "ANY") {
+				v.checkIPs(tok)
+			}
+		case "--to-dest":
+			v.checkDuplicate(seen, name, tok)
+			v.checkDestination(tok)
+		case "--to-source":
+			v.checkDuplicate(seen, name, tok)
+			v.checkIPs(tok)
+		case "-i", "-o":
+			v.checkDuplicate(seen, name, tok)
+			if value == "" {
+				v.errorf(tok.ValueColumn(), DiagEmptyValue, "%s 인터페이스 이름이 비어 있습니다", name)
+				continue
+			}
+			v.checkInterface(tok)
+		case natDescOption:
+			// 설명은 줄 끝까지 (공백 포함)
+			seen[name] = true
+			break loop
+		default:
+			v.unknownToken(tok)
+		}
+	}
+
+	v.checkFamily(familyTok)
+	if !seen["--nat-type"] {
+		v.warnf(options[0].Column, DiagMissingNATType, "--nat-type 옵션이 없어 DNAT가 적용됩니다")
+	}
+	switch natType {
+	case NATTypeDNAT:
+		if !seen["--to-dest"] {
+			v.errorf(options[0].Column, DiagMissingTarget, "DNAT 규칙에는 --to-dest가 필요합니다")
+		}
+	case NATTypeSNAT:
+		if !seen["--to-source"] {
+			v.errorf(options[0].Column, DiagMissingTarget, "SNAT 규칙에는 --to-source가 필요합니다")
+		}
+	}
+}
+
+// 포트 목록(80, 8000:8080, 80,443, @서비스객체, ${변수})을 검사합니다.
+func (v *lineValidator) checkPorts(tok Token) {
+	_, value := tok.Option()
+	column := tok.ValueColumn()
+	for _, item := range strings.Split(value, ",") {
+		if v.checkVariables(column, item) || v.checkObjectRef(column, item) {
+			column += utf8.RuneCountInString(item) + 1
+			continue
+		}
+		if err := validatePortRange(item); err != nil {
+			v.errorf(column, DiagInvalidPort, "잘못된 포트 %q: %v", item, err)
+		}
+		column += utf8.RuneCountInString(item) + 1
+	}
+}
+
+// IP 목록(10.0.0.1, 192.168.1.0/24, @주소객체, ${변수}, 쉼표 구분)을 검사합니다.
+func (v *lineValidator) checkIPs(tok Token) {
+	name, value := tok.Option()
+	if value == "" {
+		v.errorf(tok.ValueColumn(), DiagEmptyValue, "%s 값이 비어 있습니다", name)
+		return
+	}
+	column := tok.ValueColumn()
+	for _, item := range strings.Split(value, ",") {
+		if v.checkVariables(column, item) || v.checkObjectRef(column, item) {
+			column += utf8.RuneCountInString(item) + 1
+			continue
+		}
+		if !isValidIPOrCIDR(item) {
+			v.errorf(column, DiagInvalidIP, "잘못된 IP 주소: %q", item)
+		} else {
+			v.addresses = append(v.addresses, Token{Text: item, Column: column})
+		}
+		column += utf8.RuneCountInString(item) + 1
+	}
+}
+
+// 값이 객체 참조(@이름)이면 이름 형식을 검사하고 true를 반환합니다.
+// 참조한 객체의 존재 여부와 종류는 배포 시 객체를 펼칠 때 확인합니다.
+func (v *lineValidator) checkObjectRef(column int, item string) bool {
+	name, ok := ObjectRefName(item)
+	if !ok {
+		return false
+	}
+	if !v.objectRefs {
+		v.errorf(column, DiagInvalidObjectRef, "NAT 규칙에는 객체를 사용할 수 없습니다: %q", item)
+	} else if err := ValidateObjectName(name); err != nil {
+		v.errorf(column, DiagInvalidObjectRef, "%v", err)
+	}
+	return true
+}
+
+// 값에 변수 참조(${이름})가 있으면 변수 이름을 검사하고 true를 반환합니다.
+// 변수가 들어간 값의 형식은 배포 시 장비별 값으로 바꾼 뒤 검사합니다.
+func (v *lineValidator) checkVariables(column int, item string) bool {
+	start := strings.Index(item, VariablePrefix)
+	if start == -1 {
+		return false
+	}
+	for start != -1 {
+		refColumn := column + utf8.RuneCountInString(item[:start])
+		rest := item[start+len(VariablePrefix):]
+		end := strings.Index(rest, VariableSuffix)
+		if end == -1 {
+			v.errorf(refColumn, DiagInvalidVariable, "변수 참조가 닫히지 않았습니다: %q", item[start:])
+			break
+		}
+		if err := ValidateVariableName(rest[:end]); err != nil {
+			v.errorf(refColumn, DiagInvalidVariable, "%v", err)
+		}
+		next := strings.Index(rest[end:], VariablePrefix)
+		if next == -1 {
+			break
+		}
+		start += len(VariablePrefix) + end + next
+	}
+	return true
+}
+
+// 인터페이스 이름(eth0, ppp+, ${변수})을 검사합니다.
+// 이름은 배포 시 명령 인자로 전달되므로 iptables가 허용하는 문자 외에는 모두 오류입니다.
+func (v *lineValidator) checkInterface(tok Token) {
+	_, value := tok.Option()
+	if v.checkVariables(tok.ValueColumn(), value) {
+		return
+	}
+	if err := ValidateInterfaceName(value); err != nil {
+		v.errorf(tok.ValueColumn(), DiagInvalidInterface, "%v", err)
+	}
+}
+
+// --to-dest 값(IP, IP:PORT, [IPv6]:PORT 또는 ${변수})을 검사합니다.
+func (v *lineValidator) checkDestination(tok Token) {
+	_, value := tok.Option()
+	column := tok.ValueColumn()
+	if v.checkVariables(column, value) {
+		return
+	}
+	ip, port := SplitDestination(value)
+	ipColumn := column
+	if strings.HasPrefix(value, "[") {
+		ipColumn++
+	}
+	if !isValidIP(ip) {
+		v.errorf(ipColumn, DiagInvalidIP, "잘못된 IP 주소: %q", ip)
+	} else {
+		v.addresses = append(v.addresses, Token{Text: ip, Column: ipColumn})
+	}
+	if port != "" || strings.HasSuffix(value, ":") {
+		if err := validatePortRange(port); err != nil {
+			portColumn := column + utf8.RuneCountInString(value) - utf8.RuneCountInString(port)
+			v.errorf(portColumn, DiagInvalidPort, "잘못된 포트 %q: %v", port, err)
+		}
+	}
+}
+
+// 단일 포트 또는 포트 범위(시작:끝, 시작-끝)를 검사합니다.
+func validatePortRange(s string) error {
+	sep := strings.IndexAny(s, ":-")
+	if sep == -1 {
+		_, err := parsePort(s)
+		return err
+	}
+	start, err := parsePort(s[:sep])
+	if err != nil {
+		return err
+	}
+	end, err := parsePort(s[sep+1:])
+	if err != nil {
+		return err
+	}
+	if start > end {
+		return fmt.Errorf("시작 포트가 끝 포트보다 큽니다")
+	}
+	return nil
+}
+
+// 포트 번호(1-65535)를 변환합니다.
+func parsePort(s string) (int, error) {
+	port, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("숫자가 아닙니다")
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("범위(1-65535)를 벗어났습니다")
+	}
+	return port, nil
+}
+
+// IP 주소인지 확인합니다.
+func isValidIP(s string) bool {
+	return net.ParseIP(s) != nil
+}
+
+// IP 주소 또는 CIDR인지 확인합니다.
+func isValidIPOrCIDR(s string) bool {
+	if strings.Contains(s, "/") {
+		_, _, err := net.ParseCIDR(s)
+		return err == nil
+	}
+	return isValidIP(s)
+}
+
+// TCP flags 값(검사할 플래그/설정된 플래그)을 검사합니다.
+// 예: syn,rst,ack,fin/syn
+// 설정된 플래그가 비어 있으면 none과 같습니다. (예: NULL 스캔 차단 syn,rst,ack,fin,psh,urg/)
+func validateTCPFlags(s string) error {
+	mask, set, ok := strings.Cut(s, "/")
+	if !ok {
+		return fmt.Errorf("검사할플래그/설정된플래그 형식이어야 합니다")
+	}
+	parts := []string{mask}
+	if set != "" {
+		parts = append(parts, set)
+	}
+	for _, part := range parts {
+		for _, flag := range strings.Split(part, ",") {
+			if !isValidTCPFlag(flag) {
+				return fmt.Errorf("알 수 없는 플래그 %q", flag)
+			}
+		}
+	}
+	return nil
+}
+
+// TCP 플래그 이름인지 확인합니다. (all, none 포함)
+func isValidTCPFlag(flag string) bool {
+	flag = strings.ToLower(flag)
+	if flag == "all" || flag == "none" {
+		return true
+	}
+	for _, f := range GetTCPFlagsList() {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+package model
+
+import (
+	"testing"
+)
+
+// TestValidateTemplate 줄 단위 진단 테스트
+func TestValidateTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		wantCode string
+		wantCol  int
+		wantSev  string
+	}{
+		{"형식 오류", "iptables -A INPUT", DiagUnknownFormat, 1, SeverityError},
+		{"잘못된 처리 방식", "agent -m=insert;reboot -c=INPUT -a=DROP", DiagInvalidMode, 10, SeverityError},
+		{"NAT 규칙의 잘못된 처리 방식", "agent -m=list -t=nat --nat-type=masquerade -o=eth0", DiagInvalidMode, 10, SeverityError},
+		{"프로토콜 옵션 중복", "agent -m=insert -c=INPUT -p=tcp?flags=syn/syn&flags=ack/ack -a=DROP", DiagDuplicateOption, 47, SeverityError},
+		{"알 수 없는 옵션", "agent -m=insert -c=INPUT -a=DROP --mark=80", DiagUnknownOption, 34, SeverityError},
+		{"잘못된 체인", "agent -m=insert -c=INPUTT -a=DROP", DiagInvalidChain, 20, SeverityError},
+		{"잘못된 프로토콜", "agent -m=insert -c=INPUT -p=sctp -a=DROP", DiagInvalidProtocol, 29, SeverityError},
+		{"잘못된 동작", "agent -m=insert -c=INPUT -a=DENY", DiagInvalidAction, 29, SeverityError},
+		{"포트 범위 초과", "agent -m=insert -c=INPUT -a=DROP --dport=99999", DiagInvalidPort, 42, SeverityError},
+		{"포트 목록 중 오류", "agent -m=insert -c=INPUT -a=DROP --dport=80,0", DiagInvalidPort, 45, SeverityError},
+		{"잘못된 포트 범위", "agent -m=insert -c=INPUT -a=DROP --dport=90:80", DiagInvalidPort, 42, SeverityError},
+		{"잘못된 IP", "agent -m=insert -c=INPUT -a=DROP --sip=10.0.0.256", DiagInvalidIP, 40, SeverityError},
+		{"잘못된 CIDR", "agent -m=insert -c=INPUT -a=DROP --dip=10.0.0.0/33", DiagInvalidIP, 40, SeverityError},
+		{"TCP가 아닌 flags", "agent -m=insert -c=INPUT -p=udp?flags=syn/syn -a=DROP", DiagOptionProtocolMismatch, 33, SeverityError},
+		{"TCP에 ICMP type", "agent -m=insert -c=INPUT -p=tcp?type=echo-request -a=DROP", DiagOptionProtocolMismatch, 33, SeverityError},
+		{"잘못된 flags", "agent -m=insert -c=INPUT -p=tcp?flags=syn,foo/syn -a=DROP", DiagInvalidTCPFlags, 39, SeverityError},
+		{"잘못된 ICMP type", "agent -m=insert -c=INPUT -p=icmp?type=ping -a=DROP", DiagInvalidICMPType, 39, SeverityError},
+		{"type 없는 ICMP code", "agent -m=insert -c=INPUT -p=icmp?code=3 -a=DROP", DiagInvalidICMPCode, 34, SeverityError},
+		{"알 수 없는 프로토콜 옵션", "agent -m=insert -c=INPUT -p=tcp?state=new -a=DROP", DiagUnknownProtocolOption, 33, SeverityError},
+		{"ICMP에 포트", "agent -m=insert -c=INPUT -p=icmp -a=DROP --dport=22", DiagOptionProtocolMismatch, 42, SeverityError},
+		{"ICMP에 출발지 포트", "agent -m=insert -c=INPUT -p=icmp -a=DROP --sport=53", DiagOptionProtocolMismatch, 42, SeverityError},
+		{"잘못된 연결 상태", "agent -m=insert -c=INPUT -a=ACCEPT --state=ESTABLISHED,OPEN", DiagInvalidState, 44, SeverityError},
+		{"INPUT에 출력 인터페이스", "agent -m=insert -c=INPUT -a=DROP -o=eth0", DiagOptionChainMismatch, 34, SeverityError},
+		{"빈 인터페이스", "agent -m=insert -c=FORWARD -a=DROP -i=", DiagEmptyValue, 39, SeverityError},
+		{"인터페이스에 명령 치환", "agent -m=insert -c=FORWARD -a=DROP -i=$(reboot)", DiagInvalidInterface, 39, SeverityError},
+		{"인터페이스에 ;", "agent -m=insert -c=FORWARD -a=DROP -i=eth0;reboot", DiagInvalidInterface, 39, SeverityError},
+		{"인터페이스에 |", "agent -m=insert -c=FORWARD -a=DROP -o=eth0|nc", DiagInvalidInterface, 39, SeverityError},
+		{"인터페이스에 >", "agent -m=insert -c=FORWARD -a=DROP -o=eth0>/tmp/x", DiagInvalidInterface, 39, SeverityError},
+		{"긴 인터페이스 이름", "agent -m=insert -c=FORWARD -a=DROP -i=abcdefghijklmnop", DiagInvalidInterface, 39, SeverityError},
+		{"NAT 인터페이스에 ;", "agent -m=insert -t=nat --nat-type=masquerade -o=eth0;reboot", DiagInvalidInterface, 49, SeverityError},
+		{"black과 white", "agent -m=insert -c=INPUT -a=DROP --sip=1.1.1.1 --black --white", DiagConflictingFlags, 56, SeverityError},
+		{"체인 생략", "agent -m=insert -a=DROP", DiagMissingChain, 7, SeverityWarning},
+		{"동작 생략", "agent -m=insert -c=INPUT", DiagMissingAction, 7, SeverityWarning},
+		{"옵션 중복", "agent -m=insert -c=INPUT -a=DROP -a=ACCEPT", DiagDuplicateOption, 34, SeverityError},
+		{"NAT 별칭 옵션 중복", "agent -m=insert -t=nat --nat-type=masquerade --match-ip=10.0.0.0/8 -s=10.0.0.1", DiagDuplicateOption, 68, SeverityError},
+		{"불필요한 토큰", "agent -m=insert -c=INPUT -a=DROP 22", DiagUnexpectedToken, 34, SeverityError},
+		{"DNAT 대상 누락", "agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80", DiagMissingTarget, 7, SeverityError},
+		{"잘못된 NAT 타입", "agent -m=insert -t=nat --nat-type=xnat --to-dest=10.0.0.1", DiagInvalidNATType, 35, SeverityError},
+		{"잘못된 DNAT 포트", "agent -m=insert -t=nat --nat-type=dnat --to-dest=10.0.0.1:70000", DiagInvalidPort, 59, SeverityError},
+		{"NAT에 ICMP", "agent -m=insert -t=nat --nat-type=snat -p=icmp --to-source=1.2.3.4", DiagInvalidProtocol, 43, SeverityError},
+		{"잘못된 주소 체계", "agent -m=insert -c=INPUT -a=DROP --family=ipv5", DiagInvalidFamily, 43, SeverityError},
+		{"IPv4와 IPv6 주소 혼용", "agent -m=insert -c=INPUT -a=DROP --sip=10.0.0.1 --dip=2001:db8::1", DiagFamilyMismatch, 40, SeverityError},
+		{"IPv4 규칙에 IPv6 주소", "agent -m=insert -c=INPUT -a=DROP --family=ipv4 --dip=2001:db8::1", DiagFamilyMismatch, 54, SeverityError},
+		{"IPv6 규칙에 ICMP 전용 type", "agent -m=insert -c=INPUT -p=icmp?type=source-quench -a=DROP --family=ipv6", DiagInvalidICMPType, 39, SeverityError},
+		{"잘못된 IPv6 DNAT 포트", "agent -m=insert -t=nat --nat-type=dnat --to-dest=[2001:db8::10]:70000", DiagInvalidPort, 65, SeverityError},
+		{"IPv6 SNAT에 IPv4 대상", "agent -m=insert -t=nat --nat-type=snat -s=2001:db8::/64 --to-source=1.2.3.4", DiagFamilyMismatch, 69, SeverityError},
+		{"잘못된 객체 이름", "agent -m=insert -c=INPUT -a=DROP --sip=@1st,10.0.0.1", DiagInvalidObjectRef, 40, SeverityError},
+		{"NAT 규칙에 객체", "agent -m=insert -t=nat --nat-type=masquerade -s=@office -o=eth0", DiagInvalidObjectRef, 49, SeverityError},
+		{"잘못된 변수 이름", "agent -m=insert -c=INPUT -a=DROP --sip=10.0.0.1,${MGMT-NET}", DiagInvalidVariable, 49, SeverityError},
+		{"버전 없는 포함 지시문", "  #include", DiagInvalidInclude, 3, SeverityError},
+		{"닫히지 않은 변수", "agent -m=insert -t=nat --nat-type=dnat --to-dest=${WEB_HOST", DiagInvalidVariable, 50, SeverityError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics := ValidateTemplate("# 주석\n\n" + tt.line)
+			if len(diagnostics) != 1 {
+				t.Fatalf("ValidateTemplate(%q) = %v, want 진단 1개", tt.line, diagnostics)
+			}
+			d := diagnostics[0]
+			if d.Line != 3 || d.Column != tt.wantCol || d.Code != tt.wantCode || d.Severity != tt.wantSev {
+				t.Errorf("진단 = {%d:%d %s %s}, want {3:%d %s %s} (%s)",
+					d.Line, d.Column, d.Severity, d.Code, tt.wantCol, tt.wantSev, tt.wantCode, d.Message)
+			}
+		})
+	}
+}
+
+// TestValidateTemplateValid 올바른 템플릿은 진단이 없어야 함
+func TestValidateTemplateValid(t *testing.T) {
+	contents := `# 기본 규칙
+agent -m=insert -c=INPUT -p=tcp --dport=22 -a=ACCEPT
+agent -m=insert -c=INPUT -p=tcp?flags=syn,rst,ack,fin/syn -a=DROP --dport=8000:8080,443
+agent -m=insert -c=OUTPUT -p=icmp?type=destination-unreachable&code=3 -a=REJECT
+agent -m=insert -c=FORWARD -p=any -a=DROP --sip=192.168.1.0/24,10.0.0.1 --black
+agent -m=insert -c=INPUT -p=any -a=ACCEPT -i=eth1 --state=established,related
+agent -m=insert -c=FORWARD -p=udp -a=ACCEPT --sport=53 -i=eth1 -o=eth0
+agent -m=insert -c=FORWARD -p=any -a=ACCEPT -i=ppp+ -o=eth0.100
+agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=6080 --to-dest=192.168.30.180:8080
+agent -m=insert -t=nat --nat-type=masquerade -p=any -s=192.168.1.0/24 -o=eth0 --desc=외부 인터넷 연결
+agent -m=insert -c=INPUT -p=icmp?type=echo-request -a=ACCEPT --family=ipv6
+agent -m=insert -c=INPUT -p=tcp --dport=22 -a=ACCEPT --sip=2001:db8::/32,fe80::1
+agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=8080 --to-dest=[2001:db8::10]:80
+agent -m=insert -t=nat --nat-type=masquerade -p=any --family=ipv6 -o=eth0
+agent -m=insert -c=INPUT -p=any -a=ACCEPT --dport=@web,8080 --sip=@monitoring --dip=2001:db8::1
+agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=${SSH_PORT} --sip=${MGMT_NET},10.0.0.1 -i=${LAN_IF}
+agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=${WEB_HOST}:8080
+#include baseline-v3`
+
+	if diagnostics := ValidateTemplate(contents); len(diagnostics) != 0 {
+		t.Errorf("ValidateTemplate() = %v, want 진단 없음", diagnostics)
+	}
+}
+
+// TestTemplateIsValid 규칙 오류가 있으면 유효하지 않은 템플릿
+func TestTemplateIsValid(t *testing.T) {
+	if !NewTemplate("v1", "agent -m=insert -c=INPUT -a=DROP --dport=22").IsValid() {
+		t.Error("IsValid() = false, want true")
+	}
+	// 경고만 있으면 유효
+	if !NewTemplate("v1", "agent -m=insert --dport=22").IsValid() {
+		t.Error("IsValid() = false for 경고만 있는 템플릿, want true")
+	}
+	if NewTemplate("v1", "agent -m=insert -c=INPUT -a=DROP --dport=99999").IsValid() {
+		t.Error("IsValid() = true for 잘못된 포트, want false")
+	}
+}
+
+// TestIsNATLine NAT 규칙 줄 판별 테스트 (-t=nat 토큰, 설명은 제외)
+func TestIsNATLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{"agent -m=insert -t=nat --nat-type=dnat --to-dest=10.0.0.1", true},
+		{"agent\t-m=insert\t-t=nat --nat-type=masquerade -o=eth0", true},
+		{"agent -m=insert -c=INPUT -a=DROP", false},
+		{"agent -m=insert -c=INPUT -a=DROP --sip=1.1.1.1 --desc=-t=nat", false},
+		{"agent -m=insert -t=nat --nat-type=masquerade -o=eth0 --desc=-t=nat 설명", true},
+		{"# agent -m=insert -t=nat", false},
+		{"-t=nat", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsNATLine(tt.line); got != tt.want {
+			t.Errorf("IsNATLine(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+// TestValidateTemplateTCPFlagsPresets 규칙 편집기의 TCP flags 프리셋은 모두 유효
+func TestValidateTemplateTCPFlagsPresets(t *testing.T) {
+	for _, preset := range GetTCPFlagsPresets() {
+		flags := preset.ToFlagsString()
+		if flags == "" {
+			continue
+		}
+		line := "agent -m=insert -c=INPUT -p=tcp?flags=" + flags + " -a=DROP"
+		if diagnostics := ValidateTemplate(line); len(diagnostics) != 0 {
+			t.Errorf("%s 프리셋: ValidateTemplate(%q) = %v, want 진단 없음", preset.Name, line, diagnostics)
+		}
+	}
+}
+
+// TestValidateProtocol -p 값만 검사할 때도 템플릿과 같은 진단을 반환
+func TestValidateProtocol(t *testing.T) {
+	tests := []struct {
+		value  string
+		family Family
+		code   string // 빈 값이면 오류 없음
+	}{
+		{"tcp?flags=syn,rst,ack,fin,psh,urg/", FamilyIPv4, ""},
+		{"icmp?type=echo-request&code=0", FamilyIPv4, ""},
+		{"icmp?type=neighbour-solicitation", FamilyIPv6, ""},
+		{"icmp?type=neighbour-solicitation", FamilyIPv4, DiagInvalidICMPType},
+		{"sctp", FamilyIPv4, DiagInvalidProtocol},
+		{"udp?flags=syn/syn", FamilyIPv4, DiagOptionProtocolMismatch},
+		{"tcp?flags=syn/syn&flags=ack/ack", FamilyIPv4, DiagDuplicateOption},
+		{"tcp?mss=1400", FamilyIPv4, DiagUnknownProtocolOption},
+	}
+	for _, tt := range tests {
+		var code string
+		for _, d := range ValidateProtocol(tt.value, tt.family) {
+			if d.IsError() {
+				code = d.Code
+				break
+			}
+		}
+		if code != tt.code {
+			t.Errorf("ValidateProtocol(%q, %d) = %q, want %q", tt.value, tt.family, code, tt.code)
+		}
+	}
+}
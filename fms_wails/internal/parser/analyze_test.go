@@ -0,0 +1,114 @@
+package parser
+
+import (
+	"fmt"
+	"testing"
+
+	"fms_wails/internal/model"
+)
+
+// TestAnalyzeText 가려진/불필요한/충돌하는 규칙 분석 테스트
+func TestAnalyzeText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string // "종류 라인<-원인 라인"
+	}{
+		{
+			"중복 규칙",
+			"agent -m=insert -c=INPUT -p=tcp -a=DROP --dport=22\n\nagent -m=insert -c=INPUT -p=tcp -a=DROP --dport=22",
+			[]string{"redundant 3<-1"},
+		},
+		{
+			"같은 조건 다른 동작",
+			"agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=22 --sip=10.0.0.0/8\nagent -m=insert -c=INPUT -p=tcp -a=DROP --dport=22 --sip=10.0.0.0/8",
+			[]string{"conflict 2<-1"},
+		},
+		{
+			"포트 범위에 가려짐",
+			"agent -m=insert -c=INPUT -p=tcp -a=DROP --dport=1:1024\nagent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=22,80",
+			[]string{"shadowed 2<-1"},
+		},
+		{
+			"포트 목록 합집합",
+			"agent -m=insert -c=INPUT -p=tcp -a=DROP --dport=1:100,101:200\nagent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=50:150",
+			[]string{"shadowed 2<-1"},
+		},
+		{
+			"CIDR에 포함된 IP 목록",
+			"agent -m=insert -c=FORWARD -p=any -a=DROP --sip=192.168.0.0/16\nagent -m=insert -c=FORWARD -p=udp -a=ACCEPT --sip=192.168.1.5,192.168.2.0/24 --dport=53",
+			[]string{"shadowed 2<-1"},
+		},
+		{
+			"TCP flags 포함",
+			"agent -m=insert -c=INPUT -p=tcp?flags=syn/syn -a=DROP\nagent -m=insert -c=INPUT -p=tcp?flags=syn,rst,ack,fin/syn -a=DROP --dport=80",
+			[]string{"redundant 2<-1"},
+		},
+		{
+			"ICMP type",
+			"agent -m=insert -c=INPUT -p=icmp?type=echo-request -a=DROP\nagent -m=insert -c=INPUT -p=icmp?type=8&code=0 -a=ACCEPT",
+			[]string{"shadowed 2<-1"},
+		},
+		{
+			"연결 상태 포함",
+			"agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --state=ESTABLISHED,RELATED\nagent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=22 --state=ESTABLISHED",
+			[]string{"redundant 2<-1"},
+		},
+		{
+			"인터페이스와 출발지 포트",
+			"agent -m=insert -c=FORWARD -p=udp -a=DROP -i=eth0\nagent -m=insert -c=FORWARD -p=udp -a=ACCEPT -i=eth0 -o=eth1 --sport=53",
+			[]string{"shadowed 2<-1"},
+		},
+		{
+			"블랙리스트는 -a 값과 관계없이 같은 동작",
+			"agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=22 --black\nagent -m=insert -c=INPUT -p=tcp -a=DROP --dport=22 --sip=10.0.0.1 --black",
+			[]string{"redundant 2<-1"},
+		},
+		{
+			"겹치지 않는 규칙",
+			`agent -m=insert -c=INPUT -p=tcp -a=DROP --dport=22
+agent -m=insert -c=OUTPUT -p=tcp -a=DROP --dport=22
+agent -m=insert -c=INPUT -p=udp -a=DROP --dport=22
+agent -m=insert -c=INPUT -p=tcp -a=DROP --dport=22 --black
+agent -m=insert -c=INPUT -p=tcp?flags=syn/syn -a=DROP --dport=80
+agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=80
+agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=443 --sip=10.0.0.1
+agent -m=insert -c=INPUT -p=any -a=DROP --dport=443
+agent -m=insert -c=INPUT -p=tcp -a=DROP --dport=8080 --state=NEW
+agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=8080 --state=ESTABLISHED
+agent -m=insert -c=FORWARD -p=udp -a=DROP -i=eth0 --sport=53
+agent -m=insert -c=FORWARD -p=udp -a=ACCEPT -i=eth1 --sport=53
+agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=10.0.0.1`,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, f := range AnalyzeText(tt.text) {
+				got = append(got, fmt.Sprintf("%s %d<-%d", f.Kind, f.Line, f.CoverLine))
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("AnalyzeText() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAnalyzeText_Diagnostic 분석 결과를 경고 진단으로 변환
+func TestAnalyzeText_Diagnostic(t *testing.T) {
+	findings := AnalyzeText("agent -m=insert -c=INPUT -p=any -a=DROP\n# 주석\nagent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=22")
+	diagnostics := model.FindingDiagnostics(findings)
+	if len(diagnostics) != 1 {
+		t.Fatalf("FindingDiagnostics() = %v, want 1개", diagnostics)
+	}
+
+	d := diagnostics[0]
+	if d.Line != 3 || d.Severity != model.SeverityWarning || d.Code != model.DiagShadowedRule {
+		t.Errorf("Diagnostic = %+v, want 라인 3 shadowed-rule 경고", d)
+	}
+	if d.Message != "라인 1의 DROP 규칙에 가려져 적용되지 않습니다" {
+		t.Errorf("Message = %q", d.Message)
+	}
+}
@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"testing"
+
+	"fms_wails/internal/model"
+)
+
+const documentText = `# 관리 접속
+agent -m=insert -c=INPUT -p=tcp --dport=22 -a=ACCEPT
+
+# 포트 포워딩
+agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=10.0.0.1:8080
+
+# 웹 차단
+agent -m=insert  -c=INPUT -p=tcp --dport=80 -a=DROP
+# 텔넷 차단
+agent -m=insert -c=INPUT -p=tcp --dport=23 -a=DROP
+`
+
+// TestDocumentRoundTrip 변경 없는 왕복 변환은 원문과 동일해야 함
+func TestDocumentRoundTrip(t *testing.T) {
+	texts := []string{
+		"",
+		documentText,
+		"agent -m=insert -c=INPUT -a=DROP\r\n# 주석\r\n\r\nnot a rule\r\n",
+		"\n\n# 끝 주석",
+	}
+
+	for _, text := range texts {
+		doc := ParseDocument(text)
+		doc.SetRules(doc.Rules())
+		doc.SetNATRules(doc.NATRules())
+		if got := doc.String(); got != text {
+			t.Errorf("round trip = %q, want %q", got, text)
+		}
+	}
+}
+
+// TestDocumentRules 문서 순서대로 규칙 반환
+func TestDocumentRules(t *testing.T) {
+	doc := ParseDocument(documentText)
+
+	rules := doc.Rules()
+	if len(rules) != 3 || rules[0].DPort != "22" || rules[1].DPort != "80" || rules[2].DPort != "23" {
+		t.Fatalf("Rules() = %v, want 22, 80, 23", rules)
+	}
+	if natRules := doc.NATRules(); len(natRules) != 1 || natRules[0].MatchPort != "80" {
+		t.Fatalf("NATRules() = %v, want match-port 80", natRules)
+	}
+
+	// 반환된 규칙을 수정해도 문서는 바뀌지 않음
+	rules[0].DPort = "2222"
+	if got := doc.String(); got != documentText {
+		t.Errorf("String() = %q, want 원문", got)
+	}
+}
+
+// TestDocumentSetRules 빌더 편집 반영 테스트
+func TestDocumentSetRules(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func([]*model.FirewallRule) []*model.FirewallRule
+		want string
+	}{
+		{
+			name: "수정은 제자리에서 주석 유지",
+			edit: func(rules []*model.FirewallRule) []*model.FirewallRule {
+				rules[1].Action = model.ActionREJECT
+				return rules
+			},
+			want: `# 관리 접속
+agent -m=insert -c=INPUT -p=tcp --dport=22 -a=ACCEPT
+
+# 포트 포워딩
+agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=10.0.0.1:8080
+
+# 웹 차단
+agent -m=insert -c=INPUT -p=tcp -a=REJECT --dport=80
+# 텔넷 차단
+agent -m=insert -c=INPUT -p=tcp --dport=23 -a=DROP
+`,
+		},
+		{
+			name: "삭제 시 붙어 있는 주석도 삭제",
+			edit: func(rules []*model.FirewallRule) []*model.FirewallRule {
+				return []*model.FirewallRule{rules[0], rules[2]}
+			},
+			want: `# 관리 접속
+agent -m=insert -c=INPUT -p=tcp --dport=22 -a=ACCEPT
+
+# 포트 포워딩
+agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=10.0.0.1:8080
+
+# 텔넷 차단
+agent -m=insert -c=INPUT -p=tcp --dport=23 -a=DROP
+`,
+		},
+		{
+			name: "추가는 앞 규칙 바로 뒤",
+			edit: func(rules []*model.FirewallRule) []*model.FirewallRule {
+				added := model.NewFirewallRule()
+				added.DPort = "3389"
+				return []*model.FirewallRule{rules[0], added, rules[1], rules[2]}
+			},
+			want: `# 관리 접속
+agent -m=insert -c=INPUT -p=tcp --dport=22 -a=ACCEPT
+agent -m=insert -c=INPUT -p=tcp -a=DROP --dport=3389
+
+# 포트 포워딩
+agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=10.0.0.1:8080
+
+# 웹 차단
+agent -m=insert  -c=INPUT -p=tcp --dport=80 -a=DROP
+# 텔넷 차단
+agent -m=insert -c=INPUT -p=tcp --dport=23 -a=DROP
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := ParseDocument(documentText)
+			doc.SetRules(tt.edit(doc.Rules()))
+			if got := doc.String(); got != tt.want {
+				t.Errorf("String() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDocumentSetNATRules NAT 규칙이 없던 문서에 추가
+func TestDocumentSetNATRules(t *testing.T) {
+	doc := ParseDocument("# 기본\nagent -m=insert -c=INPUT -a=DROP\n")
+
+	rule := model.NewMASQUERADERule()
+	rule.OutInterface = "eth0"
+	doc.SetNATRules([]*model.NATRule{rule})
+
+	want := "# 기본\nagent -m=insert -c=INPUT -a=DROP\nagent -m=insert -t=nat --nat-type=masquerade -p=tcp -o=eth0\n"
+	if got := doc.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
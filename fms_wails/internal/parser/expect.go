@@ -0,0 +1,263 @@
+package parser
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"fms_wails/internal/model"
+)
+
+// 템플릿 주석에 작성하는 기대 결과 지시문 접두사
+// 예: "# expect: tcp 10.0.0.1 -> 10.0.0.2:22 INPUT = DROP"
+const ExpectPrefix = "expect:"
+
+// Expectation 템플릿에 포함된 패킷 기대 결과
+type Expectation struct {
+	Line    int          `json:"line"`    // 지시문의 줄 번호
+	Text    string       `json:"text"`    // 지시문 내용 ("# expect:" 이후)
+	Packet  model.Packet `json:"packet"`  // 시뮬레이션할 패킷
+	Verdict string       `json:"verdict"` // 기대하는 동작 (ACCEPT, DROP, REJECT)
+}
+
+// ExpectationResult 기대 결과 하나의 평가 결과
+type ExpectationResult struct {
+	Line        int    `json:"line"`        // 지시문의 줄 번호
+	Text        string `json:"text"`        // 지시문 내용
+	Expected    string `json:"expected"`    // 기대한 동작
+	Actual      string `json:"actual"`      // 시뮬레이션 결과 동작 (평가하지 못하면 빈 값)
+	MatchedLine int    `json:"matchedLine"` // 동작을 결정한 규칙의 줄 번호 (기본 정책이면 0)
+	Passed      bool   `json:"passed"`      // 통과 여부
+	Error       string `json:"error"`       // 지시문 파싱 또는 평가 오류
+}
+
+// String 결과를 "라인 3: tcp 10.0.0.1 -> 10.0.0.2:22 INPUT = DROP (실제 ACCEPT, 기본 정책)" 형식으로 반환
+func (r ExpectationResult) String() string {
+	switch {
+	case r.Error != "":
+		return fmt.Sprintf("라인 %d: %s (%s)", r.Line, r.Text, r.Error)
+	case r.MatchedLine == 0:
+		return fmt.Sprintf("라인 %d: %s (실제 %s, 기본 정책)", r.Line, r.Text, r.Actual)
+	default:
+		return fmt.Sprintf("라인 %d: %s (실제 %s, 라인 %d)", r.Line, r.Text, r.Actual, r.MatchedLine)
+	}
+}
+
+// IsExpectLine 기대 결과 지시문 주석인지 확인
+func IsExpectLine(line string) bool {
+	_, ok := expectBody(line)
+	return ok
+}
+
+// expectBody 지시문 주석에서 "# expect:" 이후 내용을 반환
+func expectBody(line string) (string, bool) {
+	comment, ok := strings.CutPrefix(strings.TrimSpace(line), "#")
+	if !ok {
+		return "", false
+	}
+	comment = strings.TrimSpace(comment)
+	if len(comment) < len(ExpectPrefix) || !strings.EqualFold(comment[:len(ExpectPrefix)], ExpectPrefix) {
+		return "", false
+	}
+	return strings.TrimSpace(comment[len(ExpectPrefix):]), true
+}
+
+// ParseExpectLine 기대 결과 지시문 한 줄을 파싱
+// 형식: "# expect: <프로토콜[?옵션]> <출발지 IP>[:<포트>] -> <목적지 IP>[:<포트>] [체인] [-i=<인터페이스>] [-o=<인터페이스>] [--state=<상태>] = <동작>"
+// 프로토콜 옵션은 규칙과 같은 형식이며 패킷 값으로 사용됩니다. (tcp?flags=syn, icmp?type=echo-request)
+// IPv6 주소에 포트를 붙일 때는 대괄호로 감쌉니다. (예: [2001:db8::1]:443)
+// 체인을 생략하면 INPUT으로, TCP flags를 생략하면 새 연결 요청(syn)으로 처리합니다.
+// 지시문이 아닌 라인은 nil을 반환합니다.
+func ParseExpectLine(line string) (*Expectation, error) {
+	body, ok := expectBody(line)
+	if !ok {
+		return nil, nil
+	}
+
+	match, verdict, ok := cutLast(body, "=")
+	if !ok {
+		return nil, fmt.Errorf("기대 동작이 없습니다 (예: tcp 10.0.0.1 -> 10.0.0.2:22 INPUT = DROP)")
+	}
+	action, err := model.ParseAction(strings.TrimSpace(verdict))
+	if err != nil {
+		return nil, err
+	}
+
+	fields := strings.Fields(strings.ReplaceAll(match, "→", "->"))
+	if len(fields) < 4 || fields[2] != "->" {
+		return nil, fmt.Errorf("패킷 형식이 올바르지 않습니다 (예: tcp 10.0.0.1 -> 10.0.0.2:22)")
+	}
+
+	expect := &Expectation{
+		Text:    body,
+		Verdict: model.ActionToString(action),
+		Packet:  model.Packet{Chain: model.ChainINPUT, SIP: trimBrackets(fields[1])},
+	}
+	packet := &expect.Packet
+
+	if err := checkPacketProtocol(fields[0]); err != nil {
+		return nil, err
+	}
+	protocol, opts, _ := ParseProtocolWithOptions(fields[0])
+	packet.Protocol = protocol
+	if opts != nil {
+		packet.TCPFlags = opts.TCPFlags
+		packet.ICMPType = opts.ICMPType
+		packet.ICMPCode = opts.ICMPCode
+	}
+
+	if host, port, err := net.SplitHostPort(fields[1]); err == nil && protocol != model.ProtocolICMP {
+		packet.SIP = host
+		if packet.SPort, err = strconv.Atoi(port); err != nil {
+			return nil, fmt.Errorf("잘못된 출발지 포트: %s", port)
+		}
+	}
+
+	packet.DIP = trimBrackets(fields[3])
+	if protocol != model.ProtocolICMP {
+		host, port, err := net.SplitHostPort(fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("목적지에 포트가 필요합니다: %s", fields[3])
+		}
+		packet.DIP = host
+		if packet.DPort, err = strconv.Atoi(port); err != nil {
+			return nil, fmt.Errorf("잘못된 목적지 포트: %s", port)
+		}
+	}
+
+	for _, field := range fields[4:] {
+		if iface, ok := strings.CutPrefix(field, "-i="); ok {
+			packet.InInterface = iface
+			continue
+		}
+		if iface, ok := strings.CutPrefix(field, "-o="); ok {
+			packet.OutInterface = iface
+			continue
+		}
+		if state, ok := strings.CutPrefix(field, "--state="); ok {
+			packet.State = state
+			continue
+		}
+		chain, err := model.ParseChain(field)
+		if err != nil {
+			return nil, fmt.Errorf("알 수 없는 항목: %s", field)
+		}
+		packet.Chain = chain
+	}
+
+	if err := packet.Validate(); err != nil {
+		return nil, err
+	}
+	return expect, nil
+}
+
+// checkPacketProtocol 기대 결과 패킷의 프로토콜 문자열 검사
+// 패킷의 flags는 규칙과 달리 설정된 플래그만 적으므로 프로토콜과 옵션 이름, 중복만 확인
+// (값은 주소 체계를 알 수 있는 Packet.Validate에서 검사)
+func checkPacketProtocol(s string) error {
+	base, query, hasQuery := strings.Cut(s, "?")
+	if _, err := model.ParseProtocol(base); err != nil {
+		return err
+	}
+	if !hasQuery {
+		return nil
+	}
+	seen := make(map[string]bool)
+	for _, param := range strings.Split(query, "&") {
+		key, _, ok := strings.Cut(param, "=")
+		switch {
+		case !ok:
+			return fmt.Errorf("잘못된 프로토콜 옵션: %s", param)
+		case key != "flags" && key != "type" && key != "code":
+			return fmt.Errorf("알 수 없는 프로토콜 옵션: %s", key)
+		case seen[key]:
+			return fmt.Errorf("중복된 옵션: %s", key)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// trimBrackets 포트 없이 대괄호로 감싼 IPv6 주소의 대괄호 제거
+func trimBrackets(addr string) string {
+	if inner, ok := strings.CutPrefix(addr, "["); ok {
+		return strings.TrimSuffix(inner, "]")
+	}
+	return addr
+}
+
+// cutLast 마지막 구분자를 기준으로 문자열을 나눔
+func cutLast(s, sep string) (string, string, bool) {
+	i := strings.LastIndex(s, sep)
+	if i == -1 {
+		return s, "", false
+	}
+	return s[:i], s[i+len(sep):], true
+}
+
+// ParseExpectations 템플릿 텍스트의 모든 기대 결과 지시문을 파싱
+func ParseExpectations(text string) ([]*Expectation, []error) {
+	var expects []*Expectation
+	var errors []error
+
+	for i, line := range strings.Split(text, "\n") {
+		expect, err := ParseExpectLine(line)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("라인 %d: %w", i+1, err))
+			continue
+		}
+		if expect != nil {
+			expect.Line = i + 1
+			expects = append(expects, expect)
+		}
+	}
+
+	return expects, errors
+}
+
+// RunExpectations 템플릿의 기대 결과 지시문을 템플릿 자신의 규칙으로 평가
+// 파싱할 수 없는 지시문도 실패한 결과로 포함하며, 결과는 줄 순서대로 반환합니다.
+func RunExpectations(text string) []ExpectationResult {
+	var results []ExpectationResult
+
+	for i, line := range strings.Split(text, "\n") {
+		body, ok := expectBody(line)
+		if !ok {
+			continue
+		}
+		result := ExpectationResult{Line: i + 1, Text: body}
+
+		expect, err := ParseExpectLine(line)
+		if err != nil {
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+		result.Expected = expect.Verdict
+
+		sim, err := SimulateText(text, expect.Packet)
+		if err != nil {
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+		result.Actual = sim.Verdict
+		result.MatchedLine = sim.MatchedLine
+		result.Passed = sim.Verdict == expect.Verdict
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// FailedExpectations 템플릿의 기대 결과 중 실패한 항목만 반환
+func FailedExpectations(text string) []ExpectationResult {
+	var failed []ExpectationResult
+	for _, result := range RunExpectations(text) {
+		if !result.Passed {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
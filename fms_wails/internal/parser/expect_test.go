@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"fms_wails/internal/model"
+)
+
+// TestParseExpectLine 기대 결과 지시문 파싱 테스트
+func TestParseExpectLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want model.Packet
+		verb string
+	}{
+		{
+			"# expect: tcp 10.0.0.1 -> 10.0.0.2:22 INPUT = DROP",
+			model.Packet{Chain: model.ChainINPUT, Protocol: model.ProtocolTCP, SIP: "10.0.0.1", DIP: "10.0.0.2", DPort: 22},
+			"DROP",
+		},
+		{
+			"#expect: tcp?flags=syn 10.0.0.1 → 10.0.0.2:8080 forward -i=eth0 = accept",
+			model.Packet{Chain: model.ChainFORWARD, Protocol: model.ProtocolTCP, SIP: "10.0.0.1", DIP: "10.0.0.2", DPort: 8080, TCPFlags: "syn", InInterface: "eth0"},
+			"ACCEPT",
+		},
+		{
+			"# expect: udp 8.8.8.8:53 -> 10.0.0.2:40000 OUTPUT -o=eth0 --state=ESTABLISHED = ACCEPT",
+			model.Packet{Chain: model.ChainOUTPUT, Protocol: model.ProtocolUDP, SIP: "8.8.8.8", DIP: "10.0.0.2", DPort: 40000, SPort: 53, OutInterface: "eth0", State: "ESTABLISHED"},
+			"ACCEPT",
+		},
+		{
+			"# EXPECT: icmp?type=echo-request 10.0.0.1 -> 10.0.0.2 = REJECT",
+			model.Packet{Chain: model.ChainINPUT, Protocol: model.ProtocolICMP, SIP: "10.0.0.1", DIP: "10.0.0.2", ICMPType: "echo-request"},
+			"REJECT",
+		},
+	}
+
+	for _, tt := range tests {
+		expect, err := ParseExpectLine(tt.line)
+		if err != nil || expect == nil {
+			t.Fatalf("ParseExpectLine(%q) = %v, %v", tt.line, expect, err)
+		}
+		if expect.Packet != tt.want || expect.Verdict != tt.verb {
+			t.Errorf("ParseExpectLine(%q) = %+v %s, want %+v %s", tt.line, expect.Packet, expect.Verdict, tt.want, tt.verb)
+		}
+	}
+
+	for _, line := range []string{"# 일반 주석", "agent -m=insert -c=INPUT -a=DROP"} {
+		if expect, err := ParseExpectLine(line); expect != nil || err != nil {
+			t.Errorf("ParseExpectLine(%q) = %v, %v, want nil", line, expect, err)
+		}
+	}
+
+	invalid := []string{
+		"# expect: tcp 10.0.0.1 -> 10.0.0.2:22 INPUT",
+		"# expect: tcp 10.0.0.1 -> 10.0.0.2 INPUT = DROP",
+		"# expect: tcp 10.0.0.1 10.0.0.2:22 = DROP",
+		"# expect: tcp 10.0.0.1 -> 10.0.0.2:22 INPUT = LOG",
+		"# expect: tcp 10.0.0.1 -> 10.0.0.2:22 SIDEWAYS = DROP",
+		"# expect: sctp 10.0.0.1 -> 10.0.0.2:22 = DROP",
+		"# expect: tcp?mss=1400 10.0.0.1 -> 10.0.0.2:22 = DROP",
+		"# expect: tcp?flags=syn&flags=ack 10.0.0.1 -> 10.0.0.2:22 = DROP",
+		"# expect: icmp?type=neighbour-solicitation 10.0.0.1 -> 10.0.0.2 = DROP",
+	}
+	for _, line := range invalid {
+		if _, err := ParseExpectLine(line); err == nil {
+			t.Errorf("ParseExpectLine(%q) error = nil, want error", line)
+		}
+	}
+}
+
+// TestRunExpectations 템플릿 규칙으로 기대 결과 평가 테스트
+func TestRunExpectations(t *testing.T) {
+	text := `agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=22 --sip=10.0.0.0/8
+agent -m=insert -c=INPUT -p=tcp -a=DROP --dport=22
+# expect: tcp 10.0.0.1 -> 192.168.0.1:22 INPUT = ACCEPT
+# expect: tcp 172.16.0.1 -> 192.168.0.1:22 INPUT = DROP
+# expect: tcp 172.16.0.1 -> 192.168.0.1:80 INPUT = DROP
+# expect: tcp 172.16.0.1 -> 192.168.0.1 INPUT = DROP`
+
+	results := RunExpectations(text)
+	if len(results) != 4 {
+		t.Fatalf("RunExpectations() = %d개, want 4개", len(results))
+	}
+
+	passed := []bool{true, true, false, false}
+	for i, result := range results {
+		if result.Line != i+3 || result.Passed != passed[i] {
+			t.Errorf("results[%d] = %+v, want 라인 %d passed=%t", i, result, i+3, passed[i])
+		}
+	}
+	if results[2].Actual != "ACCEPT" || results[2].String() != "라인 5: tcp 172.16.0.1 -> 192.168.0.1:80 INPUT = DROP (실제 ACCEPT, 기본 정책)" {
+		t.Errorf("results[2] = %s", results[2])
+	}
+	if results[3].Error == "" || !strings.Contains(results[3].String(), "포트") {
+		t.Errorf("results[3] = %s, want 파싱 오류", results[3])
+	}
+
+	failed := FailedExpectations(text)
+	if len(failed) != 2 || failed[0].Line != 5 || failed[1].Line != 6 {
+		t.Errorf("FailedExpectations() = %v, want 라인 5, 6", failed)
+	}
+}
+
+// TestRunExpectations_TCPFlags NULL 스캔 차단 규칙은 flags를 생략한 기대 결과에 일치하지 않음
+func TestRunExpectations_TCPFlags(t *testing.T) {
+	text := `agent -m=insert -c=INPUT -p=tcp?flags=all/none -a=DROP
+agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=80
+agent -m=insert -c=INPUT -p=tcp -a=DROP
+# expect: tcp 10.0.0.1 -> 10.0.0.2:80 = ACCEPT
+# expect: tcp?flags=none 10.0.0.1 -> 10.0.0.2:80 = DROP`
+
+	if failed := FailedExpectations(text); len(failed) != 0 {
+		t.Errorf("FailedExpectations() = %v, want 없음", failed)
+	}
+}
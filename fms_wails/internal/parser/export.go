@@ -0,0 +1,709 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fms_wails/internal/model"
+)
+
+// ExportFormat 템플릿 내보내기 형식
+type ExportFormat string
+
+const (
+	ExportIptables  ExportFormat = "iptables"  // iptables-restore 입력 파일 (IPv4 규칙)
+	ExportIp6tables ExportFormat = "ip6tables" // ip6tables-restore 입력 파일 (IPv6 규칙)
+	ExportNftables  ExportFormat = "nftables"  // nft -f 룰셋
+	ExportSmartfw   ExportFormat = "smartfw"   // /proc/smartfw 요청 라인 목록
+)
+
+// ExportText 템플릿 텍스트를 지정한 형식의 룰셋으로 변환
+func ExportText(text string, format ExportFormat) (string, error) {
+	if format == ExportSmartfw {
+		lines, err := TextToSmartfw(text, NewSmartfwID(text))
+		if err != nil {
+			return "", err
+		}
+		if len(lines) == 0 {
+			return "", fmt.Errorf("내보낼 규칙이 없습니다")
+		}
+		return strings.Join(lines, "\n") + "\n", nil
+	}
+
+	doc := ParseDocument(text)
+
+	switch format {
+	case ExportIptables:
+		return ExportIptablesRestore(doc.Rules(), doc.NATRules())
+	case ExportIp6tables:
+		return ExportIp6tablesRestore(doc.Rules(), doc.NATRules())
+	case ExportNftables:
+		return ExportNftablesRuleset(doc.Rules(), doc.NATRules())
+	default:
+		return "", fmt.Errorf("알 수 없는 내보내기 형식: %s", format)
+	}
+}
+
+// ExportIptablesRestore 규칙 목록을 iptables-restore 입력 파일로 변환
+// 규칙이 있는 테이블(filter, nat)만 출력하며, 체인 안의 규칙 순서는 템플릿 순서를 따릅니다.
+// Black/White 규칙은 일반 규칙보다 먼저 평가되도록 White → Black → 일반 규칙 순으로 배치합니다.
+// IPv6 규칙은 제외하고 주의 주석으로 개수를 남깁니다. (ExportIp6tablesRestore 사용)
+func ExportIptablesRestore(rules []*model.FirewallRule, natRules []*model.NATRule) (string, error) {
+	return exportXtables(rules, natRules, model.FamilyIPv4)
+}
+
+// ExportIp6tablesRestore 규칙 목록 중 IPv6 규칙을 ip6tables-restore 입력 파일로 변환
+// 출력 형식과 규칙 순서는 ExportIptablesRestore와 같습니다.
+func ExportIp6tablesRestore(rules []*model.FirewallRule, natRules []*model.NATRule) (string, error) {
+	return exportXtables(rules, natRules, model.FamilyIPv6)
+}
+
+// exportXtables 주소 체계에 맞는 규칙만 iptables/ip6tables-restore 입력 파일로 변환
+func exportXtables(allRules []*model.FirewallRule, allNATRules []*model.NATRule, family model.Family) (string, error) {
+	if len(allRules) == 0 && len(allNATRules) == 0 {
+		return "", fmt.Errorf("내보낼 규칙이 없습니다")
+	}
+	if err := checkFilterChains(allRules); err != nil {
+		return "", err
+	}
+
+	tool := "iptables"
+	other := "ip6tables"
+	if family == model.FamilyIPv6 {
+		tool, other = other, tool
+	}
+	rules, natRules, skipped := familyRules(allRules, allNATRules, family)
+	if len(rules) == 0 && len(natRules) == 0 {
+		return "", fmt.Errorf("%s 형식으로 내보낼 %s 규칙이 없습니다 (%s 형식을 사용하세요)", tool, familyName(family), other)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# FMS 템플릿에서 생성된 %s-restore 입력 파일\n", tool)
+	if skipped > 0 {
+		fmt.Fprintf(&b, "# 주의: %s 규칙이 아닌 규칙 %d개는 제외했습니다 (%s 형식으로 내보내세요)\n", familyName(family), skipped, other)
+	}
+
+	if len(rules) > 0 {
+		b.WriteString("*filter\n:INPUT ACCEPT [0:0]\n:FORWARD ACCEPT [0:0]\n:OUTPUT ACCEPT [0:0]\n")
+		for _, chain := range []model.Chain{model.ChainINPUT, model.ChainFORWARD, model.ChainOUTPUT} {
+			for _, rule := range orderedRules(rules) {
+				if rule.Chain != chain {
+					continue
+				}
+				lines, err := iptablesFilterRule(rule)
+				if err != nil {
+					return "", err
+				}
+				for _, line := range lines {
+					b.WriteString(line + "\n")
+				}
+			}
+		}
+		b.WriteString("COMMIT\n")
+	}
+
+	if len(natRules) > 0 {
+		b.WriteString("*nat\n:PREROUTING ACCEPT [0:0]\n:INPUT ACCEPT [0:0]\n:OUTPUT ACCEPT [0:0]\n:POSTROUTING ACCEPT [0:0]\n")
+		for _, rule := range natRules {
+			lines, err := iptablesNATRule(rule)
+			if err != nil {
+				return "", err
+			}
+			for _, line := range lines {
+				b.WriteString(line + "\n")
+			}
+		}
+		b.WriteString("COMMIT\n")
+	}
+
+	return b.String(), nil
+}
+
+// ExportNftablesRuleset 규칙 목록을 nft -f 룰셋으로 변환
+// inet fms 테이블을 다시 만들어 적용하므로 같은 파일을 반복 적용해도 규칙이 중복되지 않습니다.
+func ExportNftablesRuleset(rules []*model.FirewallRule, natRules []*model.NATRule) (string, error) {
+	if len(rules) == 0 && len(natRules) == 0 {
+		return "", fmt.Errorf("내보낼 규칙이 없습니다")
+	}
+	if err := checkFilterChains(rules); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("#!/usr/sbin/nft -f\n")
+	b.WriteString("# FMS 템플릿에서 생성된 nftables 룰셋\n\n")
+	b.WriteString("table inet fms\ndelete table inet fms\n\n")
+	b.WriteString("table inet fms {\n")
+
+	// 체인 블록 출력 (규칙이 있는 체인만)
+	first := true
+	writeChain := func(name, hook string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		if !first {
+			b.WriteString("\n")
+		}
+		first = false
+		fmt.Fprintf(&b, "\tchain %s {\n\t\t%s; policy accept;\n", name, hook)
+		for _, line := range lines {
+			b.WriteString("\t\t" + line + "\n")
+		}
+		b.WriteString("\t}\n")
+	}
+
+	filterChains := []struct {
+		chain model.Chain
+		name  string
+	}{
+		{model.ChainINPUT, "input"},
+		{model.ChainFORWARD, "forward"},
+		{model.ChainOUTPUT, "output"},
+	}
+	for _, c := range filterChains {
+		var lines []string
+		for _, rule := range orderedRules(rules) {
+			if rule.Chain == c.chain {
+				line, err := nftFilterRule(rule)
+				if err != nil {
+					return "", err
+				}
+				lines = append(lines, line)
+			}
+		}
+		writeChain(c.name, fmt.Sprintf("type filter hook %s priority filter", c.name), lines)
+	}
+
+	var prerouting, postrouting []string
+	for _, rule := range natRules {
+		line, err := nftNATRule(rule)
+		if err != nil {
+			return "", err
+		}
+		if rule.NATType == model.NATTypeDNAT {
+			prerouting = append(prerouting, line)
+		} else {
+			postrouting = append(postrouting, line)
+		}
+	}
+	writeChain("prerouting", "type nat hook prerouting priority dstnat", prerouting)
+	writeChain("postrouting", "type nat hook postrouting priority srcnat", postrouting)
+
+	b.WriteString("}\n")
+
+	return b.String(), nil
+}
+
+// familyRules 주소 체계가 같은 필터/NAT 규칙과 제외한 규칙 수 반환
+func familyRules(rules []*model.FirewallRule, natRules []*model.NATRule, family model.Family) ([]*model.FirewallRule, []*model.NATRule, int) {
+	var filtered []*model.FirewallRule
+	var filteredNAT []*model.NATRule
+	skipped := 0
+	for _, rule := range rules {
+		if rule.Family == family {
+			filtered = append(filtered, rule)
+		} else {
+			skipped++
+		}
+	}
+	for _, rule := range natRules {
+		if rule.Family == family {
+			filteredNAT = append(filteredNAT, rule)
+		} else {
+			skipped++
+		}
+	}
+	return filtered, filteredNAT, skipped
+}
+
+// familyName 주석과 에러 메시지에 사용할 주소 체계 이름
+func familyName(family model.Family) string {
+	if family == model.FamilyIPv6 {
+		return "IPv6"
+	}
+	return "IPv4"
+}
+
+// checkFilterChains 필터 규칙이 filter 테이블 체인(INPUT/OUTPUT/FORWARD)만 사용하는지 확인
+func checkFilterChains(rules []*model.FirewallRule) error {
+	for i, rule := range rules {
+		switch rule.Chain {
+		case model.ChainINPUT, model.ChainOUTPUT, model.ChainFORWARD:
+		default:
+			return fmt.Errorf("필터 규칙 %d: %s 체인은 filter 테이블에서 사용할 수 없습니다", i+1, model.ChainToString(rule.Chain))
+		}
+	}
+	return nil
+}
+
+// orderedRules White → Black → 일반 규칙 순으로 정렬한 목록 반환 (같은 종류 안에서는 원래 순서 유지)
+func orderedRules(rules []*model.FirewallRule) []*model.FirewallRule {
+	var white, black, normal []*model.FirewallRule
+	for _, rule := range rules {
+		switch {
+		case rule.White:
+			white = append(white, rule)
+		case rule.Black:
+			black = append(black, rule)
+		default:
+			normal = append(normal, rule)
+		}
+	}
+	return append(append(white, black...), normal...)
+}
+
+// exportAction 규칙의 동작 (Black/White 규칙은 플래그가 동작을 결정)
+func exportAction(rule *model.FirewallRule) model.Action {
+	switch {
+	case rule.White:
+		return model.ActionACCEPT
+	case rule.Black:
+		return model.ActionDROP
+	default:
+		return rule.Action
+	}
+}
+
+// exportProtocols 규칙에 출력할 프로토콜 목록
+// 프로토콜이 any인데 포트 조건이 있으면 tcp와 udp로 나누어 출력합니다.
+func exportProtocols(protocol model.Protocol, hasPort bool) []string {
+	if protocol == model.ProtocolANY {
+		if hasPort {
+			return []string{"tcp", "udp"}
+		}
+		return []string{""}
+	}
+	return []string{model.ProtocolToString(protocol)}
+}
+
+// splitList 콤마 리스트를 분리 (공백 제거, 빈 값과 any 제외)
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" && !strings.EqualFold(item, "any") {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// splitPorts 포트 리스트를 분리하고 범위 구분자를 sep로 통일 ("1000-2000", "1000:2000")
+func splitPorts(s, sep string) []string {
+	ports := splitList(s)
+	for i, port := range ports {
+		ports[i] = strings.NewReplacer("-", sep, ":", sep).Replace(port)
+	}
+	return ports
+}
+
+// icmpTypeCode ICMP type/code를 숫자 문자열로 변환 (IPv6 규칙은 ICMPv6 값)
+func icmpTypeCode(family model.Family, opts *model.ProtocolOptions) (string, string, error) {
+	if opts == nil || opts.ICMPType == "" {
+		if opts != nil && opts.ICMPCode != "" {
+			return "", "", fmt.Errorf("ICMP 코드는 ICMP 타입과 함께 지정해야 합니다")
+		}
+		return "", "", nil
+	}
+
+	typeNum, err := model.ICMPTypeNumber(family, opts.ICMPType)
+	if err != nil {
+		return "", "", err
+	}
+	if opts.ICMPCode == "" {
+		return strconv.Itoa(typeNum), "", nil
+	}
+	codeNum, err := model.ICMPCodeNumber(family, opts.ICMPCode)
+	if err != nil {
+		return "", "", err
+	}
+	return strconv.Itoa(typeNum), strconv.Itoa(codeNum), nil
+}
+
+// tcpFlagsMaskSet TCP flags 문자열("syn,ack/syn")을 검사 플래그와 설정 플래그 목록으로 분리
+// all은 모든 플래그, none 또는 빈 값은 빈 목록으로 변환
+func tcpFlagsMaskSet(flags string) ([]string, []string) {
+	expand := func(s string) []string {
+		var result []string
+		for _, flag := range strings.Split(strings.ToLower(s), ",") {
+			switch flag {
+			case "", "none":
+			case "all":
+				result = append(result, "fin", "syn", "rst", "psh", "ack", "urg")
+			default:
+				result = append(result, flag)
+			}
+		}
+		return result
+	}
+	mask, set, _ := strings.Cut(flags, "/")
+	return expand(mask), expand(set)
+}
+
+// quoteValue 공백이나 따옴표가 포함될 수 있는 값을 큰따옴표로 감쌈
+func quoteValue(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// ===== iptables =====
+
+// iptablesFilterRule 필터 규칙을 iptables-restore 라인으로 변환
+// IPv6 규칙의 ICMP는 ip6tables의 ipv6-icmp 프로토콜과 icmp6 모듈로 출력합니다.
+func iptablesFilterRule(rule *model.FirewallRule) ([]string, error) {
+	icmpType, icmpCode, err := icmpTypeCode(rule.Family, rule.Options)
+	if err != nil {
+		return nil, err
+	}
+	ports := splitPorts(rule.DPort, ":")
+	sports := splitPorts(rule.SPort, ":")
+
+	var lines []string
+	for _, protocol := range exportProtocols(rule.Protocol, len(ports) > 0 || len(sports) > 0) {
+		parts := []string{"-A", model.ChainToString(rule.Chain)}
+		if sip := splitList(rule.SIP); len(sip) > 0 {
+			parts = append(parts, "-s", strings.Join(sip, ","))
+		}
+		if dip := splitList(rule.DIP); len(dip) > 0 {
+			parts = append(parts, "-d", strings.Join(dip, ","))
+		}
+		if rule.InInterface != "" {
+			parts = append(parts, "-i", rule.InInterface)
+		}
+		if rule.OutInterface != "" {
+			parts = append(parts, "-o", rule.OutInterface)
+		}
+
+		switch {
+		case protocol == "icmp" && rule.Family == model.FamilyIPv6:
+			parts = append(parts, "-p", "ipv6-icmp")
+		case protocol != "":
+			parts = append(parts, "-p", protocol)
+		}
+		switch protocol {
+		case "tcp", "udp":
+			parts = append(parts, iptablesPortMatch(protocol, ports, sports, rule.Options)...)
+		case "icmp":
+			if icmpType != "" {
+				value := icmpType
+				if icmpCode != "" {
+					value += "/" + icmpCode
+				}
+				if rule.Family == model.FamilyIPv6 {
+					parts = append(parts, "-m", "icmp6", "--icmpv6-type", value)
+				} else {
+					parts = append(parts, "-m", "icmp", "--icmp-type", value)
+				}
+			}
+		}
+		if rule.State != "" {
+			parts = append(parts, "-m", "conntrack", "--ctstate", rule.State)
+		}
+
+		parts = append(parts, "-j", model.ActionToString(exportAction(rule)))
+		lines = append(lines, strings.Join(parts, " "))
+	}
+
+	return lines, nil
+}
+
+// iptablesPortMatch tcp/udp 목적지/출발지 포트와 TCP flags 매칭 옵션
+// 포트가 여러 개면 multiport 모듈, 하나면 프로토콜 모듈을 사용
+func iptablesPortMatch(protocol string, ports, sports []string, opts *model.ProtocolOptions) []string {
+	var parts []string
+	protoMatch := false
+	useProtoMatch := func() {
+		if !protoMatch {
+			parts = append(parts, "-m", protocol)
+			protoMatch = true
+		}
+	}
+
+	switch {
+	case len(ports) == 1:
+		useProtoMatch()
+		parts = append(parts, "--dport", ports[0])
+	case len(ports) > 1:
+		parts = append(parts, "-m", "multiport", "--dports", strings.Join(ports, ","))
+	}
+
+	switch {
+	case len(sports) == 1:
+		useProtoMatch()
+		parts = append(parts, "--sport", sports[0])
+	case len(sports) > 1:
+		parts = append(parts, "-m", "multiport", "--sports", strings.Join(sports, ","))
+	}
+
+	if protocol == "tcp" && opts.HasTCPOptions() {
+		mask, set := tcpFlagsMaskSet(opts.TCPFlags)
+		useProtoMatch()
+		parts = append(parts, "--tcp-flags", iptablesFlags(mask), iptablesFlags(set))
+	}
+
+	return parts
+}
+
+// iptablesFlags TCP 플래그 목록을 iptables 형식으로 변환 (빈 목록은 NONE)
+func iptablesFlags(flags []string) string {
+	if len(flags) == 0 {
+		return "NONE"
+	}
+	return strings.ToUpper(strings.Join(flags, ","))
+}
+
+// iptablesNATRule NAT 규칙을 iptables-restore 라인으로 변환
+// 체인에서 사용할 수 없는 인터페이스 조건은 제외하고 주의 주석으로 남깁니다.
+func iptablesNATRule(rule *model.NATRule) ([]string, error) {
+	chain, target, err := natTarget(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	// 체인에서 사용할 수 없는 인터페이스 조건
+	var notes []string
+	inIface, outIface := rule.InInterface, rule.OutInterface
+	if chain == "POSTROUTING" && inIface != "" {
+		notes = append(notes, fmt.Sprintf("# 주의: %s 체인에서는 입력 인터페이스(-i %s) 조건을 사용할 수 없어 제외했습니다", chain, inIface))
+		inIface = ""
+	}
+	if chain == "PREROUTING" && outIface != "" {
+		notes = append(notes, fmt.Sprintf("# 주의: %s 체인에서는 출력 인터페이스(-o %s) 조건을 사용할 수 없어 제외했습니다", chain, outIface))
+		outIface = ""
+	}
+
+	ports := splitPorts(rule.MatchPort, ":")
+	hasPort := len(ports) > 0 || rule.TranslatePort != ""
+	if rule.NATType != model.NATTypeDNAT {
+		// SNAT/MASQUERADE의 매칭 포트는 템플릿에서 사용하지 않음
+		ports = nil
+		hasPort = false
+	}
+
+	var lines []string
+	for _, protocol := range exportProtocols(rule.Protocol, hasPort) {
+		parts := []string{"-A", chain}
+		if matchIP := splitList(rule.MatchIP); len(matchIP) > 0 {
+			parts = append(parts, "-s", strings.Join(matchIP, ","))
+		}
+
+		if inIface != "" {
+			parts = append(parts, "-i", inIface)
+		}
+		if outIface != "" {
+			parts = append(parts, "-o", outIface)
+		}
+
+		if protocol != "" {
+			parts = append(parts, "-p", protocol)
+			switch {
+			case len(ports) == 1:
+				parts = append(parts, "-m", protocol, "--dport", ports[0])
+			case len(ports) > 1:
+				parts = append(parts, "-m", "multiport", "--dports", strings.Join(ports, ","))
+			}
+		}
+
+		if rule.Description != "" {
+			parts = append(parts, "-m", "comment", "--comment", quoteValue(rule.Description))
+		}
+
+		parts = append(parts, "-j", target)
+		switch rule.NATType {
+		case model.NATTypeDNAT:
+			parts = append(parts, "--to-destination", natDestination(rule, ":"))
+		case model.NATTypeSNAT:
+			parts = append(parts, "--to-source", rule.TranslateIP)
+		}
+		lines = append(lines, strings.Join(parts, " "))
+	}
+
+	return append(notes, lines...), nil
+}
+
+// natTarget NAT 타입별 체인과 타겟 (변환 대상 IP 필수 여부 검사)
+func natTarget(rule *model.NATRule) (string, string, error) {
+	switch rule.NATType {
+	case model.NATTypeDNAT:
+		if rule.TranslateIP == "" {
+			return "", "", fmt.Errorf("DNAT 규칙에 변환할 IP(--to-dest)가 없습니다")
+		}
+		return "PREROUTING", "DNAT", nil
+	case model.NATTypeSNAT:
+		if rule.TranslateIP == "" {
+			return "", "", fmt.Errorf("SNAT 규칙에 변환할 IP(--to-source)가 없습니다")
+		}
+		return "POSTROUTING", "SNAT", nil
+	case model.NATTypeMASQUERADE:
+		return "POSTROUTING", "MASQUERADE", nil
+	default:
+		return "", "", fmt.Errorf("알 수 없는 NAT 타입: %d", rule.NATType)
+	}
+}
+
+// natDestination DNAT 변환 대상 ("IP", "IP:PORT" 또는 "[IPv6]:PORT", 포트 범위 구분자는 sep)
+func natDestination(rule *model.NATRule, sep string) string {
+	if rule.TranslatePort == "" {
+		return rule.TranslateIP
+	}
+	return model.JoinDestination(rule.TranslateIP, strings.NewReplacer("-", sep, ":", sep).Replace(rule.TranslatePort))
+}
+
+// ===== nftables =====
+
+// nftFilterRule 필터 규칙을 nft 규칙으로 변환
+// IPv6 규칙은 ip6 주소와 icmpv6 매칭을 사용하며, 주소와 ICMPv6 조건이 없으면 meta nfproto ipv6로 제한합니다.
+func nftFilterRule(rule *model.FirewallRule) (string, error) {
+	icmpType, icmpCode, err := icmpTypeCode(rule.Family, rule.Options)
+	if err != nil {
+		return "", err
+	}
+
+	var parts []string
+	if rule.InInterface != "" {
+		parts = append(parts, "iifname", quoteValue(rule.InInterface))
+	}
+	if rule.OutInterface != "" {
+		parts = append(parts, "oifname", quoteValue(rule.OutInterface))
+	}
+	sip, dip := splitList(rule.SIP), splitList(rule.DIP)
+	if rule.Family == model.FamilyIPv6 && len(sip) == 0 && len(dip) == 0 && rule.Protocol != model.ProtocolICMP {
+		parts = append(parts, "meta nfproto ipv6")
+	}
+	if len(sip) > 0 {
+		parts = append(parts, nftAddr(rule.Family)+" saddr", nftSet(sip))
+	}
+	if len(dip) > 0 {
+		parts = append(parts, nftAddr(rule.Family)+" daddr", nftSet(dip))
+	}
+
+	ports := splitPorts(rule.DPort, "-")
+	sports := splitPorts(rule.SPort, "-")
+	switch rule.Protocol {
+	case model.ProtocolTCP, model.ProtocolUDP:
+		protocol := model.ProtocolToString(rule.Protocol)
+		hasFlags := rule.Protocol == model.ProtocolTCP && rule.Options.HasTCPOptions()
+		if len(sports) > 0 {
+			parts = append(parts, protocol+" sport", nftSet(sports))
+		}
+		switch {
+		case len(ports) > 0:
+			parts = append(parts, protocol+" dport", nftSet(ports))
+		case !hasFlags && len(sports) == 0:
+			parts = append(parts, "meta l4proto", protocol)
+		}
+		if hasFlags {
+			mask, set := tcpFlagsMaskSet(rule.Options.TCPFlags)
+			parts = append(parts, fmt.Sprintf("tcp flags & (%s) == %s", strings.Join(mask, "|"), nftFlags(set)))
+		}
+	case model.ProtocolICMP:
+		icmp := "icmp"
+		l4proto := "icmp"
+		if rule.Family == model.FamilyIPv6 {
+			icmp, l4proto = "icmpv6", "ipv6-icmp"
+		}
+		if icmpType != "" {
+			parts = append(parts, icmp+" type", icmpType)
+			if icmpCode != "" {
+				parts = append(parts, icmp+" code", icmpCode)
+			}
+		} else {
+			parts = append(parts, "meta l4proto "+l4proto)
+		}
+	case model.ProtocolANY:
+		if len(ports) > 0 || len(sports) > 0 {
+			parts = append(parts, "meta l4proto { tcp, udp }")
+		}
+		if len(sports) > 0 {
+			parts = append(parts, "th sport", nftSet(sports))
+		}
+		if len(ports) > 0 {
+			parts = append(parts, "th dport", nftSet(ports))
+		}
+	}
+	if rule.State != "" {
+		parts = append(parts, "ct state", nftSet(splitList(strings.ToLower(rule.State))))
+	}
+
+	parts = append(parts, strings.ToLower(model.ActionToString(exportAction(rule))))
+	return strings.Join(parts, " "), nil
+}
+
+// nftNATRule NAT 규칙을 nft 규칙으로 변환
+func nftNATRule(rule *model.NATRule) (string, error) {
+	if _, _, err := natTarget(rule); err != nil {
+		return "", err
+	}
+
+	var parts []string
+	if rule.InInterface != "" {
+		parts = append(parts, "iifname", quoteValue(rule.InInterface))
+	}
+	if rule.OutInterface != "" {
+		parts = append(parts, "oifname", quoteValue(rule.OutInterface))
+	}
+	matchIP := splitList(rule.MatchIP)
+	if rule.NATType == model.NATTypeMASQUERADE && rule.Family == model.FamilyIPv6 && len(matchIP) == 0 {
+		parts = append(parts, "meta nfproto ipv6")
+	}
+	if len(matchIP) > 0 {
+		parts = append(parts, nftAddr(rule.Family)+" saddr", nftSet(matchIP))
+	}
+
+	switch rule.NATType {
+	case model.NATTypeDNAT:
+		ports := splitPorts(rule.MatchPort, "-")
+		switch {
+		case rule.Protocol == model.ProtocolANY && (len(ports) > 0 || rule.TranslatePort != ""):
+			parts = append(parts, "meta l4proto { tcp, udp }")
+			if len(ports) > 0 {
+				parts = append(parts, "th dport", nftSet(ports))
+			}
+		case rule.Protocol != model.ProtocolANY && len(ports) > 0:
+			parts = append(parts, model.ProtocolToString(rule.Protocol)+" dport", nftSet(ports))
+		case rule.Protocol != model.ProtocolANY:
+			parts = append(parts, "meta l4proto", model.ProtocolToString(rule.Protocol))
+		}
+		parts = append(parts, "dnat "+nftAddr(rule.Family)+" to", natDestination(rule, "-"))
+	case model.NATTypeSNAT:
+		if rule.Protocol != model.ProtocolANY {
+			parts = append(parts, "meta l4proto", model.ProtocolToString(rule.Protocol))
+		}
+		parts = append(parts, "snat "+nftAddr(rule.Family)+" to", rule.TranslateIP)
+	case model.NATTypeMASQUERADE:
+		if rule.Protocol != model.ProtocolANY {
+			parts = append(parts, "meta l4proto", model.ProtocolToString(rule.Protocol))
+		}
+		parts = append(parts, "masquerade")
+	}
+
+	if rule.Description != "" {
+		parts = append(parts, "comment", quoteValue(rule.Description))
+	}
+
+	return strings.Join(parts, " "), nil
+}
+
+// nftAddr 주소 체계별 nft 주소 표현식 (ip, ip6)
+func nftAddr(family model.Family) string {
+	if family == model.FamilyIPv6 {
+		return "ip6"
+	}
+	return "ip"
+}
+
+// nftSet 값이 여러 개면 nft 익명 집합으로 묶음
+func nftSet(items []string) string {
+	if len(items) == 1 {
+		return items[0]
+	}
+	return "{ " + strings.Join(items, ", ") + " }"
+}
+
+// nftFlags TCP 플래그 목록을 nft 형식으로 변환 (빈 목록은 0x0)
+func nftFlags(flags []string) string {
+	if len(flags) == 0 {
+		return "0x0"
+	}
+	return strings.Join(flags, "|")
+}
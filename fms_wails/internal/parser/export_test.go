@@ -0,0 +1,114 @@
+package parser
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"fms_wails/internal/model"
+)
+
+var updateGolden = flag.Bool("update", false, "골든 파일 갱신")
+
+// TestExportText 템플릿 내보내기 결과를 골든 파일과 비교
+func TestExportText(t *testing.T) {
+	template, err := os.ReadFile(filepath.Join("testdata", "export_template.txt"))
+	if err != nil {
+		t.Fatalf("템플릿 읽기 실패: %v", err)
+	}
+
+	tests := []struct {
+		format ExportFormat
+		golden string
+	}{
+		{ExportIptables, "export.iptables"},
+		{ExportIp6tables, "export.ip6tables"},
+		{ExportNftables, "export.nft"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			got, err := ExportText(string(template), tt.format)
+			if err != nil {
+				t.Fatalf("ExportText() error = %v", err)
+			}
+
+			path := filepath.Join("testdata", tt.golden)
+			if *updateGolden {
+				if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+					t.Fatalf("골든 파일 저장 실패: %v", err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("골든 파일 읽기 실패: %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("ExportText() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+// TestExportText_Errors 내보낼 수 없는 템플릿
+func TestExportText_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		format  ExportFormat
+		wantErr string
+	}{
+		{"empty", "# 주석만 있음", ExportIptables, "내보낼 규칙이 없습니다"},
+		{"unknown format", "agent -m=insert -c=INPUT -a=DROP", ExportFormat("pf"), "알 수 없는 내보내기 형식"},
+		{"nat chain in filter", "agent -m=insert -c=PREROUTING -a=DROP", ExportNftables, "PREROUTING 체인은 filter 테이블에서 사용할 수 없습니다"},
+		{"dnat without target", "agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80", ExportIptables, "변환할 IP"},
+		{"ipv6 only", "agent -m=insert -c=INPUT -a=DROP --sip=2001:db8::1", ExportIptables, "내보낼 IPv4 규칙이 없습니다"},
+		{"ipv4 only", "agent -m=insert -c=INPUT -a=DROP --sip=10.0.0.1", ExportIp6tables, "내보낼 IPv6 규칙이 없습니다"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ExportText(tt.text, tt.format)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ExportText() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestExportIptablesRestore_ImportRoundTrip 내보낸 iptables 규칙을 다시 가져오면 같은 규칙
+func TestExportIptablesRestore_ImportRoundTrip(t *testing.T) {
+	rule := model.NewFirewallRule()
+	rule.DPort = "22"
+	rule.SIP = "10.0.0.5"
+	rule.Options = &model.ProtocolOptions{TCPFlags: "syn,ack/syn"}
+
+	matchRule := model.NewFirewallRule()
+	matchRule.Chain = model.ChainFORWARD
+	matchRule.Protocol = model.ProtocolUDP
+	matchRule.Action = model.ActionACCEPT
+	matchRule.SPort = "53,5353"
+	matchRule.InInterface = "eth1"
+	matchRule.OutInterface = "eth0"
+	matchRule.State = "ESTABLISHED,RELATED"
+
+	rules := []*model.FirewallRule{rule, matchRule}
+	exported, err := ExportIptablesRestore(rules, nil)
+	if err != nil {
+		t.Fatalf("ExportIptablesRestore() error = %v", err)
+	}
+	imported, err := ImportIptablesSave(exported)
+	if err != nil {
+		t.Fatalf("ImportIptablesSave() error = %v", err)
+	}
+	if len(imported.Rules) != len(rules) {
+		t.Fatalf("round trip = %v, want %d개", imported.Rules, len(rules))
+	}
+	for i, want := range rules {
+		if got := RuleToLine(imported.Rules[i]); got != RuleToLine(want) {
+			t.Errorf("round trip[%d] = %s, want %s", i, got, RuleToLine(want))
+		}
+	}
+}
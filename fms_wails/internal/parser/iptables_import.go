@@ -0,0 +1,452 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"fms_wails/internal/model"
+)
+
+// SkippedRule 가져오기에서 템플릿 규칙으로 표현할 수 없어 제외된 라인
+type SkippedRule struct {
+	Line   int    `json:"line"`   // 가져온 텍스트의 라인 번호 (1부터)
+	Text   string `json:"text"`   // 원문
+	Reason string `json:"reason"` // 제외 사유
+}
+
+// String 제외 사유를 한 줄로 반환
+func (s SkippedRule) String() string {
+	return fmt.Sprintf("라인 %d: %s (%s)", s.Line, s.Reason, s.Text)
+}
+
+// ImportResult 룰셋 가져오기 결과 (iptables-save, smartfw)
+type ImportResult struct {
+	Text     string                `json:"text"`     // 변환된 템플릿 텍스트 (필터 규칙 → NAT 규칙 순)
+	Rules    []*model.FirewallRule `json:"rules"`    // 변환된 필터 규칙
+	NATRules []*model.NATRule      `json:"natRules"` // 변환된 NAT 규칙
+	Skipped  []SkippedRule         `json:"skipped"`  // 변환하지 못한 라인
+}
+
+// ImportIptablesSave iptables-save 출력을 템플릿 규칙으로 변환
+// filter 테이블의 INPUT/OUTPUT/FORWARD 규칙과 nat 테이블의 DNAT/SNAT/MASQUERADE 규칙만 변환하며,
+// 표현할 수 없는 규칙과 기본 정책은 Skipped에 사유와 함께 기록합니다.
+// ip6tables-save 출력(머리 주석 "# Generated by ip6tables-save")은 모든 규칙을 IPv6 규칙으로 변환합니다.
+// 규칙의 -m comment 값은 필터 규칙에는 주석 라인으로, NAT 규칙에는 설명(--desc)으로 보존합니다.
+func ImportIptablesSave(text string) (*ImportResult, error) {
+	result := &ImportResult{
+		Rules:    []*model.FirewallRule{},
+		NATRules: []*model.NATRule{},
+		Skipped:  []SkippedRule{},
+	}
+	var filterLines, natLines []*DocumentLine
+
+	table := ""
+	hasTable := false
+	family := model.FamilyIPv4
+	for i, raw := range strings.Split(text, "\n") {
+		line := strings.TrimSpace(raw)
+		skip := func(format string, args ...interface{}) {
+			result.Skipped = append(result.Skipped, SkippedRule{Line: i + 1, Text: line, Reason: fmt.Sprintf(format, args...)})
+		}
+
+		switch {
+		case strings.HasPrefix(line, "# Generated by ip6tables-save"):
+			family = model.FamilyIPv6
+			continue
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "*"):
+			table = strings.TrimPrefix(line, "*")
+			hasTable = true
+			continue
+		case line == "COMMIT":
+			table = ""
+			continue
+		case strings.HasPrefix(line, ":"):
+			// 체인 선언 ":INPUT DROP [0:0]" - 내장 체인의 ACCEPT 이외 정책만 보고
+			fields := strings.Fields(strings.TrimPrefix(line, ":"))
+			if (table == "filter" || table == "nat") && len(fields) >= 2 && fields[1] != "-" && fields[1] != "ACCEPT" {
+				skip("기본 정책은 템플릿으로 표현할 수 없습니다: %s %s", fields[0], fields[1])
+			}
+			continue
+		}
+
+		if table == "" {
+			skip("테이블 선언(*filter, *nat) 밖의 라인입니다")
+			continue
+		}
+		if table != "filter" && table != "nat" {
+			skip("지원하지 않는 테이블: %s", table)
+			continue
+		}
+
+		rule, err := parseIptablesRule(line)
+		if err != nil {
+			skip("%v", err)
+			continue
+		}
+		rule.family = family
+
+		if table == "filter" {
+			filterRule, err := rule.toFirewallRule()
+			if err != nil {
+				skip("%v", err)
+				continue
+			}
+			docLine := &DocumentLine{Kind: LineRule, Text: RuleToLine(filterRule), Rule: filterRule}
+			if rule.comment != "" {
+				docLine.Comments = []string{"# " + rule.comment}
+			}
+			result.Rules = append(result.Rules, filterRule)
+			filterLines = append(filterLines, docLine)
+		} else {
+			natRule, err := rule.toNATRule()
+			if err != nil {
+				skip("%v", err)
+				continue
+			}
+			result.NATRules = append(result.NATRules, natRule)
+			natLines = append(natLines, &DocumentLine{Kind: LineNATRule, Text: NATRuleToLine(natRule), NATRule: natRule})
+		}
+	}
+
+	if !hasTable {
+		return nil, fmt.Errorf("iptables-save 형식이 아닙니다: 테이블 선언(*filter, *nat)이 없습니다")
+	}
+
+	doc := &Document{Lines: append(filterLines, natLines...)}
+	result.Text = doc.String()
+
+	return result, nil
+}
+
+// iptablesRule iptables-save의 -A 라인에서 읽은 매칭 조건과 타겟
+type iptablesRule struct {
+	family     model.Family // ip6tables-save 출력이면 IPv6
+	chain      string
+	protocol   string
+	source     string
+	dest       string
+	inIface    string
+	outIface   string
+	dport      string
+	sport      string
+	state      string
+	tcpFlags   string
+	icmpType   string
+	comment    string
+	target     string
+	targetArgs map[string]string // --to-destination, --to-source, --to-ports, --reject-with
+}
+
+// iptablesOptions 가져오기에서 해석하는 iptables 옵션 (모두 값을 가짐)
+var iptablesOptions = map[string]bool{
+	"-p":                  true,
+	"--protocol":          true,
+	"-s":                  true,
+	"--source":            true,
+	"-d":                  true,
+	"--destination":       true,
+	"-i":                  true,
+	"--in-interface":      true,
+	"-o":                  true,
+	"--out-interface":     true,
+	"-m":                  true,
+	"--match":             true,
+	"--dport":             true,
+	"--destination-port":  true,
+	"--dports":            true,
+	"--destination-ports": true,
+	"--sport":             true,
+	"--source-port":       true,
+	"--sports":            true,
+	"--source-ports":      true,
+	"--state":             true,
+	"--ctstate":           true,
+	"--tcp-flags":         true,
+	"--icmp-type":         true,
+	"--icmpv6-type":       true,
+	"--comment":           true,
+	"-j":                  true,
+	"--jump":              true,
+	"--to-destination":    true,
+	"--to-source":         true,
+	"--to-ports":          true,
+	"--reject-with":       true,
+}
+
+// parseIptablesRule "-A CHAIN ..." 라인을 파싱
+// 지원하지 않는 옵션, 모듈, 부정 조건(!)은 에러로 반환
+func parseIptablesRule(line string) (*iptablesRule, error) {
+	tokens, err := splitIptablesLine(line)
+	if err != nil {
+		return nil, err
+	}
+	// --counters 출력의 "[패킷:바이트]" 접두사 제거
+	if len(tokens) > 0 && strings.HasPrefix(tokens[0], "[") {
+		tokens = tokens[1:]
+	}
+	if len(tokens) < 2 || tokens[0] != "-A" {
+		return nil, fmt.Errorf("규칙(-A) 라인이 아닙니다")
+	}
+
+	rule := &iptablesRule{chain: tokens[1], targetArgs: make(map[string]string)}
+	for i := 2; i < len(tokens); i++ {
+		opt := tokens[i]
+		if opt == "!" {
+			return nil, fmt.Errorf("부정 조건(!)은 지원하지 않습니다")
+		}
+		if !iptablesOptions[opt] {
+			return nil, fmt.Errorf("지원하지 않는 옵션: %s", opt)
+		}
+
+		// 옵션 값 (다음 토큰)
+		value := func() (string, error) {
+			if i+1 >= len(tokens) {
+				return "", fmt.Errorf("옵션 값이 없습니다: %s", opt)
+			}
+			i++
+			if tokens[i] == "!" {
+				return "", fmt.Errorf("부정 조건(!)은 지원하지 않습니다")
+			}
+			return tokens[i], nil
+		}
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+
+		switch opt {
+		case "-p", "--protocol":
+			rule.protocol = strings.ToLower(v)
+		case "-s", "--source":
+			rule.source = trimHostMask(v)
+		case "-d", "--destination":
+			rule.dest = trimHostMask(v)
+		case "-i", "--in-interface":
+			rule.inIface = v
+		case "-o", "--out-interface":
+			rule.outIface = v
+		case "-m", "--match":
+			switch v {
+			case "tcp", "udp", "icmp", "icmp6", "multiport", "comment", "state", "conntrack":
+			default:
+				return nil, fmt.Errorf("지원하지 않는 모듈: -m %s", v)
+			}
+		case "--dport", "--destination-port", "--dports", "--destination-ports":
+			rule.dport = v
+		case "--sport", "--source-port", "--sports", "--source-ports":
+			rule.sport = v
+		case "--state", "--ctstate":
+			state, err := model.ParseConnState(v)
+			if err != nil {
+				return nil, err
+			}
+			rule.state = state
+		case "--tcp-flags":
+			set, err := value()
+			if err != nil {
+				return nil, err
+			}
+			rule.tcpFlags = strings.ToLower(v) + "/" + strings.ToLower(set)
+		case "--icmp-type", "--icmpv6-type":
+			rule.icmpType = v
+		case "--comment":
+			rule.comment = v
+		case "-j", "--jump":
+			rule.target = v
+		case "--to-destination", "--to-source", "--to-ports", "--reject-with":
+			rule.targetArgs[opt] = v
+		}
+	}
+
+	return rule, nil
+}
+
+// trimHostMask 단일 호스트 마스크(/32, /128) 제거
+func trimHostMask(addr string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(addr, "/32"), "/128")
+}
+
+// ruleFamily 규칙의 주소 체계 (ip6tables-save 출력, ICMPv6 프로토콜 또는 IPv6 주소이면 IPv6)
+func (r *iptablesRule) ruleFamily(addrs ...string) model.Family {
+	if r.family == model.FamilyIPv6 || r.protocol == "ipv6-icmp" || r.protocol == "icmpv6" {
+		return model.FamilyIPv6
+	}
+	return model.InferFamily(addrs...)
+}
+
+// toFirewallRule filter 테이블 규칙을 FirewallRule로 변환
+func (r *iptablesRule) toFirewallRule() (*model.FirewallRule, error) {
+	if r.chain != "INPUT" && r.chain != "OUTPUT" && r.chain != "FORWARD" {
+		return nil, fmt.Errorf("사용자 정의 체인은 지원하지 않습니다: %s", r.chain)
+	}
+
+	rule := model.NewFirewallRule()
+	rule.Chain, _ = model.ParseChain(r.chain)
+	rule.Family = r.ruleFamily(r.source, r.dest)
+
+	protocol, err := r.parseProtocol(true)
+	if err != nil {
+		return nil, err
+	}
+	rule.Protocol = protocol
+
+	switch r.target {
+	case "":
+		return nil, fmt.Errorf("타겟(-j)이 없는 규칙은 지원하지 않습니다")
+	case "ACCEPT", "DROP", "REJECT":
+		rule.Action, _ = model.ParseAction(r.target)
+	default:
+		return nil, fmt.Errorf("지원하지 않는 타겟: %s", r.target)
+	}
+	for opt, v := range r.targetArgs {
+		// REJECT 기본 응답 외에는 표현할 수 없음
+		if opt != "--reject-with" || (v != "icmp-port-unreachable" && v != "icmp6-port-unreachable") {
+			return nil, fmt.Errorf("지원하지 않는 타겟 옵션: %s %s", opt, v)
+		}
+	}
+
+	options := &model.ProtocolOptions{TCPFlags: r.tcpFlags}
+	if r.icmpType != "" && r.icmpType != "any" {
+		icmpType, icmpCode, _ := strings.Cut(r.icmpType, "/")
+		typeNum, err := model.ICMPTypeNumber(rule.Family, icmpType)
+		if err != nil {
+			return nil, err
+		}
+		options.ICMPType = model.ICMPTypeName(rule.Family, typeNum)
+		if icmpCode != "" {
+			codeNum, err := model.ICMPCodeNumber(rule.Family, icmpCode)
+			if err != nil {
+				return nil, err
+			}
+			options.ICMPCode = model.ICMPCodeName(rule.Family, codeNum)
+		}
+	}
+	if !options.IsEmpty() {
+		rule.Options = options
+	}
+
+	rule.DPort = r.dport
+	rule.SPort = r.sport
+	rule.SIP = r.source
+	rule.DIP = r.dest
+	rule.InInterface = r.inIface
+	rule.OutInterface = r.outIface
+	rule.State = r.state
+
+	return rule, nil
+}
+
+// toNATRule nat 테이블 규칙을 NATRule로 변환
+// PREROUTING -j DNAT, POSTROUTING -j SNAT/MASQUERADE만 지원
+func (r *iptablesRule) toNATRule() (*model.NATRule, error) {
+	if r.sport != "" || r.state != "" {
+		return nil, fmt.Errorf("NAT 규칙의 출발지 포트(--sport)와 연결 상태(--state) 조건은 지원하지 않습니다")
+	}
+
+	rule := model.NewNATRule()
+
+	protocol, err := r.parseProtocol(false)
+	if err != nil {
+		return nil, err
+	}
+	rule.Protocol = protocol
+	if r.source != "" {
+		rule.MatchIP = r.source
+	}
+	rule.Description = r.comment
+
+	switch {
+	case r.chain == "PREROUTING" && r.target == "DNAT":
+		if r.dest != "" || r.inIface != "" || r.outIface != "" {
+			return nil, fmt.Errorf("DNAT 규칙의 목적지 주소(-d)와 인터페이스(-i/-o) 조건은 지원하지 않습니다")
+		}
+		ip, port := model.SplitDestination(r.targetArgs["--to-destination"])
+		if ip == "" || strings.Contains(ip, "-") {
+			return nil, fmt.Errorf("지원하지 않는 DNAT 대상: %s", r.targetArgs["--to-destination"])
+		}
+		rule.NATType = model.NATTypeDNAT
+		rule.MatchPort = r.dport
+		rule.TranslateIP = ip
+		rule.TranslatePort = port
+
+	case r.chain == "POSTROUTING" && (r.target == "SNAT" || r.target == "MASQUERADE"):
+		if r.dest != "" || r.dport != "" {
+			return nil, fmt.Errorf("%s 규칙의 목적지 주소(-d)와 포트 조건은 지원하지 않습니다", r.target)
+		}
+		if r.target == "SNAT" {
+			ip, port := model.SplitDestination(r.targetArgs["--to-source"])
+			if ip == "" || port != "" || strings.Contains(ip, "-") {
+				return nil, fmt.Errorf("지원하지 않는 SNAT 대상: %s", r.targetArgs["--to-source"])
+			}
+			rule.NATType = model.NATTypeSNAT
+			rule.TranslateIP = ip
+		} else {
+			if ports := r.targetArgs["--to-ports"]; ports != "" {
+				return nil, fmt.Errorf("지원하지 않는 타겟 옵션: --to-ports %s", ports)
+			}
+			rule.NATType = model.NATTypeMASQUERADE
+		}
+		rule.InInterface = r.inIface
+		rule.OutInterface = r.outIface
+
+	default:
+		return nil, fmt.Errorf("지원하지 않는 NAT 규칙: %s 체인의 %s 타겟", r.chain, r.target)
+	}
+	rule.Family = r.ruleFamily(r.source, rule.TranslateIP)
+
+	return rule, nil
+}
+
+// parseProtocol iptables 프로토콜 이름을 Protocol로 변환 ("all" 또는 생략은 any)
+func (r *iptablesRule) parseProtocol(allowICMP bool) (model.Protocol, error) {
+	switch r.protocol {
+	case "", "all":
+		return model.ProtocolANY, nil
+	case "tcp", "udp":
+		return model.ParseProtocol(r.protocol)
+	case "icmp", "ipv6-icmp", "icmpv6":
+		if allowICMP {
+			return model.ProtocolICMP, nil
+		}
+	}
+	return model.ProtocolANY, fmt.Errorf("지원하지 않는 프로토콜: %s", r.protocol)
+}
+
+// splitIptablesLine iptables-save 라인을 토큰으로 분리
+// 큰따옴표로 감싼 값(--comment "웹 서버")은 하나의 토큰으로 처리
+func splitIptablesLine(line string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	inQuote, hasToken := false, false
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && inQuote && i+1 < len(line):
+			i++
+			current.WriteByte(line[i])
+		case c == '"':
+			inQuote = !inQuote
+			hasToken = true
+		case (c == ' ' || c == '\t') && !inQuote:
+			if hasToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				hasToken = false
+			}
+		default:
+			current.WriteByte(c)
+			hasToken = true
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("닫히지 않은 따옴표가 있습니다")
+	}
+	if hasToken {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,164 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"fms_wails/internal/model"
+)
+
+const iptablesSaveOutput = `# Generated by iptables-save v1.8.7 on Mon Jan  1 00:00:00 2024
+*nat
+:PREROUTING ACCEPT [0:0]
+:INPUT ACCEPT [0:0]
+:OUTPUT ACCEPT [0:0]
+:POSTROUTING ACCEPT [0:0]
+-A PREROUTING -i eth0 -p tcp -m tcp --dport 80 -j DNAT --to-destination 10.0.0.1:8080
+-A PREROUTING -p tcp -m tcp --dport 443 -m comment --comment "웹 서버" -j DNAT --to-destination 10.0.0.2:8443
+-A POSTROUTING -s 192.168.0.0/24 -o eth0 -j MASQUERADE
+-A POSTROUTING -s 10.0.0.0/8 -j SNAT --to-source 1.1.1.1
+-A OUTPUT -d 127.0.0.1/32 -j DNAT --to-destination 10.0.0.3
+COMMIT
+*mangle
+-A PREROUTING -j MARK --set-mark 1
+COMMIT
+*filter
+:INPUT DROP [0:0]
+:FORWARD ACCEPT [0:0]
+:OUTPUT ACCEPT [0:0]
+:LOGGING - [0:0]
+-A INPUT -s 10.0.0.5/32 -p tcp -m tcp --dport 22 -m comment --comment "관리 접속" -j ACCEPT
+-A INPUT -p tcp -m multiport --dports 80,443 -j DROP
+-A INPUT -p tcp -m tcp --tcp-flags FIN,SYN,RST,ACK SYN -j DROP
+-A INPUT -p icmp -m icmp --icmp-type 3/3 -j REJECT --reject-with icmp-port-unreachable
+-A INPUT -m state --state RELATED,ESTABLISHED -j ACCEPT
+-A INPUT ! -s 10.0.0.0/8 -j DROP
+-A INPUT -i lo -j ACCEPT
+-A INPUT -p tcp --dport 25 -j REJECT --reject-with tcp-reset
+-A INPUT -j LOGGING
+-A LOGGING -j DROP
+-A FORWARD -d 192.168.1.10 -p udp -m udp --dport 53 -j ACCEPT
+COMMIT
+`
+
+// TestImportIptablesSave 변환 가능한 규칙과 제외된 규칙 확인
+func TestImportIptablesSave(t *testing.T) {
+	result, err := ImportIptablesSave(iptablesSaveOutput)
+	if err != nil {
+		t.Fatalf("ImportIptablesSave() error = %v", err)
+	}
+
+	wantText := `# 관리 접속
+agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=22 --sip=10.0.0.5
+agent -m=insert -c=INPUT -p=tcp -a=DROP --dport=80,443
+agent -m=insert -c=INPUT -p=tcp?flags=fin,syn,rst,ack/syn -a=DROP
+agent -m=insert -c=INPUT -p=icmp?type=destination-unreachable&code=port-unreachable -a=REJECT
+agent -m=insert -c=INPUT -p=any -a=ACCEPT --state=RELATED,ESTABLISHED
+agent -m=insert -c=INPUT -p=any -a=ACCEPT -i=lo
+agent -m=insert -c=FORWARD -p=udp -a=ACCEPT --dport=53 --dip=192.168.1.10
+agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=443 --to-dest=10.0.0.2:8443 --desc=웹 서버
+agent -m=insert -t=nat --nat-type=masquerade -p=any -s=192.168.0.0/24 -o=eth0
+agent -m=insert -t=nat --nat-type=snat -p=any -s=10.0.0.0/8 --to-source=1.1.1.1`
+	if result.Text != wantText {
+		t.Errorf("Text =\n%s\nwant\n%s", result.Text, wantText)
+	}
+	if len(result.Rules) != 7 || len(result.NATRules) != 3 {
+		t.Errorf("규칙 수 = %d/%d, want 7/3", len(result.Rules), len(result.NATRules))
+	}
+
+	// 변환된 텍스트는 엄격 모드로 저장 가능해야 함
+	if errs := CheckText(result.Text, ModeStrict); len(errs) != 0 {
+		t.Errorf("CheckText(strict) = %v", errs)
+	}
+	if diags := model.ValidateTemplate(result.Text); model.HasErrors(diags) {
+		t.Errorf("ValidateTemplate() = %v", diags)
+	}
+
+	wantSkipped := []struct {
+		line   int
+		reason string
+	}{
+		{7, "DNAT 규칙의 목적지 주소(-d)와 인터페이스(-i/-o) 조건은 지원하지 않습니다"},
+		{11, "지원하지 않는 NAT 규칙: OUTPUT 체인의 DNAT 타겟"},
+		{14, "지원하지 않는 테이블: mangle"},
+		{17, "기본 정책은 템플릿으로 표현할 수 없습니다: INPUT DROP"},
+		{26, "부정 조건(!)은 지원하지 않습니다"},
+		{28, "지원하지 않는 타겟 옵션: --reject-with tcp-reset"},
+		{29, "지원하지 않는 타겟: LOGGING"},
+		{30, "사용자 정의 체인은 지원하지 않습니다: LOGGING"},
+	}
+	if len(result.Skipped) != len(wantSkipped) {
+		t.Fatalf("Skipped = %v, want %d개", result.Skipped, len(wantSkipped))
+	}
+	for i, want := range wantSkipped {
+		got := result.Skipped[i]
+		if got.Line != want.line || got.Reason != want.reason {
+			t.Errorf("Skipped[%d] = %d %q, want %d %q", i, got.Line, got.Reason, want.line, want.reason)
+		}
+	}
+}
+
+// TestImportIptablesSave_IPv6 ip6tables-save 출력은 IPv6 규칙으로 변환
+func TestImportIptablesSave_IPv6(t *testing.T) {
+	text := `# Generated by ip6tables-save v1.8.7 on Mon Jan  1 00:00:00 2024
+*filter
+:INPUT ACCEPT [0:0]
+:FORWARD ACCEPT [0:0]
+:OUTPUT ACCEPT [0:0]
+-A INPUT -s 2001:db8::5/128 -p tcp -m tcp --dport 22 -j ACCEPT
+-A INPUT -p ipv6-icmp -m icmp6 --icmpv6-type 135 -j ACCEPT
+-A INPUT -p ipv6-icmp -m icmp6 --icmpv6-type 1/4 -j REJECT --reject-with icmp6-port-unreachable
+-A FORWARD -j DROP
+COMMIT
+*nat
+:PREROUTING ACCEPT [0:0]
+:POSTROUTING ACCEPT [0:0]
+-A PREROUTING -p tcp -m tcp --dport 8080 -j DNAT --to-destination [2001:db8::10]:80
+-A POSTROUTING -o eth0 -j MASQUERADE
+COMMIT
+`
+	result, err := ImportIptablesSave(text)
+	if err != nil {
+		t.Fatalf("ImportIptablesSave() error = %v", err)
+	}
+
+	wantText := `agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=22 --sip=2001:db8::5
+agent -m=insert -c=INPUT -p=icmp?type=neighbour-solicitation -a=ACCEPT --family=ipv6
+agent -m=insert -c=INPUT -p=icmp?type=destination-unreachable&code=port-unreachable -a=REJECT --family=ipv6
+agent -m=insert -c=FORWARD -p=any -a=DROP --family=ipv6
+agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=8080 --to-dest=[2001:db8::10]:80
+agent -m=insert -t=nat --nat-type=masquerade -p=any --family=ipv6 -s=ANY -o=eth0`
+	if result.Text != wantText {
+		t.Errorf("Text =\n%s\nwant\n%s", result.Text, wantText)
+	}
+	if len(result.Skipped) != 0 {
+		t.Errorf("Skipped = %v, want 없음", result.Skipped)
+	}
+	if diags := model.ValidateTemplate(result.Text); model.HasErrors(diags) {
+		t.Errorf("ValidateTemplate() = %v", diags)
+	}
+}
+
+// TestImportIptablesSave_NotIptablesSave 테이블 선언이 없으면 에러
+func TestImportIptablesSave_NotIptablesSave(t *testing.T) {
+	_, err := ImportIptablesSave("agent -m=insert -c=INPUT -a=DROP")
+	if err == nil || !strings.Contains(err.Error(), "iptables-save 형식이 아닙니다") {
+		t.Errorf("ImportIptablesSave() error = %v, want 형식 오류", err)
+	}
+}
+
+// TestSplitIptablesLine 따옴표로 감싼 값 토큰 분리
+func TestSplitIptablesLine(t *testing.T) {
+	tokens, err := splitIptablesLine(`-A INPUT -m comment --comment "say \"hi\" now" -j ACCEPT`)
+	if err != nil {
+		t.Fatalf("splitIptablesLine() error = %v", err)
+	}
+	want := []string{"-A", "INPUT", "-m", "comment", "--comment", `say "hi" now`, "-j", "ACCEPT"}
+	if strings.Join(tokens, "|") != strings.Join(want, "|") {
+		t.Errorf("splitIptablesLine() = %q, want %q", tokens, want)
+	}
+
+	if _, err := splitIptablesLine(`--comment "open`); err == nil {
+		t.Error("splitIptablesLine() error = nil, want 따옴표 오류")
+	}
+}
@@ -0,0 +1,199 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"fms_wails/internal/model"
+)
+
+// TestParseLineWithMode 엄격/관대한 모드 파싱 테스트
+func TestParseLineWithMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string // 엄격 모드 에러 메시지 (빈 값이면 성공)
+	}{
+		{"valid rule", "agent -m=insert -c=INPUT -p=tcp?flags=syn/syn -a=DROP --dport=22 --black", ""},
+		{"filter table", "agent -m=insert -t=filter -c=OUTPUT -a=ACCEPT", ""},
+		{"typo option", "agent -m=insert -c=INPUT -a=DROP --dprot=22", "알 수 없는 옵션: --dprot=22"},
+		{"duplicate option", "agent -m=insert -c=INPUT -a=DROP -a=ACCEPT", "-a 옵션이 중복되었습니다"},
+		{"invalid chain", "agent -m=insert -c=INPT -a=DROP", `알 수 없는 체인: "INPT"`},
+		{"invalid protocol", "agent -m=insert -c=INPUT -p=tcpp -a=DROP", `알 수 없는 프로토콜: "tcpp"`},
+		{"invalid action", "agent -m=insert -c=INPUT -a=DENY", `알 수 없는 동작: "DENY"`},
+		{"invalid icmp type", "agent -m=insert -c=INPUT -p=icmp?type=ping -a=DROP", `알 수 없는 ICMP type: "ping"`},
+		{"unknown protocol option", "agent -m=insert -c=INPUT -p=tcp?state=new -a=DROP", `알 수 없는 프로토콜 옵션: "state=new"`},
+		{"flag with value", "agent -m=insert -c=INPUT -a=DROP --black=1", "알 수 없는 옵션: --black=1"},
+		{"trailing garbage", "agent -m=insert -c=INPUT -a=DROP 22", "불필요한 토큰: 22"},
+		{"wildcard interface", "agent -m=insert -c=FORWARD -a=ACCEPT -i=ppp+ -o=eth0.100", ""},
+		{"variable interface", "agent -m=insert -c=FORWARD -a=ACCEPT -i=${LAN_IF}", ""},
+		{"interface command substitution", "agent -m=insert -c=FORWARD -a=DROP -i=$(reboot)", "잘못된 인터페이스 이름"},
+		{"interface semicolon", "agent -m=insert -c=FORWARD -a=DROP -i=eth0;reboot", "잘못된 인터페이스 이름"},
+		{"interface pipe", "agent -m=insert -c=FORWARD -a=DROP -o=eth0|nc", "잘못된 인터페이스 이름"},
+		{"interface redirect", "agent -m=insert -c=FORWARD -a=DROP -o=eth0>/tmp/x", "잘못된 인터페이스 이름"},
+		{"interface too long", "agent -m=insert -c=FORWARD -a=DROP -i=abcdefghijklmnop", "잘못된 인터페이스 이름"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 관대한 모드는 항상 성공
+			if _, err := ParseLineWithMode(tt.input, ModeLenient); err != nil {
+				t.Errorf("ParseLineWithMode(lenient) error = %v", err)
+			}
+
+			rule, err := ParseLineWithMode(tt.input, ModeStrict)
+			if tt.wantErr == "" {
+				if err != nil || rule == nil {
+					t.Errorf("ParseLineWithMode(strict) = %v, %v, want rule", rule, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseLineWithMode(strict) error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestParseLineWithMode_LenientDefaults 관대한 모드는 잘못된 값을 기본값으로 변환
+func TestParseLineWithMode_LenientDefaults(t *testing.T) {
+	rule, err := ParseLineWithMode("agent -m=insert -c=INPT -p=tcpp -a=DENY --dprot=22", ModeLenient)
+	if err != nil {
+		t.Fatalf("ParseLineWithMode() error = %v", err)
+	}
+	if rule.Chain != model.ChainINPUT || rule.Protocol != model.ProtocolTCP || rule.Action != model.ActionDROP || rule.DPort != "" {
+		t.Errorf("ParseLineWithMode() = %+v, want INPUT/TCP/DROP 기본값", rule)
+	}
+}
+
+// TestParseNATLineWithMode NAT 규칙 엄격 모드 파싱 테스트
+func TestParseNATLineWithMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"valid dnat", "agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=10.0.0.1:8080", ""},
+		{"invalid nat type", "agent -m=insert -t=nat --nat-type=xnat --to-dest=10.0.0.1", `알 수 없는 NAT 타입: "xnat"`},
+		{"alias duplicate", "agent -m=insert -t=nat --nat-type=snat --match-ip=10.0.0.0/8 -s=10.0.0.1 --to-source=1.1.1.1", "-s 옵션이 중복되었습니다"},
+		{"unknown option", "agent -m=insert -t=nat --nat-type=masquerade -o=eth0 --todest=1.1.1.1", "알 수 없는 옵션: --todest=1.1.1.1"},
+		{"interface semicolon", "agent -m=insert -t=nat --nat-type=masquerade -o=eth0;reboot", "잘못된 인터페이스 이름"},
+		{"interface command substitution", "agent -m=insert -t=nat --nat-type=snat -i=$(reboot) --to-source=1.1.1.1", "잘못된 인터페이스 이름"},
+		{"description with spaces", "agent -m=insert -t=nat --nat-type=masquerade -o=eth0 --desc=외부 인터넷 -p=x", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseNATLineWithMode(tt.input, ModeLenient); err != nil {
+				t.Errorf("ParseNATLineWithMode(lenient) error = %v", err)
+			}
+
+			_, err := ParseNATLineWithMode(tt.input, ModeStrict)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ParseNATLineWithMode(strict) error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseNATLineWithMode(strict) error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestStrictModeValues 엄격 모드는 저장 시 검증과 같은 규칙으로 옵션 값을 검사
+func TestStrictModeValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"mode", "agent -m=insert;reboot -c=INPUT -a=DROP", "알 수 없는 처리 방식"},
+		{"mode list", "agent -m=list -c=INPUT -a=DROP", "알 수 없는 처리 방식"},
+		{"dport", "agent -m=insert -c=INPUT -a=DROP --dport=22`reboot`", "잘못된 포트"},
+		{"sport", "agent -m=insert -c=INPUT -p=udp -a=DROP --sport=53;reboot", "잘못된 포트"},
+		{"sip", "agent -m=insert -c=INPUT -a=DROP --sip=1.2.3.4|reboot", "잘못된 IP 주소"},
+		{"dip", "agent -m=insert -c=INPUT -a=DROP --dip=10.0.0.1>/tmp/x", "잘못된 IP 주소"},
+		{"protocol option", "agent -m=insert -c=INPUT -p=tcp?flags=syn/syn&flags=ack/ack -a=DROP", "flags 옵션이 중복되었습니다"},
+		{"nat mode", "agent -m=delete -t=nat --nat-type=masquerade -o=eth0", "알 수 없는 처리 방식"},
+		{"nat match-port", "agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80$(reboot) --to-dest=10.0.0.1", "잘못된 포트"},
+		{"nat match-ip", "agent -m=insert -t=nat --nat-type=snat --match-ip=10.0.0.0/8;reboot --to-source=1.1.1.1", "잘못된 IP 주소"},
+		{"nat source", "agent -m=insert -t=nat --nat-type=masquerade -s=10.0.0.0/8|nc -o=eth0", "잘못된 IP 주소"},
+		{"nat to-dest", "agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=10.0.0.1:8080|nc", "잘못된 포트"},
+		{"nat to-source", "agent -m=insert -t=nat --nat-type=snat -s=10.0.0.0/8 --to-source=1.1.1.1;reboot", "잘못된 IP 주소"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if errs := CheckText(tt.input, ModeLenient); len(errs) != 0 {
+				t.Errorf("CheckText(lenient) = %v, want 오류 없음", errs)
+			}
+			errs := CheckText(tt.input, ModeStrict)
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tt.wantErr) {
+				t.Errorf("CheckText(strict) = %v, want %q", errs, tt.wantErr)
+			}
+			if !model.HasErrors(model.ValidateTemplate(tt.input)) {
+				t.Errorf("ValidateTemplate(%q) 오류 없음, want 오류", tt.input)
+			}
+		})
+	}
+}
+
+// TestCheckText 템플릿 전체 엄격 모드 검사
+func TestCheckText(t *testing.T) {
+	text := `# 규칙
+agent -m=insert -c=INPUT -p=tcp --dport=22 -a=ACCEPT
+agent -m=insert -c=INPUT --dprot=80 -a=DROP
+
+agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=10.0.0.1:8080 extra --desc=web server`
+
+	if errs := CheckText(text, ModeLenient); len(errs) != 0 {
+		t.Errorf("CheckText(lenient) = %v, want 오류 없음", errs)
+	}
+
+	errs := CheckText(text, ModeStrict)
+	if len(errs) != 2 {
+		t.Fatalf("CheckText(strict) = %v, want 오류 2개", errs)
+	}
+	if !strings.HasPrefix(errs[0].Error(), "라인 3:") || !strings.HasPrefix(errs[1].Error(), "라인 5:") {
+		t.Errorf("CheckText(strict) = %v, want 라인 3, 5", errs)
+	}
+}
+
+// TestStrictModeMatchesValidator 엄격 모드 파싱과 템플릿 검증은 같은 줄을 오류로 판단
+func TestStrictModeMatchesValidator(t *testing.T) {
+	lines := []string{
+		"agent -m=insert -c=INPUT -p=tcp --dport=22 -a=ACCEPT",
+		"agent\t-m=insert\t-c=INPUT -a=DROP",
+		"agent -m=insert --dport=22",
+		"agent -m=insert -c=INPUT -a=DROP -a=ACCEPT",
+		"agent -m=insert -c=INPUT -a=DROP --dport=",
+		"agent -m=insert -c=INPUT -a=DROP 22",
+		"agent -m=insert -c=INPUT -a=DROP --desc=설명",
+		"agent -m=insert -c=INPUT -p=tcp?flags=syn/syn&flags=ack/ack -a=DROP",
+		"agent -m=insert -c=FORWARD -p=any -a=DROP --sip=10.0.0.1 --dip=2001:db8::1",
+		"agent -m=insert -c=INPUT -p=icmp?type=packet-too-big -a=DROP",
+		"agent -m=insert -c=INPUT -a=DROP -i=eth0;reboot",
+		"agent -m=insert -t=nat --nat-type=masquerade -o=eth0 --desc=외부 인터넷 -p=x",
+		"agent -m=insert -t=nat --nat-type=snat --match-ip=10.0.0.0/8 -s=10.0.0.1 --to-source=1.1.1.1",
+		"agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80",
+		"agent -m=insert -t=nat --nat-type=dnat --to-dest=10.0.0.1 extra --desc=web server",
+		"agent -m=insert -t=filter -c=INPUT -a=DROP",
+		"agent -m=insert -t=nat -t=filter --nat-type=masquerade",
+		"agent",
+		"iptables -A INPUT -j DROP",
+		"  #include",
+		"#include baseline-v3",
+		"# 주석",
+		"",
+	}
+
+	for _, line := range lines {
+		strictErr := len(CheckText(line, ModeStrict)) > 0
+		validatorErr := model.HasErrors(model.ValidateTemplate(line))
+		if strictErr != validatorErr {
+			t.Errorf("%q: CheckText(strict) 오류 %v, ValidateTemplate 오류 %v, want 같음", line, strictErr, validatorErr)
+		}
+	}
+}
@@ -341,3 +341,60 @@ func TestIsNATLine(t *testing.T) {
 		}
 	}
 }
+
+// TestNATRoundTrip_DescriptionWithSpaces 공백이 포함된 설명 왕복 변환 테스트
+func TestNATRoundTrip_DescriptionWithSpaces(t *testing.T) {
+	original := model.NewDNATRule()
+	original.MatchPort = "80"
+	original.TranslateIP = "10.0.0.1"
+	original.Description = "웹 서버 포워딩"
+
+	parsed, err := ParseNATLineWithMode(NATRuleToLine(original), ModeStrict)
+	if err != nil {
+		t.Fatalf("ParseNATLineWithMode() error = %v", err)
+	}
+	if parsed.Description != original.Description || parsed.TranslateIP != original.TranslateIP {
+		t.Errorf("ParseNATLineWithMode() = %+v, want %+v", parsed, original)
+	}
+}
+
+// TestParseNATLine_IPv6 대괄호 IPv6 변환 대상 파싱과 왕복 변환 테스트
+func TestParseNATLine_IPv6(t *testing.T) {
+	line := "agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=8080 --to-dest=[2001:db8::10]:80"
+
+	rule, err := ParseNATLineWithMode(line, ModeStrict)
+	if err != nil {
+		t.Fatalf("ParseNATLineWithMode() error = %v", err)
+	}
+	if rule.TranslateIP != "2001:db8::10" {
+		t.Errorf("TranslateIP = %s, want 2001:db8::10", rule.TranslateIP)
+	}
+	if rule.TranslatePort != "80" {
+		t.Errorf("TranslatePort = %s, want 80", rule.TranslatePort)
+	}
+	if rule.Family != model.FamilyIPv6 {
+		t.Errorf("Family = %d, want %d", rule.Family, model.FamilyIPv6)
+	}
+	if got := NATRuleToLine(rule); got != line {
+		t.Errorf("NATRuleToLine() = %s, want %s", got, line)
+	}
+
+	// 포트 없는 IPv6 변환 대상
+	rule, _ = ParseNATLine("agent -m=insert -t=nat --nat-type=dnat -p=any --to-dest=2001:db8::10")
+	if rule.TranslateIP != "2001:db8::10" || rule.TranslatePort != "" {
+		t.Errorf("TranslateIP, TranslatePort = %s, %s, want 2001:db8::10, \"\"", rule.TranslateIP, rule.TranslatePort)
+	}
+
+	// 주소가 없는 IPv6 MASQUERADE는 --family로 구분
+	masq := "agent -m=insert -t=nat --nat-type=masquerade -p=any --family=ipv6 -s=ANY -o=eth0"
+	rule, err = ParseNATLineWithMode(masq, ModeStrict)
+	if err != nil {
+		t.Fatalf("ParseNATLineWithMode() error = %v", err)
+	}
+	if rule.Family != model.FamilyIPv6 {
+		t.Errorf("Family = %d, want %d", rule.Family, model.FamilyIPv6)
+	}
+	if got := NATRuleToLine(rule); got != masq {
+		t.Errorf("NATRuleToLine() = %s, want %s", got, masq)
+	}
+}
@@ -287,6 +287,19 @@ func TestParseLine(t *testing.T) {
 					r.DPort == "80"
 			},
 		},
+		{
+			name:    "rule with source port, interfaces and state",
+			input:   "agent -m=insert -c=FORWARD -p=udp --sport=53 -i=eth1 -o=eth0 --state=established,related -a=ACCEPT",
+			wantNil: false,
+			wantErr: false,
+			validate: func(r *model.FirewallRule) bool {
+				return r.Chain == model.ChainFORWARD &&
+					r.SPort == "53" &&
+					r.InInterface == "eth1" &&
+					r.OutInterface == "eth0" &&
+					r.State == "ESTABLISHED,RELATED"
+			},
+		},
 		{
 			name:    "rule with ICMP type option",
 			input:   "agent -m=insert -c=INPUT -p=icmp?type=echo-request -a=DROP",
@@ -364,6 +377,21 @@ func TestRuleToLine(t *testing.T) {
 			},
 			want: "agent -m=insert -c=OUTPUT -p=udp -a=ACCEPT --dport=53 --sip=192.168.1.0/24 --dip=8.8.8.8 --black",
 		},
+		{
+			name: "rule with match options",
+			rule: &model.FirewallRule{
+				Chain:        model.ChainFORWARD,
+				Protocol:     model.ProtocolTCP,
+				Action:       model.ActionACCEPT,
+				DPort:        "443",
+				SPort:        "1024:65535",
+				SIP:          "10.0.0.0/8",
+				InInterface:  "eth1",
+				OutInterface: "eth0",
+				State:        "NEW",
+			},
+			want: "agent -m=insert -c=FORWARD -p=tcp -a=ACCEPT --dport=443 --sport=1024:65535 --sip=10.0.0.0/8 -i=eth1 -o=eth0 --state=NEW",
+		},
 		{
 			name: "rule with TCP flags",
 			rule: &model.FirewallRule{
@@ -490,3 +518,74 @@ func TestRoundTrip(t *testing.T) {
 		t.Errorf("RoundTrip TCPFlags mismatch: %v != %v", rule.Options.TCPFlags, rule2.Options.TCPFlags)
 	}
 }
+
+// TestRoundTrip_MatchOptions 출발지 포트, 인터페이스, 연결 상태 왕복 변환 테스트
+func TestRoundTrip_MatchOptions(t *testing.T) {
+	lines := []string{
+		"agent -m=insert -c=INPUT -p=any -a=ACCEPT --state=ESTABLISHED,RELATED",
+		"agent -m=insert -c=INPUT -p=udp -a=ACCEPT --sport=53 -i=eth1",
+		"agent -m=insert -c=OUTPUT -p=tcp -a=DROP --dport=25 -o=eth0 --state=NEW",
+		"agent -m=insert -c=FORWARD -p=tcp -a=ACCEPT --dport=443 --sport=1024:65535 -i=eth1 -o=eth0 --black",
+	}
+
+	for _, line := range lines {
+		rule, err := ParseLineWithMode(line, ModeStrict)
+		if err != nil {
+			t.Fatalf("ParseLineWithMode(%q) error = %v", line, err)
+		}
+		if got := RuleToLine(rule); got != line {
+			t.Errorf("RuleToLine() = %s, want %s", got, line)
+		}
+	}
+
+	// 엄격 모드에서는 알 수 없는 연결 상태 거부
+	if _, err := ParseLineWithMode("agent -m=insert -c=INPUT -a=ACCEPT --state=OPEN", ModeStrict); err == nil {
+		t.Error("ParseLineWithMode(--state=OPEN) error = nil, want error")
+	}
+}
+
+// TestRoundTrip_Family IPv6 규칙의 주소 체계 파싱과 왕복 변환 테스트
+func TestRoundTrip_Family(t *testing.T) {
+	tests := []struct {
+		line   string
+		family model.Family
+	}{
+		{"agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=22 --sip=192.168.1.0/24", model.FamilyIPv4},
+		{"agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=22 --sip=2001:db8::/32", model.FamilyIPv6},
+		{"agent -m=insert -c=FORWARD -p=any -a=DROP --sip=2001:db8::2 --dip=2001:db8::1", model.FamilyIPv6},
+		{"agent -m=insert -c=INPUT -p=icmp?type=neighbour-solicitation -a=ACCEPT --family=ipv6", model.FamilyIPv6},
+		{"agent -m=insert -c=INPUT -p=icmp?type=echo-request -a=ACCEPT", model.FamilyIPv4},
+	}
+
+	for _, tt := range tests {
+		rule, err := ParseLineWithMode(tt.line, ModeStrict)
+		if err != nil {
+			t.Fatalf("ParseLineWithMode(%q) error = %v", tt.line, err)
+		}
+		if rule.Family != tt.family {
+			t.Errorf("ParseLineWithMode(%q) Family = %d, want %d", tt.line, rule.Family, tt.family)
+		}
+		if got := RuleToLine(rule); got != tt.line {
+			t.Errorf("RuleToLine() = %s, want %s", got, tt.line)
+		}
+	}
+
+	// 엄격 모드에서는 주소 체계에 맞지 않는 ICMP type 거부
+	invalid := []string{
+		"agent -m=insert -c=INPUT -p=icmp?type=source-quench -a=DROP --family=ipv6",
+		"agent -m=insert -c=INPUT -p=icmp?type=packet-too-big -a=DROP",
+		"agent -m=insert -c=INPUT -a=DROP --family=ipx",
+		"agent -m=insert -c=FORWARD -p=any -a=DROP --sip=10.0.0.1 --dip=2001:db8::1",
+	}
+	for _, line := range invalid {
+		if _, err := ParseLineWithMode(line, ModeStrict); err == nil {
+			t.Errorf("ParseLineWithMode(%q) error = nil, want error", line)
+		}
+	}
+
+	// 관대한 모드에서는 IPv4와 IPv6 주소가 섞여 있으면 IPv6 규칙으로 봄
+	rule, err := ParseLineWithMode("agent -m=insert -c=FORWARD -p=any -a=DROP --sip=10.0.0.1 --dip=2001:db8::1", ModeLenient)
+	if err != nil || rule.Family != model.FamilyIPv6 {
+		t.Errorf("ParseLineWithMode(lenient, 혼용 주소) = %v, %v, want IPv6 규칙", rule, err)
+	}
+}
@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"strings"
+
+	"fms_wails/internal/model"
+)
+
+// 시뮬레이션에 사용할 규칙과 템플릿 위치
+type simRule struct {
+	line int
+	text string
+	rule *model.FirewallRule
+}
+
+type simNATRule struct {
+	line int
+	text string
+	rule *model.NATRule
+}
+
+// SimulateText 템플릿 텍스트에서 패킷과 일치하는 규칙과 최종 동작을 계산
+// INPUT/FORWARD 패킷은 먼저 DNAT 규칙(PREROUTING)을 순서대로 비교하여 처음 일치하는 규칙의 변환을 적용합니다.
+// 필터 규칙은 패킷 체인의 White → Black → 일반 규칙 순으로 비교하며(내보내기 룰셋과 동일),
+// 일치하는 규칙이 없으면 기본 정책(ACCEPT)을 적용합니다. 파싱할 수 없는 라인은 건너뜁니다.
+func SimulateText(text string, packet model.Packet) (*model.SimulationResult, error) {
+	if err := packet.Validate(); err != nil {
+		return nil, err
+	}
+
+	var white, black, normal []simRule
+	var natRules []simNATRule
+	for i, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if IsNATLine(trimmed) {
+			if rule, err := ParseNATLine(trimmed); err == nil && rule != nil {
+				natRules = append(natRules, simNATRule{line: i + 1, text: trimmed, rule: rule})
+			}
+			continue
+		}
+
+		rule, err := ParseLine(trimmed)
+		if err != nil || rule == nil || rule.Chain != packet.Chain {
+			continue
+		}
+		r := simRule{line: i + 1, text: trimmed, rule: rule}
+		switch {
+		case rule.White:
+			white = append(white, r)
+		case rule.Black:
+			black = append(black, r)
+		default:
+			normal = append(normal, r)
+		}
+	}
+
+	result := &model.SimulationResult{Packet: packet}
+
+	// DNAT는 외부에서 들어오는 패킷(PREROUTING)에만 적용
+	if packet.Chain == model.ChainINPUT || packet.Chain == model.ChainFORWARD {
+		for _, r := range natRules {
+			if r.rule.NATType != model.NATTypeDNAT {
+				continue
+			}
+			matched, reason := packet.MatchNAT(r.rule)
+			if matched {
+				result.Packet = packet.Translate(r.rule)
+				result.Translated = true
+				reason = "일치, 목적지 변환 → " + result.Packet.String()
+			}
+			result.Trace = append(result.Trace, model.TraceStep{Stage: model.StageDNAT, Line: r.line, Rule: r.text, Matched: matched, Reason: reason})
+			if matched {
+				break
+			}
+		}
+	}
+
+	stages := []struct {
+		name  string
+		rules []simRule
+	}{
+		{model.StageWhite, white},
+		{model.StageBlack, black},
+		{model.StageFilter, normal},
+	}
+	for _, stage := range stages {
+		for _, r := range stage.rules {
+			matched, reason := result.Packet.MatchRule(r.rule)
+			result.Trace = append(result.Trace, model.TraceStep{Stage: stage.name, Line: r.line, Rule: r.text, Matched: matched, Reason: reason})
+			if matched {
+				result.Verdict = model.ActionToString(exportAction(r.rule))
+				result.MatchedLine = r.line
+				result.MatchedRule = r.text
+				return result, nil
+			}
+		}
+	}
+
+	result.Verdict = model.ActionToString(model.DefaultPolicy)
+	return result, nil
+}
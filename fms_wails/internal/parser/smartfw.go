@@ -0,0 +1,317 @@
+package parser
+
+import (
+	"fmt"
+	"hash/crc32"
+	"strconv"
+	"strings"
+
+	"fms_wails/internal/model"
+)
+
+// smartfw 요청 라인 필드 수
+// req|INSERT|{ID}|{CHAIN}|{ACTION}|{PROTOCOL}|{SRC}|{DST}|{DPORT}|{IN_IF}|{OUT_IF}
+const smartfwFieldCount = 11
+
+// smartfw ACTION 필드의 특수 값
+const (
+	smartfwActionFlush = "FLUSH" // 체인 규칙 삭제 명령
+	smartfwActionNAT   = "NAT"   // NAT 규칙
+	smartfwActionBlack = "BLACK" // 블랙리스트 규칙 (DROP)
+	smartfwActionWhite = "WHITE" // 화이트리스트 규칙 (ACCEPT)
+)
+
+// SmartfwLine smartfw 요청 라인을 파싱한 결과 (Rule 또는 NATRule 중 하나)
+type SmartfwLine struct {
+	ID      string
+	Rule    *model.FirewallRule
+	NATRule *model.NATRule
+}
+
+// NewSmartfwID 템플릿 내용으로 smartfw 요청 ID 생성
+// 같은 내용은 항상 같은 ID가 되어 재배포 시 장비에서 같은 요청으로 식별됩니다.
+func NewSmartfwID(contents string) string {
+	return strconv.FormatUint(uint64(crc32.ChecksumIEEE([]byte(contents))), 10)
+}
+
+// RuleToSmartfw FirewallRule을 smartfw 형식으로 변환
+// req|INSERT|{ID}|{CHAIN}|{ACTION}|{PROTOCOL}|{SRC}|{DST}|{DPORT}|{IN_IF}|{OUT_IF}
+// Black/White 규칙은 ACTION에 BLACK/WHITE를 사용합니다.
+// 출발지 포트와 연결 상태는 smartfw 형식에 필드가 없어 전달되지 않습니다. (TextToSmartfw에서 검사)
+func RuleToSmartfw(rule *model.FirewallRule, id string) string {
+	if rule == nil {
+		return ""
+	}
+
+	action := model.ActionToString(rule.Action)
+	if rule.Black {
+		action = smartfwActionBlack
+	} else if rule.White {
+		action = smartfwActionWhite
+	}
+
+	protoStr := strings.ToUpper(model.ProtocolToString(rule.Protocol))
+	if opts := FormatOptionsOnly(rule.Options); opts != "" {
+		protoStr += "?" + opts
+	}
+
+	return fmt.Sprintf("req|INSERT|%s|%s|%s|%s|%s|%s|%s|%s|%s",
+		id,
+		model.ChainToString(rule.Chain),
+		action,
+		protoStr,
+		smartfwAddress(rule.SIP),
+		smartfwAddress(rule.DIP),
+		rule.DPort,
+		rule.InInterface,
+		rule.OutInterface,
+	)
+}
+
+// smartfwAddress 빈 주소를 ANY로 변환
+func smartfwAddress(s string) string {
+	if s == "" {
+		return "ANY"
+	}
+	return s
+}
+
+// smartfwValue ANY를 빈 값으로 변환
+func smartfwValue(s string) string {
+	if strings.EqualFold(s, "ANY") {
+		return ""
+	}
+	return s
+}
+
+// ParseSmartfwLine smartfw 요청 라인을 파싱하여 규칙으로 변환
+// 빈 줄, 주석, FLUSH 명령은 nil을 반환
+func ParseSmartfwLine(line string) (*SmartfwLine, error) {
+	line = strings.TrimSpace(line)
+
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil, nil
+	}
+
+	fields := strings.Split(line, "|")
+	if len(fields) != smartfwFieldCount || fields[0] != "req" {
+		return nil, fmt.Errorf("smartfw 형식이 아닙니다: %s", line)
+	}
+	if fields[1] != "INSERT" {
+		return nil, fmt.Errorf("지원하지 않는 smartfw 요청: %s", fields[1])
+	}
+
+	result := &SmartfwLine{ID: fields[2]}
+	switch strings.ToUpper(fields[4]) {
+	case smartfwActionFlush:
+		return nil, nil
+	case smartfwActionNAT:
+		rule, err := parseSmartfwNAT(fields)
+		if err != nil {
+			return nil, err
+		}
+		result.NATRule = rule
+	default:
+		rule, err := parseSmartfwFilter(fields)
+		if err != nil {
+			return nil, err
+		}
+		result.Rule = rule
+	}
+
+	return result, nil
+}
+
+// parseSmartfwFilter 필터 규칙 필드 변환
+func parseSmartfwFilter(fields []string) (*model.FirewallRule, error) {
+	rule := model.NewFirewallRule()
+
+	chain, err := model.ParseChain(fields[3])
+	if err != nil {
+		return nil, err
+	}
+	rule.Chain = chain
+
+	switch strings.ToUpper(fields[4]) {
+	case smartfwActionBlack:
+		rule.Black = true
+		rule.Action = model.ActionDROP
+	case smartfwActionWhite:
+		rule.White = true
+		rule.Action = model.ActionACCEPT
+	default:
+		action, err := model.ParseAction(fields[4])
+		if err != nil {
+			return nil, err
+		}
+		rule.Action = action
+	}
+
+	// 프로토콜 이름은 대문자, 옵션은 템플릿과 같은 쿼리 스트링 형식
+	protoStr := fields[5]
+	if idx := strings.Index(protoStr, "?"); idx != -1 {
+		protoStr = strings.ToLower(protoStr[:idx]) + protoStr[idx:]
+	} else {
+		protoStr = strings.ToLower(protoStr)
+	}
+	// ICMP type/code 이름은 주소에 맞는 주소 체계(ICMP, ICMPv6)의 표로 검사
+	if err := checkProtocol(protoStr, model.InferFamily(smartfwValue(fields[6]), smartfwValue(fields[7]))); err != nil {
+		return nil, err
+	}
+	protocol, opts, _ := ParseProtocolWithOptions(protoStr)
+	rule.Protocol = protocol
+	rule.Options = opts
+
+	rule.SIP = smartfwValue(fields[6])
+	rule.DIP = smartfwValue(fields[7])
+	rule.DPort = smartfwValue(fields[8])
+	rule.InInterface = fields[9]
+	rule.OutInterface = fields[10]
+
+	return rule, nil
+}
+
+// parseSmartfwNAT NAT 규칙 필드 변환 (NATRuleToSmartfw의 역변환)
+func parseSmartfwNAT(fields []string) (*model.NATRule, error) {
+	rule := model.NewNATRule()
+
+	protoStr, typeStr, ok := strings.Cut(fields[6], "?")
+	if !ok {
+		return nil, fmt.Errorf("NAT 타입이 없습니다: %s", fields[6])
+	}
+	protocol, err := model.ParseProtocol(protoStr)
+	if err != nil {
+		return nil, err
+	}
+	natType, err := model.ParseNATType(typeStr)
+	if err != nil {
+		return nil, err
+	}
+	rule.Protocol = protocol
+	rule.NATType = natType
+	rule.InInterface = fields[9]
+	rule.OutInterface = fields[10]
+
+	switch natType {
+	case model.NATTypeDNAT:
+		// DNAT은 매칭 IP 기본값이 ANY
+		rule.MatchIP = fields[5]
+		rule.TranslateIP = fields[7]
+		matchPort, translatePort, _ := strings.Cut(fields[8], ",")
+		rule.MatchPort = matchPort
+		rule.TranslatePort = translatePort
+	case model.NATTypeSNAT:
+		rule.MatchIP = smartfwValue(fields[5])
+		rule.TranslateIP = smartfwValue(fields[7])
+		rule.MatchPort = smartfwValue(fields[8])
+	case model.NATTypeMASQUERADE:
+		rule.MatchIP = smartfwValue(fields[5])
+	}
+
+	return rule, nil
+}
+
+// TextToSmartfw 템플릿 텍스트를 smartfw 요청 라인 목록으로 변환
+// 규칙은 엄격 모드로 파싱하며, 변환할 수 없는 라인이 있으면 라인 번호와 함께 에러를 반환합니다.
+// NAT 규칙의 설명(--desc)은 smartfw 형식에 필드가 없어 전달되지 않습니다.
+func TextToSmartfw(text, id string) ([]string, error) {
+	var lines []string
+
+	for i, line := range strings.Split(text, "\n") {
+		if IsNATLine(line) {
+			rule, err := ParseNATLineWithMode(line, ModeStrict)
+			if err != nil {
+				return nil, fmt.Errorf("라인 %d: %w", i+1, err)
+			}
+			if rule != nil && rule.Family == model.FamilyIPv6 {
+				return nil, fmt.Errorf("라인 %d: smartfw 형식은 IPv6 규칙을 지원하지 않습니다", i+1)
+			}
+			if rule != nil {
+				lines = append(lines, NATRuleToSmartfw(rule, id))
+			}
+			continue
+		}
+
+		rule, err := ParseLineWithMode(line, ModeStrict)
+		if err != nil {
+			return nil, fmt.Errorf("라인 %d: %w", i+1, err)
+		}
+		if rule != nil && rule.Family == model.FamilyIPv6 {
+			return nil, fmt.Errorf("라인 %d: smartfw 형식은 IPv6 규칙을 지원하지 않습니다", i+1)
+		}
+		if rule != nil && (rule.SPort != "" || rule.State != "") {
+			return nil, fmt.Errorf("라인 %d: smartfw 형식은 출발지 포트(--sport)와 연결 상태(--state) 조건을 지원하지 않습니다", i+1)
+		}
+		if rule != nil {
+			lines = append(lines, RuleToSmartfw(rule, id))
+		}
+	}
+
+	return lines, nil
+}
+
+// ImportSmartfw smartfw 요청 라인 목록(덤프)을 템플릿 규칙으로 변환
+// FLUSH 명령은 무시하고, 변환할 수 없는 라인은 Skipped에 사유와 함께 기록합니다.
+func ImportSmartfw(text string) (*ImportResult, error) {
+	result := &ImportResult{
+		Rules:    []*model.FirewallRule{},
+		NATRules: []*model.NATRule{},
+		Skipped:  []SkippedRule{},
+	}
+	var filterLines, natLines []*DocumentLine
+
+	hasRequest := false
+	for i, raw := range strings.Split(text, "\n") {
+		line := strings.TrimSpace(raw)
+		if strings.HasPrefix(line, "req|") {
+			hasRequest = true
+		}
+
+		parsed, err := ParseSmartfwLine(line)
+		if err != nil {
+			result.Skipped = append(result.Skipped, SkippedRule{Line: i + 1, Text: line, Reason: err.Error()})
+			continue
+		}
+		if parsed == nil {
+			continue
+		}
+
+		if parsed.Rule != nil {
+			result.Rules = append(result.Rules, parsed.Rule)
+			filterLines = append(filterLines, &DocumentLine{Kind: LineRule, Text: RuleToLine(parsed.Rule), Rule: parsed.Rule})
+		} else {
+			result.NATRules = append(result.NATRules, parsed.NATRule)
+			natLines = append(natLines, &DocumentLine{Kind: LineNATRule, Text: NATRuleToLine(parsed.NATRule), NATRule: parsed.NATRule})
+		}
+	}
+
+	if !hasRequest {
+		return nil, fmt.Errorf("smartfw 형식이 아닙니다: req| 요청 라인이 없습니다")
+	}
+
+	doc := &Document{Lines: append(filterLines, natLines...)}
+	result.Text = doc.String()
+
+	return result, nil
+}
+
+// IsSmartfwText 텍스트가 smartfw 요청 라인 목록인지 확인 (첫 번째 내용 라인 기준)
+func IsSmartfwText(text string) bool {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return strings.HasPrefix(line, "req|")
+	}
+	return false
+}
+
+// ImportRuleset 장비 룰셋 덤프를 형식에 맞게 템플릿 규칙으로 변환
+// smartfw 요청 라인 목록과 iptables-save 출력을 지원합니다.
+func ImportRuleset(text string) (*ImportResult, error) {
+	if IsSmartfwText(text) {
+		return ImportSmartfw(text)
+	}
+	return ImportIptablesSave(text)
+}
@@ -0,0 +1,202 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"fms_wails/internal/model"
+)
+
+// TestRuleToSmartfw 필터 규칙 smartfw 변환 테스트
+func TestRuleToSmartfw(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{
+			"agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=80 --sip=192.168.1.0/24",
+			"req|INSERT|123|INPUT|ACCEPT|TCP|192.168.1.0/24|ANY|80||",
+		},
+		{
+			"agent -m=insert -c=FORWARD -p=any -a=DROP --dip=10.0.0.1,10.0.0.2",
+			"req|INSERT|123|FORWARD|DROP|ANY|ANY|10.0.0.1,10.0.0.2|||",
+		},
+		{
+			"agent -m=insert -c=INPUT -p=tcp?flags=syn,ack/syn -a=REJECT --dport=22",
+			"req|INSERT|123|INPUT|REJECT|TCP?flags=syn,ack/syn|ANY|ANY|22||",
+		},
+		{
+			"agent -m=insert -c=INPUT -p=icmp?type=echo-request -a=DROP --black",
+			"req|INSERT|123|INPUT|BLACK|ICMP?type=echo-request|ANY|ANY|||",
+		},
+		{
+			"agent -m=insert -c=INPUT -p=udp -a=ACCEPT --dport=53 --sip=10.0.0.5 --white",
+			"req|INSERT|123|INPUT|WHITE|UDP|10.0.0.5|ANY|53||",
+		},
+		{
+			"agent -m=insert -c=FORWARD -p=tcp -a=ACCEPT --dport=443 -i=eth1 -o=eth0",
+			"req|INSERT|123|FORWARD|ACCEPT|TCP|ANY|ANY|443|eth1|eth0",
+		},
+	}
+
+	for _, tt := range tests {
+		rule, err := ParseLine(tt.line)
+		if err != nil {
+			t.Fatalf("ParseLine(%q) error = %v", tt.line, err)
+		}
+
+		got := RuleToSmartfw(rule, "123")
+		if got != tt.want {
+			t.Errorf("RuleToSmartfw() = %s, want %s", got, tt.want)
+		}
+
+		// 역변환 시 같은 템플릿 라인
+		parsed, err := ParseSmartfwLine(got)
+		if err != nil {
+			t.Fatalf("ParseSmartfwLine(%q) error = %v", got, err)
+		}
+		if parsed.ID != "123" || parsed.Rule == nil {
+			t.Fatalf("ParseSmartfwLine(%q) = %+v, want ID 123 필터 규칙", got, parsed)
+		}
+		if line := RuleToLine(parsed.Rule); line != RuleToLine(rule) {
+			t.Errorf("역변환 = %s, want %s", line, RuleToLine(rule))
+		}
+	}
+}
+
+// TestParseSmartfwLine_NAT NAT 규칙 smartfw 역변환 테스트
+func TestParseSmartfwLine_NAT(t *testing.T) {
+	lines := []string{
+		"agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=10.0.0.1:8080",
+		"agent -m=insert -t=nat --nat-type=dnat -p=udp --match-port=53 -s=192.168.0.0/24 --to-dest=10.0.0.53",
+		"agent -m=insert -t=nat --nat-type=snat -p=any -s=10.0.0.0/8 --to-source=1.1.1.1 -o=eth0",
+		"agent -m=insert -t=nat --nat-type=masquerade -p=any -s=192.168.0.0/24 -o=eth0",
+	}
+
+	for _, line := range lines {
+		rule, err := ParseNATLine(line)
+		if err != nil {
+			t.Fatalf("ParseNATLine(%q) error = %v", line, err)
+		}
+
+		smartfw := NATRuleToSmartfw(rule, "123")
+		parsed, err := ParseSmartfwLine(smartfw)
+		if err != nil {
+			t.Fatalf("ParseSmartfwLine(%q) error = %v", smartfw, err)
+		}
+		if parsed.NATRule == nil {
+			t.Fatalf("ParseSmartfwLine(%q) = %+v, want NAT 규칙", smartfw, parsed)
+		}
+		if got := NATRuleToLine(parsed.NATRule); got != line {
+			t.Errorf("역변환(%s) = %s, want %s", smartfw, got, line)
+		}
+	}
+}
+
+// TestParseSmartfwLine_Invalid 명령 라인과 잘못된 라인 처리
+func TestParseSmartfwLine_Invalid(t *testing.T) {
+	for _, line := range []string{"", "# 주석", "req|INSERT|1|INPUT|FLUSH|ANY|ANY|ANY|||"} {
+		parsed, err := ParseSmartfwLine(line)
+		if parsed != nil || err != nil {
+			t.Errorf("ParseSmartfwLine(%q) = %+v, %v, want nil", line, parsed, err)
+		}
+	}
+
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"agent -m=insert -c=INPUT -a=DROP", "smartfw 형식이 아닙니다"},
+		{"req|DELETE|1|INPUT|DROP|TCP|ANY|ANY|||", "지원하지 않는 smartfw 요청"},
+		{"req|INSERT|1|INPUT|LOG|TCP|ANY|ANY|||", "알 수 없는 동작"},
+		{"req|INSERT|1|INPUT|DROP|TCP?mss=1400|ANY|ANY|||", "mss"},
+		{"req|INSERT|1|INPUT|DROP|UDP?flags=syn/syn|ANY|ANY|||", "tcp 프로토콜에서만"},
+		{"req|INSERT|1|INPUT|DROP|ICMP?type=neighbour-solicitation|10.0.0.1|ANY|||", "알 수 없는 ICMP type"},
+		{"req|INSERT|1|ANY|NAT|ANY|TCP|10.0.0.1|80,8080||", "NAT 타입이 없습니다"},
+	}
+	for _, tt := range tests {
+		_, err := ParseSmartfwLine(tt.line)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseSmartfwLine(%q) error = %v, want %q", tt.line, err, tt.want)
+		}
+	}
+
+	// IPv6 주소의 규칙은 ICMPv6 이름으로 검사
+	if _, err := ParseSmartfwLine("req|INSERT|1|INPUT|DROP|ICMP?type=neighbour-solicitation|2001:db8::1|ANY|||"); err != nil {
+		t.Errorf("ParseSmartfwLine(ICMPv6) error = %v", err)
+	}
+}
+
+// TestTextToSmartfw 템플릿 전체 변환 및 ID 생성 테스트
+func TestTextToSmartfw(t *testing.T) {
+	text := `# 웹 서버
+agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=80
+
+agent -m=insert -t=nat --nat-type=masquerade -p=any -s=192.168.0.0/24 -o=eth0`
+
+	id := NewSmartfwID(text)
+	if id != NewSmartfwID(text) || id == NewSmartfwID(text+"\n") {
+		t.Errorf("NewSmartfwID() = %s, want 내용별 고정 ID", id)
+	}
+
+	lines, err := TextToSmartfw(text, id)
+	if err != nil {
+		t.Fatalf("TextToSmartfw() error = %v", err)
+	}
+	want := []string{
+		"req|INSERT|" + id + "|INPUT|ACCEPT|TCP|ANY|ANY|80||",
+		"req|INSERT|" + id + "|ANY|NAT|192.168.0.0/24|ANY?MASQUERADE|ANY|ANY||eth0",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("TextToSmartfw() =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+
+	_, err = TextToSmartfw("agent -m=insert -c=INPUT -a=DROP --foo=1", id)
+	if err == nil || !strings.HasPrefix(err.Error(), "라인 1:") {
+		t.Errorf("TextToSmartfw() error = %v, want 라인 1 오류", err)
+	}
+
+	// 출발지 포트와 연결 상태는 smartfw 필드가 없어 변환 불가
+	_, err = TextToSmartfw("# 상태\nagent -m=insert -c=INPUT -p=any -a=ACCEPT --state=ESTABLISHED", id)
+	if err == nil || !strings.HasPrefix(err.Error(), "라인 2:") || !strings.Contains(err.Error(), "--state") {
+		t.Errorf("TextToSmartfw() error = %v, want 라인 2 연결 상태 오류", err)
+	}
+}
+
+// TestImportRuleset smartfw 덤프 가져오기 및 형식 자동 감지 테스트
+func TestImportRuleset(t *testing.T) {
+	dump := `req|INSERT|3813792919|INPUT|FLUSH|ANY|ANY|ANY|||
+req|INSERT|3813792919|ANY|NAT|ANY|TCP?DNAT|10.0.0.1|80,8080||
+req|INSERT|3813792919|INPUT|ACCEPT|TCP|192.168.1.0/24|ANY|80||
+req|INSERT|3813792919|INPUT|LOG|TCP|ANY|ANY|||
+req|INSERT|3813792919|INPUT|BLACK|ANY|1.2.3.4|ANY|||`
+
+	result, err := ImportRuleset(dump)
+	if err != nil {
+		t.Fatalf("ImportRuleset() error = %v", err)
+	}
+
+	wantText := `agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=80 --sip=192.168.1.0/24
+agent -m=insert -c=INPUT -p=any -a=DROP --sip=1.2.3.4 --black
+agent -m=insert -t=nat --nat-type=dnat -p=tcp --match-port=80 --to-dest=10.0.0.1:8080`
+	if result.Text != wantText {
+		t.Errorf("Text =\n%s\nwant\n%s", result.Text, wantText)
+	}
+	if len(result.Rules) != 2 || len(result.NATRules) != 1 {
+		t.Errorf("규칙 수 = %d/%d, want 2/1", len(result.Rules), len(result.NATRules))
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Line != 4 {
+		t.Errorf("Skipped = %v, want 라인 4", result.Skipped)
+	}
+	if errs := CheckText(result.Text, ModeStrict); len(errs) != 0 {
+		t.Errorf("CheckText(strict) = %v", errs)
+	}
+	if diags := model.ValidateTemplate(result.Text); model.HasErrors(diags) {
+		t.Errorf("ValidateTemplate() = %v", diags)
+	}
+
+	// req| 라인이 없으면 iptables-save로 처리
+	if _, err := ImportRuleset("agent -m=insert -c=INPUT -a=DROP"); err == nil || !strings.Contains(err.Error(), "iptables-save 형식이 아닙니다") {
+		t.Errorf("ImportRuleset() error = %v, want iptables-save 형식 오류", err)
+	}
+}
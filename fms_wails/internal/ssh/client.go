@@ -0,0 +1,451 @@
+// Package ssh는 SSH로 장비에 접속하여 agent 명령을 실행하거나 smartfw 요청을 기록하는 기능을 제공합니다.
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"fms_wails/internal/http"
+	"fms_wails/internal/model"
+	"fms_wails/internal/parser"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// 장비에서 실행하는 명령
+const (
+	agentProgram  = "agent"                      // 규칙 적용 명령
+	healthCommand = "command -v " + agentProgram // agent 명령 설치 여부 확인
+	rulesCommand  = agentProgram + " -m=list"    // 적용된 규칙 조회
+
+	smartfwPath          = "/proc/smartfw"
+	smartfwHealthCommand = "test -w " + smartfwPath // smartfw 커널 모듈 인터페이스 확인
+	smartfwRulesCommand  = "cat " + smartfwPath     // 적용된 smartfw 요청 조회
+)
+
+// SSH 설정(사용자, 개인키 등)을 불러올 수 없을 때 반환되는 에러입니다.
+var ErrSSHConfig = errors.New("SSH 설정 오류")
+
+// SSH 사용자 인증 또는 호스트 키 검증 실패 에러입니다.
+type AuthError struct {
+	Reason string // 사용자 표시용 사유 (예: 호스트 키 불일치)
+	Err    error
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Reason, e.Err)
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
+
+func (e *AuthError) Category() string {
+	return model.ErrorCategoryAuth
+}
+
+// Client는 SSH 클라이언트를 나타냅니다.
+type Client struct {
+	sshConfig *ssh.ClientConfig
+	configErr error // SSH 설정 로드 실패 시 에러
+	timeout   time.Duration
+	config    *model.Config
+}
+
+// 새로운 SSH 클라이언트를 생성합니다.
+// SSH 설정을 불러올 수 없으면 모든 요청이 SSH 설정 오류를 반환합니다.
+func NewClient(config *model.Config) *Client {
+	timeout := time.Duration(config.GetTimeoutSeconds()) * time.Second
+	c := &Client{config: config, timeout: timeout}
+	c.sshConfig, c.configErr = newClientConfig(config.SSH)
+	if c.configErr != nil {
+		c.configErr = fmt.Errorf("%w: %v", ErrSSHConfig, c.configErr)
+	}
+	return c
+}
+
+// SSH 설정을 crypto/ssh 클라이언트 설정으로 변환합니다.
+func newClientConfig(cfg model.SSHConfig) (*ssh.ClientConfig, error) {
+	if strings.TrimSpace(cfg.Username) == "" {
+		return nil, fmt.Errorf("사용자를 입력해야 합니다")
+	}
+	if cfg.KeyFile == "" {
+		return nil, fmt.Errorf("개인키 경로를 입력해야 합니다")
+	}
+
+	data, err := os.ReadFile(cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("개인키 읽기 실패: %v", err)
+	}
+	signer, err := ssh.ParsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("개인키 파싱 실패: %v", err)
+	}
+
+	hostKeyCallback, err := newHostKeyCallback(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssh.ClientConfig{
+		User:            strings.TrimSpace(cfg.Username),
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: hostKeyCallback,
+	}, nil
+}
+
+// 호스트 키 검증 함수를 생성합니다.
+// 호스트 키 지문이 설정되어 있으면 지문으로, 아니면 known_hosts 파일로 검증합니다.
+func newHostKeyCallback(cfg model.SSHConfig) (ssh.HostKeyCallback, error) {
+	if len(cfg.HostKeySHA256) > 0 {
+		fingerprints := make(map[string]bool)
+		for _, fingerprint := range cfg.HostKeySHA256 {
+			fingerprint = strings.TrimPrefix(strings.TrimSpace(fingerprint), "SHA256:")
+			if fingerprint != "" {
+				fingerprints["SHA256:"+fingerprint] = true
+			}
+		}
+		return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			if fingerprints[ssh.FingerprintSHA256(key)] {
+				return nil
+			}
+			return &AuthError{
+				Reason: "호스트 키 불일치",
+				Err:    fmt.Errorf("%s의 호스트 키 %s가 설정된 지문과 다릅니다", hostname, ssh.FingerprintSHA256(key)),
+			}
+		}, nil
+	}
+
+	path := cfg.KnownHostsFile
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("known_hosts 경로 확인 실패: %v", err)
+		}
+		path = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	callback, err := knownhosts.New(path)
+	if err != nil {
+		return nil, fmt.Errorf("known_hosts 읽기 실패: %v", err)
+	}
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if err := callback(hostname, remote, key); err != nil {
+			var keyErr *knownhosts.KeyError
+			if errors.As(err, &keyErr) && len(keyErr.Want) == 0 {
+				return &AuthError{Reason: "등록되지 않은 호스트 키", Err: err}
+			}
+			return &AuthError{Reason: "호스트 키 불일치", Err: err}
+		}
+		return nil
+	}, nil
+}
+
+// 장비 SSH 주소를 생성합니다. 장비 주소에 포트가 있으면 그대로 사용합니다.
+func (c *Client) address(deviceIP string) string {
+	host, port, err := model.SplitDeviceAddress(deviceIP)
+	if err != nil {
+		// IP가 아닌 장비 이름은 그대로 사용
+		if _, _, err := net.SplitHostPort(deviceIP); err == nil {
+			return deviceIP
+		}
+		return net.JoinHostPort(deviceIP, strconv.Itoa(c.config.GetSSHPort()))
+	}
+	if port == "" {
+		port = strconv.Itoa(c.config.GetSSHPort())
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// 장비에 SSH로 접속합니다.
+// 접속 실패는 원인별 에러 타입으로 반환합니다.
+func (c *Client) connect(ctx context.Context, fw *model.Firewall) (*ssh.Client, error) {
+	if c.configErr != nil {
+		return nil, c.configErr
+	}
+
+	addr := c.address(fw.DeviceName)
+	dialer := net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("장비 연결 실패: %w", http.ClassifyError(err))
+	}
+
+	// 핸드셰이크 중 시간 초과 및 취소 처리
+	conn.SetDeadline(time.Now().Add(c.timeout))
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, addr, c.sshConfig)
+	if err != nil {
+		conn.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("장비 연결 실패: %w", classifyHandshakeError(err))
+	}
+	conn.SetDeadline(time.Time{})
+
+	return ssh.NewClient(sshConn, chans, reqs), nil
+}
+
+// SSH 핸드셰이크 에러를 원인별 에러 타입으로 변환합니다.
+func classifyHandshakeError(err error) error {
+	var authErr *AuthError
+	if errors.As(err, &authErr) {
+		return err
+	}
+	// 사용자 인증 실패는 crypto/ssh가 메시지로만 전달함
+	if strings.Contains(err.Error(), "unable to authenticate") {
+		return &AuthError{Reason: "SSH 인증 실패", Err: err}
+	}
+	return http.ClassifyError(err)
+}
+
+// 명령을 실행하고 출력(stdout 뒤에 stderr)과 종료 코드를 반환합니다.
+// 명령 하나의 실행 시간은 설정된 타임아웃으로 제한합니다.
+func (c *Client) run(ctx context.Context, client *ssh.Client, command string) (string, int, error) {
+	session, err := client.NewSession()
+	if err != nil {
+		return "", 0, &http.NetworkError{Err: fmt.Errorf("세션 생성 실패: %v", err)}
+	}
+	defer session.Close()
+
+	// stdout과 stderr는 별도 고루틴에서 복사되므로 버퍼를 분리
+	var stdout, stderr bytes.Buffer
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+
+	runCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	stop := context.AfterFunc(runCtx, func() {
+		session.Close()
+	})
+	defer stop()
+
+	err = session.Run(command)
+	if ctx.Err() != nil {
+		return "", 0, ctx.Err()
+	}
+	if runCtx.Err() != nil {
+		return "", 0, &http.TimeoutError{Err: runCtx.Err()}
+	}
+
+	output := stdout.String() + stderr.String()
+	var exitErr *ssh.ExitError
+	if errors.As(err, &exitErr) {
+		return output, exitErr.ExitStatus(), nil
+	}
+	if err != nil {
+		return "", 0, &http.NetworkError{Err: fmt.Errorf("명령 실행 실패: %v", err)}
+	}
+	return output, 0, nil
+}
+
+// 장비 상태를 확인합니다.
+// 접속 후 agent 명령(smartfw 형식은 /proc/smartfw)을 사용할 수 있으면 running으로 판단합니다.
+func (c *Client) CheckHealth(ctx context.Context, fw *model.Firewall) (string, error) {
+	client, err := c.connect(ctx, fw)
+	if err != nil {
+		return model.ServerStatusStop, err
+	}
+	defer client.Close()
+
+	command := healthCommand
+	if c.config.IsSmartfwRuleFormat() {
+		command = smartfwHealthCommand
+	}
+	_, exitStatus, err := c.run(ctx, client, command)
+	if err != nil {
+		return model.ServerStatusStop, err
+	}
+	if exitStatus != 0 {
+		return model.ServerStatusStop, nil
+	}
+	return model.ServerStatusRunning, nil
+}
+
+// 템플릿의 agent 명령을 장비 셸에서 순서대로 실행합니다.
+// 각 줄은 규칙으로 파싱하여 다시 만든 명령을 인자별로 셸 인용하여 실행합니다.
+// 주석과 빈 줄은 실행하지 않으며, 명령별 종료 코드와 출력을 배포 결과로 변환합니다.
+// smartfw 형식이면 규칙을 smartfw 요청 라인으로 변환하여 /proc/smartfw에 한 줄씩 기록합니다.
+func (c *Client) DeployTemplate(ctx context.Context, fw *model.Firewall, template string) (*model.DeployResult, error) {
+	commands, err := c.deployCommands(template)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := c.connect(ctx, fw)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	result := &model.DeployResult{
+		IP:     fw.DeviceName,
+		Status: model.DeployStatusSuccess,
+	}
+	for _, cmd := range commands {
+		output, exitStatus, err := c.run(ctx, client, cmd.command)
+		if err != nil {
+			return nil, err
+		}
+		result.Info = append(result.Info, parseOutput(len(result.Info)+1, cmd.rule, output, exitStatus))
+		if exitStatus != 0 {
+			result.Status = model.DeployStatusFail
+		}
+	}
+
+	return result, nil
+}
+
+// 배포 결과에 표시할 규칙과 장비에서 실행할 명령
+type deployCommand struct {
+	rule    string
+	command string
+}
+
+// 템플릿을 장비에서 실행할 명령 목록으로 변환합니다.
+// 파싱할 수 없는 줄이 있으면 어떤 명령도 실행하지 않도록 에러를 반환합니다.
+func (c *Client) deployCommands(template string) ([]deployCommand, error) {
+	var commands []deployCommand
+
+	if c.config.IsSmartfwRuleFormat() {
+		lines, err := parser.TextToSmartfw(template, parser.NewSmartfwID(template))
+		if err != nil {
+			return nil, fmt.Errorf("smartfw 변환 실패: %v", err)
+		}
+		for _, line := range lines {
+			commands = append(commands, deployCommand{rule: line, command: "printf '%s\\n' " + shellQuote(line) + " > " + smartfwPath})
+		}
+		return commands, nil
+	}
+
+	for i, line := range strings.Split(template, "\n") {
+		args, err := agentArgs(line)
+		if err != nil {
+			return nil, fmt.Errorf("라인 %d: %w", i+1, err)
+		}
+		if args == nil {
+			continue
+		}
+		commands = append(commands, deployCommand{
+			rule:    agentProgram + " " + strings.Join(args, " "),
+			command: agentCommand(args),
+		})
+	}
+	return commands, nil
+}
+
+// 템플릿 규칙 줄을 엄격 모드로 파싱하고, 파싱한 규칙으로 다시 만든 agent 명령 인자를 반환합니다.
+// 템플릿 텍스트를 셸에 그대로 넘기지 않으므로 규칙 문법에 없는 토큰은 장비에서 실행되지 않습니다.
+// 빈 줄과 주석은 nil을 반환합니다.
+func agentArgs(line string) ([]string, error) {
+	if parser.IsNATLine(line) {
+		rule, err := parser.ParseNATLineWithMode(line, parser.ModeStrict)
+		if err != nil || rule == nil {
+			return nil, err
+		}
+		// 설명은 공백을 포함하므로 인자 하나로 전달
+		desc := rule.Description
+		rule = rule.Clone()
+		rule.Description = ""
+		args := strings.Fields(parser.NATRuleToLine(rule))[1:]
+		if desc != "" {
+			args = append(args, "--desc="+desc)
+		}
+		return args, nil
+	}
+
+	rule, err := parser.ParseLineWithMode(line, parser.ModeStrict)
+	if err != nil || rule == nil {
+		return nil, err
+	}
+	return strings.Fields(parser.RuleToLine(rule))[1:], nil
+}
+
+// agent 명령 인자를 하나씩 작은따옴표로 감싸 장비 셸에서 실행할 명령을 만듭니다.
+func agentCommand(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	return agentProgram + " " + strings.Join(quoted, " ")
+}
+
+// 장비에 적용된 규칙을 조회합니다.
+// smartfw 형식이면 /proc/smartfw의 요청 라인을 템플릿 규칙으로 변환하여 반환합니다.
+func (c *Client) FetchRules(ctx context.Context, fw *model.Firewall) (string, error) {
+	client, err := c.connect(ctx, fw)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	command := rulesCommand
+	if c.config.IsSmartfwRuleFormat() {
+		command = smartfwRulesCommand
+	}
+	output, exitStatus, err := c.run(ctx, client, command)
+	if err != nil {
+		return "", err
+	}
+	if exitStatus != 0 {
+		return "", fmt.Errorf("규칙 조회 실패 (종료 코드 %d): %s", exitStatus, collapseOutput(output))
+	}
+
+	if c.config.IsSmartfwRuleFormat() && parser.IsSmartfwText(output) {
+		imported, err := parser.ImportSmartfw(output)
+		if err != nil {
+			return "", err
+		}
+		return imported.Text, nil
+	}
+	return output, nil
+}
+
+// 셸 명령 인자로 사용할 수 있도록 작은따옴표로 감쌉니다.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// 명령 실행 결과를 규칙별 배포 결과로 변환합니다.
+// 성공 시 출력의 마지막 줄을 장비에서 처리된 규칙 텍스트로, 실패 시 출력을 사유로 사용합니다.
+func parseOutput(index int, rule, output string, exitStatus int) model.ResultInfo {
+	info := model.ResultInfo{
+		Index:  index,
+		Rule:   rule,
+		Text:   rule,
+		Status: model.RuleStatusOK,
+	}
+
+	if exitStatus != 0 {
+		info.Status = model.RuleStatusError
+		info.Reason = collapseOutput(output)
+		if info.Reason == "" {
+			info.Reason = fmt.Sprintf("종료 코드 %d", exitStatus)
+		}
+		return info
+	}
+
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		info.Text = last
+	}
+	return info
+}
+
+// 명령 출력을 한 줄로 합칩니다.
+func collapseOutput(output string) string {
+	return strings.Join(strings.Fields(output), " ")
+}
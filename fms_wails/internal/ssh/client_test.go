@@ -0,0 +1,414 @@
+package ssh
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"fms_wails/internal/http"
+	"fms_wails/internal/model"
+	"fms_wails/internal/parser"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// testDevice는 테스트용 SSH 장비입니다.
+type testDevice struct {
+	addr    string
+	hostKey ssh.Signer
+
+	mu       sync.Mutex
+	commands []string // 실행된 명령 목록
+}
+
+// 실행된 명령 목록을 반환합니다.
+func (d *testDevice) executed() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.commands...)
+}
+
+// 테스트용 SSH 서버를 시작합니다.
+// authorized 키로만 로그인할 수 있으며, exec 요청은 handler의 출력과 종료 코드로 응답합니다.
+func newTestDevice(t *testing.T, authorized ssh.PublicKey, handler func(command string) (string, uint32)) *testDevice {
+	t.Helper()
+
+	_, hostPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("호스트 키 생성 실패: %v", err)
+	}
+	hostKey, err := ssh.NewSignerFromKey(hostPriv)
+	if err != nil {
+		t.Fatalf("호스트 키 변환 실패: %v", err)
+	}
+
+	serverConfig := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) == string(authorized.Marshal()) {
+				return nil, nil
+			}
+			return nil, errors.New("unauthorized")
+		},
+	}
+	serverConfig.AddHostKey(hostKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("포트 할당 실패: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	device := &testDevice{addr: listener.Addr().String(), hostKey: hostKey}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go device.serve(conn, serverConfig, handler)
+		}
+	}()
+	return device
+}
+
+// SSH 연결 하나를 처리합니다.
+func (d *testDevice) serve(conn net.Conn, config *ssh.ServerConfig, handler func(string) (string, uint32)) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "session only")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			defer channel.Close()
+			for req := range requests {
+				if req.Type != "exec" {
+					req.Reply(false, nil)
+					continue
+				}
+				var payload struct{ Command string }
+				if err := ssh.Unmarshal(req.Payload, &payload); err != nil {
+					req.Reply(false, nil)
+					return
+				}
+				req.Reply(true, nil)
+
+				d.mu.Lock()
+				d.commands = append(d.commands, payload.Command)
+				d.mu.Unlock()
+
+				output, status := handler(payload.Command)
+				channel.Write([]byte(output))
+				channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{status}))
+				return
+			}
+		}()
+	}
+}
+
+// 클라이언트 키를 생성하여 파일로 저장하고 공개키를 반환합니다.
+func writeClientKey(t *testing.T) (string, ssh.PublicKey) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("클라이언트 키 생성 실패: %v", err)
+	}
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		t.Fatalf("개인키 변환 실패: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "id_ed25519")
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatalf("개인키 저장 실패: %v", err)
+	}
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("공개키 변환 실패: %v", err)
+	}
+	return path, sshPub
+}
+
+// 테스트 장비 명령 처리기
+func agentHandler(command string) (string, uint32) {
+	switch {
+	case command == healthCommand:
+		return "/usr/bin/agent\n", 0
+	case command == rulesCommand:
+		return "agent -m=insert -c=INPUT -p=tcp -a=DROP --dport=22\n", 0
+	case strings.Contains(command, "'--dport=6666'"):
+		return "port in use: 6666\n", 1
+	default:
+		// 셸이 인용을 풀어 전달한 인자를 출력
+		return "applied\n" + strings.ReplaceAll(strings.TrimPrefix(command, "agent "), "'", "") + "\n", 0
+	}
+}
+
+// TestDeployTemplate SSH 배포 및 결과 변환 테스트
+func TestDeployTemplate(t *testing.T) {
+	keyFile, clientKey := writeClientKey(t)
+	device := newTestDevice(t, clientKey, agentHandler)
+
+	config := model.DefaultConfig()
+	config.ConnectionMode = model.ConnectionModeSSH
+	config.SSH = model.SSHConfig{
+		Username:      "admin",
+		KeyFile:       keyFile,
+		HostKeySHA256: []string{ssh.FingerprintSHA256(device.hostKey.PublicKey())},
+	}
+	client := NewClient(config)
+	fw := model.NewFirewall(device.addr)
+
+	template := `# 테스트 규칙
+agent -m=insert -c=INPUT -p=tcp --dport=9010 -a=DROP
+
+agent -m=insert -c=INPUT -p=tcp --dport=6666 -a=DROP`
+
+	result, err := client.DeployTemplate(context.Background(), fw, template)
+	if err != nil {
+		t.Fatalf("DeployTemplate() error: %v", err)
+	}
+
+	if got := device.executed(); len(got) != 2 {
+		t.Fatalf("실행된 명령 = %v, want agent 명령 2개", got)
+	}
+	if result.IP != device.addr || result.Status != model.DeployStatusFail {
+		t.Errorf("DeployTemplate() = {IP: %s, Status: %s}, want {%s, fail}", result.IP, result.Status, device.addr)
+	}
+	if len(result.Info) != 2 {
+		t.Fatalf("Info 수 = %d, want 2", len(result.Info))
+	}
+
+	ok := result.Info[0]
+	if ok.Index != 1 || ok.Status != model.RuleStatusOK || ok.Text != "-m=insert -c=INPUT -p=tcp -a=DROP --dport=9010" {
+		t.Errorf("Info[0] = %+v, want 성공 및 출력 마지막 줄", ok)
+	}
+	failed := result.Info[1]
+	if failed.Index != 2 || failed.Status != model.RuleStatusError || failed.Reason != "port in use: 6666" {
+		t.Errorf("Info[1] = %+v, want 실패 및 출력 사유", failed)
+	}
+}
+
+// TestDeployTemplateQuoting 배포 명령은 파싱한 규칙으로 다시 만들고 인자별로 셸 인용
+func TestDeployTemplateQuoting(t *testing.T) {
+	keyFile, clientKey := writeClientKey(t)
+	device := newTestDevice(t, clientKey, agentHandler)
+
+	config := model.DefaultConfig()
+	config.ConnectionMode = model.ConnectionModeSSH
+	config.SSH = model.SSHConfig{
+		Username:      "admin",
+		KeyFile:       keyFile,
+		HostKeySHA256: []string{ssh.FingerprintSHA256(device.hostKey.PublicKey())},
+	}
+	client := NewClient(config)
+	fw := model.NewFirewall(device.addr)
+
+	template := `agent   -m=insert -c=FORWARD -a=ACCEPT -i=ppp+ --sip=10.0.0.0/8
+agent -m=insert -t=nat --nat-type=masquerade -s=192.168.1.0/24 -o=eth0 --desc=it's $(외부) 연결`
+
+	result, err := client.DeployTemplate(context.Background(), fw, template)
+	if err != nil {
+		t.Fatalf("DeployTemplate() error: %v", err)
+	}
+	want := []string{
+		`agent '-m=insert' '-c=FORWARD' '-p=tcp' '-a=ACCEPT' '--sip=10.0.0.0/8' '-i=ppp+'`,
+		`agent '-m=insert' '-t=nat' '--nat-type=masquerade' '-p=tcp' '-s=192.168.1.0/24' '-o=eth0' '--desc=it'\''s $(외부) 연결'`,
+	}
+	got := device.executed()
+	if len(got) != len(want) {
+		t.Fatalf("실행된 명령 = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("명령[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+	if rule := result.Info[0].Rule; rule != "agent -m=insert -c=FORWARD -p=tcp -a=ACCEPT --sip=10.0.0.0/8 -i=ppp+" {
+		t.Errorf("Info[0].Rule = %s, want 다시 만든 규칙", rule)
+	}
+
+	// 규칙 문법에 맞지 않는 줄이 있으면 어떤 명령도 실행하지 않음
+	injections := []string{
+		"agent -m=insert;reboot -c=INPUT -a=DROP",
+		"agent -m=insert -c=INPUT -a=DROP --dport=22`reboot`",
+		"agent -m=insert -c=INPUT -a=DROP --sip=1.2.3.4|reboot",
+		"agent -m=insert -c=FORWARD -a=DROP -i=$(reboot)",
+		"agent -m=insert -c=INPUT -a=DROP && reboot",
+		"agent -m=insert -t=nat --nat-type=masquerade -o=eth0>/tmp/x",
+		"reboot",
+	}
+	for _, line := range injections {
+		if _, err := client.DeployTemplate(context.Background(), fw, "agent -m=insert -c=INPUT -a=DROP\n"+line); err == nil {
+			t.Errorf("DeployTemplate(%q) error = nil, want 파싱 에러", line)
+		}
+	}
+	if got := device.executed(); len(got) != len(want) {
+		t.Errorf("실행된 명령 = %v, want 거부된 템플릿의 명령은 실행하지 않음", got)
+	}
+}
+
+// TestCheckHealthAndFetchRules known_hosts 검증으로 상태 확인 및 규칙 조회 테스트
+func TestCheckHealthAndFetchRules(t *testing.T) {
+	keyFile, clientKey := writeClientKey(t)
+	device := newTestDevice(t, clientKey, agentHandler)
+
+	knownHostsFile := filepath.Join(t.TempDir(), "known_hosts")
+	line := knownhosts.Line([]string{knownhosts.Normalize(device.addr)}, device.hostKey.PublicKey())
+	if err := os.WriteFile(knownHostsFile, []byte(line+"\n"), 0600); err != nil {
+		t.Fatalf("known_hosts 저장 실패: %v", err)
+	}
+
+	config := model.DefaultConfig()
+	config.SSH = model.SSHConfig{Username: "admin", KeyFile: keyFile, KnownHostsFile: knownHostsFile}
+	client := NewClient(config)
+	fw := model.NewFirewall(device.addr)
+
+	status, err := client.CheckHealth(context.Background(), fw)
+	if err != nil || status != model.ServerStatusRunning {
+		t.Errorf("CheckHealth() = %s, %v, want running", status, err)
+	}
+
+	rules, err := client.FetchRules(context.Background(), fw)
+	if err != nil {
+		t.Fatalf("FetchRules() error: %v", err)
+	}
+	if !strings.Contains(rules, "--dport=22") {
+		t.Errorf("FetchRules() = %q, want 장비 규칙", rules)
+	}
+}
+
+// TestSmartfwRuleFormat smartfw 형식 배포 및 규칙 조회 테스트
+func TestSmartfwRuleFormat(t *testing.T) {
+	keyFile, clientKey := writeClientKey(t)
+	device := newTestDevice(t, clientKey, func(command string) (string, uint32) {
+		if command == smartfwRulesCommand {
+			return "req|INSERT|1|INPUT|DROP|TCP|ANY|ANY|22||\n", 0
+		}
+		return "", 0
+	})
+
+	config := model.DefaultConfig()
+	config.ConnectionMode = model.ConnectionModeSSH
+	config.SSH = model.SSHConfig{
+		Username:      "admin",
+		KeyFile:       keyFile,
+		HostKeySHA256: []string{ssh.FingerprintSHA256(device.hostKey.PublicKey())},
+		RuleFormat:    model.RuleFormatSmartfw,
+	}
+	client := NewClient(config)
+	fw := model.NewFirewall(device.addr)
+
+	template := "agent -m=insert -c=INPUT -p=tcp -a=ACCEPT --dport=80"
+	result, err := client.DeployTemplate(context.Background(), fw, template)
+	if err != nil {
+		t.Fatalf("DeployTemplate() error: %v", err)
+	}
+
+	request := "req|INSERT|" + parser.NewSmartfwID(template) + "|INPUT|ACCEPT|TCP|ANY|ANY|80||"
+	want := "printf '%s\\n' '" + request + "' > " + smartfwPath
+	if got := device.executed(); len(got) != 1 || got[0] != want {
+		t.Fatalf("실행된 명령 = %v, want [%s]", got, want)
+	}
+	if result.Status != model.DeployStatusSuccess || len(result.Info) != 1 || result.Info[0].Rule != request {
+		t.Errorf("DeployTemplate() = %+v, want smartfw 요청 성공", result)
+	}
+
+	rules, err := client.FetchRules(context.Background(), fw)
+	if err != nil {
+		t.Fatalf("FetchRules() error: %v", err)
+	}
+	if rules != "agent -m=insert -c=INPUT -p=tcp -a=DROP --dport=22" {
+		t.Errorf("FetchRules() = %q, want 템플릿 규칙으로 변환", rules)
+	}
+}
+
+// TestConnectionErrors SSH 접속 실패 분류 테스트
+func TestConnectionErrors(t *testing.T) {
+	keyFile, clientKey := writeClientKey(t)
+	otherKeyFile, _ := writeClientKey(t)
+	device := newTestDevice(t, clientKey, agentHandler)
+	fingerprint := ssh.FingerprintSHA256(device.hostKey.PublicKey())
+
+	// 닫힌 포트 (연결 거부)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("포트 할당 실패: %v", err)
+	}
+	closedAddr := listener.Addr().String()
+	listener.Close()
+
+	tests := []struct {
+		name     string
+		addr     string
+		ssh      model.SSHConfig
+		wantCat  string
+		wantText string
+	}{
+		{
+			name:     "등록되지 않은 클라이언트 키",
+			addr:     device.addr,
+			ssh:      model.SSHConfig{Username: "admin", KeyFile: otherKeyFile, HostKeySHA256: []string{fingerprint}},
+			wantCat:  model.ErrorCategoryAuth,
+			wantText: "SSH 인증 실패",
+		},
+		{
+			name:     "호스트 키 불일치",
+			addr:     device.addr,
+			ssh:      model.SSHConfig{Username: "admin", KeyFile: keyFile, HostKeySHA256: []string{"SHA256:AAAA"}},
+			wantCat:  model.ErrorCategoryAuth,
+			wantText: "호스트 키 불일치",
+		},
+		{
+			name:     "연결 거부",
+			addr:     closedAddr,
+			ssh:      model.SSHConfig{Username: "admin", KeyFile: keyFile, HostKeySHA256: []string{fingerprint}},
+			wantCat:  model.ErrorCategoryRefused,
+			wantText: "연결 거부",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := model.DefaultConfig()
+			config.SSH = tt.ssh
+			_, err := NewClient(config).DeployTemplate(context.Background(), model.NewFirewall(tt.addr), "agent -m=insert -c=INPUT -a=DROP")
+			if err == nil {
+				t.Fatal("DeployTemplate() error = nil")
+			}
+			if cat := http.CategoryOf(err); cat != tt.wantCat {
+				t.Errorf("CategoryOf() = %q, want %q (err: %v)", cat, tt.wantCat, err)
+			}
+			if !strings.Contains(err.Error(), tt.wantText) {
+				t.Errorf("error = %v, want %q 포함", err, tt.wantText)
+			}
+		})
+	}
+
+	// 개인키가 없으면 설정 오류
+	config := model.DefaultConfig()
+	config.SSH = model.SSHConfig{Username: "admin", KeyFile: filepath.Join(t.TempDir(), "missing")}
+	_, err = NewClient(config).CheckHealth(context.Background(), model.NewFirewall(device.addr))
+	if !errors.Is(err, ErrSSHConfig) {
+		t.Errorf("CheckHealth() error = %v, want ErrSSHConfig", err)
+	}
+}
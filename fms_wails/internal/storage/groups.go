@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"fms_wails/internal/model"
+)
+
+// 장비 그룹 파일명
+const groupsFile = "groups.json"
+
+// loadGroups는 장비 그룹 데이터를 로드합니다.
+func (s *JSONStore) loadGroups() error {
+	path := filepath.Join(s.configDir, groupsFile)
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var groups []*model.DeviceGroup
+	if err := json.Unmarshal(data, &groups); err != nil {
+		return err
+	}
+
+	for _, g := range groups {
+		s.groups[g.Name] = g
+	}
+	return nil
+}
+
+// saveGroups는 장비 그룹 데이터를 이름순으로 저장합니다.
+func (s *JSONStore) saveGroups() error {
+	data, err := json.MarshalIndent(s.sortedGroups(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.configDir, groupsFile)
+	return os.WriteFile(path, data, 0644)
+}
+
+// sortedGroups는 장비 그룹 복사본을 이름순으로 반환합니다.
+func (s *JSONStore) sortedGroups() []*model.DeviceGroup {
+	groups := make([]*model.DeviceGroup, 0, len(s.groups))
+	for _, g := range s.groups {
+		groups = append(groups, g.Clone())
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
+
+// ===== DeviceGroup 메서드 =====
+
+// GetAllGroups는 모든 장비 그룹을 이름순으로 반환합니다.
+func (s *JSONStore) GetAllGroups() ([]*model.DeviceGroup, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sortedGroups(), nil
+}
+
+// GetGroup은 이름으로 장비 그룹을 반환합니다.
+func (s *JSONStore) GetGroup(name string) (*model.DeviceGroup, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	g, ok := s.groups[name]
+	if !ok {
+		return nil, fmt.Errorf("장비 그룹을 찾을 수 없습니다: %s", name)
+	}
+	return g.Clone(), nil
+}
+
+// SaveGroup은 장비 그룹을 저장합니다. (같은 이름의 그룹은 덮어씀)
+func (s *JSONStore) SaveGroup(group *model.DeviceGroup) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.groups[group.Name] = group.Clone()
+	return s.saveGroups()
+}
+
+// DeleteGroup은 장비 그룹을 삭제합니다.
+func (s *JSONStore) DeleteGroup(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.groups[name]; !ok {
+		return fmt.Errorf("장비 그룹을 찾을 수 없습니다: %s", name)
+	}
+
+	delete(s.groups, name)
+	return s.saveGroups()
+}
@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"testing"
+
+	"fms_wails/internal/model"
+)
+
+// TestGroupsPersisted 장비 그룹 저장/조회/삭제 테스트
+func TestGroupsPersisted(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewJSONStore(dir)
+	if err != nil {
+		t.Fatalf("NewJSONStore() error: %v", err)
+	}
+
+	seoul := model.NewDeviceGroup("seoul")
+	seoul.Variables["MGMT_NET"] = "10.1.0.0/24"
+	busan := model.NewDeviceGroup("busan")
+	busan.Variables["MGMT_NET"] = "10.2.0.0/24"
+	for _, g := range []*model.DeviceGroup{seoul, busan} {
+		if err := store.SaveGroup(g); err != nil {
+			t.Fatalf("SaveGroup() error: %v", err)
+		}
+	}
+
+	// 파일에서 다시 로드해도 이름순으로 조회
+	reloaded, err := NewJSONStore(dir)
+	if err != nil {
+		t.Fatalf("NewJSONStore() error: %v", err)
+	}
+	groups, _ := reloaded.GetAllGroups()
+	if len(groups) != 2 || groups[0].Name != "busan" || groups[1].Name != "seoul" {
+		t.Fatalf("GetAllGroups() = %v, want [busan seoul]", groups)
+	}
+
+	// 반환된 그룹을 수정해도 저장소에는 영향 없음
+	groups[1].Variables["MGMT_NET"] = "10.9.9.0/24"
+	if g, _ := reloaded.GetGroup("seoul"); g.Variables["MGMT_NET"] != "10.1.0.0/24" {
+		t.Errorf("GetGroup() MGMT_NET = %s, want 10.1.0.0/24", g.Variables["MGMT_NET"])
+	}
+
+	if err := reloaded.DeleteGroup("busan"); err != nil {
+		t.Fatalf("DeleteGroup() error: %v", err)
+	}
+	if _, err := reloaded.GetGroup("busan"); err == nil {
+		t.Error("GetGroup(busan) error = nil, want 삭제됨")
+	}
+	if err := reloaded.DeleteGroup("busan"); err == nil {
+		t.Error("DeleteGroup(busan) error = nil, want 없는 그룹 에러")
+	}
+}
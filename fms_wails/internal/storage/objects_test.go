@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"testing"
+
+	"fms_wails/internal/model"
+)
+
+// TestObjectsPersisted 객체 저장/조회/삭제 테스트
+func TestObjectsPersisted(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewJSONStore(dir)
+	if err != nil {
+		t.Fatalf("NewJSONStore() error: %v", err)
+	}
+
+	for _, o := range []*model.NamedObject{
+		model.NewNamedObject("web", model.ObjectKindService, "tcp/80", "tcp/443"),
+		model.NewNamedObject("monitoring", model.ObjectKindAddress, "10.0.0.1", "10.0.1.0/24"),
+	} {
+		if err := store.SaveObject(o); err != nil {
+			t.Fatalf("SaveObject() error: %v", err)
+		}
+	}
+
+	// 파일에서 다시 로드해도 이름순으로 조회
+	reloaded, err := NewJSONStore(dir)
+	if err != nil {
+		t.Fatalf("NewJSONStore() error: %v", err)
+	}
+	objects, _ := reloaded.GetAllObjects()
+	if len(objects) != 2 || objects[0].Name != "monitoring" || objects[1].Name != "web" {
+		t.Fatalf("GetAllObjects() = %v, want [monitoring web]", objects)
+	}
+
+	// 반환된 객체를 수정해도 저장소에는 영향 없음
+	objects[0].Members[0] = "10.9.9.9"
+	if o, _ := reloaded.GetObject("monitoring"); o.Members[0] != "10.0.0.1" {
+		t.Errorf("GetObject() Members[0] = %s, want 10.0.0.1", o.Members[0])
+	}
+
+	if err := reloaded.DeleteObject("web"); err != nil {
+		t.Fatalf("DeleteObject() error: %v", err)
+	}
+	if _, err := reloaded.GetObject("web"); err == nil {
+		t.Error("GetObject(web) error = nil, want 삭제됨")
+	}
+	if err := reloaded.DeleteObject("web"); err == nil {
+		t.Error("DeleteObject(web) error = nil, want 없는 객체 에러")
+	}
+}
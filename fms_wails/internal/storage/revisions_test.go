@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"fms_wails/internal/model"
+)
+
+// TestTemplateRevisions 템플릿 저장 시 리비전 기록/조회/유지 테스트
+func TestTemplateRevisions(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewJSONStore(dir)
+	if err != nil {
+		t.Fatalf("NewJSONStore() error: %v", err)
+	}
+
+	first, err := store.CommitTemplate(model.NewTemplate("v1", "agent -m=insert -c=INPUT -a=DROP"), "admin", "최초 작성")
+	if err != nil {
+		t.Fatalf("CommitTemplate() error: %v", err)
+	}
+	// 내용이 같으면 리비전을 추가하지 않음
+	if same, _ := store.CommitTemplate(model.NewTemplate("v1", "agent -m=insert -c=INPUT -a=DROP"), "other", "변경 없음"); same.Hash != first.Hash || same.Number != 1 {
+		t.Errorf("CommitTemplate(같은 내용) = %v, want %v", same, first)
+	}
+	second, _ := store.CommitTemplate(model.NewTemplate("v1", "agent -m=insert -c=INPUT -a=ACCEPT"), "admin", "허용으로 변경")
+	if second.Number != 2 || second.Parent != first.Hash || second.Author != "admin" || second.Note != "허용으로 변경" {
+		t.Fatalf("second = %+v, want r2 (부모 r1)", second)
+	}
+
+	// 파일에서 다시 로드해도 리비전과 템플릿의 현재 리비전 유지
+	reloaded, err := NewJSONStore(dir)
+	if err != nil {
+		t.Fatalf("NewJSONStore() error: %v", err)
+	}
+	if tmpl, _ := reloaded.GetTemplate("v1"); tmpl.Revision != second.Hash {
+		t.Errorf("GetTemplate().Revision = %s, want %s", tmpl.Revision, second.Hash)
+	}
+	revisions, _ := reloaded.GetTemplateRevisions("v1")
+	if len(revisions) != 2 || revisions[0].Hash != second.Hash || revisions[1].Hash != first.Hash {
+		t.Fatalf("GetTemplateRevisions() = %v, want [r2 r1]", revisions)
+	}
+	if r, err := reloaded.GetTemplateRevision("v1", model.ShortHash(first.Hash)); err != nil || r.Contents != "agent -m=insert -c=INPUT -a=DROP" {
+		t.Errorf("GetTemplateRevision(짧은 해시) = %v, %v, want r1", r, err)
+	}
+	if _, err := reloaded.GetTemplateRevision("v1", "ffff"); err == nil {
+		t.Error("GetTemplateRevision(없는 해시) error = nil")
+	}
+
+	// 템플릿을 삭제해도 리비전은 남고, 다시 저장하면 이어서 번호를 매김
+	if err := reloaded.DeleteTemplate("v1"); err != nil {
+		t.Fatalf("DeleteTemplate() error: %v", err)
+	}
+	if revisions, _ := reloaded.GetTemplateRevisions("v1"); len(revisions) != 2 {
+		t.Errorf("삭제 후 GetTemplateRevisions() = %d개, want 2", len(revisions))
+	}
+	third, _ := reloaded.CommitTemplate(model.NewTemplate("v1", "agent -m=insert -c=INPUT -a=DROP"), "admin", "r1 복원")
+	if third.Number != 3 || third.Parent != second.Hash || third.Hash != first.Hash {
+		t.Errorf("third = %+v, want r3 (r1과 같은 해시)", third)
+	}
+
+	// 초기화하면 리비전도 삭제
+	if err := reloaded.ClearTemplates(); err != nil {
+		t.Fatalf("ClearTemplates() error: %v", err)
+	}
+	if revisions, _ := reloaded.GetTemplateRevisions("v1"); len(revisions) != 0 {
+		t.Errorf("ClearTemplates() 후 리비전 %d개, want 0", len(revisions))
+	}
+}
+
+// TestLegacyTemplatesMigrated 리비전 도입 전 템플릿에 첫 리비전을 만드는지 테스트
+func TestLegacyTemplatesMigrated(t *testing.T) {
+	dir := t.TempDir()
+	data, _ := json.Marshal([]*model.Template{model.NewTemplate("legacy", "agent -m=insert -c=INPUT -a=DROP")})
+	if err := os.WriteFile(filepath.Join(dir, templatesFile), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewJSONStore(dir)
+	if err != nil {
+		t.Fatalf("NewJSONStore() error: %v", err)
+	}
+	revisions, _ := store.GetTemplateRevisions("legacy")
+	if len(revisions) != 1 || revisions[0].Number != 1 || revisions[0].Note != legacyRevisionNote {
+		t.Fatalf("GetTemplateRevisions() = %v, want 기존 템플릿 r1", revisions)
+	}
+	if tmpl, _ := store.GetTemplate("legacy"); tmpl.Revision != revisions[0].Hash {
+		t.Errorf("GetTemplate().Revision = %s, want %s", tmpl.Revision, revisions[0].Hash)
+	}
+
+	// 다시 로드해도 리비전을 또 만들지 않음
+	reloaded, _ := NewJSONStore(dir)
+	if revisions, _ := reloaded.GetTemplateRevisions("legacy"); len(revisions) != 1 {
+		t.Errorf("다시 로드 후 리비전 %d개, want 1", len(revisions))
+	}
+}
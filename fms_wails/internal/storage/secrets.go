@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"fms_wails/internal/model"
+)
+
+// 인증 비밀값 파일명 (config.json과 분리, 소유자만 읽기/쓰기)
+const secretsFile = "secrets.json"
+
+// secrets는 인증 비밀값 파일 구조입니다.
+type secrets struct {
+	Config    string         `json:"config,omitempty"`    // 전역 인증 비밀값
+	Firewalls map[int]string `json:"firewalls,omitempty"` // 장비 Index별 인증 비밀값
+}
+
+// loadSecrets는 인증 비밀값을 로드합니다.
+func (s *JSONStore) loadSecrets() error {
+	s.secrets = secrets{Firewalls: make(map[int]string)}
+
+	path := filepath.Join(s.configDir, secretsFile)
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, &s.secrets); err != nil {
+		return err
+	}
+	if s.secrets.Firewalls == nil {
+		s.secrets.Firewalls = make(map[int]string)
+	}
+	return nil
+}
+
+// saveSecrets는 인증 비밀값을 저장합니다.
+func (s *JSONStore) saveSecrets() error {
+	data, err := json.MarshalIndent(s.secrets, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.configDir, secretsFile)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return err
+	}
+	// 기존 파일의 권한도 제한
+	return os.Chmod(path, 0600)
+}
+
+// syncFirewallSecrets는 캐시된 장비의 인증 비밀값으로 비밀값 목록을 갱신합니다.
+func (s *JSONStore) syncFirewallSecrets() {
+	s.secrets.Firewalls = make(map[int]string)
+	for index, f := range s.firewalls {
+		if f.Auth != nil && f.Auth.Secret != "" {
+			s.secrets.Firewalls[index] = f.Auth.Secret
+		}
+	}
+}
+
+// applyFirewallSecret는 저장된 인증 비밀값을 장비에 채웁니다.
+func (s *JSONStore) applyFirewallSecret(f *model.Firewall) {
+	if f.Auth != nil {
+		f.Auth.Secret = s.secrets.Firewalls[f.Index]
+	}
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"fms_wails/internal/model"
+)
+
+// TestAuthSecretsStoredSeparately 인증 비밀값 분리 저장 테스트
+func TestAuthSecretsStoredSeparately(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewJSONStore(dir)
+	if err != nil {
+		t.Fatalf("NewJSONStore() error: %v", err)
+	}
+
+	config := model.DefaultConfig()
+	config.Auth = model.AuthConfig{Type: model.AuthTypeBearer, Secret: "global-token"}
+	if err := store.SaveConfig(config); err != nil {
+		t.Fatalf("SaveConfig() error: %v", err)
+	}
+
+	fw := model.NewFirewall("10.0.0.1")
+	fw.Auth = &model.AuthConfig{Type: model.AuthTypeBasic, Username: "admin", Secret: "device-pass"}
+	if err := store.SaveFirewall(fw); err != nil {
+		t.Fatalf("SaveFirewall() error: %v", err)
+	}
+
+	// 일반 설정 파일에는 비밀값이 없어야 함
+	for _, name := range []string{configFile, firewallsFile} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("%s 읽기 실패: %v", name, err)
+		}
+		if strings.Contains(string(data), "global-token") || strings.Contains(string(data), "device-pass") {
+			t.Errorf("%s에 비밀값이 저장되었습니다: %s", name, data)
+		}
+	}
+
+	info, err := os.Stat(filepath.Join(dir, secretsFile))
+	if err != nil {
+		t.Fatalf("%s 확인 실패: %v", secretsFile, err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("%s 권한 = %o, want 600", secretsFile, perm)
+	}
+
+	// 다시 로드하면 비밀값이 복원되어야 함
+	reloaded, err := NewJSONStore(dir)
+	if err != nil {
+		t.Fatalf("NewJSONStore() error: %v", err)
+	}
+	loadedConfig, err := reloaded.GetConfig()
+	if err != nil {
+		t.Fatalf("GetConfig() error: %v", err)
+	}
+	if loadedConfig.Auth.Secret != "global-token" {
+		t.Errorf("Config.Auth.Secret = %q, want %q", loadedConfig.Auth.Secret, "global-token")
+	}
+	loadedFw, err := reloaded.GetFirewall(fw.Index)
+	if err != nil {
+		t.Fatalf("GetFirewall() error: %v", err)
+	}
+	if loadedFw.Auth == nil || loadedFw.Auth.Secret != "device-pass" {
+		t.Errorf("Firewall.Auth = %+v, want 비밀값 device-pass", loadedFw.Auth)
+	}
+
+	// 장비 삭제 시 비밀값도 삭제
+	if err := reloaded.DeleteFirewall(fw.Index); err != nil {
+		t.Fatalf("DeleteFirewall() error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, secretsFile))
+	if err != nil {
+		t.Fatalf("%s 읽기 실패: %v", secretsFile, err)
+	}
+	if strings.Contains(string(data), "device-pass") {
+		t.Errorf("삭제한 장비의 비밀값이 남아 있습니다: %s", data)
+	}
+}